@@ -648,7 +648,7 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		tbl := ws.TableParts.TableParts[idx]
 		target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
 		tableXML := strings.ReplaceAll(target, "..", "xl")
-		content, ok := f.Pkg.Load(tableXML)
+		content, ok := f.pkgLoad(tableXML)
 		if !ok {
 			continue
 		}
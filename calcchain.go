@@ -26,6 +26,7 @@ func (f *File) calcChainReader() (*xlsxCalcChain, error) {
 			Decode(f.CalcChain); err != nil && err != io.EOF {
 			return f.CalcChain, err
 		}
+		f.trackPart(defaultXMLPathCalcChain, f.CalcChain)
 	}
 	return f.CalcChain, nil
 }
@@ -33,7 +34,7 @@ func (f *File) calcChainReader() (*xlsxCalcChain, error) {
 // calcChainWriter provides a function to save xl/calcChain.xml after
 // serialize structure.
 func (f *File) calcChainWriter() {
-	if f.CalcChain != nil && f.CalcChain.C != nil {
+	if f.CalcChain != nil && f.CalcChain.C != nil && !f.isPartUnchanged(defaultXMLPathCalcChain, f.CalcChain) {
 		output, _ := xml.Marshal(f.CalcChain)
 		f.saveFileList(defaultXMLPathCalcChain, output)
 	}
@@ -86,7 +87,7 @@ func (c xlsxCalcChainCollection) Filter(fn func(v xlsxCalcChainC) bool) []xlsxCa
 // after deserialization of xl/volatileDependencies.xml.
 func (f *File) volatileDepsReader() (*xlsxVolTypes, error) {
 	if f.VolatileDeps == nil {
-		volatileDeps, ok := f.Pkg.Load(defaultXMLPathVolatileDeps)
+		volatileDeps, ok := f.pkgLoad(defaultXMLPathVolatileDeps)
 		if !ok {
 			return f.VolatileDeps, nil
 		}
@@ -95,6 +96,7 @@ func (f *File) volatileDepsReader() (*xlsxVolTypes, error) {
 			Decode(f.VolatileDeps); err != nil && err != io.EOF {
 			return f.VolatileDeps, err
 		}
+		f.trackPart(defaultXMLPathVolatileDeps, f.VolatileDeps)
 	}
 	return f.VolatileDeps, nil
 }
@@ -102,7 +104,7 @@ func (f *File) volatileDepsReader() (*xlsxVolTypes, error) {
 // volatileDepsWriter provides a function to save xl/volatileDependencies.xml
 // after serialize structure.
 func (f *File) volatileDepsWriter() {
-	if f.VolatileDeps != nil {
+	if f.VolatileDeps != nil && !f.isPartUnchanged(defaultXMLPathVolatileDeps, f.VolatileDeps) {
 		output, _ := xml.Marshal(f.VolatileDeps)
 		f.saveFileList(defaultXMLPathVolatileDeps, output)
 	}
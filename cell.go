@@ -15,7 +15,6 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -455,9 +454,11 @@ func (f *File) sharedStringsLoader() (err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	if path, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
-		f.Pkg.Store(defaultXMLPathSharedStrings, f.readBytes(defaultXMLPathSharedStrings))
+		content := f.readBytes(defaultXMLPathSharedStrings)
+		f.Pkg.Store(defaultXMLPathSharedStrings, content)
+		f.setOriginPartContent(defaultXMLPathSharedStrings, content)
 		f.tempFiles.Delete(defaultXMLPathSharedStrings)
-		if err = os.Remove(path.(string)); err != nil {
+		if err = f.tempStorage().Remove(path.(string)); err != nil {
 			return
 		}
 		f.SharedStrings = nil
@@ -467,7 +468,7 @@ func (f *File) sharedStringsLoader() (err error) {
 			return err
 		}
 		f.tempFiles.Delete(defaultTempFileSST)
-		f.sharedStringItem, err = nil, os.Remove(f.sharedStringTemp.Name())
+		f.sharedStringItem, err = nil, f.tempStorage().Remove(f.sharedStringTemp.Name())
 		f.sharedStringTemp = nil
 	}
 	return
@@ -579,22 +580,36 @@ func (c *xlsxC) setCellDefault(value string) {
 
 // getCellDate parse cell value which contains a date in the ISO 8601 format.
 func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
-	if !raw {
-		layout := "20060102T150405.999"
-		if strings.HasSuffix(c.V, "Z") {
-			layout = "20060102T150405Z"
-			if strings.Contains(c.V, "-") {
-				layout = "2006-01-02T15:04:05Z"
-			}
-		} else if strings.Contains(c.V, "-") {
-			layout = "2006-01-02 15:04:05Z"
-		}
-		if timestamp, err := time.Parse(layout, strings.ReplaceAll(c.V, ",", ".")); err == nil {
-			excelTime, _ := timeToExcelTime(timestamp, false)
-			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
+	if raw {
+		return f.formattedValue(c, raw, CellTypeDate)
+	}
+	cell := *c
+	date1904, _ := f.date1904()
+	if excelTime, ok := parseCellDate(c.V, date1904); ok {
+		cell.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
+	}
+	return f.formattedValue(&cell, raw, CellTypeDate)
+}
+
+// parseCellDate provides a function to convert the date, time or date time in
+// the ISO 8601 format to the Excel serial date time, the wall clock time will
+// be used if the value contains a time zone offset.
+func parseCellDate(value string, date1904 bool) (float64, bool) {
+	value = strings.ReplaceAll(value, ",", ".")
+	for _, layout := range []string{
+		"20060102T150405.999", "20060102T150405Z", "2006-01-02 15:04:05Z",
+		time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+			excelTime, err := timeToExcelTime(t, date1904)
+			return excelTime, err == nil
 		}
 	}
-	return f.formattedValue(c, raw, CellTypeDate)
+	if t, err := time.Parse("15:04:05.999999999", value); err == nil {
+		return float64(t.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC))) / float64(dayNanoseconds), true
+	}
+	return 0, false
 }
 
 // getValueFrom return a value from a column/row cell, this function is
@@ -1662,22 +1677,26 @@ func getSharedFormula(ws *xlsxWorksheet, si int, cell string) string {
 		for column := 0; column < len(r.C); column++ {
 			c := &r.C[column]
 			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && *c.F.Si == si {
-				col, row, _ := CellNameToCoordinates(cell)
-				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
-				dCol := col - sharedCol
-				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return shiftSharedFormula(c.R, c.F.Content, cell)
 			}
 		}
 	}
 	return ""
 }
 
+// shiftSharedFormula returns the formula of the given cell which derived from
+// the master cell of a shared formula.
+func shiftSharedFormula(master, formula, cell string) string {
+	col, row, _ := CellNameToCoordinates(cell)
+	sharedCol, sharedRow, _ := CellNameToCoordinates(master)
+	orig := []byte(formula)
+	res, start := parseSharedFormula(col-sharedCol, row-sharedRow, orig)
+	if start < len(orig) {
+		res += string(orig[start:])
+	}
+	return res
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
 // consideration absolute references with dollar sign ($)
 func shiftCell(cellID string, dCol, dRow int) string {
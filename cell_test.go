@@ -501,6 +501,36 @@ func TestGetCellValue(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestParseCellDate(t *testing.T) {
+	for value, expected := range map[string]float64{
+		"2024-03-01T08:30:00":       45352.354166666664,
+		"2024-03-01T08:30:00.000":   45352.354166666664,
+		"2024-03-01T08:30:00+08:00": 45352.354166666664,
+		"2024-03-01":                45352,
+		"12:00:00":                  0.5,
+		"06:00:00,000":              0.25,
+	} {
+		excelTime, ok := parseCellDate(value, false)
+		assert.True(t, ok, value)
+		assert.InDelta(t, expected, excelTime, 1e-9, value)
+	}
+	excelTime, ok := parseCellDate("2024-03-01", true)
+	assert.True(t, ok)
+	assert.Equal(t, 43890.0, excelTime)
+	_, ok = parseCellDate("2020-07-10 15:00:00.000", false)
+	assert.False(t, ok)
+	// Test get the date cell value without changing the cell
+	f := NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", T: "d", V: "2024-03-01"}}}}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "45352", val)
+	assert.Equal(t, "2024-03-01", ws.SheetData.Row[0].C[0].V)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetCellType(t *testing.T) {
 	f := NewFile()
 	cellType, err := f.GetCellType("Sheet1", "A1")
@@ -0,0 +1,2220 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// ChartImageOptions directly maps the settings of the chart image. The Format
+// specifies the image format, the supported formats are "png" and "svg", and
+// the default format is "png". The Width and Height specifies the size of the
+// image in pixels, the size of the chart will be used by default. The area of
+// the image must be less than or equal to 67108864 pixels, such as 8192 x 8192.
+type ChartImageOptions struct {
+	Format string
+	Width  uint
+	Height uint
+}
+
+// chartImageRenderer defined the structure used to render the chart to image.
+type chartImageRenderer struct {
+	f          *File
+	groups     []*chartImageGroup
+	caches     map[string][]string
+	categories []string
+	count      int
+	width      float64
+	height     float64
+	elements   []chartImageElement
+	faces      map[chartImageFont]font.Face
+}
+
+// chartImageGroup defined the structure used to store the series of a chart
+// group, such as the primary chart or the combo charts.
+type chartImageGroup struct {
+	chart     *Chart
+	family    string
+	grouping  string
+	varied    bool
+	secondary bool
+	series    []*chartImageSeries
+}
+
+// chartImageSeries defined the structure used to store the values of a chart
+// series resolved by the references.
+type chartImageSeries struct {
+	options    *ChartSeries
+	index      int
+	name       string
+	categories []string
+	x          []float64
+	values     []float64
+	base       []float64
+	top        []float64
+	color      string
+}
+
+// chartImageAxis defined the structure used to map the category index or the
+// value to the position in the image.
+type chartImageAxis struct {
+	options  *ChartAxis
+	category bool
+	between  bool
+	count    int
+	min      float64
+	max      float64
+	unit     float64
+	numFmt   string
+	start    float64
+	end      float64
+}
+
+// chartImageFont defined the font settings of the text in the chart image,
+// the size in points.
+type chartImageFont struct {
+	family string
+	size   float64
+	bold   bool
+	italic bool
+	color  string
+}
+
+// chartImageElement defined the drawing element of the chart image, which is
+// a polygon, polyline, or a text when the text is not empty.
+type chartImageElement struct {
+	points [][2]float64
+	closed bool
+	fill   string
+	stroke string
+	width  float64
+	text   string
+	font   chartImageFont
+	x, y   float64
+	anchor string
+	rotate bool
+	cx, cy float64
+}
+
+var (
+	// chartImageFonts defined the fonts used to measure and draw text.
+	chartImageFonts struct {
+		sync.Once
+		regular, bold *opentype.Font
+	}
+	// chartImagePalette defined the default accent colors of the theme.
+	chartImagePalette = []string{"5B9BD5", "ED7D31", "A5A5A5", "FFC000", "4472C4", "70AD47"}
+	// chartImageLumMods defined the luminance modulation and offset of the
+	// series colors after the accent colors have been used.
+	chartImageLumMods = [][2]float64{{1, 0}, {0.6, 0}, {0.8, 0.2}, {0.8, 0}, {0.6, 0.4}, {0.5, 0}}
+	// chartImageMarkers defined the marker symbols used by the automatic
+	// marker in order.
+	chartImageMarkers = []string{"diamond", "square", "triangle", "x", "star", "circle", "plus"}
+	// chartImageLegendPos defined the position of the legend in the chart.
+	chartImageLegendPos = map[string]string{"b": "bottom", "l": "left", "r": "right", "t": "top", "tr": "top_right"}
+)
+
+// RenderChart provides a function to render the chart to PNG or SVG image by
+// given chart format sets and the combo charts, the series of the chart will
+// be resolved by the cell values of the referenced worksheets. The chart
+// family of bar, column, line, area, pie, doughnut and scatter are supported,
+// and the 3D charts will be rendered as 2D charts. For example, render a
+// clustered column chart to SVG image:
+//
+//	var buf bytes.Buffer
+//	err := f.RenderChart(&buf, &excelize.ChartImageOptions{Format: "svg"},
+//	    &excelize.Chart{
+//	        Type: excelize.Col,
+//	        Series: []excelize.ChartSeries{
+//	            {
+//	                Name:       "Sheet1!$A$2",
+//	                Categories: "Sheet1!$B$1:$D$1",
+//	                Values:     "Sheet1!$B$2:$D$2",
+//	            },
+//	        },
+//	        Title: []excelize.RichTextRun{{Text: "Fruit Column Chart"}},
+//	    })
+func (f *File) RenderChart(w io.Writer, opts *ChartImageOptions, chart *Chart, combo ...*Chart) error {
+	options, err := parseChartImageOptions(opts)
+	if err != nil {
+		return err
+	}
+	if chart == nil {
+		return ErrParameterInvalid
+	}
+	r := &chartImageRenderer{f: f, caches: map[string][]string{}}
+	if err = r.loadCharts(append([]*Chart{chart}, combo...)); err != nil {
+		return err
+	}
+	r.width, r.height = float64(r.groups[0].chart.Dimension.Width), float64(r.groups[0].chart.Dimension.Height)
+	return r.render(w, options)
+}
+
+// ExportChart provides a function to render the chart in the worksheet to PNG
+// or SVG image by given worksheet name, the cell reference of the top-left
+// corner of the chart and the image options. The size of the chart in the
+// worksheet will be used as the image size by default. The series of the
+// chart will be resolved by the cell values, and the cached values in the
+// chart will be used when the referenced worksheet doesn't exist. For
+// example, export the chart at the cell E1 on Sheet1 to PNG image:
+//
+//	out, err := os.Create("chart.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer out.Close()
+//	err = f.ExportChart("Sheet1", "E1", out)
+func (f *File) ExportChart(sheet, cell string, w io.Writer, opts ...ChartImageOptions) error {
+	var options *ChartImageOptions
+	for i := range opts {
+		options = &opts[i]
+	}
+	parsed, err := parseChartImageOptions(options)
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	chartXML, width, height, err := f.getChartByCell(sheet, col-1, row-1)
+	if err != nil {
+		return err
+	}
+	if chartXML == "" {
+		return newNoExistChartError(sheet, cell)
+	}
+	space := new(decodeChartSpace)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(chartXML)))).
+		Decode(space); err != nil && err != io.EOF {
+		return err
+	}
+	r := &chartImageRenderer{f: f, caches: map[string][]string{}}
+	charts, err := r.decodeCharts(space)
+	if err != nil {
+		return err
+	}
+	if err = r.loadCharts(charts); err != nil {
+		return err
+	}
+	r.width, r.height = width, height
+	return r.render(w, parsed)
+}
+
+// parseChartImageOptions provides a function to parse the chart image options
+// with default value.
+func parseChartImageOptions(opts *ChartImageOptions) (*ChartImageOptions, error) {
+	options := ChartImageOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.Format = strings.ToLower(strings.TrimPrefix(options.Format, ".")); options.Format == "" {
+		options.Format = "png"
+	}
+	if options.Format != "png" && options.Format != "svg" {
+		return &options, ErrImgExt
+	}
+	return &options, nil
+}
+
+// getChartByCell provides a function to get the chart part path and the size
+// of the chart in pixels by given worksheet name and the zero-based column and
+// row number of the top-left corner of the chart.
+func (f *File) getChartByCell(sheet string, col, row int) (string, float64, float64, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Drawing == nil {
+		return "", 0, 0, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchor := range append(wsDr.TwoCellAnchor, wsDr.OneCellAnchor...) {
+		deAnchor := new(decodeChartAnchor)
+		_ = f.xmlNewDecoder(strings.NewReader("<decodeChartAnchor>" + anchor.GraphicFrame + "</decodeChartAnchor>")).Decode(deAnchor)
+		if deAnchor.GraphicFrame == nil || deAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		from, to := deAnchor.From, deAnchor.To
+		if anchor.From != nil {
+			from = &decodeFrom{Col: anchor.From.Col, ColOff: anchor.From.ColOff, Row: anchor.From.Row, RowOff: anchor.From.RowOff}
+		}
+		if anchor.To != nil {
+			to = &decodeTo{Col: anchor.To.Col, ColOff: anchor.To.ColOff, Row: anchor.To.Row, RowOff: anchor.To.RowOff}
+		}
+		if from == nil || from.Col != col || from.Row != row {
+			continue
+		}
+		rel := f.getDrawingRelationships(drawingRelationships, deAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID)
+		if rel == nil {
+			continue
+		}
+		chartXML := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(rel.Target, "/") {
+			chartXML = filepath.ToSlash(filepath.Clean("xl/drawings/" + rel.Target))
+		}
+		width, height := float64(defaultChartDimensionWidth), float64(defaultChartDimensionHeight)
+		if to != nil {
+			width, height = float64(to.ColOff-from.ColOff)/float64(EMU), float64(to.RowOff-from.RowOff)/float64(EMU)
+			for c := from.Col; c < to.Col; c++ {
+				width += float64(f.getColWidth(sheet, c+1))
+			}
+			for r := from.Row; r < to.Row; r++ {
+				height += float64(f.getRowHeight(sheet, r+1))
+			}
+		} else if anchor.Ext != nil {
+			width, height = float64(anchor.Ext.Cx)/float64(EMU), float64(anchor.Ext.Cy)/float64(EMU)
+		} else if deAnchor.Ext != nil {
+			width, height = float64(deAnchor.Ext.Cx)/float64(EMU), float64(deAnchor.Ext.Cy)/float64(EMU)
+		}
+		return chartXML, math.Max(width, 1), math.Max(height, 1), err
+	}
+	return "", 0, 0, err
+}
+
+// decodeCharts provides a function to convert the chart part to the chart
+// format sets of the primary chart and the combo charts, the cached values of
+// the series will be stored for resolving the series values.
+func (r *chartImageRenderer) decodeCharts(space *decodeChartSpace) ([]*Chart, error) {
+	var (
+		charts      []*Chart
+		primaryAxID int
+		plotArea    = &space.Chart.PlotArea
+		axes        = map[int]*decodeChartAxis{}
+	)
+	for _, axs := range [][]*decodeChartAxis{plotArea.CatAx, plotArea.DateAx, plotArea.ValAx} {
+		for _, ax := range axs {
+			if ax.AxID.Val != nil {
+				axes[*ax.AxID.Val] = ax
+			}
+		}
+	}
+	for _, group := range plotArea.Charts {
+		chartType, ok := chartImageDecodeType(group)
+		if !ok {
+			continue
+		}
+		chart := &Chart{Type: chartType, VaryColors: boolPtr(chartType == Pie || chartType == Pie3D || chartType == Doughnut)}
+		if group.VaryColors != nil {
+			chart.VaryColors = group.VaryColors.Val
+		}
+		if group.HoleSize != nil && group.HoleSize.Val != nil {
+			chart.HoleSize = *group.HoleSize.Val
+		}
+		r.decodeDataLabels(chart, group.DLbls)
+		for _, ser := range group.Ser {
+			chart.Series = append(chart.Series, r.decodeSeries(chart, &ser))
+		}
+		if len(group.AxID) > 1 && group.AxID[0].Val != nil && group.AxID[1].Val != nil {
+			chart.XAxis = r.decodeAxis(axes[*group.AxID[0].Val])
+			chart.YAxis = r.decodeAxis(axes[*group.AxID[1].Val])
+			if len(charts) == 0 {
+				primaryAxID = *group.AxID[1].Val
+			}
+			chart.YAxis.Secondary = *group.AxID[1].Val != primaryAxID
+		}
+		charts = append(charts, chart)
+	}
+	if len(charts) == 0 {
+		return nil, ErrParameterInvalid
+	}
+	chart := charts[0]
+	chart.Legend.Position = "none"
+	if legend := space.Chart.Legend; legend != nil {
+		chart.Legend.Position = "right"
+		if legend.LegendPos != nil && legend.LegendPos.Val != nil {
+			chart.Legend.Position = chartImageLegendPos[*legend.LegendPos.Val]
+		}
+	}
+	if space.Chart.DispBlanksAs != nil && space.Chart.DispBlanksAs.Val != nil {
+		chart.ShowBlanksAs = *space.Chart.DispBlanksAs.Val
+	}
+	if title := space.Chart.Title; title != nil {
+		if chart.Title = r.decodeTitle(title); chart.Title == nil && len(chart.Series) == 1 {
+			name, err := r.seriesName(chart.Series[0].Name, 0)
+			if err != nil {
+				return charts, err
+			}
+			chart.Title = []RichTextRun{{Text: name, Font: r.decodeFont(title.TxPr)}}
+		}
+	}
+	if spPr := space.SpPr; spPr != nil {
+		chart.Fill = r.decodeFill(spPr)
+		if spPr.Ln != nil && spPr.Ln.NoFill != nil {
+			chart.Border.Type = ChartLineNone
+		}
+	}
+	if plotArea.SpPr != nil {
+		chart.PlotArea.Fill = r.decodeFill(plotArea.SpPr)
+	}
+	return charts, nil
+}
+
+// chartImageDecodeType returns the chart type by given chart group element.
+func chartImageDecodeType(group *decodeChartGroup) (ChartType, bool) {
+	grouping, barDir := "standard", "col"
+	if group.Grouping != nil && group.Grouping.Val != nil {
+		grouping = *group.Grouping.Val
+	}
+	if group.BarDir != nil && group.BarDir.Val != nil {
+		barDir = *group.BarDir.Val
+	}
+	types := map[string]map[string]ChartType{
+		"areaChart":      {"standard": Area, "stacked": AreaStacked, "percentStacked": AreaPercentStacked},
+		"area3DChart":    {"standard": Area3D, "stacked": Area3DStacked, "percentStacked": Area3DPercentStacked},
+		"barChart":       {"clustered": Col, "stacked": ColStacked, "percentStacked": ColPercentStacked},
+		"barChartbar":    {"clustered": Bar, "stacked": BarStacked, "percentStacked": BarPercentStacked},
+		"bar3DChart":     {"standard": Col3D, "clustered": Col3DClustered, "stacked": Col3DStacked, "percentStacked": Col3DPercentStacked},
+		"bar3DChartbar":  {"clustered": Bar3DClustered, "stacked": Bar3DStacked, "percentStacked": Bar3DPercentStacked},
+		"bubbleChart":    {"": Bubble},
+		"doughnutChart":  {"": Doughnut},
+		"lineChart":      {"": Line},
+		"line3DChart":    {"": Line3D},
+		"ofPieChart":     {"": PieOfPie},
+		"pieChart":       {"": Pie},
+		"pie3DChart":     {"": Pie3D},
+		"radarChart":     {"": Radar},
+		"scatterChart":   {"": Scatter},
+		"surfaceChart":   {"": Contour},
+		"surface3DChart": {"": Surface3D},
+	}
+	name := group.XMLName.Local
+	if barDir == "bar" && strings.HasPrefix(name, "bar") {
+		name += barDir
+	}
+	chartTypes, ok := types[name]
+	if !ok {
+		return Area, false
+	}
+	if chartType, ok := chartTypes[""]; ok {
+		if chartType == PieOfPie && group.OfPieType != nil && group.OfPieType.Val != nil && *group.OfPieType.Val == "bar" {
+			chartType = BarOfPie
+		}
+		return chartType, true
+	}
+	if chartType, ok := chartTypes[grouping]; ok {
+		return chartType, true
+	}
+	if strings.HasPrefix(name, "bar") {
+		return chartTypes["clustered"], true
+	}
+	return chartTypes["standard"], true
+}
+
+// decodeSeries provides a function to convert the series of the chart group
+// to the chart series format sets.
+func (r *chartImageRenderer) decodeSeries(chart *Chart, ser *decodeChartSer) ChartSeries {
+	series := ChartSeries{Line: ChartLine{Type: ChartLineAutomatic}}
+	if ser.Tx != nil {
+		series.Name = ser.Tx.V
+		if ser.Tx.StrRef != nil {
+			series.Name = r.decodeData(&decodeChartData{StrRef: ser.Tx.StrRef})
+		}
+	}
+	for _, data := range []*decodeChartData{ser.Cat, ser.XVal} {
+		if data != nil {
+			series.Categories = r.decodeData(data)
+		}
+	}
+	for _, data := range []*decodeChartData{ser.Val, ser.YVal} {
+		if data != nil {
+			series.Values = r.decodeData(data)
+		}
+	}
+	series.Sizes = r.decodeData(ser.BubbleSize)
+	if spPr := ser.SpPr; spPr != nil {
+		series.Fill = r.decodeFill(spPr)
+		if spPr.Ln != nil {
+			series.Line.Type = ChartLineSolid
+			if spPr.Ln.NoFill != nil {
+				series.Line.Type = ChartLineNone
+			}
+			if spPr.Ln.W > 0 {
+				series.Line.Width = float64(spPr.Ln.W) / 12700
+			}
+			if spPr.SolidFill == nil && spPr.Ln.SolidFill != nil {
+				series.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{r.decodeColor(spPr.Ln.SolidFill)}}
+			}
+		}
+	}
+	if ser.Smooth != nil && ser.Smooth.Val != nil {
+		series.Line.Smooth = *ser.Smooth.Val
+	}
+	if marker := ser.Marker; marker != nil {
+		if marker.Symbol != nil && marker.Symbol.Val != nil {
+			series.Marker.Symbol = *marker.Symbol.Val
+		}
+		if marker.Size != nil && marker.Size.Val != nil {
+			series.Marker.Size = *marker.Size.Val
+		}
+		if marker.SpPr != nil {
+			series.Marker.Fill = r.decodeFill(marker.SpPr)
+		}
+	}
+	if dLbls := r.decodeDataLabels(chart, ser.DLbls); dLbls != nil && dLbls.DLblPos != nil && dLbls.DLblPos.Val != nil {
+		for pos, val := range chartDataLabelsPositionTypes {
+			if val == *dLbls.DLblPos.Val {
+				series.DataLabelPosition = pos
+			}
+		}
+	}
+	return series
+}
+
+// decodeDataLabels provides a function to merge the data labels settings to
+// the plot area format sets of the chart.
+func (r *chartImageRenderer) decodeDataLabels(chart *Chart, dLbls *decodeChartDLbls) *decodeChartDLbls {
+	if dLbls == nil || (dLbls.Delete != nil && dLbls.Delete.Val != nil && *dLbls.Delete.Val) {
+		return nil
+	}
+	for flag, val := range map[*bool]*attrValBool{
+		&chart.PlotArea.ShowVal:     dLbls.ShowVal,
+		&chart.PlotArea.ShowCatName: dLbls.ShowCatName,
+		&chart.PlotArea.ShowSerName: dLbls.ShowSerName,
+		&chart.PlotArea.ShowPercent: dLbls.ShowPercent,
+	} {
+		if val != nil && val.Val != nil && *val.Val {
+			*flag = true
+		}
+	}
+	if dLbls.NumFmt != nil && !dLbls.NumFmt.SourceLinked {
+		chart.PlotArea.NumFmt.CustomNumFmt = dLbls.NumFmt.FormatCode
+	}
+	return dLbls
+}
+
+// decodeAxis provides a function to convert the axis element to the chart
+// axis format sets.
+func (r *chartImageRenderer) decodeAxis(ax *decodeChartAxis) ChartAxis {
+	var axis ChartAxis
+	if ax == nil {
+		return axis
+	}
+	axis.None = ax.Delete != nil && ax.Delete.Val != nil && *ax.Delete.Val
+	axis.MajorGridLines, axis.MinorGridLines = ax.MajorGridlines != nil, ax.MinorGridlines != nil
+	if ax.Scaling != nil {
+		if ax.Scaling.Max != nil {
+			axis.Maximum = ax.Scaling.Max.Val
+		}
+		if ax.Scaling.Min != nil {
+			axis.Minimum = ax.Scaling.Min.Val
+		}
+		if ax.Scaling.Orientation != nil && ax.Scaling.Orientation.Val != nil {
+			axis.ReverseOrder = *ax.Scaling.Orientation.Val == "maxMin"
+		}
+	}
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		axis.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if ax.NumFmt != nil {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	if fnt := r.decodeFont(ax.TxPr); fnt != nil {
+		axis.Font = *fnt
+	}
+	axis.Title = r.decodeTitle(ax.Title)
+	return axis
+}
+
+// decodeTitle provides a function to convert the title element to the rich
+// text runs, each paragraph of the title will be converted to a run.
+func (r *chartImageRenderer) decodeTitle(title *decodeChartTitle) []RichTextRun {
+	var runs []RichTextRun
+	if title == nil || title.Tx == nil {
+		return runs
+	}
+	if title.Tx.StrRef != nil {
+		text, _ := r.seriesName(r.decodeData(&decodeChartData{StrRef: title.Tx.StrRef}), 0)
+		return []RichTextRun{{Text: text, Font: r.decodeFont(title.TxPr)}}
+	}
+	if title.Tx.Rich == nil {
+		return runs
+	}
+	for _, p := range title.Tx.Rich.P {
+		var (
+			text string
+			fnt  *Font
+		)
+		if p.PPr != nil && p.PPr.DefRPr != nil {
+			fnt = r.decodeRunFont(p.PPr.DefRPr)
+		}
+		for i, run := range p.R {
+			if text += run.T; i == 0 && run.RPr != nil {
+				fnt = r.decodeRunFont(run.RPr)
+			}
+		}
+		runs = append(runs, RichTextRun{Text: text, Font: fnt})
+	}
+	return runs
+}
+
+// decodeFont provides a function to get the font settings by given text
+// properties element.
+func (r *chartImageRenderer) decodeFont(txPr *decodeChartRich) *Font {
+	if txPr == nil || len(txPr.P) == 0 || txPr.P[0].PPr == nil || txPr.P[0].PPr.DefRPr == nil {
+		return nil
+	}
+	return r.decodeRunFont(txPr.P[0].PPr.DefRPr)
+}
+
+// decodeRunFont provides a function to convert the text run properties to
+// the font settings.
+func (r *chartImageRenderer) decodeRunFont(rPr *decodeChartRPr) *Font {
+	fnt := &Font{Size: rPr.Sz / 100}
+	if rPr.B != nil {
+		fnt.Bold = *rPr.B
+	}
+	if rPr.I != nil {
+		fnt.Italic = *rPr.I
+	}
+	if rPr.SolidFill != nil {
+		fnt.Color = r.decodeColor(rPr.SolidFill)
+	}
+	if rPr.Latin != nil && !strings.HasPrefix(rPr.Latin.Typeface, "+") {
+		fnt.Family = rPr.Latin.Typeface
+	}
+	return fnt
+}
+
+// decodeFill provides a function to convert the shape properties to the fill
+// format sets, the fill without color specifies no fill.
+func (r *chartImageRenderer) decodeFill(spPr *decodeChartSpPr) Fill {
+	if spPr.SolidFill != nil {
+		if clr := r.decodeColor(spPr.SolidFill); clr != "" {
+			return Fill{Type: "pattern", Pattern: 1, Color: []string{clr}}
+		}
+	}
+	if spPr.NoFill != nil {
+		return Fill{Type: "pattern", Pattern: 1}
+	}
+	return Fill{}
+}
+
+// decodeColor provides a function to get the RGB color by given solid fill
+// element with the RGB color or scheme color.
+func (r *chartImageRenderer) decodeColor(fill *decodeChartSolidFill) string {
+	clr, base := fill.SrgbClr, ""
+	if clr != nil {
+		base = clr.Val
+	}
+	if fill.SchemeClr != nil {
+		clr, base = fill.SchemeClr, r.schemeColor(fill.SchemeClr.Val)
+	}
+	if clr == nil || htmlColor(base) == "" {
+		return ""
+	}
+	lumMod, lumOff := 1.0, 0.0
+	if clr.LumMod != nil && clr.LumMod.Val != nil {
+		lumMod = float64(*clr.LumMod.Val) / 100000
+	}
+	if clr.LumOff != nil && clr.LumOff.Val != nil {
+		lumOff = float64(*clr.LumOff.Val) / 100000
+	}
+	return strings.TrimPrefix(chartImageLumColor(base, lumMod, lumOff), "#")
+}
+
+// decodeData provides a function to get the reference formula of the series
+// data, and store the cached values of the data. The literal data will be
+// stored by a generated key.
+func (r *chartImageRenderer) decodeData(data *decodeChartData) string {
+	if data == nil {
+		return ""
+	}
+	store := func(key string, cache *decodeChartCache) string {
+		var values []string
+		if cache != nil {
+			for _, pt := range cache.Pt {
+				if pt.Idx >= 0 && pt.Idx < TotalRows {
+					for len(values) <= pt.Idx {
+						values = append(values, "")
+					}
+					values[pt.Idx] = pt.V
+				}
+			}
+		}
+		r.caches[key] = values
+		return key
+	}
+	for _, ref := range []*decodeChartStrRef{data.NumRef, data.StrRef} {
+		if ref == nil {
+			continue
+		}
+		if ref.NumCache != nil {
+			return store(ref.F, ref.NumCache)
+		}
+		return store(ref.F, ref.StrCache)
+	}
+	for _, lit := range []*decodeChartCache{data.NumLit, data.StrLit} {
+		if lit != nil {
+			return store("{"+strconv.Itoa(len(r.caches))+"}", lit)
+		}
+	}
+	return ""
+}
+
+// chartImageFamily returns the family of the chart type used to render, the
+// empty string means the chart type is unsupported.
+func chartImageFamily(chartType ChartType) string {
+	switch {
+	case chartType <= Area3DPercentStacked:
+		return "area"
+	case chartType <= Bar3DCylinderPercentStacked:
+		return "bar"
+	case chartType <= Col3DCylinderPercentStacked:
+		return "col"
+	}
+	return map[ChartType]string{
+		Doughnut: "doughnut", Line: "line", Line3D: "line", Pie: "pie", Pie3D: "pie", Scatter: "scatter",
+	}[chartType]
+}
+
+// loadCharts provides a function to parse the format sets of the charts and
+// resolve the values of the series.
+func (r *chartImageRenderer) loadCharts(charts []*Chart) error {
+	var order int
+	for i, c := range charts {
+		if c == nil {
+			continue
+		}
+		chart := *c
+		if _, err := parseChartOptions(&chart); err != nil {
+			return err
+		}
+		family := chartImageFamily(chart.Type)
+		if family == "" {
+			return newUnsupportedChartType(chart.Type)
+		}
+		if i > 0 {
+			primary := r.groups[0].family
+			if primary == "pie" || primary == "doughnut" || family == "pie" || family == "doughnut" ||
+				(primary == "bar") != (family == "bar") {
+				continue
+			}
+		}
+		group := &chartImageGroup{
+			chart:     &chart,
+			family:    family,
+			grouping:  plotAreaChartGrouping[chart.Type],
+			secondary: i > 0 && chart.YAxis.Secondary,
+		}
+		for j := range chart.Series {
+			series, err := r.loadSeries(&chart.Series[j], order+j, family == "scatter")
+			if err != nil {
+				return err
+			}
+			group.series = append(group.series, series)
+		}
+		order += len(chart.Series)
+		group.varied = *chart.VaryColors && (family == "pie" || family == "doughnut" ||
+			((family == "bar" || family == "col") && len(group.series) == 1 && !chartImageHasFill(chart.Series[0].Fill)))
+		r.groups = append(r.groups, group)
+	}
+	for _, group := range r.groups {
+		for _, series := range group.series {
+			if r.count = maxInt(r.count, maxInt(len(series.values), len(series.categories))); r.categories == nil {
+				r.categories = series.categories
+			}
+		}
+	}
+	for _, group := range r.groups {
+		group.stack(r.count)
+	}
+	return nil
+}
+
+// loadSeries provides a function to resolve the name, categories and values
+// of the series by the references.
+func (r *chartImageRenderer) loadSeries(opts *ChartSeries, idx int, scatter bool) (*chartImageSeries, error) {
+	series := &chartImageSeries{options: opts, index: idx, color: r.paletteColor(idx)}
+	if chartImageHasFill(opts.Fill) {
+		series.color = htmlColor(opts.Fill.Color[0])
+	}
+	var err error
+	if series.name, err = r.seriesName(opts.Name, idx); err != nil {
+		return series, err
+	}
+	if series.categories, _, err = r.refValues(opts.Categories, false); err != nil {
+		return series, err
+	}
+	values, _, err := r.refValues(opts.Values, true)
+	if err != nil {
+		return series, err
+	}
+	series.values = chartImageNumbers(values)
+	if scatter {
+		if series.x = chartImageNumbers(series.categories); len(series.x) == 0 {
+			series.x = make([]float64, len(series.values))
+		}
+		for i, x := range series.x {
+			if math.IsNaN(x) || !chartImageIsNumeric(series.categories, i) {
+				for j := range series.x {
+					series.x[j] = float64(j + 1)
+				}
+				break
+			}
+		}
+	}
+	return series, nil
+}
+
+// seriesName provides a function to get the name of the series by given
+// reference or literal text of the name.
+func (r *chartImageRenderer) seriesName(name string, idx int) (string, error) {
+	values, ok, err := r.refValues(name, false)
+	if err != nil {
+		return name, err
+	}
+	if ok {
+		return strings.Join(values, " "), err
+	}
+	if name = strings.Trim(name, "\""); name == "" {
+		name = "Series" + strconv.Itoa(idx+1)
+	}
+	return name, err
+}
+
+// refValues provides a function to get the cell values by given reference,
+// the cached values will be used if the referenced worksheet doesn't exist.
+// The second returned value specifies whether the given text is a reference.
+func (r *chartImageRenderer) refValues(ref string, raw bool) ([]string, bool, error) {
+	cache, cached := r.caches[ref]
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "=")
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return cache, cached, nil
+	}
+	sheet := ref[:idx]
+	if len(sheet) > 1 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	cells := strings.Split(strings.ReplaceAll(ref[idx+1:], "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[len(cells)-1])
+	if err != nil || len(cells) > 2 {
+		return cache, cached, nil
+	}
+	_ = sortCoordinates(coordinates)
+	var values []string
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			val, err := r.f.GetCellValue(sheet, cell, Options{RawCellValue: raw})
+			if err != nil {
+				if cached {
+					return cache, true, nil
+				}
+				return values, true, err
+			}
+			values = append(values, val)
+		}
+	}
+	return values, true, nil
+}
+
+// chartImageNumbers returns the numbers by given cell values, the empty value
+// will be converted to NaN, and the non-numeric value will be converted to 0.
+func chartImageNumbers(values []string) []float64 {
+	numbers := make([]float64, len(values))
+	for i, val := range values {
+		if numbers[i] = math.NaN(); strings.TrimSpace(val) != "" {
+			numbers[i], _ = strconv.ParseFloat(strings.TrimSpace(val), 64)
+		}
+	}
+	return numbers
+}
+
+// chartImageIsNumeric returns whether the value at the given index of the
+// cell values is numeric, the empty value is treated as numeric.
+func chartImageIsNumeric(values []string, idx int) bool {
+	if idx >= len(values) || strings.TrimSpace(values[idx]) == "" {
+		return true
+	}
+	_, err := strconv.ParseFloat(strings.TrimSpace(values[idx]), 64)
+	return err == nil
+}
+
+// chartImageHasFill returns whether the fill format sets specifies a solid
+// color fill.
+func chartImageHasFill(fill Fill) bool {
+	return fill.Type == "pattern" && fill.Pattern == 1 && len(fill.Color) == 1 && htmlColor(fill.Color[0]) != ""
+}
+
+// stack provides a function to calculate the base and top values of each
+// data point of the series in the chart group by the grouping of the chart.
+func (g *chartImageGroup) stack(count int) {
+	positive, negative, total := make([]float64, count), make([]float64, count), make([]float64, count)
+	stacked := g.grouping == "stacked" || g.grouping == "percentStacked"
+	for _, series := range g.series {
+		for i := 0; i < count && i < len(series.values); i++ {
+			if !math.IsNaN(series.values[i]) {
+				total[i] += math.Abs(series.values[i])
+			}
+		}
+	}
+	for _, series := range g.series {
+		series.base, series.top = make([]float64, count), make([]float64, count)
+		for i := 0; i < count; i++ {
+			value := math.NaN()
+			if i < len(series.values) {
+				value = series.values[i]
+			}
+			if !stacked || math.IsNaN(value) {
+				series.base[i], series.top[i] = 0, value
+				if stacked && g.family == "area" {
+					series.base[i], series.top[i] = positive[i], positive[i]
+				}
+				continue
+			}
+			if g.grouping == "percentStacked" {
+				if value = 0; total[i] != 0 {
+					value = series.values[i] / total[i]
+				}
+			}
+			if value >= 0 {
+				series.base[i], positive[i] = positive[i], positive[i]+value
+				series.top[i] = positive[i]
+				continue
+			}
+			series.base[i], negative[i] = negative[i], negative[i]+value
+			series.top[i] = negative[i]
+		}
+	}
+}
+
+// schemeColor provides a function to get the RGB color by given scheme color
+// name with the workbook theme.
+func (r *chartImageRenderer) schemeColor(name string) string {
+	idx, ok := map[string]int{
+		"lt1": 0, "bg1": 0, "dk1": 1, "tx1": 1, "lt2": 2, "bg2": 2, "dk2": 3, "tx2": 3,
+		"accent1": 4, "accent2": 5, "accent3": 6, "accent4": 7, "accent5": 8, "accent6": 9,
+	}[name]
+	if !ok {
+		return ""
+	}
+	if r.f.Theme != nil {
+		scheme := r.f.Theme.ThemeElements.ClrScheme
+		clr := []decodeCTColor{
+			scheme.Lt1, scheme.Dk1, scheme.Lt2, scheme.Dk2, scheme.Accent1,
+			scheme.Accent2, scheme.Accent3, scheme.Accent4, scheme.Accent5, scheme.Accent6,
+		}[idx]
+		if clr.SrgbClr != nil && clr.SrgbClr.Val != nil && htmlColor(*clr.SrgbClr.Val) != "" {
+			return *clr.SrgbClr.Val
+		}
+		if clr.SysClr != nil && htmlColor(clr.SysClr.LastClr) != "" {
+			return clr.SysClr.LastClr
+		}
+	}
+	return append([]string{"FFFFFF", "000000", "E7E6E6", "44546A"}, chartImagePalette...)[idx]
+}
+
+// paletteColor provides a function to get the automatic color of the series
+// or data point by given index.
+func (r *chartImageRenderer) paletteColor(idx int) string {
+	lum := chartImageLumMods[idx/len(chartImagePalette)%len(chartImageLumMods)]
+	return chartImageLumColor(r.schemeColor("accent"+strconv.Itoa(idx%len(chartImagePalette)+1)), lum[0], lum[1])
+}
+
+// chartImageLumColor returns the color by given RGB color with the luminance
+// modulation and offset.
+func chartImageLumColor(clr string, lumMod, lumOff float64) string {
+	if clr = htmlColor(clr); clr == "" || (lumMod == 1 && lumOff == 0) {
+		return clr
+	}
+	rgb, _ := strconv.ParseUint(clr[1:], 16, 32)
+	h, s, l := RGBToHSL(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb))
+	red, green, blue := HSLToRGB(h, s, math.Max(0, math.Min(1, l*lumMod+lumOff)))
+	return fmt.Sprintf("#%02X%02X%02X", red, green, blue)
+}
+
+// pointColor provides a function to get the color of the data point.
+func (r *chartImageRenderer) pointColor(g *chartImageGroup, s *chartImageSeries, idx int) string {
+	if g.varied {
+		return r.paletteColor(idx)
+	}
+	return s.color
+}
+
+// category returns the category label by given index.
+func (r *chartImageRenderer) category(idx int) string {
+	if idx < len(r.categories) {
+		return r.categories[idx]
+	}
+	return strconv.Itoa(idx + 1)
+}
+
+// chartImageFontOf returns the font of the chart text by given font settings
+// and the default size and color.
+func chartImageFontOf(fnt *Font, size float64, clr string) chartImageFont {
+	f := chartImageFont{family: "Calibri", size: size, color: "#" + clr}
+	if fnt == nil {
+		return f
+	}
+	if fnt.Family != "" {
+		f.family = fnt.Family
+	}
+	if fnt.Size > 0 {
+		f.size = fnt.Size
+	}
+	if c := htmlColor(fnt.Color); c != "" {
+		f.color = c
+	}
+	f.bold, f.italic = fnt.Bold, fnt.Italic
+	return f
+}
+
+// face provides a function to get the font face used to measure and draw the
+// text by given font.
+func (r *chartImageRenderer) face(fnt chartImageFont) font.Face {
+	key := chartImageFont{size: fnt.size, bold: fnt.bold}
+	if face, ok := r.faces[key]; ok {
+		return face
+	}
+	chartImageFonts.Do(func() {
+		chartImageFonts.regular, _ = opentype.Parse(goregular.TTF)
+		chartImageFonts.bold, _ = opentype.Parse(gobold.TTF)
+	})
+	src := chartImageFonts.regular
+	if fnt.bold {
+		src = chartImageFonts.bold
+	}
+	face, _ := opentype.NewFace(src, &opentype.FaceOptions{Size: fnt.size * 4 / 3, DPI: 72, Hinting: font.HintingNone})
+	if r.faces == nil {
+		r.faces = map[chartImageFont]font.Face{}
+	}
+	r.faces[key] = face
+	return face
+}
+
+// textWidth provides a function to measure the width of the text in pixels.
+func (r *chartImageRenderer) textWidth(text string, fnt chartImageFont) float64 {
+	return float64(font.MeasureString(r.face(fnt), text)) / 64
+}
+
+// lineHeight provides a function to get the height of the text line in
+// pixels.
+func (r *chartImageRenderer) lineHeight(fnt chartImageFont) float64 {
+	metrics := r.face(fnt).Metrics()
+	return float64(metrics.Ascent+metrics.Descent)/64 + 2
+}
+
+// addText provides a function to add the text element, the x and y specifies
+// the anchor point and the vertical center of the text, the rotated text will
+// be drawn from bottom to top and centered at the given point.
+func (r *chartImageRenderer) addText(text string, x, y float64, fnt chartImageFont, anchor string, rotate bool) {
+	if text == "" {
+		return
+	}
+	metrics := r.face(fnt).Metrics()
+	baseline := y + float64(metrics.Ascent-metrics.Descent)/128
+	r.elements = append(r.elements, chartImageElement{
+		text: text, font: fnt, x: x, y: baseline, anchor: anchor, rotate: rotate, cx: x, cy: y,
+	})
+}
+
+// addShape provides a function to add the polygon or polyline element.
+func (r *chartImageRenderer) addShape(points [][2]float64, closed bool, fill, stroke string, width float64) {
+	if len(points) < 2 || (fill == "" && (stroke == "" || width <= 0)) {
+		return
+	}
+	r.elements = append(r.elements, chartImageElement{points: points, closed: closed, fill: fill, stroke: stroke, width: width})
+}
+
+// addRect provides a function to add the rectangle element.
+func (r *chartImageRenderer) addRect(x0, y0, x1, y1 float64, fill, stroke string, width float64) {
+	r.addShape([][2]float64{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}, true, fill, stroke, width)
+}
+
+// render provides a function to layout and draw the chart, and write the
+// image by given format.
+func (r *chartImageRenderer) render(w io.Writer, opts *ChartImageOptions) error {
+	if opts.Width > 0 {
+		r.width = float64(opts.Width)
+	}
+	if opts.Height > 0 {
+		r.height = float64(opts.Height)
+	}
+	if math.Ceil(r.width)*math.Ceil(r.height) > maxChartImagePixels {
+		return ErrChartImageSize
+	}
+	chart := r.groups[0].chart
+	background, border := "#FFFFFF", "#D9D9D9"
+	if chart.Fill.Type == "pattern" && chart.Fill.Pattern == 1 {
+		if background = ""; chartImageHasFill(chart.Fill) {
+			background = htmlColor(chart.Fill.Color[0])
+		}
+	}
+	if chart.Border.Type == ChartLineNone {
+		border = ""
+	}
+	r.addRect(0.5, 0.5, r.width-0.5, r.height-0.5, background, border, chart.Border.Width*4/3)
+	area := [4]float64{10, 10, r.width - 10, r.height - 10}
+	area = r.drawTitle(area)
+	area = r.drawLegend(area)
+	if family := r.groups[0].family; family == "pie" || family == "doughnut" {
+		r.drawPie(area)
+	} else {
+		r.drawPlot(area)
+	}
+	if opts.Format == "svg" {
+		return r.writeSVG(w)
+	}
+	return r.writePNG(w)
+}
+
+// drawTitle provides a function to draw the chart title and returns the rest
+// area of the chart.
+func (r *chartImageRenderer) drawTitle(area [4]float64) [4]float64 {
+	y := area[1]
+	for _, run := range r.groups[0].chart.Title {
+		fnt := chartImageFontOf(run.Font, 14, "595959")
+		for _, line := range strings.Split(run.Text, "\n") {
+			height := r.lineHeight(fnt)
+			r.addText(line, (area[0]+area[2])/2, y+height/2, fnt, "middle", false)
+			y += height
+		}
+	}
+	if y > area[1] {
+		area[1] = y + 6
+	}
+	return area
+}
+
+// chartImageLegendEntry defined the entry of the chart legend.
+type chartImageLegendEntry struct {
+	label  string
+	color  string
+	line   bool
+	marker string
+	width  float64
+}
+
+// legendEntries provides a function to get the legend entries, the legend of
+// the chart with varied colors shows the categories.
+func (r *chartImageRenderer) legendEntries() []chartImageLegendEntry {
+	var entries []chartImageLegendEntry
+	if g := r.groups[0]; g.varied && len(g.series) > 0 {
+		for i := 0; i < r.count; i++ {
+			entries = append(entries, chartImageLegendEntry{label: r.category(i), color: r.pointColor(g, g.series[0], i)})
+		}
+		return entries
+	}
+	for _, g := range r.groups {
+		for _, s := range g.series {
+			entry := chartImageLegendEntry{label: s.name, color: s.color}
+			if g.family == "line" || g.family == "scatter" {
+				entry.line, entry.marker = r.lineVisible(g, s), r.markerSymbol(g, s)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// drawLegend provides a function to draw the chart legend and returns the
+// rest area of the chart.
+func (r *chartImageRenderer) drawLegend(area [4]float64) [4]float64 {
+	pos, entries := r.groups[0].chart.Legend.Position, r.legendEntries()
+	if pos == "none" || len(entries) == 0 {
+		return area
+	}
+	fnt := chartImageFontOf(nil, 9, "595959")
+	height := r.lineHeight(fnt)
+	var maxWidth float64
+	for i := range entries {
+		entries[i].width = 11 + r.textWidth(entries[i].label, fnt)
+		if entries[i].line || entries[i].marker != "" {
+			entries[i].width += 11
+		}
+		maxWidth = math.Max(maxWidth, entries[i].width)
+	}
+	if pos == "top" || pos == "bottom" {
+		var rows [][]chartImageLegendEntry
+		var rowWidth float64
+		for _, entry := range entries {
+			if len(rows) == 0 || rowWidth+12+entry.width > area[2]-area[0] {
+				rows, rowWidth = append(rows, nil), -12
+			}
+			rows[len(rows)-1], rowWidth = append(rows[len(rows)-1], entry), rowWidth+12+entry.width
+		}
+		y := area[1]
+		if pos == "bottom" {
+			y = area[3] - float64(len(rows))*height
+			area[3] = y - 6
+		} else {
+			area[1] = y + float64(len(rows))*height + 6
+		}
+		for _, row := range rows {
+			width := -12.0
+			for _, entry := range row {
+				width += entry.width + 12
+			}
+			x := (area[0] + area[2] - width) / 2
+			for _, entry := range row {
+				r.drawLegendEntry(entry, x, y+height/2, fnt)
+				x += entry.width + 12
+			}
+			y += height
+		}
+		return area
+	}
+	x, y := area[2]-maxWidth, (area[1]+area[3]-float64(len(entries))*height)/2
+	if pos == "left" {
+		x = area[0]
+		area[0] += maxWidth + 10
+	} else {
+		area[2] = x - 10
+	}
+	if pos == "top_right" {
+		y = area[1]
+	}
+	for _, entry := range entries {
+		r.drawLegendEntry(entry, x, y+height/2, fnt)
+		y += height
+	}
+	return area
+}
+
+// drawLegendEntry provides a function to draw the legend key and the label of
+// the legend entry.
+func (r *chartImageRenderer) drawLegendEntry(entry chartImageLegendEntry, x, y float64, fnt chartImageFont) {
+	keyWidth := 7.0
+	if entry.line || entry.marker != "" {
+		if keyWidth = 18; entry.line {
+			r.addShape([][2]float64{{x, y}, {x + keyWidth, y}}, false, "", entry.color, 2)
+		}
+		r.drawMarker(entry.marker, x+keyWidth/2, y, 5, entry.color, entry.color)
+	} else {
+		r.addRect(x, y-3.5, x+keyWidth, y+3.5, entry.color, "", 0)
+	}
+	r.addText(entry.label, x+keyWidth+4, y, fnt, "start", false)
+}
+
+// lineVisible returns whether the line of the series is visible, the line of
+// the scatter chart is invisible unless the line type or width is specified.
+func (r *chartImageRenderer) lineVisible(g *chartImageGroup, s *chartImageSeries) bool {
+	if s.options.Line.Type == ChartLineNone {
+		return false
+	}
+	return g.family != "scatter" || s.options.Line.Width > 0 || s.options.Line.Type == ChartLineAutomatic
+}
+
+// markerSymbol returns the marker symbol of the series.
+func (r *chartImageRenderer) markerSymbol(g *chartImageGroup, s *chartImageSeries) string {
+	symbol := s.options.Marker.Symbol
+	if symbol == "" && g.family == "scatter" {
+		symbol = "circle"
+	}
+	if symbol == "auto" {
+		symbol = chartImageMarkers[s.index%len(chartImageMarkers)]
+	}
+	if symbol == "none" || symbol == "picture" {
+		symbol = ""
+	}
+	return symbol
+}
+
+// drawMarker provides a function to draw the marker by given symbol, center
+// point, size in points, fill and stroke color.
+func (r *chartImageRenderer) drawMarker(symbol string, x, y, size float64, fill, stroke string) {
+	if size <= 0 {
+		size = 5
+	}
+	d := size * 2 / 3
+	switch symbol {
+	case "circle", "dot":
+		if symbol == "dot" {
+			d /= 2
+		}
+		r.addShape(chartImageArc(x, y, d, 0, 2*math.Pi), true, fill, stroke, 1)
+	case "square":
+		r.addRect(x-d, y-d, x+d, y+d, fill, stroke, 1)
+	case "diamond":
+		r.addShape([][2]float64{{x, y - d}, {x + d, y}, {x, y + d}, {x - d, y}}, true, fill, stroke, 1)
+	case "triangle":
+		r.addShape([][2]float64{{x, y - d}, {x + d, y + d}, {x - d, y + d}}, true, fill, stroke, 1)
+	case "x", "star", "plus":
+		if symbol != "plus" {
+			r.addShape([][2]float64{{x - d, y - d}, {x + d, y + d}}, false, "", stroke, 1.5)
+			r.addShape([][2]float64{{x - d, y + d}, {x + d, y - d}}, false, "", stroke, 1.5)
+		}
+		if symbol != "x" {
+			r.addShape([][2]float64{{x, y - d}, {x, y + d}}, false, "", stroke, 1.5)
+		}
+		if symbol == "plus" {
+			r.addShape([][2]float64{{x - d, y}, {x + d, y}}, false, "", stroke, 1.5)
+		}
+	case "dash":
+		r.addRect(x-d, y-d/4, x+d, y+d/4, fill, stroke, 1)
+	}
+}
+
+// chartImageArc returns the points of the arc by given center point, radius,
+// start and end angle in radians.
+func chartImageArc(cx, cy, radius, start, end float64) [][2]float64 {
+	steps := int(math.Ceil(math.Abs(end-start)*radius/2)) + 1
+	steps = minInt(maxInt(steps, 8), 720)
+	points := make([][2]float64, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		angle := start + (end-start)*float64(i)/float64(steps)
+		points = append(points, [2]float64{cx + radius*math.Cos(angle), cy + radius*math.Sin(angle)})
+	}
+	return points
+}
+
+// chartImageNumber returns the formatted number by given value and number
+// format code.
+func chartImageNumber(value float64, numFmt string) string {
+	value, _ = strconv.ParseFloat(strconv.FormatFloat(value, 'g', 12, 64), 64)
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if numFmt == "" || strings.EqualFold(numFmt, "General") {
+		if len(text) > 11 {
+			text = strconv.FormatFloat(value, 'g', 10, 64)
+		}
+		return text
+	}
+	return format(text, numFmt, false, CellTypeNumber, nil)
+}
+
+// dataLabel provides a function to get the data label text of the data point.
+func (r *chartImageRenderer) dataLabel(g *chartImageGroup, s *chartImageSeries, idx int, percent float64) string {
+	var (
+		parts    []string
+		plotArea = g.chart.PlotArea
+	)
+	if idx >= len(s.values) || math.IsNaN(s.values[idx]) {
+		return ""
+	}
+	if plotArea.ShowSerName {
+		parts = append(parts, s.name)
+	}
+	if plotArea.ShowCatName {
+		if category := r.category(idx); g.family == "scatter" && idx < len(s.x) {
+			parts = append(parts, chartImageNumber(s.x[idx], ""))
+		} else {
+			parts = append(parts, category)
+		}
+	}
+	if plotArea.ShowVal {
+		parts = append(parts, chartImageNumber(s.values[idx], plotArea.NumFmt.CustomNumFmt))
+	}
+	if plotArea.ShowPercent && (g.family == "pie" || g.family == "doughnut") {
+		parts = append(parts, chartImageNumber(percent, "0%"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// drawPie provides a function to draw the pie or doughnut chart in the given
+// area.
+func (r *chartImageRenderer) drawPie(area [4]float64) {
+	g := r.groups[0]
+	series := g.series
+	if g.family == "pie" && len(series) > 1 {
+		series = series[:1]
+	}
+	if len(series) == 0 {
+		return
+	}
+	fnt := chartImageFontOf(nil, 9, "404040")
+	cx, cy := (area[0]+area[2])/2, (area[1]+area[3])/2
+	radius := math.Min(area[2]-area[0], area[3]-area[1])/2 - 4
+	outside := false
+	for _, s := range series {
+		outside = outside || s.options.DataLabelPosition == ChartDataLabelsPositionOutsideEnd
+	}
+	if outside {
+		radius -= r.lineHeight(fnt) * 1.5
+	}
+	if radius <= 0 {
+		return
+	}
+	hole := 0.0
+	if g.family == "doughnut" {
+		holeSize := 75
+		if g.chart.HoleSize > 0 && g.chart.HoleSize <= 90 {
+			holeSize = g.chart.HoleSize
+		}
+		hole = radius * float64(holeSize) / 100
+	}
+	ring := (radius - hole) / float64(len(series))
+	type label struct {
+		text string
+		x, y float64
+	}
+	var labels []label
+	for k, s := range series {
+		inner, outer := hole+float64(k)*ring, hole+float64(k+1)*ring
+		var total float64
+		for _, v := range s.values {
+			if !math.IsNaN(v) {
+				total += math.Abs(v)
+			}
+		}
+		angle := -math.Pi / 2
+		for i, v := range s.values {
+			if math.IsNaN(v) || v == 0 || total == 0 {
+				continue
+			}
+			sweep := math.Abs(v) / total * 2 * math.Pi
+			points := chartImageArc(cx, cy, outer, angle, angle+sweep)
+			if inner > 0 {
+				reverse := chartImageArc(cx, cy, inner, angle+sweep, angle)
+				points = append(points, reverse...)
+			} else if sweep < 2*math.Pi {
+				points = append(points, [2]float64{cx, cy})
+			}
+			r.addShape(points, true, r.pointColor(g, s, i), "#FFFFFF", 1)
+			mid, distance := angle+sweep/2, (inner+outer)/2
+			if inner == 0 {
+				distance = outer * 0.65
+			}
+			switch s.options.DataLabelPosition {
+			case ChartDataLabelsPositionCenter:
+				distance = (inner + outer) / 2
+			case ChartDataLabelsPositionInsideEnd:
+				distance = outer * 0.8
+			case ChartDataLabelsPositionOutsideEnd:
+				distance = outer + r.lineHeight(fnt)
+			}
+			if text := r.dataLabel(g, s, i, math.Abs(v)/total); text != "" {
+				labels = append(labels, label{text: text, x: cx + distance*math.Cos(mid), y: cy + distance*math.Sin(mid)})
+			}
+			angle += sweep
+		}
+	}
+	for _, l := range labels {
+		r.addText(l.text, l.x, l.y, fnt, "middle", false)
+	}
+}
+
+// newValueAxis provides a function to create the value axis by given axis
+// format sets, the value range of the data and the length of the axis.
+func (r *chartImageRenderer) newValueAxis(opts *ChartAxis, chartType ChartType, lo, hi, length float64) *chartImageAxis {
+	axis := &chartImageAxis{options: opts, numFmt: opts.NumFmt.CustomNumFmt}
+	if axis.numFmt == "" {
+		axis.numFmt = chartValAxNumFmtFormatCode[chartType]
+	}
+	percent := strings.HasSuffix(plotAreaChartGrouping[chartType], "percentStacked")
+	if math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		lo, hi = 0, 1
+	}
+	if lo >= 0 && lo <= hi*5/6 {
+		lo = 0
+	}
+	if hi <= 0 && hi >= lo*5/6 {
+		hi = 0
+	}
+	if opts.Minimum != nil {
+		lo = *opts.Minimum
+	}
+	if opts.Maximum != nil {
+		hi = *opts.Maximum
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+	if axis.unit = opts.MajorUnit; axis.unit <= 0 {
+		axis.unit = chartImageUnit((hi - lo) / math.Max(2, math.Min(10, length/30)))
+	}
+	if (hi-lo)/axis.unit > 1000 {
+		axis.unit = (hi - lo) / 10
+	}
+	axis.min, axis.max = lo, hi
+	if opts.Minimum == nil {
+		axis.min = math.Floor(lo/axis.unit+1e-9) * axis.unit
+	}
+	if opts.Maximum == nil {
+		headroom := 0.0
+		if !percent && hi > 0 {
+			headroom = (hi - axis.min) * 0.05
+		}
+		axis.max = math.Ceil((hi+headroom)/axis.unit-1e-9) * axis.unit
+	}
+	if axis.max <= axis.min {
+		axis.max = axis.min + axis.unit
+	}
+	return axis
+}
+
+// chartImageUnit returns the major unit of the value axis rounded to 1, 2 or
+// 5 times a power of 10 by given raw unit.
+func chartImageUnit(raw float64) float64 {
+	if raw <= 0 || math.IsNaN(raw) || math.IsInf(raw, 0) {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, step := range []float64{1, 2, 5} {
+		if raw <= step*magnitude*(1+1e-9) {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// ticks returns the values of the major tick marks of the value axis.
+func (a *chartImageAxis) ticks() []float64 {
+	var ticks []float64
+	for i := 0; ; i++ {
+		value := a.min + float64(i)*a.unit
+		if value > a.max+a.unit*1e-9 || i > 1000 {
+			break
+		}
+		value, _ = strconv.ParseFloat(strconv.FormatFloat(value, 'g', 12, 64), 64)
+		ticks = append(ticks, value)
+	}
+	return ticks
+}
+
+// labels returns the tick labels of the value axis.
+func (a *chartImageAxis) labels() []string {
+	var labels []string
+	for _, value := range a.ticks() {
+		labels = append(labels, chartImageNumber(value, a.numFmt))
+	}
+	return labels
+}
+
+// setRange provides a function to set the position range of the axis, the
+// positions will be swapped for the axis in reverse order.
+func (a *chartImageAxis) setRange(start, end float64) {
+	if a.start, a.end = start, end; a.options != nil && a.options.ReverseOrder {
+		a.start, a.end = end, start
+	}
+}
+
+// pos returns the position in the image by given category index or value.
+func (a *chartImageAxis) pos(v float64) float64 {
+	if a.category {
+		n := float64(a.count)
+		if a.between {
+			return a.start + (v+0.5)*(a.end-a.start)/n
+		}
+		if n <= 1 {
+			return (a.start + a.end) / 2
+		}
+		return a.start + v*(a.end-a.start)/(n-1)
+	}
+	v = math.Max(a.min, math.Min(a.max, v))
+	return a.start + (v-a.min)/(a.max-a.min)*(a.end-a.start)
+}
+
+// band returns the width of a category on the category axis.
+func (a *chartImageAxis) band() float64 {
+	if a.between || a.count <= 1 {
+		return math.Abs(a.end-a.start) / float64(maxInt(a.count, 1))
+	}
+	return math.Abs(a.end-a.start) / float64(a.count-1)
+}
+
+// valueRange provides a function to get the range of the values of the chart
+// groups on the primary or secondary axis.
+func (r *chartImageRenderer) valueRange(secondary bool) (float64, float64, bool) {
+	lo, hi, found := math.Inf(1), math.Inf(-1), false
+	for _, g := range r.groups {
+		if g.secondary != secondary {
+			continue
+		}
+		found = true
+		for _, s := range g.series {
+			for i := range s.top {
+				for _, v := range []float64{s.base[i], s.top[i]} {
+					if !math.IsNaN(v) && (v != 0 || g.family != "scatter" || s.top[i] == 0) {
+						lo, hi = math.Min(lo, v), math.Max(hi, v)
+					}
+				}
+			}
+		}
+	}
+	return lo, hi, found
+}
+
+// xRange provides a function to get the range of the X values of the scatter
+// chart.
+func (r *chartImageRenderer) xRange() (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, g := range r.groups {
+		for _, s := range g.series {
+			for i, x := range s.x {
+				if i < len(s.values) && !math.IsNaN(x) {
+					lo, hi = math.Min(lo, x), math.Max(hi, x)
+				}
+			}
+		}
+	}
+	return lo, hi
+}
+
+// maxLabelWidth returns the maximum width of the labels.
+func (r *chartImageRenderer) maxLabelWidth(labels []string, fnt chartImageFont) float64 {
+	var width float64
+	for _, label := range labels {
+		width = math.Max(width, r.textWidth(label, fnt))
+	}
+	return width
+}
+
+// axisTitle returns the text of the axis title.
+func chartImageAxisTitle(opts *ChartAxis) (string, chartImageFont) {
+	var (
+		texts []string
+		fnt   = chartImageFontOf(nil, 10, "595959")
+	)
+	for i, run := range opts.Title {
+		if texts = append(texts, run.Text); i == 0 {
+			fnt = chartImageFontOf(run.Font, 10, "595959")
+		}
+	}
+	return strings.Join(texts, " "), fnt
+}
+
+// drawPlot provides a function to draw the axes and the series of the bar,
+// column, line, area and scatter chart in the given area.
+func (r *chartImageRenderer) drawPlot(area [4]float64) {
+	primary := r.groups[0]
+	chart := primary.chart
+	horizontal, scatter := primary.family == "bar", primary.family == "scatter"
+	catOpts, valOpts := &chart.XAxis, &chart.YAxis
+	catFont, valFont := chartImageFontOf(&catOpts.Font, 9, "595959"), chartImageFontOf(&valOpts.Font, 9, "595959")
+	var secOpts *ChartAxis
+	for _, g := range r.groups {
+		if g.secondary && secOpts == nil {
+			secOpts = &g.chart.YAxis
+		}
+	}
+	plot := area
+	catTitle, catTitleFont := chartImageAxisTitle(catOpts)
+	valTitle, valTitleFont := chartImageAxisTitle(valOpts)
+	// Reserve the space for the axis titles, the title of the vertical axis
+	// will be rotated.
+	if catTitle != "" {
+		if horizontal {
+			plot[0] += r.lineHeight(catTitleFont) + 4
+		} else {
+			plot[3] -= r.lineHeight(catTitleFont) + 4
+		}
+	}
+	if valTitle != "" {
+		if horizontal {
+			plot[3] -= r.lineHeight(valTitleFont) + 4
+		} else {
+			plot[0] += r.lineHeight(valTitleFont) + 4
+		}
+	}
+	var secTitle string
+	var secTitleFont chartImageFont
+	if secOpts != nil {
+		if secTitle, secTitleFont = chartImageAxisTitle(secOpts); secTitle != "" {
+			plot[2] -= r.lineHeight(secTitleFont) + 4
+		}
+	}
+	// Create the category axis, the X axis of the scatter chart is a value
+	// axis.
+	var catAxis *chartImageAxis
+	var catLabels []string
+	if scatter {
+		lo, hi := r.xRange()
+		length := plot[3] - plot[1]
+		if !horizontal {
+			length = plot[2] - plot[0]
+		}
+		catAxis = r.newValueAxis(catOpts, Scatter, lo, hi, length)
+		catAxis.numFmt = catOpts.NumFmt.CustomNumFmt
+		catLabels = catAxis.labels()
+	} else {
+		catAxis = &chartImageAxis{options: catOpts, category: true, count: maxInt(r.count, 1)}
+		catAxis.between = chartValAxCrossBetween[chart.Type] != "midCat"
+		for _, g := range r.groups {
+			catAxis.between = catAxis.between || g.family == "bar" || g.family == "col"
+		}
+		for i := 0; i < catAxis.count; i++ {
+			catLabels = append(catLabels, r.category(i))
+		}
+	}
+	lineHeight := r.lineHeight(valFont)
+	valLength := plot[3] - plot[1] - lineHeight - 4
+	if horizontal {
+		valLength = plot[2] - plot[0]
+	}
+	lo, hi, _ := r.valueRange(false)
+	valAxis := r.newValueAxis(valOpts, chart.Type, lo, hi, valLength)
+	var secAxis *chartImageAxis
+	if secOpts != nil {
+		lo, hi, _ := r.valueRange(true)
+		secChart := chart.Type
+		for _, g := range r.groups {
+			if g.secondary {
+				secChart = g.chart.Type
+				break
+			}
+		}
+		secAxis = r.newValueAxis(secOpts, secChart, lo, hi, valLength)
+	}
+	// Reserve the space for the tick labels of the axes.
+	valLabels := valAxis.labels()
+	if horizontal {
+		if !catOpts.None {
+			plot[0] += math.Min(r.maxLabelWidth(catLabels, catFont), (area[2]-area[0])*0.4) + 6
+		}
+		if !valOpts.None {
+			plot[3] -= r.lineHeight(valFont) + 2
+		}
+	} else {
+		if !valOpts.None {
+			plot[0] += r.maxLabelWidth(valLabels, valFont) + 6
+		}
+		if !catOpts.None {
+			plot[3] -= r.lineHeight(catFont) + 2
+		}
+		if secAxis != nil && !secOpts.None {
+			plot[2] -= r.maxLabelWidth(secAxis.labels(), valFont) + 6
+		}
+	}
+	plot[1] += 4
+	plot[2] -= 4
+	if !horizontal && !catOpts.None && len(catLabels) > 0 && (scatter || !catAxis.between) {
+		// Keep the labels at both ends of the horizontal axis inside the chart.
+		plot[0] = math.Max(plot[0], area[0]+r.textWidth(catLabels[0], catFont)/2)
+		plot[2] = math.Min(plot[2], area[2]-r.textWidth(catLabels[len(catLabels)-1], catFont)/2)
+	}
+	if plot[2]-plot[0] < 1 || plot[3]-plot[1] < 1 {
+		return
+	}
+	if horizontal {
+		catAxis.setRange(plot[3], plot[1])
+		valAxis.setRange(plot[0], plot[2])
+	} else {
+		catAxis.setRange(plot[0], plot[2])
+		valAxis.setRange(plot[3], plot[1])
+		if secAxis != nil {
+			secAxis.setRange(plot[3], plot[1])
+		}
+	}
+	if chartImageHasFill(chart.PlotArea.Fill) {
+		r.addRect(plot[0], plot[1], plot[2], plot[3], htmlColor(chart.PlotArea.Fill.Color[0]), "", 0)
+	}
+	r.drawGridLines(plot, catAxis, valAxis, horizontal)
+	for _, g := range r.groups {
+		axis := valAxis
+		if g.secondary && secAxis != nil {
+			axis = secAxis
+		}
+		switch g.family {
+		case "area":
+			r.drawArea(g, catAxis, axis)
+		case "bar", "col":
+			r.drawBars(g, catAxis, axis, horizontal)
+		default:
+			r.drawLines(g, catAxis, axis, scatter)
+		}
+	}
+	// Draw the axis lines at the crossing points and the tick labels.
+	line := "#D9D9D9"
+	cross := valAxis.pos(math.Max(valAxis.min, math.Min(valAxis.max, 0)))
+	catCross := plot[0]
+	if scatter {
+		catCross = catAxis.pos(math.Max(catAxis.min, math.Min(catAxis.max, 0)))
+	}
+	if horizontal {
+		if !catOpts.None {
+			r.addShape([][2]float64{{cross, plot[1]}, {cross, plot[3]}}, false, "", line, 1)
+		}
+		if !valOpts.None {
+			for i, value := range valAxis.ticks() {
+				r.addText(valLabels[i], valAxis.pos(value), plot[3]+2+lineHeight/2, valFont, "middle", false)
+			}
+		}
+		if !catOpts.None {
+			step := r.labelStep(catOpts, catAxis, r.lineHeight(catFont))
+			for i := 0; i < len(catLabels); i += step {
+				r.addText(catLabels[i], plot[0]-6, catAxis.pos(float64(i)), catFont, "end", false)
+			}
+		}
+		r.addText(catTitle, area[0]+r.lineHeight(catTitleFont)/2, (plot[1]+plot[3])/2, catTitleFont, "middle", true)
+		r.addText(valTitle, (plot[0]+plot[2])/2, area[3]-r.lineHeight(valTitleFont)/2, valTitleFont, "middle", false)
+		return
+	}
+	if !catOpts.None {
+		r.addShape([][2]float64{{plot[0], cross}, {plot[2], cross}}, false, "", line, 1)
+		labelY := plot[3] + 2 + r.lineHeight(catFont)/2
+		if scatter {
+			for i, value := range catAxis.ticks() {
+				r.addText(catLabels[i], catAxis.pos(value), labelY, catFont, "middle", false)
+			}
+		} else {
+			step := r.labelStep(catOpts, catAxis, r.maxLabelWidth(catLabels, catFont)+6)
+			for i := 0; i < len(catLabels); i += step {
+				r.addText(catLabels[i], catAxis.pos(float64(i)), labelY, catFont, "middle", false)
+			}
+		}
+	}
+	if scatter && !valOpts.None {
+		r.addShape([][2]float64{{catCross, plot[1]}, {catCross, plot[3]}}, false, "", line, 1)
+	}
+	if !valOpts.None {
+		for i, value := range valAxis.ticks() {
+			r.addText(valLabels[i], plot[0]-6, valAxis.pos(value), valFont, "end", false)
+		}
+	}
+	if secAxis != nil && !secOpts.None {
+		labels := secAxis.labels()
+		for i, value := range secAxis.ticks() {
+			r.addText(labels[i], plot[2]+6, secAxis.pos(value), valFont, "start", false)
+		}
+		r.addText(secTitle, area[2]-r.lineHeight(secTitleFont)/2, (plot[1]+plot[3])/2, secTitleFont, "middle", true)
+	}
+	r.addText(catTitle, (plot[0]+plot[2])/2, area[3]-r.lineHeight(catTitleFont)/2, catTitleFont, "middle", false)
+	r.addText(valTitle, area[0]+r.lineHeight(valTitleFont)/2, (plot[1]+plot[3])/2, valTitleFont, "middle", true)
+}
+
+// labelStep returns the interval of the category labels to avoid the labels
+// overlapping by given label size.
+func (r *chartImageRenderer) labelStep(opts *ChartAxis, axis *chartImageAxis, size float64) int {
+	if opts.TickLabelSkip > 0 {
+		return opts.TickLabelSkip
+	}
+	if band := axis.band(); band > 0 && size > band {
+		return int(math.Ceil(size / band))
+	}
+	return 1
+}
+
+// drawGridLines provides a function to draw the major grid lines of the axes.
+func (r *chartImageRenderer) drawGridLines(plot [4]float64, catAxis, valAxis *chartImageAxis, horizontal bool) {
+	line := func(pos float64, vertical bool) {
+		if vertical {
+			r.addShape([][2]float64{{pos, plot[1]}, {pos, plot[3]}}, false, "", "#D9D9D9", 1)
+			return
+		}
+		r.addShape([][2]float64{{plot[0], pos}, {plot[2], pos}}, false, "", "#D9D9D9", 1)
+	}
+	if valAxis.options.MajorGridLines {
+		for _, value := range valAxis.ticks() {
+			line(valAxis.pos(value), horizontal)
+		}
+	}
+	if !catAxis.options.MajorGridLines {
+		return
+	}
+	if !catAxis.category {
+		for _, value := range catAxis.ticks() {
+			line(catAxis.pos(value), !horizontal)
+		}
+		return
+	}
+	for i := 0; i <= catAxis.count; i++ {
+		pos := catAxis.pos(float64(i))
+		if catAxis.between {
+			pos = catAxis.pos(float64(i) - 0.5)
+		} else if i == catAxis.count {
+			break
+		}
+		line(pos, !horizontal)
+	}
+}
+
+// drawBars provides a function to draw the series of the bar or column chart.
+func (r *chartImageRenderer) drawBars(g *chartImageGroup, catAxis, valAxis *chartImageAxis, horizontal bool) {
+	slots := len(g.series)
+	stacked := g.grouping == "stacked" || g.grouping == "percentStacked"
+	if stacked {
+		slots = 1
+	}
+	if slots == 0 {
+		return
+	}
+	fnt := chartImageFontOf(nil, 9, "404040")
+	width := catAxis.band() / (float64(slots) + 1.5)
+	for j, s := range g.series {
+		offset := 0.0
+		if !stacked {
+			offset = (float64(j) - float64(slots-1)/2) * width
+		}
+		for i := range s.top {
+			if math.IsNaN(s.top[i]) {
+				continue
+			}
+			center := catAxis.pos(float64(i))
+			if catAxis.start > catAxis.end {
+				center -= offset
+			} else {
+				center += offset
+			}
+			c0, c1 := center-width/2, center+width/2
+			v0, v1 := valAxis.pos(s.base[i]), valAxis.pos(s.top[i])
+			if horizontal {
+				r.addRect(v0, c0, v1, c1, r.pointColor(g, s, i), "", 0)
+			} else {
+				r.addRect(c0, v0, c1, v1, r.pointColor(g, s, i), "", 0)
+			}
+			text := r.dataLabel(g, s, i, 0)
+			if text == "" {
+				continue
+			}
+			pos := s.options.DataLabelPosition
+			if pos == ChartDataLabelsPositionUnset {
+				if pos = ChartDataLabelsPositionOutsideEnd; stacked {
+					pos = ChartDataLabelsPositionCenter
+				}
+			}
+			dir := 1.0
+			if v1 < v0 {
+				dir = -1
+			}
+			labelPos := map[ChartDataLabelPositionType]float64{
+				ChartDataLabelsPositionCenter:     (v0 + v1) / 2,
+				ChartDataLabelsPositionInsideBase: v0 + dir*8,
+				ChartDataLabelsPositionInsideEnd:  v1 - dir*8,
+			}
+			value, ok := labelPos[pos]
+			if !ok {
+				value = v1 + dir*8
+				if horizontal {
+					value = v1 + dir*(r.textWidth(text, fnt)/2+4)
+				}
+			}
+			if horizontal {
+				r.addText(text, value, center, fnt, "middle", false)
+			} else {
+				r.addText(text, center, value, fnt, "middle", false)
+			}
+		}
+	}
+}
+
+// drawArea provides a function to draw the series of the area chart.
+func (r *chartImageRenderer) drawArea(g *chartImageGroup, catAxis, valAxis *chartImageAxis) {
+	fnt := chartImageFontOf(nil, 9, "404040")
+	for _, s := range g.series {
+		var top, base [][2]float64
+		for i := range s.top {
+			t, b := s.top[i], s.base[i]
+			if math.IsNaN(t) {
+				t, b = 0, 0
+			}
+			x := catAxis.pos(float64(i))
+			top = append(top, [2]float64{x, valAxis.pos(t)})
+			base = append([][2]float64{{x, valAxis.pos(b)}}, base...)
+		}
+		r.addShape(append(top, base...), true, s.color, "", 0)
+		for i := range s.top {
+			if text := r.dataLabel(g, s, i, 0); text != "" {
+				r.addText(text, top[i][0], (top[i][1]+base[len(base)-1-i][1])/2, fnt, "middle", false)
+			}
+		}
+	}
+}
+
+// drawLines provides a function to draw the series of the line or scatter
+// chart with the markers.
+func (r *chartImageRenderer) drawLines(g *chartImageGroup, catAxis, valAxis *chartImageAxis, scatter bool) {
+	fnt := chartImageFontOf(nil, 9, "404040")
+	blanks := r.groups[0].chart.ShowBlanksAs
+	for _, s := range g.series {
+		var (
+			segments [][][2]float64
+			points   = make([][2]float64, len(s.top))
+			segment  [][2]float64
+		)
+		for i := range s.top {
+			value, x := s.top[i], float64(i)
+			if scatter {
+				if x = math.NaN(); i < len(s.x) {
+					x = s.x[i]
+				}
+			}
+			if math.IsNaN(value) && blanks == "zero" && !scatter {
+				value = 0
+			}
+			if math.IsNaN(value) || math.IsNaN(x) {
+				points[i] = [2]float64{math.NaN(), math.NaN()}
+				if blanks != "span" && len(segment) > 0 {
+					segments, segment = append(segments, segment), nil
+				}
+				continue
+			}
+			points[i] = [2]float64{catAxis.pos(x), valAxis.pos(value)}
+			segment = append(segment, points[i])
+		}
+		if len(segment) > 0 {
+			segments = append(segments, segment)
+		}
+		if r.lineVisible(g, s) {
+			width := 2.25
+			if s.options.Line.Width > 0 {
+				width = s.options.Line.Width
+			}
+			for _, segment := range segments {
+				if s.options.Line.Smooth {
+					segment = chartImageSmooth(segment)
+				}
+				r.addShape(segment, false, "", s.color, width*4/3)
+			}
+		}
+		symbol, fill := r.markerSymbol(g, s), s.color
+		if chartImageHasFill(s.options.Marker.Fill) {
+			fill = htmlColor(s.options.Marker.Fill.Color[0])
+		}
+		for i, p := range points {
+			if math.IsNaN(p[0]) {
+				continue
+			}
+			r.drawMarker(symbol, p[0], p[1], float64(s.options.Marker.Size), fill, s.color)
+			if text := r.dataLabel(g, s, i, 0); text != "" {
+				switch s.options.DataLabelPosition {
+				case ChartDataLabelsPositionCenter:
+					r.addText(text, p[0], p[1], fnt, "middle", false)
+				case ChartDataLabelsPositionLeft:
+					r.addText(text, p[0]-6, p[1], fnt, "end", false)
+				case ChartDataLabelsPositionAbove:
+					r.addText(text, p[0], p[1]-r.lineHeight(fnt)/2-3, fnt, "middle", false)
+				case ChartDataLabelsPositionBelow:
+					r.addText(text, p[0], p[1]+r.lineHeight(fnt)/2+3, fnt, "middle", false)
+				default:
+					r.addText(text, p[0]+6, p[1], fnt, "start", false)
+				}
+			}
+		}
+	}
+}
+
+// chartImageSmooth returns the points of the smooth curve through the given
+// points by the Catmull-Rom spline.
+func chartImageSmooth(points [][2]float64) [][2]float64 {
+	if len(points) < 3 {
+		return points
+	}
+	smooth := [][2]float64{points[0]}
+	for i := 0; i < len(points)-1; i++ {
+		p0, p1, p2, p3 := points[maxInt(i-1, 0)], points[i], points[i+1], points[minInt(i+2, len(points)-1)]
+		for step := 1; step <= 16; step++ {
+			t := float64(step) / 16
+			t2, t3 := t*t, t*t*t
+			var p [2]float64
+			for k := 0; k < 2; k++ {
+				p[k] = 0.5 * (2*p1[k] + (p2[k]-p0[k])*t + (2*p0[k]-5*p1[k]+4*p2[k]-p3[k])*t2 + (3*p1[k]-p0[k]-3*p2[k]+p3[k])*t3)
+			}
+			smooth = append(smooth, p)
+		}
+	}
+	return smooth
+}
+
+// chartImageNum returns the string of the number in the SVG image.
+func chartImageNum(num float64) string {
+	s := strconv.FormatFloat(math.Round(num*100)/100, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// writeSVG provides a function to write the drawing elements as SVG image.
+func (r *chartImageRenderer) writeSVG(w io.Writer) error {
+	var buf bytes.Buffer
+	width, height := chartImageNum(r.width), chartImageNum(r.height)
+	buf.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + width + `" height="` + height +
+		`" viewBox="0 0 ` + width + " " + height + `">`)
+	for _, e := range r.elements {
+		if e.text != "" {
+			buf.WriteString(`<text x="` + chartImageNum(e.x) + `" y="` + chartImageNum(e.y) +
+				`" font-family="` + html.EscapeString(e.font.family) + `, sans-serif" font-size="` +
+				chartImageNum(e.font.size*4/3) + `" fill="` + e.font.color + `"`)
+			if e.font.bold {
+				buf.WriteString(` font-weight="bold"`)
+			}
+			if e.font.italic {
+				buf.WriteString(` font-style="italic"`)
+			}
+			if e.anchor == "middle" || e.anchor == "end" {
+				buf.WriteString(` text-anchor="` + e.anchor + `"`)
+			}
+			if e.rotate {
+				buf.WriteString(` transform="rotate(-90 ` + chartImageNum(e.cx) + " " + chartImageNum(e.cy) + `)"`)
+			}
+			buf.WriteString(">" + html.EscapeString(e.text) + "</text>")
+			continue
+		}
+		buf.WriteString(`<path d="`)
+		for i, p := range e.points {
+			if i == 0 {
+				buf.WriteString("M")
+			} else {
+				buf.WriteString(" L")
+			}
+			buf.WriteString(chartImageNum(p[0]) + " " + chartImageNum(p[1]))
+		}
+		if e.closed {
+			buf.WriteString(" Z")
+		}
+		fill := e.fill
+		if fill == "" {
+			fill = "none"
+		}
+		buf.WriteString(`" fill="` + fill + `"`)
+		if e.stroke != "" && e.width > 0 {
+			buf.WriteString(` stroke="` + e.stroke + `" stroke-width="` + chartImageNum(e.width) + `" stroke-linejoin="round"`)
+		}
+		buf.WriteString("/>")
+	}
+	buf.WriteString("</svg>")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// chartImageRGBA returns the color by given RGB color in hex.
+func chartImageRGBA(clr string) color.RGBA {
+	rgb, _ := strconv.ParseUint(strings.TrimPrefix(htmlColor(clr), "#"), 16, 32)
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
+
+// writePNG provides a function to rasterize the drawing elements and write
+// the PNG image.
+func (r *chartImageRenderer) writePNG(w io.Writer) error {
+	width, height := maxInt(int(math.Ceil(r.width)), 1), maxInt(int(math.Ceil(r.height)), 1)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	z := vector.NewRasterizer(width, height)
+	for i := range r.elements {
+		e := &r.elements[i]
+		if e.text != "" {
+			r.drawPNGText(img, e)
+			continue
+		}
+		if e.fill != "" && len(e.points) > 2 {
+			z.Reset(width, height)
+			for j, p := range e.points {
+				if j == 0 {
+					z.MoveTo(float32(p[0]), float32(p[1]))
+					continue
+				}
+				z.LineTo(float32(p[0]), float32(p[1]))
+			}
+			z.ClosePath()
+			z.Draw(img, img.Bounds(), image.NewUniform(chartImageRGBA(e.fill)), image.Point{})
+		}
+		if e.stroke != "" && e.width > 0 {
+			z.Reset(width, height)
+			chartImageStroke(z, e.points, e.closed, e.width)
+			z.Draw(img, img.Bounds(), image.NewUniform(chartImageRGBA(e.stroke)), image.Point{})
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// chartImageStroke provides a function to add the outline of the polyline
+// with the round joins to the rasterizer, all sub-paths have the same
+// orientation to avoid the overlapped area being cancelled.
+func chartImageStroke(z *vector.Rasterizer, points [][2]float64, closed bool, width float64) {
+	if closed {
+		points = append(append([][2]float64{}, points...), points[0])
+	}
+	hw := width / 2
+	for i := 1; i < len(points); i++ {
+		p, q := points[i-1], points[i]
+		dx, dy := q[0]-p[0], q[1]-p[1]
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		nx, ny := -dy/length*hw, dx/length*hw
+		z.MoveTo(float32(p[0]+nx), float32(p[1]+ny))
+		z.LineTo(float32(q[0]+nx), float32(q[1]+ny))
+		z.LineTo(float32(q[0]-nx), float32(q[1]-ny))
+		z.LineTo(float32(p[0]-nx), float32(p[1]-ny))
+		z.ClosePath()
+	}
+	if width <= 1.5 {
+		return
+	}
+	for i := 1; i < len(points)-1 || (closed && i < len(points)); i++ {
+		for j, p := range chartImageArc(points[i][0], points[i][1], hw, 0, -2*math.Pi) {
+			if j == 0 {
+				z.MoveTo(float32(p[0]), float32(p[1]))
+				continue
+			}
+			z.LineTo(float32(p[0]), float32(p[1]))
+		}
+		z.ClosePath()
+	}
+}
+
+// drawPNGText provides a function to draw the text element on the image, the
+// rotated text will be drawn on a temporary image and then rotated.
+func (r *chartImageRenderer) drawPNGText(img *image.RGBA, e *chartImageElement) {
+	face := r.face(e.font)
+	src := image.NewUniform(chartImageRGBA(e.font.color))
+	width := r.textWidth(e.text, e.font)
+	x := e.x
+	switch e.anchor {
+	case "middle":
+		x -= width / 2
+	case "end":
+		x -= width
+	}
+	if !e.rotate {
+		d := font.Drawer{Dst: img, Src: src, Face: face, Dot: fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(e.y * 64)}}
+		d.DrawString(e.text)
+		return
+	}
+	metrics := face.Metrics()
+	ascent := float64(metrics.Ascent) / 64
+	tmp := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(width))+2, int(math.Ceil(ascent+float64(metrics.Descent)/64))+2))
+	d := font.Drawer{Dst: tmp, Src: src, Face: face, Dot: fixed.Point26_6{X: 64, Y: fixed.Int26_6((1 + ascent) * 64)}}
+	d.DrawString(e.text)
+	bounds := tmp.Bounds()
+	for ty := 0; ty < bounds.Dy(); ty++ {
+		for tx := 0; tx < bounds.Dx(); tx++ {
+			c := tmp.RGBAAt(tx, ty)
+			if c.A == 0 {
+				continue
+			}
+			px, py := x-1+float64(tx), e.y-1-ascent+float64(ty)
+			dx, dy := int(math.Round(e.cx+py-e.cy)), int(math.Round(e.cy-px+e.cx))
+			if !(image.Point{X: dx, Y: dy}).In(img.Bounds()) {
+				continue
+			}
+			dst, a := img.RGBAAt(dx, dy), 255-uint32(c.A)
+			img.SetRGBA(dx, dy, color.RGBA{
+				R: uint8(uint32(c.R) + uint32(dst.R)*a/255), G: uint8(uint32(c.G) + uint32(dst.G)*a/255),
+				B: uint8(uint32(c.B) + uint32(dst.B)*a/255), A: uint8(uint32(c.A) + uint32(dst.A)*a/255),
+			})
+		}
+	}
+}
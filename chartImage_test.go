@@ -0,0 +1,231 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareChartImageData(t *testing.T) (*File, []ChartSeries) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	return f, []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	f, series := prepareChartImageData(t)
+	for _, chartType := range []ChartType{
+		Area, AreaStacked, AreaPercentStacked, Area3D, Bar, BarStacked, BarPercentStacked, Bar3DClustered,
+		Col, ColStacked, ColPercentStacked, Col3D, Col3DCylinderClustered, Line, Line3D, Scatter,
+	} {
+		var buf bytes.Buffer
+		assert.NoError(t, f.RenderChart(&buf, &ChartImageOptions{Format: "svg"}, &Chart{
+			Type:     chartType,
+			Series:   series,
+			Title:    []RichTextRun{{Text: "Fruit"}, {Text: "Sales", Font: &Font{Bold: true, Italic: true, Color: "FF0000"}}},
+			PlotArea: ChartPlotArea{ShowVal: true, ShowCatName: true, ShowSerName: true},
+			XAxis:    ChartAxis{Title: []RichTextRun{{Text: "Category"}}, MajorGridLines: true},
+			YAxis:    ChartAxis{Title: []RichTextRun{{Text: "Value"}}, MajorGridLines: true},
+		}))
+		svg := buf.String()
+		assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="480" height="260"`), chartType)
+		for _, text := range []string{">Fruit</text>", `font-weight="bold" font-style="italic"`, ">Small</text>", ">Category</text>", ">Value</text>", `transform="rotate(-90`} {
+			assert.Contains(t, svg, text, chartType)
+		}
+		if chartType != Scatter {
+			assert.Contains(t, svg, ">Apple</text>", chartType)
+		}
+		if chartType == AreaPercentStacked || chartType == BarPercentStacked || chartType == ColPercentStacked {
+			assert.Contains(t, svg, ">100%</text>", chartType)
+		}
+	}
+	// Test render the pie and doughnut chart with the varied colors
+	for _, chartType := range []ChartType{Pie, Pie3D, Doughnut} {
+		var buf bytes.Buffer
+		assert.NoError(t, f.RenderChart(&buf, &ChartImageOptions{Format: "svg", Width: 320, Height: 320}, &Chart{
+			Type:     chartType,
+			Series:   []ChartSeries{series[0], {Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", DataLabelPosition: ChartDataLabelsPositionOutsideEnd}},
+			PlotArea: ChartPlotArea{ShowPercent: true, ShowVal: true},
+			Legend:   ChartLegend{Position: "right"},
+			HoleSize: 50,
+		}))
+		svg := buf.String()
+		assert.Contains(t, svg, `width="320" height="320"`)
+		assert.Contains(t, svg, ">2, 25%</text>")
+		for _, clr := range []string{"#5B9BD5", "#ED7D31", "#A5A5A5"} {
+			assert.Contains(t, svg, `fill="`+clr+`" stroke="#FFFFFF"`)
+		}
+		assert.Contains(t, svg, ">Pear</text>")
+	}
+	// Test render the chart with custom colors, markers, smooth lines and blanks
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", nil))
+	for _, chartType := range []ChartType{Line, Scatter, Col} {
+		var buf bytes.Buffer
+		assert.NoError(t, f.RenderChart(&buf, &ChartImageOptions{Format: "svg"}, &Chart{
+			Type: chartType,
+			Series: []ChartSeries{
+				{
+					Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+					Fill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"00FF00"}},
+					Line:   ChartLine{Smooth: true, Width: 3},
+					Marker: ChartMarker{Symbol: "square", Size: 8},
+				},
+				{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Marker: ChartMarker{Symbol: "auto"}},
+				{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4", Line: ChartLine{Type: ChartLineNone}},
+			},
+			Legend:       ChartLegend{Position: "top"},
+			ShowBlanksAs: "span",
+			YAxis:        ChartAxis{Maximum: float64Ptr(20), Minimum: float64Ptr(-10), MajorUnit: 10, NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}},
+			XAxis:        ChartAxis{ReverseOrder: true},
+		}))
+		svg := buf.String()
+		assert.Contains(t, svg, `#00FF00`)
+		assert.Contains(t, svg, ">20.00</text>")
+		assert.Contains(t, svg, ">-10.00</text>")
+	}
+	// Test render the combo chart with the secondary axis
+	var buf bytes.Buffer
+	assert.NoError(t, f.RenderChart(&buf, &ChartImageOptions{Format: "svg"}, &Chart{Type: Col, Series: series[:2]},
+		&Chart{Type: Line, Series: series[2:], YAxis: ChartAxis{Secondary: true, Title: []RichTextRun{{Text: "Secondary"}}}},
+		&Chart{Type: Bar, Series: series[2:]}))
+	assert.Contains(t, buf.String(), ">Secondary</text>")
+	assert.Contains(t, buf.String(), ">Large</text>")
+	// Test render the chart to PNG image
+	buf.Reset()
+	assert.NoError(t, f.RenderChart(&buf, nil, &Chart{
+		Type: Col, Series: series, Title: []RichTextRun{{Text: "Fruit"}},
+		YAxis: ChartAxis{Title: []RichTextRun{{Text: "Value"}}},
+	}))
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 480, img.Bounds().Dx())
+	assert.Equal(t, 260, img.Bounds().Dy())
+	// Test render the chart with unsupported image format
+	assert.Equal(t, ErrImgExt, f.RenderChart(&buf, &ChartImageOptions{Format: "jpg"}, &Chart{Type: Col, Series: series}))
+	// Test render chart with the image size exceeds the limit
+	assert.Equal(t, ErrChartImageSize, f.RenderChart(&buf, &ChartImageOptions{Width: 100000, Height: 100000}, &Chart{Type: Col, Series: series}))
+	assert.Equal(t, ErrChartImageSize, f.RenderChart(&buf, nil, &Chart{Type: Col, Series: series, Dimension: ChartDimension{Width: 100000, Height: 100000}}))
+	// Test render the chart without chart format sets
+	assert.Equal(t, ErrParameterInvalid, f.RenderChart(&buf, nil, nil))
+	// Test render the chart with unsupported chart type
+	assert.EqualError(t, f.RenderChart(&buf, nil, &Chart{Type: Radar, Series: series}), newUnsupportedChartType(Radar).Error())
+	// Test render the chart with invalid chart format sets
+	assert.EqualError(t, f.RenderChart(&buf, nil, &Chart{Type: ChartType(100), Series: series}), newUnsupportedChartType(ChartType(100)).Error())
+	// Test render the chart with the reference of not exists worksheet
+	assert.EqualError(t, f.RenderChart(&buf, nil, &Chart{Type: Col, Series: []ChartSeries{{Values: "SheetN!$B$2:$D$2"}}}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RenderChart(&buf, nil, &Chart{Type: Col, Series: []ChartSeries{{Name: "SheetN!$A$2", Values: "Sheet1!$B$2:$D$2"}}}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RenderChart(&buf, nil, &Chart{Type: Col, Series: []ChartSeries{{Categories: "SheetN!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}}), "sheet SheetN does not exist")
+	// Test render the chart with writer error
+	assert.EqualError(t, f.RenderChart(errorWriter{}, &ChartImageOptions{Format: "svg"}, &Chart{Type: Col, Series: series}), "write error")
+	assert.EqualError(t, f.RenderChart(errorWriter{}, nil, &Chart{Type: Col, Series: series}), "write error")
+	assert.NoError(t, f.Close())
+}
+
+func TestExportChart(t *testing.T) {
+	f, series := prepareChartImageData(t)
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 20))
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type:     Col,
+		Series:   series,
+		Title:    []RichTextRun{{Text: "Fruit"}},
+		Legend:   ChartLegend{Position: "left"},
+		PlotArea: ChartPlotArea{ShowVal: true, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}},
+		XAxis:    ChartAxis{Title: []RichTextRun{{Text: "Category"}}},
+		YAxis:    ChartAxis{MajorGridLines: true, Maximum: float64Ptr(10), ReverseOrder: true},
+	}, &Chart{Type: Line, Series: series[2:], YAxis: ChartAxis{Secondary: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{
+		Type:   Pie,
+		Series: series[:1],
+		Format: GraphicOptions{ScaleX: 0.5, ScaleY: 0.5},
+		Legend: ChartLegend{Position: "none"},
+	}))
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportChart("Sheet1", "F1", &buf, ChartImageOptions{Format: "svg"}))
+	svg := buf.String()
+	assert.Contains(t, svg, `width="480" height="260"`)
+	assert.Contains(t, svg, ">Fruit</text>")
+	assert.Contains(t, svg, ">Small</text>")
+	assert.Contains(t, svg, `fill="#FFFF00"`)
+	buf.Reset()
+	assert.NoError(t, f.ExportChart("Sheet1", "F20", &buf))
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 240, img.Bounds().Dx())
+	assert.Equal(t, 130, img.Bounds().Dy())
+	path := filepath.Join("test", "TestExportChart.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.ExportChart("Sheet1", "F1", &buf, ChartImageOptions{Format: "svg"}))
+	assert.Equal(t, svg, buf.String())
+	// Test export the chart with the cached values when the worksheet doesn't exist
+	assert.NoError(t, f.SetSheetName("Sheet1", "Sheet2"))
+	chartXML, ok := f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/charts/chart2.xml", []byte(strings.NewReplacer(
+		"<f>Sheet1!$A$2</f>", "<f>Sheet1!$A$2</f><strCache><ptCount val=\"1\"/><pt idx=\"0\"><v>Small</v></pt></strCache>",
+		"<f>Sheet1!$B$1:$D$1</f>", "<f>Sheet1!$B$1:$D$1</f><strCache><ptCount val=\"3\"/><pt idx=\"0\"><v>Apple</v></pt><pt idx=\"2\"><v>Pear</v></pt></strCache>",
+		"<f>Sheet1!$B$2:$D$2</f>", "<f>Sheet1!$B$2:$D$2</f><numCache><formatCode>General</formatCode><ptCount val=\"3\"/><pt idx=\"0\"><v>2</v></pt><pt idx=\"2\"><v>6</v></pt></numCache>",
+		"<showVal val=\"0\"></showVal>", "<showVal val=\"1\"></showVal>",
+	).Replace(string(chartXML.([]byte)))))
+	buf.Reset()
+	assert.NoError(t, f.ExportChart("Sheet2", "F20", &buf, ChartImageOptions{Format: "svg", Width: 200, Height: 200}))
+	assert.Contains(t, buf.String(), `width="200" height="200"`)
+	assert.Contains(t, buf.String(), ">6</text>")
+	assert.Contains(t, buf.String(), `fill="#A5A5A5" stroke="#FFFFFF"`)
+	// Test export the chart with not exists chart
+	assert.EqualError(t, f.ExportChart("Sheet2", "A1", &buf), "chart at cell A1 on sheet Sheet2 does not exist")
+	// Test export the chart with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ExportChart("Sheet2", "A", &buf))
+	// Test export the chart with not exists worksheet
+	assert.EqualError(t, f.ExportChart("SheetN", "F1", &buf), "sheet SheetN does not exist")
+	// Test export the chart with unsupported image format
+	assert.Equal(t, ErrImgExt, f.ExportChart("Sheet2", "F1", &buf, ChartImageOptions{Format: "bmp"}))
+	assert.Equal(t, ErrChartImageSize, f.ExportChart("Sheet2", "F1", &buf, ChartImageOptions{Format: "svg", Width: 8193, Height: 8192}))
+	assert.NoError(t, f.Close())
+
+	// Test export the chart on the worksheet without drawing
+	f = NewFile()
+	assert.EqualError(t, f.ExportChart("Sheet1", "A1", &buf), "chart at cell A1 on sheet Sheet1 does not exist")
+	// Test export the chart with unsupported chart type
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Radar, Series: series}))
+	assert.EqualError(t, f.ExportChart("Sheet1", "A1", &buf), newUnsupportedChartType(Radar).Error())
+	// Test export the chart with unsupported charset
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportChart("Sheet1", "A1", &buf), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartImageDecodeType(t *testing.T) {
+	for name, expected := range map[string]ChartType{
+		"barChart": Col, "bar3DChart": Col3DClustered, "areaChart": Area, "ofPieChart": BarOfPie,
+	} {
+		chartType, ok := chartImageDecodeType(&decodeChartGroup{
+			XMLName:   xml.Name{Local: name},
+			Grouping:  &attrValString{Val: stringPtr("unknown")},
+			OfPieType: &attrValString{Val: stringPtr("bar")},
+		})
+		assert.True(t, ok)
+		assert.Equal(t, expected, chartType)
+	}
+	_, ok := chartImageDecodeType(&decodeChartGroup{XMLName: xml.Name{Local: "layout"}})
+	assert.False(t, ok)
+}
@@ -12,7 +12,9 @@
 package excelize
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
 	"math"
 	"strconv"
@@ -39,8 +41,35 @@ type Cols struct {
 	f                                      *File
 	sheetXML                               []byte
 	sst                                    *xlsxSST
+	index                                  *colIndex
 }
 
+// colIndex defined the temporary on-disk column index of the worksheet for
+// the columns iterator. Each cell of the worksheet will be stored as a record
+// in the index file, and each record contains the offset of the previous
+// record in the same column, so only the offset of the last record of each
+// column will be kept in memory.
+type colIndex struct {
+	file        TempFile
+	writer      *bufio.Writer
+	offset      int64
+	lastCellRow int
+	tails       []int64
+	buf         []byte
+	bufOffset   int64
+}
+
+const (
+	// colIndexRecordHeaderSize defined the size of the fixed length header of
+	// the column index record, which contains the offset of the previous
+	// record, the row number, the style index, and the length of the cell
+	// type and value.
+	colIndexRecordHeaderSize = 8 + 4 + 4 + 1 + 4
+	// colIndexBlockSize defined the size of the block to read the column
+	// index records backwards.
+	colIndexBlockSize = 64 << 10
+)
+
 // GetCols gets the value of all cells by columns on the worksheet based on the
 // given worksheet name, returned as a two-dimensional array, where the value
 // of the cell is converted to the `string` type. If the cell format can be
@@ -72,7 +101,7 @@ func (f *File) GetCols(sheet string, opts ...Options) ([][]string, error) {
 		col, _ := cols.Rows(opts...)
 		results = append(results, col)
 	}
-	return results, nil
+	return results, cols.Close()
 }
 
 // Next will return true if the next column is found.
@@ -86,6 +115,23 @@ func (cols *Cols) Error() error {
 	return cols.err
 }
 
+// Close closes and removes the temporary column index file of the iterator.
+// The column index file which hasn't been closed will be removed when closing
+// the spreadsheet.
+func (cols *Cols) Close() error {
+	if cols.index == nil {
+		return nil
+	}
+	name := cols.index.file.Name()
+	err := cols.index.file.Close()
+	cols.index = nil
+	cols.f.tempIndexes.Delete(name)
+	if removeErr := cols.f.tempStorage().Remove(name); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
 // Rows return the current column's row values.
 func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 	var rowIterator rowXMLIterator
@@ -96,6 +142,9 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
+	if cols.index != nil {
+		return cols.index.rows(cols)
+	}
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
 	for {
 		token, _ := decoder.Token()
@@ -188,9 +237,154 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 	}
 }
 
+// add append a cell record to the column index by given cell coordinates.
+func (idx *colIndex) add(col, row int, c *xlsxC) error {
+	for len(idx.tails) < col {
+		idx.tails = append(idx.tails, -1)
+	}
+	header := make([]byte, colIndexRecordHeaderSize)
+	binary.LittleEndian.PutUint64(header, uint64(idx.tails[col-1]))
+	binary.LittleEndian.PutUint32(header[8:], uint32(row))
+	binary.LittleEndian.PutUint32(header[12:], uint32(c.S))
+	header[16] = byte(len(c.T))
+	binary.LittleEndian.PutUint32(header[17:], uint32(len(c.V)))
+	if _, err := idx.writer.Write(header); err != nil {
+		return err
+	}
+	if _, err := idx.writer.WriteString(c.T + c.V); err != nil {
+		return err
+	}
+	idx.tails[col-1] = idx.offset
+	idx.offset += int64(colIndexRecordHeaderSize + len(c.T) + len(c.V))
+	return nil
+}
+
+// readAt read the bytes of the column index by given offset and size. The
+// records of the column are traversed backwards, so the block which ends with
+// the requested bytes will be read into the buffer for the following records.
+func (idx *colIndex) readAt(offset int64, size int) ([]byte, error) {
+	end := offset + int64(size)
+	if offset < idx.bufOffset || end > idx.bufOffset+int64(len(idx.buf)) {
+		start := end - colIndexBlockSize
+		if start > offset {
+			start = offset
+		}
+		if start < 0 {
+			start = 0
+		}
+		if int64(cap(idx.buf)) < end-start {
+			idx.buf = make([]byte, end-start)
+		}
+		idx.buf, idx.bufOffset = idx.buf[:end-start], start
+		if _, err := idx.file.ReadAt(idx.buf, start); err != nil {
+			idx.buf = idx.buf[:0]
+			return nil, err
+		}
+	}
+	return idx.buf[offset-idx.bufOffset : end-idx.bufOffset], nil
+}
+
+// rows return the current column's row values from the column index by
+// traversing the records of the column from the last record.
+func (idx *colIndex) rows(cols *Cols) ([]string, error) {
+	var (
+		cells  []string
+		offset = int64(-1)
+	)
+	if cols.curCol <= len(idx.tails) {
+		offset = idx.tails[cols.curCol-1]
+	}
+	for offset >= 0 {
+		header, err := idx.readAt(offset, colIndexRecordHeaderSize)
+		if err != nil {
+			return cells, err
+		}
+		row, size := int(binary.LittleEndian.Uint32(header[8:])), int(header[16])
+		c := xlsxC{S: int(binary.LittleEndian.Uint32(header[12:]))}
+		prev := int64(binary.LittleEndian.Uint64(header))
+		buf, err := idx.readAt(offset+colIndexRecordHeaderSize, size+int(binary.LittleEndian.Uint32(header[17:])))
+		if err != nil {
+			return cells, err
+		}
+		c.T, c.V = string(buf[:size]), string(buf[size:])
+		val, _ := c.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+		if cells == nil {
+			cells = make([]string, int(math.Max(float64(row), float64(idx.lastCellRow-1))))
+		}
+		cells[row-1] = val
+		offset = prev
+	}
+	if cells == nil && idx.lastCellRow > 1 {
+		cells = make([]string, idx.lastCellRow-1)
+	}
+	return cells, nil
+}
+
+// colsIndexed build the temporary on-disk column index in one streaming pass
+// over the worksheet which stored in the system temporary directory, and
+// returns the columns iterator based on the column index.
+func (f *File) colsIndexed(sheet, name string) (*Cols, error) {
+	var colIterator columnXMLIterator
+	tempFile, err := f.readTemp(name)
+	if err != nil {
+		return &colIterator.cols, err
+	}
+	defer tempFile.Close()
+	indexFile, err := f.tempStorage().Create()
+	if err != nil {
+		return &colIterator.cols, err
+	}
+	f.tempIndexes.Store(indexFile.Name(), indexFile)
+	idx := &colIndex{file: indexFile, writer: bufio.NewWriter(indexFile)}
+	colIterator.cols.f, colIterator.cols.sheet, colIterator.cols.index = f, sheet, idx
+	if err = buildColIndex(f.xmlNewDecoder(tempFile), &colIterator); err != nil {
+		_ = colIterator.cols.Close()
+	}
+	return &colIterator.cols, err
+}
+
+// buildColIndex write the cell records of the worksheet to the column index
+// by given XML decoder and columns iterator.
+func buildColIndex(decoder *xml.Decoder, colIterator *columnXMLIterator) error {
+	idx := colIterator.cols.index
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if columnXMLHandler(colIterator, &xmlElement); colIterator.err != nil {
+				return colIterator.err
+			}
+			if xmlElement.Name.Local == "c" {
+				var c xlsxC
+				_ = decoder.DecodeElement(&c, &xmlElement)
+				if c.IS != nil {
+					c.V = c.IS.String()
+				}
+				idx.lastCellRow = colIterator.row
+				if err := idx.add(colIterator.cellCol, colIterator.row, &c); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return idx.writer.Flush()
+			}
+		}
+	}
+	return idx.writer.Flush()
+}
+
 // Cols returns a columns iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. This function is concurrency safe. When the
+// worksheet has been extracted to the system temporary directory on open the
+// spreadsheet, the iterator will build a temporary on-disk column index in
+// one streaming pass over the worksheet, so that the memory usage will be
+// bounded, please call the Close function of the iterator to remove the
+// temporary file after reading, or it will be removed when closing the
+// spreadsheet. For example:
 //
 //	cols, err := f.Cols("Sheet1")
 //	if err != nil {
@@ -207,6 +401,9 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 //	    }
 //	    fmt.Println()
 //	}
+//	if err = cols.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
 func (f *File) Cols(sheet string) (*Cols, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
@@ -219,8 +416,13 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 		ws := worksheet.(*xlsxWorksheet)
 		ws.mu.Lock()
 		defer ws.mu.Unlock()
-		output, _ := xml.Marshal(ws)
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		if !f.isPartUnchanged(name, ws) {
+			output, _ := xml.Marshal(ws)
+			f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		}
+	}
+	if _, ok := f.tempFiles.Load(name); ok && len(f.readXML(name)) == 0 {
+		return f.colsIndexed(sheet, name)
 	}
 	var colIterator columnXMLIterator
 	colIterator.cols.sheetXML = f.readBytes(name)
@@ -516,18 +718,22 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 		ws.Cols = &cols
 		return err
 	}
-	ws.Cols.Col = flatCols(col, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.Hidden = c.Hidden
-		fc.OutlineLevel = c.OutlineLevel
-		fc.Phonetic = c.Phonetic
-		fc.Style = c.Style
-		return fc
-	})
+	ws.Cols.Col = flatCols(col, ws.Cols.Col, replaceColWidth)
 	return err
 }
 
+// replaceColWidth keeps the other attributes of the existing column on
+// setting the column width.
+func replaceColWidth(fc, c xlsxCol) xlsxCol {
+	fc.BestFit = c.BestFit
+	fc.Collapsed = c.Collapsed
+	fc.Hidden = c.Hidden
+	fc.OutlineLevel = c.OutlineLevel
+	fc.Phonetic = c.Phonetic
+	fc.Style = c.Style
+	return fc
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -1,7 +1,10 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -116,6 +119,116 @@ func TestColumnsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumCol, colCount)
 }
 
+func TestColsIndexed(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	expected, err := f.GetCols("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// Test columns iterator with worksheet in the system temporary directory
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	cols, err := f.Cols("Sheet2")
+	assert.NoError(t, err)
+	assert.NotNil(t, cols.index)
+	indexFile := cols.index.file.Name()
+	var collected [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		collected = append(collected, col)
+	}
+	assert.Equal(t, expected, collected)
+	assert.NoError(t, cols.Close())
+	assert.NoError(t, cols.Close())
+	_, err = os.Stat(indexFile)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, cols.index)
+
+	cols, err = f.Cols("Sheet2")
+	assert.NoError(t, err)
+	indexFile = cols.index.file.Name()
+	// Test get rows of the column which is out of the column index
+	cols.curCol = cols.totalCols + 1
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Len(t, col, cols.index.lastCellRow-1)
+	// Test get rows from the closed column index file
+	assert.NoError(t, cols.index.file.Close())
+	cols.curCol = 1
+	_, err = cols.Rows()
+	assert.Error(t, err)
+	cols.index.file, err = os.Open(indexFile)
+	assert.NoError(t, err)
+	// Test save the workbook while the columns iterator is open
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, zf := range zr.File {
+		assert.NotContains(t, zf.Name, filepath.Base(indexFile))
+	}
+	assert.NoError(t, cols.Close())
+	assert.NoError(t, f.Close())
+
+	// Test remove the column index file which hasn't been closed on close the
+	// spreadsheet
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	cols, err = f.Cols("Sheet2")
+	assert.NoError(t, err)
+	indexFile = cols.index.file.Name()
+	_, ok := f.tempIndexes.Load(indexFile)
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+	_, err = os.Stat(indexFile)
+	assert.True(t, os.IsNotExist(err))
+	_, ok = f.tempIndexes.Load(indexFile)
+	assert.False(t, ok)
+
+	// Test read the column index which exceeds the block size
+	f = NewFile()
+	for row := 1; row <= 3000; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row, fmt.Sprintf("%0*d", row%50, 0), row * 2}))
+	}
+	expected, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf, Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	cols, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.NotNil(t, cols.index)
+	assert.Greater(t, cols.index.offset, int64(colIndexBlockSize))
+	collected = nil
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		collected = append(collected, col)
+	}
+	assert.Equal(t, expected, collected)
+	assert.NoError(t, f.Close())
+
+	// Test build column index with invalid cell reference
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	sheetXML, ok := f.tempFiles.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, os.WriteFile(sheetXML.(string), []byte(`<worksheet><sheetData><row r="1"><c r="A" t="inlineStr"><is><t>A</t></is></c></row></sheetData></worksheet>`), 0o600))
+	cols, err = f.Cols("Sheet2")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.Nil(t, cols.index)
+	// Test build column index without the worksheet temporary file
+	assert.NoError(t, os.Remove(sheetXML.(string)))
+	_, err = f.Cols("Sheet2")
+	assert.Error(t, err)
+	f.tempFiles.Delete("xl/worksheets/sheet2.xml")
+	assert.NoError(t, f.Close())
+}
+
 func TestColsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
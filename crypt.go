@@ -15,6 +15,8 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -38,6 +40,12 @@ import (
 
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	verifierHashInputBlockKey   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	verifierHashValueBlockKey   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
+	hmacKeyBlockKey             = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	hmacValueBlockKey           = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
+	agileEncryptionSpinCount    = 100000
+	maxEncryptionSpinCount      = 10000000
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
@@ -129,12 +137,26 @@ type StandardEncryptionVerifier struct {
 	EncryptedVerifierHash []byte
 }
 
-// encryptionInfo structure is used for standard encryption with SHA1
-// cryptographic algorithm.
-type encryption struct {
-	BlockSize, SaltSize                                                                  int
-	EncryptedKeyValue, EncryptedVerifierHashInput, EncryptedVerifierHashValue, SaltValue []byte
-	KeyBits                                                                              uint32
+// agileCiphers defined the block size in bytes and the supported key sizes
+// in bits of the cipher algorithms for agile encryption, the first key size
+// will be used by default.
+var agileCiphers = map[string]struct {
+	blockSize int
+	keyBits   []int
+}{
+	"AES":      {16, []int{256, 128, 192}},
+	"3DES":     {8, []int{192}},
+	"3DES_112": {8, []int{128}},
+}
+
+// agileHashAlgorithms defined the names of the hash algorithms in the
+// encryption info by the supported hash algorithm names for agile encryption.
+var agileHashAlgorithms = map[string]string{
+	"MD5":     "MD5",
+	"SHA-1":   "SHA1",
+	"SHA-256": "SHA256",
+	"SHA-384": "SHA384",
+	"SHA-512": "SHA512",
 }
 
 // Decrypt API decrypts the CFB file format with ECMA-376 agile encryption and
@@ -156,31 +178,45 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypt data with the password by ECMA-376 agile encryption,
+// and the data integrity HMAC of the encrypted package will be generated. The
+// cipher algorithm, key size, hash algorithm and spin count can be specified
+// by the options, the AES cipher with 256-bit key, SHA-512 hash algorithm and
+// 100000 spin count will be used by default.
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
+	if len(opts.Password) == 0 || len(opts.Password) > MaxFieldLength {
+		return nil, ErrPasswordLengthInvalid
 	}
-	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
+	encryption, err := newAgileEncryption(opts)
 	if err != nil {
 		return nil, err
 	}
 	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
+	packageKey, err := randomBytes(encryption.KeyData.KeyBits / 8)
+	if err != nil {
+		return nil, err
+	}
+	encryptedPackage, err := encryptPackage(packageKey, raw, *encryption)
+	if err != nil {
+		return nil, err
+	}
+	// Key Encryption
+	if err = encryption.agileKeyEncryption(opts.Password, packageKey, encryptedPackage); err != nil {
+		return nil, err
+	}
+	encryptionInfo := bytes.NewBuffer([]byte{0x04, 0x00, 0x04, 0x00, 0x40, 0x00, 0x00, 0x00})
+	encryptionInfo.WriteString(xml.Header)
+	if err = xml.NewEncoder(encryptionInfo).EncodeElement(encryption, xml.StartElement{
+		Name: xml.Name{Space: NameSpaceEncryption, Local: "encryption"},
+	}); err != nil {
+		return nil, err
+	}
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
 		sectors: []sector{{name: "Root Entry", typeID: 5}},
 	}
-	compoundFile.put("EncryptionInfo", encryptionInfoBuffer)
+	compoundFile.put("EncryptionInfo", encryptionInfo.Bytes())
 	compoundFile.put("EncryptedPackage", encryptedPackage)
 	return compoundFile.write(), nil
 }
@@ -329,68 +365,6 @@ func standardXORBytes(a, b []byte) []byte {
 	return buf
 }
 
-// encrypt provides a function to encrypt given value with AES cryptographic
-// algorithm.
-func (e *encryption) encrypt(input []byte) []byte {
-	inputBytes := len(input)
-	if pad := inputBytes % e.BlockSize; pad != 0 {
-		inputBytes += e.BlockSize - pad
-	}
-	var output, chunk []byte
-	encryptedChunk := make([]byte, e.BlockSize)
-	for i := 0; i < inputBytes; i += e.BlockSize {
-		if i+e.BlockSize <= len(input) {
-			chunk = input[i : i+e.BlockSize]
-		} else {
-			chunk = input[i:]
-		}
-		chunk = append(chunk, make([]byte, e.BlockSize-len(chunk))...)
-		c, _ := aes.NewCipher(e.EncryptedKeyValue)
-		c.Encrypt(encryptedChunk, chunk)
-		output = append(output, encryptedChunk...)
-	}
-	return output
-}
-
-// standardKeyEncryption encrypt convert the password to an encryption key.
-func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
-	if len(password) == 0 || len(password) > MaxFieldLength {
-		return nil, ErrPasswordLengthInvalid
-	}
-	var storage cfb
-	storage.writeUint16(0x0003)
-	storage.writeUint16(0x0002)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0xA4)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0x00)
-	storage.writeUint32(0x660E)
-	storage.writeUint32(0x8004)
-	storage.writeUint32(0x80)
-	storage.writeUint32(0x18)
-	storage.writeUint64(0x00)
-	providerName := "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
-	storage.writeStrings(providerName)
-	storage.writeUint16(0x00)
-	storage.writeUint32(0x10)
-	keyDataSaltValue, _ := randomBytes(16)
-	verifierHashInput, _ := randomBytes(16)
-	e.SaltValue = keyDataSaltValue
-	e.EncryptedKeyValue, _ = standardConvertPasswdToKey(
-		StandardEncryptionHeader{KeySize: e.KeyBits},
-		StandardEncryptionVerifier{Salt: e.SaltValue},
-		&Options{Password: password})
-	verifierHashInputKey := hashing("sha1", verifierHashInput)
-	e.EncryptedVerifierHashInput = e.encrypt(verifierHashInput)
-	e.EncryptedVerifierHashValue = e.encrypt(verifierHashInputKey)
-	storage.writeBytes(e.SaltValue)
-	storage.writeBytes(e.EncryptedVerifierHashInput)
-	storage.writeUint32(0x14)
-	storage.writeBytes(e.EncryptedVerifierHashValue)
-	storage.position = 0
-	return storage.stream, nil
-}
-
 // ECMA-376 Agile Encryption
 
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
@@ -416,13 +390,25 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 	if err != nil {
 		return
 	}
-	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
+	packageKey, _ := decrypt(encryptedKey.CipherAlgorithm, key, saltValue, encryptedKeyValue)
+	if keyBytes := encryptionInfo.KeyData.KeyBits / 8; keyBytes > 0 && len(packageKey) > keyBytes {
+		packageKey = packageKey[:keyBytes]
+	}
 	// Use the package key to decrypt the package.
 	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
 }
 
 // convertPasswdToKey convert the password into an encryption key.
 func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (key []byte, err error) {
+	if key, err = hashPasswd(passwd, encryption); err != nil {
+		return
+	}
+	return deriveKey(key, blockKey, encryption), err
+}
+
+// hashPasswd generate the hash of the password by the salt and spin count of
+// the password key encryptor, which is used to derive the encryption keys.
+func hashPasswd(passwd string, encryption Encryption) (key []byte, err error) {
 	var b bytes.Buffer
 	saltValue, err := base64.StdEncoding.DecodeString(encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SaltValue)
 	if err != nil {
@@ -442,32 +428,51 @@ func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (
 		iterator := createUInt32LEBuffer(i, 4)
 		key = hashing(encryption.KeyData.HashAlgorithm, iterator, key)
 	}
-	// Now generate the final hash.
-	key = hashing(encryption.KeyData.HashAlgorithm, key, blockKey)
-	// Truncate or pad as needed to get to length of keyBits.
-	keyBytes := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits / 8
-	if len(key) < keyBytes {
-		tmp := make([]byte, 0x36)
-		key = append(key, tmp...)
-	} else if len(key) > keyBytes {
-		key = key[:keyBytes]
-	}
 	return
 }
 
+// deriveKey generate the encryption key by given hash of the password and
+// block key, the key will be truncated or padded to the key size of the
+// password key encryptor.
+func deriveKey(passwdHash, blockKey []byte, encryption Encryption) []byte {
+	key := hashing(encryption.KeyData.HashAlgorithm, passwdHash, blockKey)
+	return padBytes(key, encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits/8)
+}
+
+// padBytes truncate or pad the given bytes with 0x36 to the given size.
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b[:size]
+	}
+	return append(b[:len(b):len(b)], bytes.Repeat([]byte{0x36}, size-len(b))...)
+}
+
+// newHash returns a new hash by specified hash algorithm, and returns nil if
+// the hash algorithm is unsupported.
+func newHash(hashAlgorithm string) hash.Hash {
+	switch strings.ToLower(hashAlgorithm) {
+	case "md4":
+		return md4.New()
+	case "md5":
+		return md5.New()
+	case "ripemd-160":
+		return ripemd160.New()
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "sha384":
+		return sha512.New384()
+	case "sha512":
+		return sha512.New()
+	}
+	return nil
+}
+
 // hashing data by specified hash algorithm.
 func hashing(hashAlgorithm string, buffer ...[]byte) (key []byte) {
-	hashMap := map[string]hash.Hash{
-		"md4":        md4.New(),
-		"md5":        md5.New(),
-		"ripemd-160": ripemd160.New(),
-		"sha1":       sha1.New(),
-		"sha256":     sha256.New(),
-		"sha384":     sha512.New384(),
-		"sha512":     sha512.New(),
-	}
-	handler, ok := hashMap[strings.ToLower(hashAlgorithm)]
-	if !ok {
+	handler := newHash(hashAlgorithm)
+	if handler == nil {
 		return key
 	}
 	for _, buf := range buffer {
@@ -491,17 +496,45 @@ func parseEncryptionInfo(encryptionInfo []byte) (encryption Encryption, err erro
 	return
 }
 
+// newBlockCipher provides a function to create the block cipher by given
+// cipher algorithm and key, the AES cipher will be used by default.
+func newBlockCipher(cipherAlgorithm string, key []byte) (cipher.Block, error) {
+	switch strings.ToUpper(cipherAlgorithm) {
+	case "3DES", "3DES_112":
+		if len(key) == 16 {
+			key = append(key[:16:16], key[:8]...)
+		}
+		return des.NewTripleDESCipher(key)
+	}
+	return aes.NewCipher(key)
+}
+
 // decrypt provides a function to decrypt input by given cipher algorithm,
-// cipher chaining, key and initialization vector.
-func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
-	block, err := aes.NewCipher(key)
+// key and initialization vector with the cipher block chaining mode.
+func decrypt(cipherAlgorithm string, key, iv, input []byte) (packageKey []byte, err error) {
+	block, err := newBlockCipher(cipherAlgorithm, key)
 	if err != nil {
 		return input, err
 	}
-	cipher.NewCBCDecrypter(block, iv).CryptBlocks(input, input)
+	cipher.NewCBCDecrypter(block, padBytes(iv, block.BlockSize())).CryptBlocks(input, input)
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input by given cipher algorithm,
+// key and initialization vector with the cipher block chaining mode, the
+// input will be padded with zero to an integer multiple of the block size.
+func encrypt(cipherAlgorithm string, key, iv, input []byte) ([]byte, error) {
+	block, err := newBlockCipher(cipherAlgorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	size := block.BlockSize()
+	output := make([]byte, (len(input)+size-1)/size*size)
+	copy(output, input)
+	cipher.NewCBCEncrypter(block, padBytes(iv, size)).CryptBlocks(output, output)
+	return output, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -534,7 +567,7 @@ func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChun
 			return
 		}
 		// Decrypt the chunk and add it to the array
-		outputChunk, err = decrypt(packageKey, iv, inputChunk)
+		outputChunk, err = decrypt(encryptedKey.CipherAlgorithm, packageKey, iv, inputChunk)
 		if err != nil {
 			return
 		}
@@ -560,14 +593,143 @@ func createIV(blockKey interface{}, encryption Encryption) ([]byte, error) {
 	}
 	// Create the initialization vector by hashing the salt with the block key.
 	// Truncate or pad as needed to meet the block size.
-	iv := hashing(encryptedKey.HashAlgorithm, append(saltValue, blockKeyBuf...))
-	if len(iv) < encryptedKey.BlockSize {
-		tmp := make([]byte, 0x36)
-		iv = append(iv, tmp...)
-	} else if len(iv) > encryptedKey.BlockSize {
-		iv = iv[:encryptedKey.BlockSize]
+	return padBytes(hashing(encryptedKey.HashAlgorithm, append(saltValue, blockKeyBuf...)), encryptedKey.BlockSize), nil
+}
+
+// newAgileEncryption provides a function to create the encryption info of
+// agile encryption by given options, the salt values will be generated
+// randomly.
+func newAgileEncryption(opts *Options) (*Encryption, error) {
+	cipherAlgorithm, keyBits := strings.ToUpper(opts.EncryptionCipher), opts.EncryptionKeyBits
+	if cipherAlgorithm == "" {
+		cipherAlgorithm = "AES"
+	}
+	c, ok := agileCiphers[cipherAlgorithm]
+	if !ok {
+		return nil, ErrUnsupportedEncryptMechanism
+	}
+	if keyBits == 0 {
+		keyBits = c.keyBits[0]
+	}
+	if inIntSlice(c.keyBits, keyBits) == -1 {
+		return nil, ErrUnsupportedEncryptMechanism
+	}
+	hashAlgorithm := opts.EncryptionHashAlgorithm
+	if hashAlgorithm == "" {
+		hashAlgorithm = "SHA-512"
+	}
+	hashName, ok := agileHashAlgorithms[strings.ToUpper(hashAlgorithm)]
+	if !ok {
+		return nil, ErrUnsupportedHashAlgorithm
+	}
+	spinCount := opts.EncryptionSpinCount
+	if spinCount == 0 {
+		spinCount = agileEncryptionSpinCount
+	}
+	if spinCount < 0 || spinCount > maxEncryptionSpinCount {
+		return nil, ErrOptionsEncryptionSpinCount
+	}
+	keyDataSalt, err := randomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+	keyEncryptorSalt, err := randomBytes(16)
+	if err != nil {
+		return nil, err
 	}
-	return iv, nil
+	keyData := KeyData{
+		SaltSize:        16,
+		BlockSize:       c.blockSize,
+		KeyBits:         keyBits,
+		HashSize:        len(hashing(hashName)),
+		CipherAlgorithm: cipherAlgorithm,
+		CipherChaining:  "ChainingModeCBC",
+		HashAlgorithm:   hashName,
+		SaltValue:       base64.StdEncoding.EncodeToString(keyDataSalt),
+	}
+	encryption := &Encryption{KeyData: keyData}
+	keyData.SaltValue = base64.StdEncoding.EncodeToString(keyEncryptorSalt)
+	encryption.KeyEncryptors.KeyEncryptor = []KeyEncryptor{{
+		URI:          NameSpaceKeyEncryptorPassword,
+		EncryptedKey: EncryptedKey{SpinCount: spinCount, KeyData: keyData},
+	}}
+	return encryption, nil
+}
+
+// encryptPackage provides a function to encrypt the package by given package
+// key and encryption info, the encrypted package begins with the size of the
+// package.
+func encryptPackage(packageKey, input []byte, encryption Encryption) ([]byte, error) {
+	output := make([]byte, packageOffset)
+	binary.LittleEndian.PutUint64(output, uint64(len(input)))
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := start + packageEncryptionChunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+		iv, err := createIV(i, encryption)
+		if err != nil {
+			return nil, err
+		}
+		chunk, err := encrypt(encryption.KeyData.CipherAlgorithm, packageKey, iv, input[start:end])
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, chunk...)
+	}
+	return output, nil
+}
+
+// agileKeyEncryption provides a function to encrypt the password verifier and
+// the package key by the keys derived from the password, and encrypt the key
+// and value of the data integrity HMAC by given package key and encrypted
+// package.
+func (e *Encryption) agileKeyEncryption(passwd string, packageKey, encryptedPackage []byte) error {
+	encryptedKey := &e.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+	if err != nil {
+		return err
+	}
+	passwdHash, err := hashPasswd(passwd, *e)
+	if err != nil {
+		return err
+	}
+	verifierHashInput, err := randomBytes(encryptedKey.SaltSize)
+	if err != nil {
+		return err
+	}
+	hmacKey, err := randomBytes(e.KeyData.HashSize)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(func() hash.Hash { return newHash(e.KeyData.HashAlgorithm) }, hmacKey)
+	_, _ = mac.Write(encryptedPackage)
+	for _, v := range []struct {
+		blockKey, key, iv, value []byte
+		target                   *string
+	}{
+		{verifierHashInputBlockKey, nil, saltValue, verifierHashInput, &encryptedKey.EncryptedVerifierHashInput},
+		{verifierHashValueBlockKey, nil, saltValue, hashing(encryptedKey.HashAlgorithm, verifierHashInput), &encryptedKey.EncryptedVerifierHashValue},
+		{blockKey, nil, saltValue, packageKey, &encryptedKey.EncryptedKeyValue},
+		{hmacKeyBlockKey, packageKey, nil, hmacKey, &e.DataIntegrity.EncryptedHmacKey},
+		{hmacValueBlockKey, packageKey, nil, mac.Sum(nil), &e.DataIntegrity.EncryptedHmacValue},
+	} {
+		key, iv := v.key, v.iv
+		if key == nil {
+			key = deriveKey(passwdHash, v.blockKey, *e)
+		}
+		if iv == nil {
+			if iv, err = createIV(v.blockKey, *e); err != nil {
+				return err
+			}
+		}
+		encrypted, err := encrypt(encryptedKey.CipherAlgorithm, key, iv, v.value)
+		if err != nil {
+			return err
+		}
+		*v.target = base64.StdEncoding.EncodeToString(encrypted)
+	}
+	return nil
 }
 
 // randomBytes returns securely generated random bytes. It will return an
@@ -668,14 +830,6 @@ func (c *cfb) writeUint32(value int) {
 	c.writeBytes(buf)
 }
 
-// writeUint64 write an uint64 data type bytes in the stream by a given value
-// with an offset.
-func (c *cfb) writeUint64(value int) {
-	buf := make([]byte, 8)
-	binary.LittleEndian.PutUint64(buf, uint64(value))
-	c.writeBytes(buf)
-}
-
 // writeStrings write strings in the stream by a given value with an offset.
 func (c *cfb) writeStrings(value string) {
 	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
@@ -944,7 +1098,7 @@ func (c *cfb) writeSectorChains(location []int) sector {
 		if sectorSize = len(sector.content); sectorSize == 0 || sectorSize >= 0x1000 {
 			continue
 		}
-		sector.start = offset
+		c.sectors[j].start = offset
 		offset = writeSectorChain((sectorSize+0x3F)>>6, offset)
 	}
 	for c.position&0x1FF != 0 {
@@ -13,6 +13,9 @@ package excelize
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
 	"encoding/binary"
 	"os"
 	"path/filepath"
@@ -61,7 +64,7 @@ func TestEncrypt(t *testing.T) {
 	binary.LittleEndian.PutUint64(encryptionInfoBuf[20:32], uint64(0))
 	_, err = standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "password"})
 	assert.NoError(t, err)
-	_, err = decrypt(nil, nil, nil)
+	_, err = decrypt("AES", nil, nil, nil)
 	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
 	_, err = agileDecrypt(encryptionInfoBuf, MacintoshCyrillicCharset, &Options{Password: "password"})
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid character entity &0 (no semicolon)")
@@ -75,6 +78,116 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
 }
 
+func TestAgileEncrypt(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "SECRET"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	raw := buf.Bytes()
+	// Test encrypt spreadsheet with the default options
+	encrypted, err := Encrypt(raw, &Options{Password: "password"})
+	assert.NoError(t, err)
+	doc, err := mscfb.New(bytes.NewReader(encrypted))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	mechanism, err := encryptionMechanism(encryptionInfoBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, "agile", mechanism)
+	encryption, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+	assert.NoError(t, err)
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	assert.Equal(t, []interface{}{"AES", 256, "SHA512", 64, 100000}, []interface{}{
+		encryption.KeyData.CipherAlgorithm, encryption.KeyData.KeyBits,
+		encryption.KeyData.HashAlgorithm, encryption.KeyData.HashSize, encryptedKey.SpinCount,
+	})
+	// Test verify the data integrity HMAC of the encrypted package
+	key, err := convertPasswdToKey("password", blockKey, encryption)
+	assert.NoError(t, err)
+	encryptedKeyValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedKeyValue)
+	assert.NoError(t, err)
+	packageKey, err := decrypt(encryptedKey.CipherAlgorithm, key, mustDecodeBase64(t, encryptedKey.SaltValue), encryptedKeyValue)
+	assert.NoError(t, err)
+	hmacValues := make([][]byte, 2)
+	for i, v := range []struct {
+		blockKey []byte
+		value    string
+	}{
+		{hmacKeyBlockKey, encryption.DataIntegrity.EncryptedHmacKey},
+		{hmacValueBlockKey, encryption.DataIntegrity.EncryptedHmacValue},
+	} {
+		iv, err := createIV(v.blockKey, encryption)
+		assert.NoError(t, err)
+		hmacValues[i], err = decrypt(encryption.KeyData.CipherAlgorithm, packageKey, iv, mustDecodeBase64(t, v.value))
+		assert.NoError(t, err)
+	}
+	mac := hmac.New(sha512.New, hmacValues[0][:encryption.KeyData.HashSize])
+	_, _ = mac.Write(encryptedPackageBuf)
+	assert.Equal(t, mac.Sum(nil), hmacValues[1][:encryption.KeyData.HashSize])
+	// Test decrypt spreadsheet encrypted with the default options
+	decrypted, err := Decrypt(encrypted, &Options{Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, raw, decrypted[:len(raw)])
+	f, err = OpenReader(bytes.NewReader(encrypted), Options{Password: "password"})
+	assert.NoError(t, err)
+	cell, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", cell)
+	_, err = OpenReader(bytes.NewReader(encrypted), Options{Password: "passwd"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+
+	// Test encrypt spreadsheet with the specified cipher, key size, hash
+	// algorithm and spin count
+	for _, opts := range []Options{
+		{EncryptionCipher: "AES", EncryptionKeyBits: 128, EncryptionHashAlgorithm: "SHA-1"},
+		{EncryptionCipher: "aes", EncryptionKeyBits: 192, EncryptionHashAlgorithm: "MD5"},
+		{EncryptionCipher: "3DES", EncryptionHashAlgorithm: "SHA-256"},
+		{EncryptionCipher: "3DES_112", EncryptionHashAlgorithm: "SHA-384"},
+	} {
+		opts.Password, opts.EncryptionSpinCount = "password", 10
+		encrypted, err := Encrypt(raw, &opts)
+		assert.NoError(t, err)
+		decrypted, err := Decrypt(encrypted, &Options{Password: "password"})
+		assert.NoError(t, err)
+		assert.Equal(t, raw, decrypted[:len(raw)], opts.EncryptionCipher)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAgileEncrypt.xlsx"), Options{Password: "password", EncryptionSpinCount: 1000}))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestAgileEncrypt.xlsx"), Options{Password: "password"})
+	assert.NoError(t, err)
+	cell, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", cell)
+	assert.NoError(t, f.Close())
+
+	// Test encrypt spreadsheet with invalid options
+	for _, c := range []struct {
+		opts Options
+		err  error
+	}{
+		{Options{EncryptionCipher: "RC2"}, ErrUnsupportedEncryptMechanism},
+		{Options{EncryptionKeyBits: 64}, ErrUnsupportedEncryptMechanism},
+		{Options{EncryptionCipher: "3DES", EncryptionKeyBits: 128}, ErrUnsupportedEncryptMechanism},
+		{Options{EncryptionHashAlgorithm: "SHA-3"}, ErrUnsupportedHashAlgorithm},
+		{Options{EncryptionSpinCount: -1}, ErrOptionsEncryptionSpinCount},
+		{Options{EncryptionSpinCount: maxEncryptionSpinCount + 1}, ErrOptionsEncryptionSpinCount},
+	} {
+		c.opts.Password = "password"
+		_, err = Encrypt(raw, &c.opts)
+		assert.Equal(t, c.err, err)
+	}
+	_, err = encrypt("AES", nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(make([]byte, 16), raw, Encryption{KeyData: KeyData{SaltValue: "=="}})
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+}
+
+// mustDecodeBase64 decodes the base64 encoded string for testing.
+func mustDecodeBase64(t *testing.T, s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	assert.NoError(t, err)
+	return b
+}
+
 func TestEncryptionMechanism(t *testing.T) {
 	mechanism, err := encryptionMechanism([]byte{3, 0, 3, 0})
 	assert.Equal(t, mechanism, "extensible")
@@ -0,0 +1,382 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions defined the options for import and export the worksheet from
+// and to the CSV (comma-separated values) or TSV (tab-separated values) data.
+//
+// Comma specifies the field delimiter, the default value is ','. Set it to
+// '\t' for the TSV data.
+//
+// Comment specifies the comment character on import, the lines beginning
+// with the comment character without preceding whitespace will be ignored.
+//
+// LazyQuotes specifies if a quote may appear in an unquoted field and a
+// non-doubled quote may appear in a quoted field on import.
+//
+// TrimLeadingSpace specifies if the leading white space in a field will be
+// ignored on import.
+//
+// Charset specifies the character encoding of the data on import, such as
+// "gbk" or "windows-1252", the data will be decoded by the charset transcoder
+// of the workbook, which can be changed by the CharsetTranscoder function. An
+// error will be returned if the charset transcoder of the workbook is nil.
+//
+// StartCell specifies the top-left cell of the imported data, the default
+// value is "A1".
+//
+// DisableTypeInference specifies if import all fields as the string values.
+// By default, the numbers, percentages, dates and booleans will be converted
+// to the typed cell values with the corresponding number formats.
+//
+// DateLayouts specifies the time layouts for inferring the date fields on
+// import, the default layouts are the ISO 8601 date and date time layouts.
+//
+// RawCellValue specifies if export the raw cell values without applying the
+// number format on export. The raw values will also be exported if the
+// spreadsheet was opened with the RawCellValue option.
+//
+// UseCRLF specifies if use \r\n as the line terminator on export.
+//
+// QuoteAll specifies if quote all fields on export, by default, the fields
+// will be quoted only when necessary.
+//
+// FillMergedCells specifies if fill each cell of the merged cells with the
+// value of the top-left cell on export, by default, only the top-left cell
+// has the value.
+//
+// SkipHiddenRows specifies if skip the hidden rows on export.
+type CSVOptions struct {
+	Comma                rune
+	Comment              rune
+	LazyQuotes           bool
+	TrimLeadingSpace     bool
+	Charset              string
+	StartCell            string
+	DisableTypeInference bool
+	DateLayouts          []string
+	RawCellValue         bool
+	UseCRLF              bool
+	QuoteAll             bool
+	FillMergedCells      bool
+	SkipHiddenRows       bool
+}
+
+// defaultCSVDateLayouts defined the default time layouts for inferring the
+// date fields on import the CSV data.
+var defaultCSVDateLayouts = []string{
+	"2006-01-02", "2006-01-02 15:04:05", "2006-01-02T15:04:05", time.RFC3339,
+}
+
+// getCSVOptions provides a function to parse the optional settings for import
+// and export the CSV data.
+func getCSVOptions(opts ...CSVOptions) CSVOptions {
+	options := CSVOptions{}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Comma == 0 {
+		options.Comma = ','
+	}
+	if options.DateLayouts == nil {
+		options.DateLayouts = defaultCSVDateLayouts
+	}
+	return options
+}
+
+// csvImporter directly maps the styles of the inferred types for import the
+// CSV data.
+type csvImporter struct {
+	f       *File
+	options CSVOptions
+	styles  map[int]int
+}
+
+// style returns the style index by given built-in number format index, the
+// style will be created on first use.
+func (imp *csvImporter) style(numFmt int) (int, error) {
+	if styleID, ok := imp.styles[numFmt]; ok {
+		return styleID, nil
+	}
+	styleID, err := imp.f.NewStyle(&Style{NumFmt: numFmt})
+	imp.styles[numFmt] = styleID
+	return styleID, err
+}
+
+// inferCell provides a function to infer the type of the given field, and
+// returns the typed cell value with the number format style.
+func (imp *csvImporter) inferCell(field string) (interface{}, error) {
+	if imp.options.DisableTypeInference || field == "" || strings.TrimSpace(field) != field {
+		return field, nil
+	}
+	switch strings.ToUpper(field) {
+	case "TRUE":
+		return true, nil
+	case "FALSE":
+		return false, nil
+	}
+	if isNumeric, precision, _ := isNumeric(field); isNumeric && !strings.ContainsAny(strings.ToLower(field), "inpx") {
+		// Keep the leading zeros of the codes such as "00123"
+		if len(field) > 1 && field[0] == '0' && field[1] != '.' || precision > 15 {
+			return field, nil
+		}
+		num, _ := strconv.ParseFloat(field, 64)
+		return num, nil
+	}
+	if strings.HasSuffix(field, "%") {
+		if num, err := strconv.ParseFloat(strings.TrimSuffix(field, "%"), 64); err == nil {
+			numFmt := 9
+			if strings.Contains(field, ".") {
+				numFmt = 10
+			}
+			styleID, err := imp.style(numFmt)
+			return Cell{StyleID: styleID, Value: num / 100}, err
+		}
+	}
+	for _, layout := range imp.options.DateLayouts {
+		if t, err := time.Parse(layout, field); err == nil {
+			numFmt := 22
+			if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+				numFmt = 14
+			}
+			styleID, err := imp.style(numFmt)
+			return Cell{StyleID: styleID, Value: t}, err
+		}
+	}
+	return field, nil
+}
+
+// ImportCSV provides a function to import the CSV or TSV data from the
+// io.Reader to the worksheet by given worksheet name and the optional
+// settings. The data will be written by the stream writer, so the whole
+// worksheet will be replaced by the imported data, and all the existing cells
+// of the worksheet will be lost, including the cells outside the range of the
+// imported data. The worksheet will be kept unchanged if the import failed.
+// The non UTF-8 data will be decoded by the charset transcoder of the
+// workbook, and an error will be returned if the transcoder has been set to
+// nil. By default, the numbers,
+// percentages, dates and booleans fields will be converted to the typed cell
+// values, and the built-in number formats will be applied for the percentages
+// and dates. For example, import the TSV data encoded in GBK into the
+// worksheet named 'Sheet1':
+//
+//	file, err := os.Open("data.tsv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	f := excelize.NewFile()
+//	defer func() {
+//	    if err := f.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	if err := f.ImportCSV("Sheet1", file, excelize.CSVOptions{Comma: '\t', Charset: "gbk"}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+func (f *File) ImportCSV(sheet string, r io.Reader, opts ...CSVOptions) error {
+	options := getCSVOptions(opts...)
+	col, row := 1, 1
+	if options.StartCell != "" {
+		var err error
+		if col, row, err = CellNameToCoordinates(options.StartCell); err != nil {
+			return err
+		}
+	}
+	if options.Charset != "" && !strings.EqualFold(options.Charset, "utf-8") {
+		if f.CharsetReader == nil {
+			return newUnsupportedCharsetError(options.Charset)
+		}
+		var err error
+		if r, err = f.CharsetReader(options.Charset, r); err != nil {
+			return err
+		}
+	}
+	reader := csv.NewReader(r)
+	reader.Comma, reader.Comment = options.Comma, options.Comment
+	reader.LazyQuotes, reader.TrimLeadingSpace = options.LazyQuotes, options.TrimLeadingSpace
+	reader.FieldsPerRecord, reader.ReuseRecord = -1, true
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	imp := csvImporter{f: f, options: options, styles: map[int]int{}}
+	if err = imp.importRows(reader, sw, col, row); err == nil {
+		err = sw.Flush()
+	}
+	if err != nil {
+		sw.discard()
+	}
+	return err
+}
+
+// importRows provides a function to read the records of the CSV data and
+// write them to the stream writer by given top-left cell coordinates.
+func (imp *csvImporter) importRows(reader *csv.Reader, sw *StreamWriter, col, row int) error {
+	for ; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			if values[i], err = imp.inferCell(field); err != nil {
+				return err
+			}
+		}
+		cell, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		if err = sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+}
+
+// csvMergeCell directly maps the merged cell range for export the CSV data.
+type csvMergeCell struct {
+	rect  []int
+	value *string
+}
+
+// ExportCSV provides a function to export the worksheet to the io.Writer as
+// the CSV or TSV data by given worksheet name and the optional settings. The
+// worksheet will be read by the rows iterator, the formatted cell values will
+// be exported by default. For example, export the raw cell values of the
+// worksheet named 'Sheet1' without the hidden rows:
+//
+//	file, err := os.Create("data.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.ExportCSV("Sheet1", file, excelize.CSVOptions{
+//	    RawCellValue:   true,
+//	    SkipHiddenRows: true,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExportCSV(sheet string, w io.Writer, opts ...CSVOptions) error {
+	options := getCSVOptions(opts...)
+	var mergeCells []csvMergeCell
+	if options.FillMergedCells {
+		cells, err := f.GetMergeCells(sheet)
+		if err != nil {
+			return err
+		}
+		for _, mc := range cells {
+			rect, err := rangeRefToCoordinates(mc[0])
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			mergeCells = append(mergeCells, csvMergeCell{rect: rect})
+		}
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma, writer.UseCRLF = options.Comma, options.UseCRLF
+	rawCellValue := options.RawCellValue || (f.options != nil && f.options.RawCellValue)
+	for rows.Next() {
+		record, err := rows.Columns(Options{RawCellValue: rawCellValue})
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if options.SkipHiddenRows && rows.GetRowOpts().Hidden {
+			continue
+		}
+		record = fillMergedCells(mergeCells, rows.seekRow, record)
+		if options.QuoteAll {
+			err = writeQuotedCSVRecord(w, record, options)
+		} else {
+			err = writer.Write(record)
+		}
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
+
+// fillMergedCells provides a function to fill the cells of the merged cells
+// in the given row with the value of the top-left cell.
+func fillMergedCells(mergeCells []csvMergeCell, row int, record []string) []string {
+	for i := range mergeCells {
+		mc := &mergeCells[i]
+		if row < mc.rect[1] || row > mc.rect[3] {
+			continue
+		}
+		if row == mc.rect[1] {
+			var value string
+			if mc.rect[0]-1 < len(record) {
+				value = record[mc.rect[0]-1]
+			}
+			mc.value = &value
+		}
+		if mc.value == nil {
+			continue
+		}
+		for len(record) < mc.rect[2] {
+			record = append(record, "")
+		}
+		for col := mc.rect[0]; col <= mc.rect[2]; col++ {
+			record[col-1] = *mc.value
+		}
+	}
+	return record
+}
+
+// writeQuotedCSVRecord provides a function to write the CSV record with all
+// fields quoted.
+func writeQuotedCSVRecord(w io.Writer, record []string, options CSVOptions) error {
+	var sb strings.Builder
+	for i, field := range record {
+		if i > 0 {
+			sb.WriteRune(options.Comma)
+		}
+		sb.WriteByte('"')
+		sb.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		sb.WriteByte('"')
+	}
+	if options.UseCRLF {
+		sb.WriteString("\r\n")
+	} else {
+		sb.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
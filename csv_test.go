@@ -0,0 +1,156 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCSV(t *testing.T) {
+	f := NewFile()
+	data := "Name,Code,Amount,Rate,Date,Time,Active,Note\n" +
+		"Alice,00123,1024.5,12.5%,2024-03-01,2024-03-01 08:30:00,TRUE,\"Hello, \"\"World\"\"\"\n" +
+		"Bob,7,-3e2,50%,2024/03/01,NaN,false, padded\n"
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data)))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Code", "Amount", "Rate", "Date", "Time", "Active", "Note"},
+		{"Alice", "00123", "1024.5", "12.50%", "03-01-24", "3/1/24 08:30", "TRUE", "Hello, \"World\""},
+		{"Bob", "7", "-300", "50%", "2024/03/01", "NaN", "FALSE", " padded"},
+	}, rows)
+	for cell, expected := range map[string]CellType{
+		"B2": CellTypeInlineString, "C2": CellTypeUnset, "E2": CellTypeUnset, "G2": CellTypeBool, "F3": CellTypeInlineString,
+	} {
+		typ, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, typ, cell)
+	}
+	val, err := f.GetCellValue("Sheet1", "E2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45352", val)
+
+	// Test import TSV data with start cell, custom date layouts and without type inference
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportCSV("Sheet2", strings.NewReader("# comment\n1\t2024/03/01\n"), CSVOptions{
+		Comma: '\t', Comment: '#', StartCell: "B2", DateLayouts: []string{"2006/01/02"},
+	}))
+	rows, err = f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"", "1", "03-01-24"}}, rows)
+	assert.NoError(t, f.ImportCSV("Sheet2", strings.NewReader("1,TRUE\n"), CSVOptions{DisableTypeInference: true}))
+	typ, err := f.GetCellType("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, typ)
+
+	// Test import data with charset
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportCSV("Sheet3", bytes.NewReader([]byte{0xc4, 0xe3, 0xba, 0xc3, 0x2c, 0x31}), CSVOptions{Charset: "gbk"}))
+	rows, err = f.GetRows("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"你好", "1"}}, rows)
+
+	// Test import data with invalid options and data
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ImportCSV("Sheet1", strings.NewReader(data), CSVOptions{StartCell: "A"}))
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader(data)), "sheet SheetN does not exist")
+	assert.ErrorIs(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b\n")), csv.ErrBareQuote)
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b\n"), CSVOptions{LazyQuotes: true}))
+	assert.Equal(t, ErrMaxRows, f.ImportCSV("Sheet1", strings.NewReader("1\n2\n"), CSVOptions{StartCell: "A1048576"}))
+	// Test the worksheet will be kept unchanged if the import failed
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a\"b"}}, rows)
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet4", "C3", "kept"))
+	assert.ErrorIs(t, f.ImportCSV("Sheet4", strings.NewReader("1\na\"b\n")), csv.ErrBareQuote)
+	_, ok := f.streams["xl/worksheets/sheet4.xml"]
+	assert.False(t, ok)
+	rows, err = f.GetRows("Sheet4")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, {"", "", "kept"}}, rows)
+	f.CharsetTranscoder(func(charset string, input io.Reader) (io.Reader, error) {
+		return nil, errors.New("unsupported charset")
+	})
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVOptions{Charset: "gbk"}), "unsupported charset")
+	f.CharsetTranscoder(nil)
+	assert.Equal(t, newUnsupportedCharsetError("gbk"), f.ImportCSV("Sheet1", strings.NewReader(data), CSVOptions{Charset: "gbk"}))
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVOptions{Charset: "UTF-8"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportCSV.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestExportCSV(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Name", "B1": "Amount", "C1": "Date",
+		"A2": "Alice", "B2": 1024.5, "C2": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"A3": "Bob, \"Jr\"", "B3": 0.5,
+		"A5": "Merged",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	styleID, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", styleID))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "Hidden"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "B6"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf))
+	assert.Equal(t, "Name,Amount,Date\nAlice,1024.5,3/1/24 00:00\n\"Bob, \"\"Jr\"\"\",50.00%\nHidden\nMerged\n\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{
+		Comma: '\t', RawCellValue: true, SkipHiddenRows: true, FillMergedCells: true, UseCRLF: true,
+	}))
+	assert.Equal(t, "Name\tAmount\tDate\r\nAlice\t1024.5\t45352\r\n\"Bob, \"\"Jr\"\"\"\t0.5\r\nMerged\tMerged\r\nMerged\tMerged\r\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{QuoteAll: true, UseCRLF: true}))
+	assert.Equal(t, "\"Name\",\"Amount\",\"Date\"\r\n\"Alice\",\"1024.5\",\"3/1/24 00:00\"\r\n\"Bob, \"\"Jr\"\"\",\"50.00%\"\r\n\"Hidden\"\r\n\"Merged\"\r\n\r\n", buf.String())
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{QuoteAll: true}))
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+
+	// Test round trip the exported data
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{RawCellValue: true}))
+	f2 := NewFile()
+	assert.NoError(t, f2.ImportCSV("Sheet1", &buf))
+	val, err := f2.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1024.5", val)
+	assert.NoError(t, f2.Close())
+
+	// Test export with invalid worksheet, merged cells and writer
+	assert.EqualError(t, f.ExportCSV("SheetN", &buf), "sheet SheetN does not exist")
+	assert.EqualError(t, f.ExportCSV("SheetN", &buf, CSVOptions{FillMergedCells: true}), "sheet SheetN does not exist")
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A"
+	assert.Equal(t, ErrParameterInvalid, f.ExportCSV("Sheet1", &buf, CSVOptions{FillMergedCells: true}))
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A5:B6"
+	assert.EqualError(t, f.ExportCSV("Sheet1", errWriter{}), "write error")
+	assert.EqualError(t, f.ExportCSV("Sheet1", errWriter{}, CSVOptions{QuoteAll: true}), "write error")
+	assert.NoError(t, f.Close())
+}
+
+// errWriter is an io.Writer which always returns an error, used for testing.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write error") }
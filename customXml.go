@@ -0,0 +1,281 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// customXMLItem defined the paths and relationship ID of the custom XML data
+// part in the workbook.
+type customXMLItem struct {
+	rID, path, propsPath string
+}
+
+// AddCustomXMLPart provides a function to add a custom XML data part to the
+// workbook, the custom XML data part can be used to store the business data
+// which bound to the workbook by the document management systems or the
+// add-ins. The ID of the custom XML data part will be generated if it is
+// empty. For example, add a custom XML data part with the associated schema:
+//
+//	err := f.AddCustomXMLPart(excelize.CustomXMLPart{
+//	    SchemaRefs: []string{"http://example.com/schemas/client"},
+//	    Data:       []byte(`<client xmlns="http://example.com/schemas/client"><id>C-1024</id></client>`),
+//	})
+func (f *File) AddCustomXMLPart(part CustomXMLPart) error {
+	if len(part.Data) == 0 {
+		return ErrParameterRequired
+	}
+	if err := f.checkCustomXMLData(part.Data); err != nil {
+		return err
+	}
+	items, err := f.getCustomXMLItems()
+	if err != nil {
+		return err
+	}
+	if part.ID == "" {
+		if part.ID, err = f.genCustomXMLItemID(part.Data); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		props, err := f.customXMLItemPropsReader(item.propsPath)
+		if err != nil {
+			return err
+		}
+		if props != nil && strings.EqualFold(props.ItemID, part.ID) {
+			return ErrParameterInvalid
+		}
+	}
+	idx := 1
+	for ; f.pkgExists(fmt.Sprintf("customXml/item%d.xml", idx)); idx++ {
+	}
+	itemPath := fmt.Sprintf("customXml/item%d.xml", idx)
+	propsPath := fmt.Sprintf("customXml/itemProps%d.xml", idx)
+	props := xlsxDatastoreItem{ItemID: part.ID, XMLNSDs: NameSpaceCustomXML}
+	if len(part.SchemaRefs) > 0 {
+		props.SchemaRefs = &xlsxSchemaRefs{}
+		for _, uri := range part.SchemaRefs {
+			props.SchemaRefs.SchemaRef = append(props.SchemaRefs.SchemaRef, xlsxSchemaRef{URI: uri})
+		}
+	}
+	output, err := xml.Marshal(props)
+	if err != nil {
+		return err
+	}
+	f.Pkg.Store(itemPath, part.Data)
+	f.saveFileList(propsPath, output)
+	f.addRels(getCustomXMLItemRelsPath(itemPath), SourceRelationshipCustomXMLProps, path.Base(propsPath), "")
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipCustomXML, "../"+itemPath, "")
+	if err = f.setContentTypes("/"+propsPath, ContentTypeCustomXMLProperties); err != nil {
+		return err
+	}
+	return f.setContentTypeDefault("xml", "application/xml")
+}
+
+// GetCustomXMLParts provides a function to get all custom XML data parts of
+// the workbook, with the unique identifier and the associated schemas of each
+// part.
+func (f *File) GetCustomXMLParts() ([]CustomXMLPart, error) {
+	var parts []CustomXMLPart
+	items, err := f.getCustomXMLItems()
+	if err != nil {
+		return parts, err
+	}
+	for _, item := range items {
+		part := CustomXMLPart{Data: f.readXML(item.path)}
+		props, err := f.customXMLItemPropsReader(item.propsPath)
+		if err != nil {
+			return parts, err
+		}
+		if props != nil {
+			part.ID = props.ItemID
+			if props.SchemaRefs != nil {
+				for _, ref := range props.SchemaRefs.SchemaRef {
+					part.SchemaRefs = append(part.SchemaRefs, ref.URI)
+				}
+			}
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// DeleteCustomXMLPart provides a function to delete the custom XML data part
+// by given unique identifier, the data part, properties part and the
+// relationships of the custom XML data will be removed from the workbook.
+func (f *File) DeleteCustomXMLPart(id string) error {
+	items, err := f.getCustomXMLItems()
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		props, err := f.customXMLItemPropsReader(item.propsPath)
+		if err != nil {
+			return err
+		}
+		if props == nil || !strings.EqualFold(props.ItemID, id) {
+			continue
+		}
+		rels, err := f.relsReader(f.getWorkbookRelsPath())
+		if err != nil {
+			return err
+		}
+		rels.mu.Lock()
+		for k, v := range rels.Relationships {
+			if v.ID == item.rID {
+				rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+				break
+			}
+		}
+		rels.mu.Unlock()
+		relsPath := getCustomXMLItemRelsPath(item.path)
+		f.Pkg.Delete(item.path)
+		f.Pkg.Delete(item.propsPath)
+		f.Pkg.Delete(relsPath)
+		f.Relationships.Delete(relsPath)
+		return f.removeContentTypesPart(ContentTypeCustomXMLProperties, "/"+item.propsPath)
+	}
+	return newNoExistCustomXMLPartError(id)
+}
+
+// getCustomXMLItemRelsPath provides a function to get the relationships part
+// path of the custom XML data part.
+func getCustomXMLItemRelsPath(itemPath string) string {
+	return path.Join(path.Dir(itemPath), "_rels", path.Base(itemPath)+".rels")
+}
+
+// getCustomXMLItems provides a function to get the paths of the custom XML
+// data parts and the properties parts by the workbook relationships.
+func (f *File) getCustomXMLItems() ([]customXMLItem, error) {
+	var items []customXMLItem
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return items, err
+	}
+	rels.mu.Lock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomXML {
+			items = append(items, customXMLItem{rID: rel.ID, path: f.getWorksheetPath(rel.Target)})
+		}
+	}
+	rels.mu.Unlock()
+	for i, item := range items {
+		itemRels, err := f.relsReader(getCustomXMLItemRelsPath(item.path))
+		if err != nil {
+			return items, err
+		}
+		if itemRels == nil {
+			continue
+		}
+		for _, rel := range itemRels.Relationships {
+			if rel.Type == SourceRelationshipCustomXMLProps {
+				items[i].propsPath = strings.TrimPrefix(path.Join(path.Dir(item.path), rel.Target), "/")
+				if strings.HasPrefix(rel.Target, "/") {
+					items[i].propsPath = strings.TrimPrefix(rel.Target, "/")
+				}
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
+// customXMLItemPropsReader provides a function to get the pointer to the
+// structure of the custom XML data properties after deserialization.
+func (f *File) customXMLItemPropsReader(propsPath string) (*decodeDatastoreItem, error) {
+	if propsPath == "" {
+		return nil, nil
+	}
+	content, ok := f.pkgLoad(propsPath)
+	if !ok || content == nil {
+		return nil, nil
+	}
+	props := new(decodeDatastoreItem)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(props); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return props, nil
+}
+
+// checkCustomXMLData provides a function to check if the custom XML data is
+// well-formed and contains the root element.
+func (f *File) checkCustomXMLData(data []byte) error {
+	var root bool
+	decoder := f.xmlNewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			root = true
+		}
+	}
+	if !root {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// genCustomXMLItemID provides a function to generate the unique identifier of
+// the custom XML data part in the GUID format. The identifier will be derived
+// from the data when the deterministic output is enabled.
+func (f *File) genCustomXMLItemID(data []byte) (string, error) {
+	var b []byte
+	if f.deterministic() {
+		sum := sha256.Sum256(data)
+		b = sum[:16]
+	} else {
+		var err error
+		if b, err = randomBytes(16); err != nil {
+			return "", err
+		}
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// pkgExists provides a function to check if the part exists in the package.
+func (f *File) pkgExists(name string) bool {
+	_, ok := f.pkgLoad(name)
+	return ok
+}
+
+// setContentTypeDefault provides a function to add the default content type
+// by given file extension if not exist.
+func (f *File) setContentTypeDefault(extension, contentType string) error {
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, v := range content.Defaults {
+		if strings.EqualFold(v.Extension, extension) {
+			return err
+		}
+	}
+	content.Defaults = append(content.Defaults, xlsxDefault{Extension: extension, ContentType: contentType})
+	return err
+}
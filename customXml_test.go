@@ -0,0 +1,138 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomXMLParts(t *testing.T) {
+	f := NewFile()
+	client := []byte(`<client xmlns="http://example.com/schemas/client"><id>C-1024</id></client>`)
+	assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{
+		ID:         "{A1B2C3D4-0000-4000-8000-000000000000}",
+		SchemaRefs: []string{"http://example.com/schemas/client"},
+		Data:       client,
+	}))
+	assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{Data: []byte(`<tags><tag>Internal</tag></tags>`)}))
+	parts, err := f.GetCustomXMLParts()
+	assert.NoError(t, err)
+	assert.Len(t, parts, 2)
+	assert.Equal(t, CustomXMLPart{
+		ID:         "{A1B2C3D4-0000-4000-8000-000000000000}",
+		SchemaRefs: []string{"http://example.com/schemas/client"},
+		Data:       client,
+	}, parts[0])
+	assert.Regexp(t, regexp.MustCompile(`^\{[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}\}$`), parts[1].ID)
+	assert.Nil(t, parts[1].SchemaRefs)
+	// Test add custom XML part with duplicate identifier
+	assert.Equal(t, ErrParameterInvalid, f.AddCustomXMLPart(CustomXMLPart{
+		ID: "{a1b2c3d4-0000-4000-8000-000000000000}", Data: client,
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomXMLParts.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCustomXMLParts.xlsx"))
+	assert.NoError(t, err)
+	reopened, err := f.GetCustomXMLParts()
+	assert.NoError(t, err)
+	assert.Equal(t, parts, reopened)
+	// Test delete custom XML part
+	assert.NoError(t, f.DeleteCustomXMLPart("{A1B2C3D4-0000-4000-8000-000000000000}"))
+	for _, name := range []string{"customXml/item1.xml", "customXml/itemProps1.xml", "customXml/_rels/item1.xml.rels"} {
+		_, ok := f.Pkg.Load(name)
+		assert.False(t, ok)
+	}
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, v := range content.Overrides {
+		assert.NotEqual(t, "/customXml/itemProps1.xml", v.PartName)
+	}
+	parts, err = f.GetCustomXMLParts()
+	assert.NoError(t, err)
+	assert.Equal(t, reopened[1:], parts)
+	// Test add custom XML part reuse the first free part name
+	assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{ID: "{B1B2C3D4-0000-4000-8000-000000000000}", Data: client}))
+	assert.Equal(t, client, f.readXML("customXml/item1.xml"))
+	// Test delete not exist custom XML part
+	assert.EqualError(t, f.DeleteCustomXMLPart("{A1B2C3D4-0000-4000-8000-000000000000}"),
+		"custom XML part {A1B2C3D4-0000-4000-8000-000000000000} does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test add custom XML part with deterministic identifier
+	for i := 0; i < 2; i++ {
+		f = NewFile(Options{Deterministic: true})
+		assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}))
+		parts, err = f.GetCustomXMLParts()
+		assert.NoError(t, err)
+		assert.Equal(t, "{40E99BD2-A910-4B03-8E8F-BDC45FF14BEF}", parts[0].ID)
+		assert.NoError(t, f.Close())
+	}
+
+	// Test add custom XML part with invalid data
+	f = NewFile()
+	assert.Equal(t, ErrParameterRequired, f.AddCustomXMLPart(CustomXMLPart{}))
+	assert.Equal(t, ErrParameterInvalid, f.AddCustomXMLPart(CustomXMLPart{Data: []byte("text")}))
+	assert.EqualError(t, f.AddCustomXMLPart(CustomXMLPart{Data: []byte("<a>")}), "XML syntax error on line 1: unexpected EOF")
+
+	// Test custom XML part without properties part
+	f.Pkg.Store("customXml/item1.xml", client)
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipCustomXML, "../customXml/item1.xml", "")
+	parts, err = f.GetCustomXMLParts()
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomXMLPart{{Data: client}}, parts)
+	assert.EqualError(t, f.DeleteCustomXMLPart(""), "custom XML part  does not exist")
+	assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}))
+	assert.True(t, f.pkgExists("customXml/item2.xml"))
+	assert.NoError(t, f.Close())
+
+	// Test custom XML parts with unsupported charset properties part
+	f = NewFile()
+	assert.NoError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}))
+	f.Pkg.Store("customXml/itemProps1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCustomXMLParts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteCustomXMLPart(""), "XML syntax error on line 1: invalid UTF-8")
+	// Test custom XML parts with unsupported charset item relationships
+	f.Relationships.Delete("customXml/_rels/item1.xml.rels")
+	f.Pkg.Store("customXml/_rels/item1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetCustomXMLParts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteCustomXMLPart(""), "XML syntax error on line 1: invalid UTF-8")
+	// Test custom XML parts with unsupported charset workbook relationships
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	_, err = f.GetCustomXMLParts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test add custom XML part with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddCustomXMLPart(CustomXMLPart{Data: client}), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.Pkg.Store("customXml/item1.xml", client)
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipCustomXML, "../customXml/item1.xml", "")
+	f.addRels("customXml/_rels/item1.xml.rels", SourceRelationshipCustomXMLProps, "/customXml/itemProps1.xml", "")
+	f.saveFileList("customXml/itemProps1.xml", []byte(`<ds:datastoreItem ds:itemID="{C1B2C3D4-0000-4000-8000-000000000000}" xmlns:ds="http://schemas.openxmlformats.org/officeDocument/2006/customXml"/>`))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteCustomXMLPart("{C1B2C3D4-0000-4000-8000-000000000000}"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
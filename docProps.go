@@ -14,8 +14,13 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"io"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -271,3 +276,239 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// setDocPropsModTime provides a function to set the modified time of the
+// document core properties by the ModTime option for the deterministic output.
+// The created time will be set as the same time if it hasn't been set or it's
+// the default value of the new workbook.
+func (f *File) setDocPropsModTime() error {
+	if !f.deterministic() || f.options.ModTime.IsZero() {
+		return nil
+	}
+	if _, ok := f.Pkg.Load(defaultXMLPathDocPropsCore); !ok {
+		return nil
+	}
+	props, err := f.GetDocProps()
+	if err != nil {
+		return err
+	}
+	modTime := f.options.ModTime.UTC().Format(time.RFC3339)
+	docProps := &DocProperties{Modified: modTime}
+	if props.Created == "" || props.Created == defaultDocPropsCreated {
+		docProps.Created = modTime
+	}
+	return f.SetDocProps(docProps)
+}
+
+// SetCustomProps provides a function to set custom file properties by given
+// property name and value. If the property name already exists, it will be
+// updated, otherwise a new property will be added. The value can be of type
+// int32, float64, bool, string, time.Time or nil. The property will be delete
+// if the value is nil. The function returns an error if the property value is
+// not of the correct type. For example, set the custom properties which used
+// by the document management system:
+//
+//	err := f.SetCustomProps(excelize.CustomProperty{
+//	    Name:  "ClientID",
+//	    Value: "C-1024",
+//	})
+//
+// Delete the custom property named "Classification":
+//
+//	err := f.SetCustomProps(excelize.CustomProperty{Name: "Classification"})
+func (f *File) SetCustomProps(prop CustomProperty) error {
+	if prop.Name == "" {
+		return ErrParameterRequired
+	}
+	props, err := f.customPropsReader()
+	if err != nil {
+		return err
+	}
+	idx := -1
+	for i, p := range props.Property {
+		if strings.EqualFold(p.Name, prop.Name) {
+			idx = i
+			break
+		}
+	}
+	if prop.Value == nil {
+		if idx == -1 {
+			return nil
+		}
+		if props.Property = append(props.Property[:idx], props.Property[idx+1:]...); len(props.Property) == 0 {
+			return f.removeCustomProps()
+		}
+		return f.saveCustomProps(props)
+	}
+	value, err := marshalCustomPropValue(prop.Value)
+	if err != nil {
+		return err
+	}
+	if idx != -1 {
+		props.Property[idx].Value = value
+		props.Property[idx].LinkTarget = ""
+		return f.saveCustomProps(props)
+	}
+	pid := 1
+	for _, p := range props.Property {
+		if p.PID > pid {
+			pid = p.PID
+		}
+	}
+	props.Property = append(props.Property, xlsxCustomProperty{
+		FmtID: defaultCustomPropsFmtID,
+		PID:   pid + 1,
+		Name:  prop.Name,
+		Value: value,
+	})
+	return f.saveCustomProps(props)
+}
+
+// GetCustomProps provides a function to get custom file properties. The value
+// of the property is returned as int32, float64, bool, string or time.Time
+// according to the data type stored in the workbook, the value in the other
+// variant types will be returned as string.
+func (f *File) GetCustomProps() ([]CustomProperty, error) {
+	var props []CustomProperty
+	custom := new(decodeCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return props, err
+	}
+	for _, p := range custom.Property {
+		props = append(props, CustomProperty{Name: p.Name, Value: p.value()})
+	}
+	return props, nil
+}
+
+// value returns the value of the custom property in Go data type.
+func (p *decodeCustomProperty) value() interface{} {
+	switch {
+	case p.Lpwstr != nil:
+		return *p.Lpwstr
+	case p.Lpstr != nil:
+		return *p.Lpstr
+	case p.I4 != nil:
+		return *p.I4
+	case p.R8 != nil:
+		return *p.R8
+	case p.Bool != nil:
+		return *p.Bool
+	case p.FileTime != nil:
+		if t, err := time.Parse(time.RFC3339, *p.FileTime); err == nil {
+			return t
+		}
+		return *p.FileTime
+	case p.Other != nil:
+		return p.Other.Text
+	}
+	return nil
+}
+
+// customPropsReader provides a function to get the pointer to the structure
+// of the custom file properties after deserialization, the variant type
+// elements of the existing properties will be kept as is.
+func (f *File) customPropsReader() (*xlsxCustomProperties, error) {
+	custom := new(decodeCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return nil, err
+	}
+	props := &xlsxCustomProperties{Vt: NameSpaceDocumentPropertiesVariantTypes.Value}
+	for _, p := range custom.Property {
+		props.Property = append(props.Property, xlsxCustomProperty{
+			FmtID:      p.FmtID,
+			PID:        p.PID,
+			Name:       p.Name,
+			LinkTarget: p.LinkTarget,
+			Value:      p.Content,
+		})
+	}
+	return props, nil
+}
+
+// marshalCustomPropValue provides a function to convert the custom property
+// value to the variant type element.
+func marshalCustomPropValue(value interface{}) (string, error) {
+	var typ, text string
+	switch v := value.(type) {
+	case int:
+		if typ, text = "i4", strconv.Itoa(v); v > math.MaxInt32 || v < math.MinInt32 {
+			typ = "r8"
+		}
+	case int8, int16, int32:
+		typ, text = "i4", fmt.Sprint(v)
+	case float64:
+		typ, text = "r8", strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		typ, text = "lpwstr", v
+	case bool:
+		typ, text = "bool", strconv.FormatBool(v)
+	case time.Time:
+		typ, text = "filetime", v.UTC().Format("2006-01-02T15:04:05Z")
+	default:
+		return "", ErrParameterInvalid
+	}
+	var buf bytes.Buffer
+	if err := xml.EscapeText(&buf, []byte(text)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<vt:%s>%s</vt:%s>", typ, buf.String(), typ), nil
+}
+
+// saveCustomProps provides a function to save the custom file properties
+// part, and create the package relationship and content type of the part if
+// not exist.
+func (f *File) saveCustomProps(props *xlsxCustomProperties) error {
+	output, err := xml.Marshal(props)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(defaultXMLPathDocPropsCustom, output)
+	rels, err := f.relsReader(defaultXMLPathRootRels)
+	if err != nil {
+		return err
+	}
+	var exist bool
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipCustomProperties {
+				exist = true
+				break
+			}
+		}
+	}
+	if !exist {
+		f.addRels(defaultXMLPathRootRels, SourceRelationshipCustomProperties, defaultXMLPathDocPropsCustom, "")
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	for _, v := range content.Overrides {
+		if v.PartName == "/"+defaultXMLPathDocPropsCustom {
+			return nil
+		}
+	}
+	return f.setContentTypes("/"+defaultXMLPathDocPropsCustom, ContentTypeCustomProperties)
+}
+
+// removeCustomProps provides a function to remove the custom file properties
+// part, and the package relationship and content type of the part.
+func (f *File) removeCustomProps() error {
+	rels, err := f.relsReader(defaultXMLPathRootRels)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for k := len(rels.Relationships) - 1; k >= 0; k-- {
+			if rels.Relationships[k].Type == SourceRelationshipCustomProperties {
+				rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+			}
+		}
+		rels.mu.Unlock()
+	}
+	f.Pkg.Delete(defaultXMLPathDocPropsCustom)
+	return f.removeContentTypesPart(ContentTypeCustomProperties, "/"+defaultXMLPathDocPropsCustom)
+}
@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"math"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -116,3 +118,113 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCustomProps(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	for _, prop := range []CustomProperty{
+		{Name: "ClientID", Value: "C-1024"},
+		{Name: "Pages", Value: int32(12)},
+		{Name: "Score", Value: 95.5},
+		{Name: "Approved", Value: true},
+		{Name: "Reviewed", Value: date},
+		{Name: "Escaped", Value: "<a&b>"},
+		{Name: "Large", Value: math.MaxInt32 + 1},
+		{Name: "Count", Value: 8},
+	} {
+		assert.NoError(t, f.SetCustomProps(prop))
+	}
+	// Test update the custom property with case-insensitive name
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "clientid", Value: "C-2048"}))
+	// Test delete the custom property
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Count"}))
+	// Test delete not exist custom property
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Classification"}))
+	expected := []CustomProperty{
+		{Name: "ClientID", Value: "C-2048"},
+		{Name: "Pages", Value: int32(12)},
+		{Name: "Score", Value: 95.5},
+		{Name: "Approved", Value: true},
+		{Name: "Reviewed", Value: date},
+		{Name: "Escaped", Value: "<a&b>"},
+		{Name: "Large", Value: float64(math.MaxInt32 + 1)},
+	}
+	props, err := f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomProps.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCustomProps.xlsx"))
+	assert.NoError(t, err)
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	// Test add custom property into existing part
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Classification", Value: "Internal"}))
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var count int
+	for _, v := range content.Overrides {
+		if v.PartName == "/docProps/custom.xml" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	// Test delete all custom properties
+	for _, prop := range append(expected, CustomProperty{Name: "Classification"}) {
+		assert.NoError(t, f.SetCustomProps(CustomProperty{Name: prop.Name}))
+	}
+	_, ok := f.Pkg.Load(defaultXMLPathDocPropsCustom)
+	assert.False(t, ok)
+	rels, err := f.relsReader(defaultXMLPathRootRels)
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipCustomProperties, rel.Type)
+	}
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+	assert.NoError(t, f.Close())
+
+	// Test get custom properties with the other variant types
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Code"><vt:lpstr>A1</vt:lpstr></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="Amount"><vt:cy>10.5</vt:cy></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="4" name="Date"><vt:filetime>invalid</vt:filetime></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="5" name="Empty"></property></Properties>`))
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomProperty{
+		{Name: "Code", Value: "A1"},
+		{Name: "Amount", Value: "10.5"},
+		{Name: "Date", Value: "invalid"},
+		{Name: "Empty"},
+	}, props)
+	// Test the unknown variant types will be kept on update
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Code", Value: "B2"}))
+	assert.Contains(t, string(f.readXML(defaultXMLPathDocPropsCustom)), `<property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="Amount"><vt:cy>10.5</vt:cy></property>`)
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Total", Value: int16(1)}))
+	assert.Contains(t, string(f.readXML(defaultXMLPathDocPropsCustom)), `pid="6" name="Total"><vt:i4>1</vt:i4>`)
+
+	// Test set custom properties with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SetCustomProps(CustomProperty{Value: "value"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomProps(CustomProperty{Name: "Name", Value: []string{}}))
+	assert.NoError(t, f.Close())
+
+	// Test set and get custom properties with unsupported charset
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Name", Value: "value"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCustomProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test set custom properties with unsupported charset relationships
+	f.Pkg.Delete(defaultXMLPathDocPropsCustom)
+	f.Relationships.Delete(defaultXMLPathRootRels)
+	f.Pkg.Store(defaultXMLPathRootRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Name", Value: "value"}), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Name"/></Properties>`))
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Name"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set custom properties with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Name", Value: "value"}), "XML syntax error on line 1: invalid UTF-8")
+}
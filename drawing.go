@@ -1312,7 +1312,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 			Xdr: NameSpaceDrawingMLSpreadSheet.Value,
 			A:   NameSpaceDrawingML.Value,
 		}
-		if _, ok = f.Pkg.Load(path); ok { // Append Model
+		if _, ok = f.pkgLoad(path); ok { // Append Model
 			decodeWsDr := decodeWsDr{}
 			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
 				Decode(&decodeWsDr); err != nil && err != io.EOF {
@@ -1337,6 +1337,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 					GraphicFrame: v.Content,
 				})
 			}
+			f.trackPart(path, &content)
 		}
 		f.Drawings.Store(path, &content)
 	}
@@ -28,6 +28,9 @@ var (
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartImageSize defined the error message on receive a chart image
+	// size that exceeds the pixels limit.
+	ErrChartImageSize = fmt.Errorf("the chart image size exceeds the %d pixels limit", maxChartImagePixels)
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
@@ -71,6 +74,12 @@ var (
 	// ErrInvalidFormula defined the error message on receive an invalid
 	// formula.
 	ErrInvalidFormula = errors.New("formula not valid")
+	// ErrMacroWorkbookFileFormat defined the error message on saving the
+	// workbook with VBA project as the file format without macro support.
+	ErrMacroWorkbookFileFormat = errors.New("the workbook with VBA project must be saved as .xlam, .xlsm or .xltm file")
+	// ErrMarshalRows defined the error message on receive an invalid value for
+	// marshal the worksheet rows.
+	ErrMarshalRows = errors.New("the value for marshal rows must be a slice of structs")
 	// ErrMaxFilePathLength defined the error message on receive the file path
 	// length overflow.
 	ErrMaxFilePathLength = fmt.Errorf("file path length exceeds maximum limit %d characters", MaxFilePathLength)
@@ -82,12 +91,24 @@ var (
 	// ErrNameLength defined the error message on receiving the defined name or
 	// table name length exceeds the limit.
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
+	// ErrOptionsEncryptionSpinCount defined the error message for receiving
+	// invalid EncryptionSpinCount.
+	ErrOptionsEncryptionSpinCount = fmt.Errorf("the value of EncryptionSpinCount should be between 0 and %d", maxEncryptionSpinCount)
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
 	// ErrOutlineLevel defined the error message on receive an invalid outline
 	// level number.
 	ErrOutlineLevel = errors.New("invalid outline level")
+	// ErrPackageWriterClosed defined the error message on using the package
+	// writer after it has been closed.
+	ErrPackageWriterClosed = errors.New("the package writer has been closed")
+	// ErrPackageWriterEncrypt defined the error message on using the package
+	// writer for the workbook with password protection.
+	ErrPackageWriterEncrypt = errors.New("the package writer doesn't support encrypting the workbook with password")
+	// ErrPackageWriterPartChanged defined the error message on the parts of
+	// the workbook have been changed after written by the package writer.
+	ErrPackageWriterPartChanged = errors.New("the parts of the workbook have been changed after written by the package writer")
 	// ErrParameterInvalid defined the error message on receive the invalid
 	// parameter.
 	ErrParameterInvalid = errors.New("parameter is invalid")
@@ -129,18 +150,30 @@ var (
 	// ErrSparklineType defined the error message on receive the invalid
 	// sparkline Type parameters.
 	ErrSparklineType = errors.New("parameter 'Type' must be 'line', 'column' or 'win_loss'")
+	// ErrStreamDirectRead defined the error message on reading the rows which
+	// have been written to the package writer by the stream writer.
+	ErrStreamDirectRead = errors.New("the rows have been written to the package writer and can't be read")
+	// ErrStreamNotFlushed defined the error message on creating a stream
+	// writer before the previous stream writer of the package writer flushed.
+	ErrStreamNotFlushed = errors.New("must call the Flush function of the previous stream writer")
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrTemplateFileFormat defined the error message on receive an
+	// unsupported template or add-in file format.
+	ErrTemplateFileFormat = errors.New("unsupported template file format")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
 	// ErrUnknownEncryptMechanism defined the error message on unsupported
 	// encryption mechanism.
 	ErrUnknownEncryptMechanism = errors.New("unknown encryption mechanism")
+	// ErrUnmarshalRows defined the error message on receive an invalid value
+	// for unmarshal the worksheet rows.
+	ErrUnmarshalRows = errors.New("the value for unmarshal rows must be a non-nil pointer to a slice of structs")
 	// ErrUnprotectSheet defined the error message on worksheet has set no
 	// protection.
 	ErrUnprotectSheet = errors.New("worksheet has set no protect")
@@ -180,6 +213,24 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// ErrCellUnmarshal defined an error of the cell value that can't be converted
+// to the type of the struct field on unmarshal the worksheet rows.
+type ErrCellUnmarshal struct {
+	Cell  string
+	Field string
+	Err   error
+}
+
+// Error returns the error message on unmarshal the cell value.
+func (err ErrCellUnmarshal) Error() string {
+	return fmt.Sprintf("cannot unmarshal cell %s into field %s: %v", err.Cell, err.Field, err.Err)
+}
+
+// Unwrap returns the underlying error of converting the cell value.
+func (err ErrCellUnmarshal) Unwrap() error {
+	return err.Err
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
@@ -216,6 +267,12 @@ func newInvalidAutoFilterOperatorError(op, exp string) error {
 	return fmt.Errorf("the operator %q in expression %q is not valid in relation to Blanks/NonBlanks", op, exp)
 }
 
+// newInvalidBinaryRecordError defined the error message on receiving the
+// invalid record in the part of the binary workbook.
+func newInvalidBinaryRecordError(part string, recordType int) error {
+	return fmt.Errorf("invalid record %d in binary part %s", recordType, part)
+}
+
 // newInvalidCellNameError defined the error message on receiving the invalid
 // cell name.
 func newInvalidCellNameError(cell string) error {
@@ -264,6 +321,24 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newNoExistChartError defined the error message on receiving the non existing
+// chart at the given cell.
+func newNoExistChartError(sheet, cell string) error {
+	return fmt.Errorf("chart at cell %s on sheet %s does not exist", cell, sheet)
+}
+
+// newNoExistCustomXMLPartError defined the error message on receiving the non
+// existing custom XML data part identifier.
+func newNoExistCustomXMLPartError(id string) error {
+	return fmt.Errorf("custom XML part %s does not exist", id)
+}
+
+// newNoExistExternalLinkError defined the error message on receiving the non
+// existing external link index.
+func newNoExistExternalLinkError(index int) error {
+	return fmt.Errorf("external link %d does not exist", index)
+}
+
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
@@ -300,6 +375,12 @@ func newUnknownFilterTokenError(token string) error {
 	return fmt.Errorf("unknown operator: %s", token)
 }
 
+// newUnsupportedCharsetError defined the error message on receiving the non
+// UTF-8 charset without the charset transcoder.
+func newUnsupportedCharsetError(charset string) error {
+	return fmt.Errorf("unsupported charset %q without the charset transcoder", charset)
+}
+
 // newUnsupportedChartType defined the error message on receiving the chart
 // type are unsupported.
 func newUnsupportedChartType(chartType ChartType) error {
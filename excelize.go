@@ -17,11 +17,13 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/net/html/charset"
 )
@@ -31,13 +33,20 @@ type File struct {
 	mu               sync.Mutex
 	checked          sync.Map
 	formulaChecked   bool
+	lazyMu           sync.Mutex
+	lazyParts        sync.Map
+	originParts      sync.Map
+	partDigests      sync.Map
 	options          *Options
+	pkgCloser        io.Closer
 	sharedStringItem [][]uint
 	sharedStringsMap map[string]int
-	sharedStringTemp *os.File
+	sharedStringTemp TempFile
 	sheetMap         map[string]string
+	storage          TempStorage
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
+	tempIndexes      sync.Map
 	xmlAttr          sync.Map
 	CalcChain        *xlsxCalcChain
 	CharsetReader    charsetTranscoderFn
@@ -70,6 +79,24 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // Password specifies the password of the spreadsheet in plain text.
 //
+// EncryptionCipher specifies the cipher algorithm for encrypting the
+// spreadsheet with the password on save, the ECMA-376 agile encryption will be
+// used. The optional values are "AES", "3DES" and "3DES_112", the default
+// value is "AES".
+//
+// EncryptionKeyBits specifies the key size in bits of the cipher algorithm for
+// encrypting the spreadsheet. The optional values are 128, 192 and 256 for the
+// AES cipher, 192 for the 3DES cipher and 128 for the 3DES_112 cipher, the
+// default value is 256 for the AES cipher.
+//
+// EncryptionHashAlgorithm specifies the hash algorithm for encrypting the
+// spreadsheet. The optional values are "MD5", "SHA-1", "SHA-256", "SHA-384"
+// and "SHA-512", the default value is "SHA-512".
+//
+// EncryptionSpinCount specifies the number of times to iterate the password
+// hash when encrypting the spreadsheet, the value should be less than or equal
+// to 10000000, the default value is 100000.
+//
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
 //
@@ -97,16 +124,98 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// LoadSheets specifies the names of the worksheets to be loaded on open the
+// spreadsheet. When this option is set, the other worksheets, drawings, pivot
+// caches and media parts will not be decompressed until they are accessed,
+// which reduces the time and memory usage of opening a spreadsheet with a lot
+// of worksheets but only a few of them will be used. The parts which haven't
+// been accessed will be copied to the saved spreadsheet as is.
+//
+// Deterministic specifies if save the spreadsheet as a byte-for-byte
+// reproducible package. When this option is set, the parts will be written in
+// a stable order with the fixed modification time, and the relationships and
+// content types will be sorted, so that saving the same logical workbook
+// always gives the same bytes.
+//
+// ModTime specifies the modification time of the parts in the package when
+// the Deterministic option is set, the MS-DOS epoch 1980-01-01 00:00:00 UTC
+// will be used by default. If this option is set with the Deterministic
+// option, it will also be written as the modified time of the document core
+// properties, and as the created time when the created time hasn't been set
+// for the new workbook.
+//
+// PreserveUnchangedParts specifies if copy the parts which haven't been
+// changed from the original spreadsheet verbatim on save, including their
+// original compression. By default, all loaded parts will be serialized again
+// on save, which may drop the unknown extensions and change the XML
+// formatting. This option should be set on open the spreadsheet, and the part
+// will be considered as unchanged if the serialized content of the part is the
+// same with the one after it has been parsed.
+//
+// Strict specifies if save the spreadsheet in the ISO/IEC 29500 Strict
+// conformance class. When this option is set, the parts will be written with
+// the Strict namespaces and relationship types, the numeric cells with the
+// date number format will be stored as the ISO 8601 date cells, and the VML
+// parts such as legacy comments shapes and form controls which aren't allowed
+// in the Strict package will be dropped.
+//
+// ParseConcurrency specifies the number of workers to parse the worksheets in
+// parallel on open the spreadsheet. When this option is set, all worksheets,
+// or the worksheets specified by the LoadSheets option, will be parsed on
+// open, which speeds up the spreadsheet opening for reading every worksheet.
+// Note that the parsed worksheets will be kept in memory, so this option
+// should not be used with the streaming reader for large worksheets.
+//
+// TempStorage specifies the storage backend of the temporary files, which used
+// for the unzipped worksheets and shared string table over the
+// UnzipXMLSizeLimit, the shared string index of the rows iterator, the column
+// index of the columns iterator and the spilled data of the stream writer. By
+// default, the temporary files will be created in the system temporary
+// directory.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations       uint
+	Password                string
+	EncryptionCipher        string
+	EncryptionKeyBits       int
+	EncryptionHashAlgorithm string
+	EncryptionSpinCount     int
+	RawCellValue            bool
+	UnzipSizeLimit          int64
+	UnzipXMLSizeLimit       int64
+	ShortDatePattern        string
+	LongDatePattern         string
+	LongTimePattern         string
+	CultureInfo             CultureName
+	LoadSheets              []string
+	Deterministic           bool
+	ModTime                 time.Time
+	PreserveUnchangedParts  bool
+	Strict                  bool
+	ParseConcurrency        int
+	TempStorage             TempStorage
+}
+
+// TempFile defined the interface of the temporary file created by the
+// temporary storage backend.
+type TempFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Writer
+	io.Closer
+	Name() string
+}
+
+// TempStorage defined the interface of the temporary storage backend, which
+// can be used to keep the temporary files in memory, on a tmpfs mount or in
+// an encrypted scratch area. The Create function creates a new temporary file
+// for writing and reading, the name of the file should be unique in the
+// storage. The Open function opens the temporary file by given name for
+// reading. The Remove function removes the temporary file by given name.
+type TempStorage interface {
+	Create() (TempFile, error)
+	Open(name string) (TempFile, error)
+	Remove(name string) error
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -115,6 +224,14 @@ type Options struct {
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
+// The binary workbook (XLSB) files are supported for reading, the binary parts
+// will be converted to the XML parts on open, and should be saved as the XLSX
+// file by the SaveAs function. The legacy workbook (XLS) files in the BIFF8
+// format are supported for reading in the same way, the cell values, shared
+// strings, basic styles, merged cells, formulas and defined names will be
+// converted, and the encrypted legacy workbook could be opened with the
+// password in the options.
+//
 // Close the file by Close function after opening the spreadsheet.
 func OpenFile(filename string, opts ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
@@ -140,6 +257,7 @@ func newFile() *File {
 		checked:          sync.Map{},
 		sheetMap:         make(map[string]string),
 		tempFiles:        sync.Map{},
+		tempIndexes:      sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
 		sharedStringsMap: make(map[string]int),
@@ -184,25 +302,130 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if err = f.checkOpenReaderOptions(); err != nil {
 		return nil, err
 	}
+	f.storage = f.tempStorage()
 	if bytes.Contains(b, oleIdentifier) {
-		if b, err = Decrypt(b, f.options); err != nil {
-			return nil, ErrWorkbookFileFormat
+		if b, err = f.readCompoundFile(b); err != nil {
+			return nil, err
+		}
+	}
+	return f.openZipReader(bytes.NewReader(b), int64(len(b)))
+}
+
+// OpenReaderAt take an io.ReaderAt and the size of the spreadsheet in bytes,
+// and returns a populated spreadsheet file struct for it. Unlike OpenReader,
+// the spreadsheet will be read through the io.ReaderAt without copying the
+// whole file into memory, so it can be used to open large workbooks on disk
+// or behind a range-readable object store. The encrypted spreadsheet will be
+// read into memory for decryption. When opening the spreadsheet with the
+// LoadSheets or PreserveUnchangedParts option, the io.ReaderAt should be kept
+// readable until the spreadsheet has been closed, since the parts which
+// haven't been loaded and the unchanged parts will be read from it on demand
+// and on save. For example:
+//
+//	file, err := os.Open("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	info, err := file.Stat()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	f, err := excelize.OpenReaderAt(file, info.Size())
+func OpenReaderAt(r io.ReaderAt, size int64, opts ...Options) (*File, error) {
+	f := newFile()
+	f.options = f.getOptions(opts...)
+	if err := f.checkOpenReaderOptions(); err != nil {
+		return nil, err
+	}
+	f.storage = f.tempStorage()
+	header := make([]byte, len(oleIdentifier))
+	if _, err := r.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(header, oleIdentifier) {
+		b, err := io.ReadAll(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = f.readCompoundFile(b); err != nil {
+			return nil, err
+		}
+		return f.openZipReader(bytes.NewReader(b), int64(len(b)))
+	}
+	return f.openZipReader(r, size)
+}
+
+// OpenFS take a file system and the name of a spreadsheet file in it, and
+// returns a populated spreadsheet file struct. If the opened file implements
+// the io.ReaderAt interface, such as the files of os.DirFS, the spreadsheet
+// will be read without copying the whole file into memory. For example, open
+// the spreadsheet embedded in the program:
+//
+//	//go:embed templates
+//	var templates embed.FS
+//
+//	f, err := excelize.OpenFS(templates, "templates/Book1.xlsx")
+//
+// Close the file by Close function after opening the spreadsheet.
+func OpenFS(fsys fs.FS, name string, opts ...Options) (*File, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	var f *File
+	if r, ok := file.(io.ReaderAt); ok && info.Mode().IsRegular() {
+		if f, err = OpenReaderAt(r, info.Size(), opts...); err == nil && (f.hasLazyParts() || f.hasOriginParts()) {
+			f.pkgCloser = file
+			return f, nil
 		}
+	} else {
+		f, err = OpenReader(file, opts...)
 	}
-	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		if closeErr := file.Close(); closeErr != nil {
+			return f, closeErr
+		}
+		return f, err
+	}
+	return f, file.Close()
+}
+
+// openZipReader provides a function to read the parts of the spreadsheet from
+// the given io.ReaderAt of the ZIP archive.
+func (f *File) openZipReader(r io.ReaderAt, size int64) (*File, error) {
+	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		if len(f.options.Password) > 0 {
 			return nil, ErrWorkbookPassword
 		}
 		return nil, err
 	}
+	if isOpenDocument(zr) {
+		b, err := f.convertOpenDocument(zr)
+		if err != nil {
+			return nil, err
+		}
+		return f.openZipReader(bytes.NewReader(b), int64(len(b)))
+	}
 	file, sheetCount, err := f.ReadZipReader(zr)
 	if err != nil {
 		return nil, err
 	}
 	f.SheetCount = sheetCount
+	if err = f.convertBinaryWorkbook(file); err != nil {
+		return nil, err
+	}
 	for k, v := range file {
 		f.Pkg.Store(k, v)
+		f.setOriginPartContent(k, v)
 	}
 	if f.CalcChain, err = f.calcChainReader(); err != nil {
 		return f, err
@@ -210,6 +433,18 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if f.sheetMap, err = f.getSheetMap(); err != nil {
 		return f, err
 	}
+	for _, sheet := range f.options.LoadSheets {
+		name, ok := f.getSheetXMLPath(sheet)
+		if !ok {
+			return f, ErrSheetNotExist{sheet}
+		}
+		f.loadLazyPart(name)
+	}
+	if f.options.ParseConcurrency > 0 {
+		if err = f.parseWorksheets(f.options.ParseConcurrency); err != nil {
+			return f, err
+		}
+	}
 	if f.Styles, err = f.stylesReader(); err != nil {
 		return f, err
 	}
@@ -271,11 +506,9 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		ws = worksheet.(*xlsxWorksheet)
 		return
 	}
-	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
-		if strings.HasPrefix(name, sheetType) {
-			err = newNotWorksheetError(sheet)
-			return
-		}
+	if f.isNotWorksheetPath(name) {
+		err = newNotWorksheetError(sheet)
+		return
 	}
 	ws = new(xlsxWorksheet)
 	if attrs, ok := f.xmlAttr.Load(name); !ok {
@@ -298,10 +531,69 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		}
 		f.checked.Store(name, true)
 	}
+	f.trackPart(name, ws)
 	f.Sheet.Store(name, ws)
 	return
 }
 
+// parseWorksheets provides a function to parse the worksheets in parallel by
+// given number of workers, only the worksheets specified by the LoadSheets
+// option will be parsed if it was set. The first error encountered will be
+// returned after all workers have finished.
+func (f *File) parseWorksheets(workers int) error {
+	var (
+		err    error
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		sheets []string
+		queue  = make(chan string)
+	)
+	names := f.options.LoadSheets
+	if names == nil {
+		names = f.GetSheetList()
+	}
+	for _, sheet := range names {
+		if name, ok := f.getSheetXMLPath(sheet); ok && !f.isNotWorksheetPath(name) {
+			sheets = append(sheets, sheet)
+		}
+	}
+	if workers > len(sheets) {
+		workers = len(sheets)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sheet := range queue {
+				if _, parseErr := f.workSheetReader(sheet); parseErr != nil {
+					mu.Lock()
+					if err == nil {
+						err = parseErr
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, sheet := range sheets {
+		queue <- sheet
+	}
+	close(queue)
+	wg.Wait()
+	return err
+}
+
+// isNotWorksheetPath returns if the part of the given path is a chart sheet,
+// dialog sheet or macro sheet.
+func (f *File) isNotWorksheetPath(name string) bool {
+	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
+		if strings.HasPrefix(name, sheetType) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkSheet provides a function to fill each row element and make that is
 // continuous in a worksheet of XML.
 func (ws *xlsxWorksheet) checkSheet() {
@@ -565,6 +857,7 @@ func (f *File) AddVBAProject(file []byte) error {
 // type for relationship parts and the main document part.
 func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 	var ok bool
+	partName := "/" + f.getWorkbookPath()
 	content, err := f.contentTypesReader()
 	if err != nil {
 		return err
@@ -577,7 +870,7 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 		}
 	}
 	for idx, o := range content.Overrides {
-		if o.PartName == "/xl/workbook.xml" {
+		if o.PartName == partName {
 			content.Overrides[idx].ContentType = contentType
 		}
 	}
@@ -590,6 +883,41 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 	return err
 }
 
+// getWorkbookContentType provides a function to get the content type of the
+// main document part.
+func (f *File) getWorkbookContentType() (string, error) {
+	partName := "/" + f.getWorkbookPath()
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return "", err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, o := range content.Overrides {
+		if o.PartName == partName {
+			return o.ContentType, err
+		}
+	}
+	return "", err
+}
+
+// hasVBAProject provides a function to check if the workbook contains the VBA
+// project.
+func (f *File) hasVBAProject() bool {
+	rels, _ := f.relsReader(f.getWorkbookRelsPath())
+	if rels == nil {
+		return false
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			return true
+		}
+	}
+	return false
+}
+
 // metadataReader provides a function to get the pointer to the structure
 // after deserialization of xl/metadata.xml.
 func (f *File) metadataReader() (*xlsxMetadata, error) {
@@ -11,6 +11,7 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"io/fs"
 	"math"
 	"os"
 	"path/filepath"
@@ -329,6 +330,219 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenFileLoadSheets(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LoadSheets: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	_, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, name := range []string{"xl/worksheets/sheet2.xml", "xl/drawings/drawing1.xml", "xl/media/image1.jpeg"} {
+		_, ok = f.lazyParts.Load(name)
+		assert.True(t, ok, name)
+		_, ok = f.Pkg.Load(name)
+		assert.False(t, ok, name)
+	}
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	// Test save the spreadsheet with the parts which haven't been loaded
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	_, ok = f.lazyParts.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	f2, err := OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err := f2.GetRows("Sheet2")
+	assert.NoError(t, err)
+	// Test access the worksheet which hasn't been loaded
+	rows2, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, rows, rows2)
+	_, ok = f.lazyParts.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	pics, err := f.GetPictures("Sheet2", "I1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 0)
+	assert.Equal(t, 1, f.countMedia())
+	_, ok = f.lazyParts.Load("xl/media/image1.jpeg")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenFileLoadSheets.xlsx")))
+	assert.NoError(t, f2.Close())
+	assert.NoError(t, f.Close())
+	// Test load the part which hasn't been loaded concurrently
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LoadSheets: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	var wg sync.WaitGroup
+	loaded := make([]bool, 16)
+	for i := range loaded {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, loaded[i] = f.pkgLoad("xl/drawings/drawing1.xml")
+		}(i)
+	}
+	wg.Wait()
+	for _, ok := range loaded {
+		assert.True(t, ok)
+	}
+	assert.NoError(t, f.Close())
+	// Test open the spreadsheet with the worksheet which doesn't exist
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LoadSheets: []string{"SheetN"}})
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+}
+
+func TestOpenFileParseConcurrency(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{ParseConcurrency: 4})
+	assert.NoError(t, err)
+	for _, name := range []string{"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		_, ok := f.Sheet.Load(name)
+		assert.True(t, ok, name)
+	}
+	assert.NoError(t, f.Close())
+	// Test parse the worksheets specified by the LoadSheets option
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{ParseConcurrency: 4, LoadSheets: []string{"Sheet2"}})
+	assert.NoError(t, err)
+	_, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	_, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+	// Test parse the worksheets with the chart sheet
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1", Values: "Sheet1!$B$2"}},
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf, Options{ParseConcurrency: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test parse the worksheets with unsupported charset
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	_, err = OpenReader(buf, Options{ParseConcurrency: 2})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestOpenReaderAt(t *testing.T) {
+	file, err := os.Open(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	info, err := file.Stat()
+	assert.NoError(t, err)
+	f, err := OpenReaderAt(file, info.Size())
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+	// Test open the encrypted spreadsheet
+	b, err := os.ReadFile(filepath.Join("test", "encryptSHA1.xlsx"))
+	assert.NoError(t, err)
+	f, err = OpenReaderAt(bytes.NewReader(b), int64(len(b)), Options{Password: "password"})
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", val)
+	assert.NoError(t, f.Close())
+	_, err = OpenReaderAt(bytes.NewReader(b), int64(len(b)), Options{Password: "passwd"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test open the spreadsheet with invalid options
+	_, err = OpenReaderAt(bytes.NewReader(b), int64(len(b)), Options{UnzipSizeLimit: 1, UnzipXMLSizeLimit: 2})
+	assert.Equal(t, ErrOptionsUnzipSizeLimit, err)
+	// Test open the spreadsheet with unsupported file format
+	_, err = OpenReaderAt(strings.NewReader(""), 0)
+	assert.EqualError(t, err, zip.ErrFormat.Error())
+}
+
+func TestOpenFS(t *testing.T) {
+	f, err := OpenFS(os.DirFS("test"), "Book1.xlsx")
+	assert.NoError(t, err)
+	assert.Nil(t, f.pkgCloser)
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+	// Test open the spreadsheet with the parts which haven't been loaded
+	f, err = OpenFS(os.DirFS("test"), "Book1.xlsx", Options{LoadSheets: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, f.pkgCloser)
+	_, err = f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.pkgCloser)
+	// Test open the spreadsheet with preserve unchanged parts and save it
+	f, err = OpenFS(os.DirFS("test"), "Book1.xlsx", Options{PreserveUnchangedParts: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, f.pkgCloser)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Preserved"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenFS.xlsx")))
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.pkgCloser)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "Preserved", "A19": "Total:"} {
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.Close())
+	// Test open the spreadsheet from the file which doesn't implement io.ReaderAt
+	b, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f, err = OpenFS(gzipFS{"Book1.xlsx": b}, "Book1.xlsx")
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+	// Test open the spreadsheet which doesn't exist
+	_, err = OpenFS(os.DirFS("test"), "NotExist.xlsx")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	// Test open the spreadsheet with unsupported file format
+	_, err = OpenFS(os.DirFS("test"), "images/excel.png")
+	assert.EqualError(t, err, zip.ErrFormat.Error())
+	_, err = OpenFS(gzipFS{"Book1.xlsx": nil}, "Book1.xlsx")
+	assert.EqualError(t, err, zip.ErrFormat.Error())
+}
+
+// gzipFS is a file system which files don't implement the io.ReaderAt
+// interface, used for testing.
+type gzipFS map[string][]byte
+
+func (fsys gzipFS) Open(name string) (fs.File, error) {
+	b, ok := fsys[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(b)
+	_ = zw.Close()
+	zr, err := gzip.NewReader(&buf)
+	return &gzipFile{Reader: zr, name: name, size: int64(len(b))}, err
+}
+
+// gzipFile is a file of the gzipFS, used for testing.
+type gzipFile struct {
+	*gzip.Reader
+	name string
+	size int64
+}
+
+func (f *gzipFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *gzipFile) Name() string               { return f.name }
+func (f *gzipFile) Size() int64                { return f.size }
+func (f *gzipFile) Mode() fs.FileMode          { return 0o444 }
+func (f *gzipFile) ModTime() time.Time         { return time.Time{} }
+func (f *gzipFile) IsDir() bool                { return false }
+func (f *gzipFile) Sys() interface{}           { return nil }
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
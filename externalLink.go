@@ -0,0 +1,829 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// regexpChartExternalRef matches the series text, data references and
+	// formulas in the chart part, the series text and the data references
+	// should be matched before the formulas inside them.
+	regexpChartExternalRef = regexp.MustCompile(`(?s)<c:tx>\s*<c:strRef>\s*<c:f>([^<]*)</c:f>(.*?)</c:strRef>\s*</c:tx>|<c:(numRef|strRef|multiLvlStrRef)>\s*<c:f>([^<]*)</c:f>(.*?)</c:(?:numRef|strRef|multiLvlStrRef)>|<c:f>([^<]*)</c:f>`)
+	// regexpChartCache matches the cached values in the chart data reference.
+	regexpChartCache = regexp.MustCompile(`(?s)<c:(?:numCache|strCache|multiLvlStrCache)>(.*)</c:(?:numCache|strCache|multiLvlStrCache)>`)
+	// regexpChartFirstLevel matches the first level of the cached values in the
+	// multiple level string reference.
+	regexpChartFirstLevel = regexp.MustCompile(`(?s)<c:lvl>(.*?)</c:lvl>`)
+	// regexpChartPointCount matches the point count of the cached values in
+	// the chart data reference.
+	regexpChartPointCount = regexp.MustCompile(`<c:ptCount\s[^>]*/>`)
+	// regexpChartTextValue matches the first text value of the cached values
+	// in the series text.
+	regexpChartTextValue = regexp.MustCompile(`(?s)<c:v>(.*?)</c:v>`)
+	// regexpExtFormula matches the data validations and formulas in the
+	// worksheet extension list, the data validations should be matched before
+	// the formulas inside them.
+	regexpExtFormula = regexp.MustCompile(`(?s)<x14:dataValidation\b([^>]*)>.*?</x14:dataValidation>|<xm:f>([^<]*)</xm:f>`)
+	// regexpXMFormula matches the formulas in the worksheet extension list.
+	regexpXMFormula = regexp.MustCompile(`<xm:f>([^<]*)</xm:f>`)
+	// externalLinkFormulaUnescaper unescapes the formula text in the XML
+	// parts which were stored as raw XML.
+	externalLinkFormulaUnescaper = strings.NewReplacer(
+		`&amp;`, `&`,
+		`&lt;`, `<`,
+		`&gt;`, `>`,
+		`&quot;`, `"`,
+		`&apos;`, `'`,
+	)
+)
+
+// externalLinkPart defined the external link part of the workbook with the
+// relationship ID in the workbook relationships.
+type externalLinkPart struct {
+	rID  string
+	path string
+	link *xlsxExternalLink
+}
+
+// externalRef defined the external reference in the formula, such as
+// [1]Sheet1!$A$1, '[1]Sheet 1'!A1:B2 and [1]!Name.
+type externalRef struct {
+	book   int
+	sheet  string
+	ref    string
+	quoted bool
+}
+
+// String returns the external reference formula text.
+func (r externalRef) String() string {
+	if r.quoted {
+		return fmt.Sprintf("'[%d]%s'!%s", r.book, strings.ReplaceAll(r.sheet, "'", "''"), r.ref)
+	}
+	return fmt.Sprintf("[%d]%s!%s", r.book, r.sheet, r.ref)
+}
+
+// externalLinkCache defined the cached sheet names, defined names and cell
+// values of the external workbook.
+type externalLinkCache struct {
+	sheets []string
+	names  []xlsxExternalDefinedName
+	cells  map[int]map[string]xlsxExternalCell
+}
+
+// externalLinkReader provides a function to get the pointer to the structure
+// after deserialization of xl/externalLinks/externalLink%d.xml.
+func (f *File) externalLinkReader(path string) (*xlsxExternalLink, error) {
+	content, ok := f.pkgLoad(path)
+	externalLink := &xlsxExternalLink{}
+	if ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(externalLink); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return externalLink, nil
+}
+
+// getExternalLinkParts provides a function to get the external link parts in
+// the order of the external references of the workbook.
+func (f *File) getExternalLinkParts() ([]externalLinkPart, error) {
+	var parts []externalLinkPart
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return parts, err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return parts, err
+	}
+	for _, ref := range wb.ExternalReferences.ExternalReference {
+		part := externalLinkPart{rID: ref.RID}
+		if rels != nil {
+			for _, rel := range rels.Relationships {
+				if rel.ID == ref.RID {
+					part.path = f.getWorksheetPath(rel.Target)
+					break
+				}
+			}
+		}
+		if part.link, err = f.externalLinkReader(part.path); err != nil {
+			return parts, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, err
+}
+
+// getExternalLinkPart provides a function to get the external link part by
+// given one-based external link index.
+func (f *File) getExternalLinkPart(index int) (externalLinkPart, error) {
+	parts, err := f.getExternalLinkParts()
+	if err != nil {
+		return externalLinkPart{}, err
+	}
+	if index < 1 || index > len(parts) {
+		return externalLinkPart{}, newNoExistExternalLinkError(index)
+	}
+	return parts[index-1], err
+}
+
+// getExternalLinkRelsPath returns the relationships part path of the external
+// link part.
+func getExternalLinkRelsPath(partPath string) string {
+	return path.Join(path.Dir(partPath), "_rels", path.Base(partPath)+".rels")
+}
+
+// GetExternalLinks provides a function to get the external links of the
+// workbook. The Index of each external link is the number in the formulas
+// which reference the external workbook, for example, the formula
+// =[1]Sheet1!A1 references the cell A1 on the worksheet Sheet1 of the
+// external workbook which Index is 1. For example, get the target path of
+// the external workbooks:
+//
+//	links, err := f.GetExternalLinks()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, link := range links {
+//	    fmt.Println(link.Index, link.Target)
+//	}
+func (f *File) GetExternalLinks() ([]ExternalLink, error) {
+	var links []ExternalLink
+	parts, err := f.getExternalLinkParts()
+	if err != nil {
+		return links, err
+	}
+	for i, part := range parts {
+		link := ExternalLink{Index: i + 1}
+		switch {
+		case part.link.ExternalBook != nil:
+			book := part.link.ExternalBook
+			link.Type = "externalBook"
+			if link.Target, err = f.getExternalLinkTarget(part); err != nil {
+				return links, err
+			}
+			cache := newExternalLinkCache(book)
+			link.SheetNames = cache.sheets
+			for _, definedName := range cache.names {
+				name := ExternalLinkDefinedName{Name: definedName.Name, RefersTo: definedName.RefersTo}
+				if definedName.SheetID != nil && *definedName.SheetID >= 0 && *definedName.SheetID < len(cache.sheets) {
+					name.Scope = cache.sheets[*definedName.SheetID]
+				}
+				link.DefinedNames = append(link.DefinedNames, name)
+			}
+		case part.link.DdeLink != nil:
+			link.Type = "ddeLink"
+		case part.link.OleLink != nil:
+			link.Type = "oleLink"
+		}
+		links = append(links, link)
+	}
+	return links, err
+}
+
+// getExternalLinkTarget provides a function to get the target path of the
+// external workbook by given external link part.
+func (f *File) getExternalLinkTarget(part externalLinkPart) (string, error) {
+	rels, err := f.relsReader(getExternalLinkRelsPath(part.path))
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.ID == part.link.ExternalBook.RID {
+			return rel.Target, err
+		}
+	}
+	return "", err
+}
+
+// GetExternalLinkCellValues provides a function to get the cached cell values
+// of the external workbook by given one-based external link index. These
+// values were stored by the spreadsheet application when the workbook was
+// saved, and will be used in the formulas until the links are updated. For
+// example, get the cached cell values of the first external workbook:
+//
+//	values, err := f.GetExternalLinkCellValues(1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, value := range values {
+//	    fmt.Println(value.Sheet, value.Cell, value.Value)
+//	}
+func (f *File) GetExternalLinkCellValues(index int) ([]ExternalLinkCellValue, error) {
+	var values []ExternalLinkCellValue
+	part, err := f.getExternalLinkPart(index)
+	if err != nil || part.link.ExternalBook == nil || part.link.ExternalBook.SheetDataSet == nil {
+		return values, err
+	}
+	cache := newExternalLinkCache(part.link.ExternalBook)
+	for _, sheetData := range part.link.ExternalBook.SheetDataSet.SheetData {
+		var sheet string
+		if sheetData.SheetID >= 0 && sheetData.SheetID < len(cache.sheets) {
+			sheet = cache.sheets[sheetData.SheetID]
+		}
+		for _, row := range sheetData.Row {
+			for _, cell := range row.Cell {
+				values = append(values, ExternalLinkCellValue{
+					Sheet: sheet, Cell: cell.R, Type: getExternalCellType(cell.T), Value: cell.V,
+				})
+			}
+		}
+	}
+	return values, err
+}
+
+// getExternalCellType returns the cell type by given data type of the cached
+// cell value in the external workbook.
+func getExternalCellType(t string) CellType {
+	switch t {
+	case "", "n":
+		return CellTypeNumber
+	case "str":
+		return CellTypeInlineString
+	}
+	return cellTypes[t]
+}
+
+// SetExternalLinkTarget provides a function to change the target path of the
+// external workbook by given one-based external link index and the new
+// target path. The target path could be an absolute path, a relative path
+// to the workbook or an URL. Note that the cached cell values of the external
+// workbook will not be updated until the links are updated by the
+// spreadsheet application. For example, repoint the first external link to
+// the workbook Book2.xlsx:
+//
+//	err := f.SetExternalLinkTarget(1, "Book2.xlsx")
+func (f *File) SetExternalLinkTarget(index int, target string) error {
+	if target == "" {
+		return ErrParameterRequired
+	}
+	part, err := f.getExternalLinkPart(index)
+	if err != nil {
+		return err
+	}
+	if part.link.ExternalBook == nil {
+		return ErrParameterInvalid
+	}
+	rels, err := f.relsReader(getExternalLinkRelsPath(part.path))
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for i, rel := range rels.Relationships {
+			if rel.ID == part.link.ExternalBook.RID {
+				rels.Relationships[i].Target, rels.Relationships[i].TargetMode = target, "External"
+				return err
+			}
+		}
+	}
+	return ErrParameterInvalid
+}
+
+// BreakExternalLink provides a function to break the external link by given
+// one-based external link index. The formulas in the cells which reference
+// the external workbook will be replaced with their cached values, and the
+// external references in the defined names, data validations and charts
+// will be replaced with the cached values of the external workbook. The
+// external link part will be removed from the workbook, and the index of
+// the subsequent external links in the formulas will be decreased. For
+// example, break the first external link:
+//
+//	err := f.BreakExternalLink(1)
+func (f *File) BreakExternalLink(index int) error {
+	parts, err := f.getExternalLinkParts()
+	if err != nil {
+		return err
+	}
+	if index < 1 || index > len(parts) {
+		return newNoExistExternalLinkError(index)
+	}
+	part := parts[index-1]
+	cache := newExternalLinkCache(part.link.ExternalBook)
+	for _, name := range f.GetSheetList() {
+		if sheetXMLPath, ok := f.getSheetXMLPath(name); ok && f.isNotWorksheetPath(sheetXMLPath) {
+			continue
+		}
+		f.mu.Lock()
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			f.mu.Unlock()
+			return err
+		}
+		f.mu.Unlock()
+		if err = f.breakExternalLinkCells(ws, f.getSheetID(name), cache, index); err != nil {
+			return err
+		}
+		breakExternalLinkDataValidations(ws, cache, index)
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i, definedName := range wb.DefinedNames.DefinedName {
+			wb.DefinedNames.DefinedName[i].Data, _ = cache.adjustFormula(definedName.Data, index, false)
+		}
+	}
+	f.breakExternalLinkCharts(cache, index)
+	return f.removeExternalLink(wb, part, index)
+}
+
+// breakExternalLinkCells provides a function to replace the formulas which
+// reference the breaking external link with their cached values, and
+// renumber the references of the subsequent external links in the formulas
+// of the worksheet.
+func (f *File) breakExternalLinkCells(ws *xlsxWorksheet, sheetID int, cache *externalLinkCache, index int) error {
+	shared := map[int]bool{}
+	for r := range ws.SheetData.Row {
+		for _, c := range ws.SheetData.Row[r].C {
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil {
+				continue
+			}
+			if _, found := cache.adjustFormula(c.F.Content, index, false); found {
+				shared[*c.F.Si] = true
+			}
+		}
+	}
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			c := &ws.SheetData.Row[r].C[i]
+			if c.F == nil {
+				continue
+			}
+			formula, found := cache.adjustFormula(c.F.Content, index, false)
+			if !found && !(c.F.T == STCellFormulaTypeShared && c.F.Si != nil && shared[*c.F.Si]) {
+				c.F.Content = formula
+				continue
+			}
+			if err := f.deleteCalcChain(sheetID, c.R); err != nil {
+				return err
+			}
+			c.F = nil
+			if c.T == "str" {
+				idx, err := f.setSharedString(c.V)
+				if err != nil {
+					return err
+				}
+				c.T, c.V = "s", strconv.Itoa(idx)
+			}
+		}
+	}
+	return nil
+}
+
+// breakExternalLinkDataValidations provides a function to replace the
+// external references of the breaking external link with the cached values,
+// and renumber the references of the subsequent external links in the data
+// validations of the worksheet.
+func breakExternalLinkDataValidations(ws *xlsxWorksheet, cache *externalLinkCache, index int) {
+	adjust := func(formula *xlsxInnerXML, list bool) {
+		if formula == nil {
+			return
+		}
+		content, _ := cache.adjustFormula(formulaUnescaper.Replace(formula.Content), index, list)
+		formula.Content = formulaEscaper.Replace(content)
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			if dv != nil {
+				adjust(dv.Formula1, dv.Type == "list")
+				adjust(dv.Formula2, false)
+			}
+		}
+	}
+	if ws.ExtLst == nil {
+		return
+	}
+	adjustXMFormula := func(list bool) func(string) string {
+		return func(match string) string {
+			formula := regexpXMFormula.FindStringSubmatch(match)[1]
+			content, _ := cache.adjustFormula(externalLinkFormulaUnescaper.Replace(formula), index, list)
+			return "<xm:f>" + formulaEscaper.Replace(content) + "</xm:f>"
+		}
+	}
+	ws.ExtLst.Ext = regexpExtFormula.ReplaceAllStringFunc(ws.ExtLst.Ext, func(match string) string {
+		if strings.HasPrefix(match, "<xm:f>") {
+			return adjustXMFormula(false)(match)
+		}
+		list := strings.Contains(regexpExtFormula.FindStringSubmatch(match)[1], `type="list"`)
+		return regexpXMFormula.ReplaceAllStringFunc(match, adjustXMFormula(list))
+	})
+}
+
+// breakExternalLinkCharts provides a function to replace the chart data
+// references of the breaking external link with the cached values, and
+// renumber the references of the subsequent external links in the charts.
+func (f *File) breakExternalLinkCharts(cache *externalLinkCache, index int) {
+	var charts []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if name := k.(string); strings.HasPrefix(name, "xl/charts/chart") && strings.HasSuffix(name, ".xml") {
+			charts = append(charts, name)
+		}
+		return true
+	})
+	for _, name := range charts {
+		content, ok := f.Pkg.Load(name)
+		if !ok {
+			continue
+		}
+		f.Pkg.Store(name, regexpChartExternalRef.ReplaceAllFunc(content.([]byte), func(match []byte) []byte {
+			return []byte(cache.adjustChartRef(regexpChartExternalRef.FindStringSubmatch(string(match)), index))
+		}))
+	}
+}
+
+// adjustChartRef returns the chart series text, data reference or formula
+// by given regular expression submatches, the data reference of the breaking
+// external link will be converted to the literal values.
+func (c *externalLinkCache) adjustChartRef(submatch []string, index int) string {
+	adjust := func(formula string) (string, bool) {
+		content, found := c.adjustFormula(externalLinkFormulaUnescaper.Replace(formula), index, false)
+		return formulaEscaper.Replace(content), found
+	}
+	switch {
+	case strings.HasPrefix(submatch[0], "<c:tx>"):
+		formula, found := adjust(submatch[1])
+		if !found {
+			return fmt.Sprintf("<c:tx><c:strRef><c:f>%s</c:f>%s</c:strRef></c:tx>", formula, submatch[2])
+		}
+		var text string
+		if value := regexpChartTextValue.FindStringSubmatch(submatch[2]); value != nil {
+			text = value[1]
+		}
+		return fmt.Sprintf("<c:tx><c:v>%s</c:v></c:tx>", text)
+	case submatch[3] != "":
+		formula, found := adjust(submatch[4])
+		if !found {
+			return fmt.Sprintf("<c:%s><c:f>%s</c:f>%s</c:%s>", submatch[3], formula, submatch[5], submatch[3])
+		}
+		var values string
+		if cache := regexpChartCache.FindStringSubmatch(submatch[5]); cache != nil {
+			values = cache[1]
+		}
+		if submatch[3] == "multiLvlStrRef" {
+			var level string
+			if lvl := regexpChartFirstLevel.FindStringSubmatch(values); lvl != nil {
+				level = lvl[1]
+			}
+			values = regexpChartPointCount.FindString(values) + level
+		}
+		if values == "" {
+			values = `<c:ptCount val="0"/>`
+		}
+		if submatch[3] == "numRef" {
+			return "<c:numLit>" + values + "</c:numLit>"
+		}
+		return "<c:strLit>" + values + "</c:strLit>"
+	}
+	formula, _ := adjust(submatch[6])
+	return "<c:f>" + formula + "</c:f>"
+}
+
+// removeExternalLink provides a function to remove the external link part,
+// the relationships and the content type of the external link.
+func (f *File) removeExternalLink(wb *xlsxWorkbook, part externalLinkPart, index int) error {
+	refs := wb.ExternalReferences.ExternalReference
+	if wb.ExternalReferences.ExternalReference = append(refs[:index-1], refs[index:]...); len(wb.ExternalReferences.ExternalReference) == 0 {
+		wb.ExternalReferences = nil
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for k, v := range rels.Relationships {
+			if v.ID == part.rID {
+				rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	relsPath := getExternalLinkRelsPath(part.path)
+	f.Pkg.Delete(part.path)
+	f.Pkg.Delete(relsPath)
+	f.Relationships.Delete(relsPath)
+	return f.removeContentTypesPart(ContentTypeSpreadSheetMLExternalLink, "/"+part.path)
+}
+
+// newExternalLinkCache returns the cached sheet names, defined names and cell
+// values of the external workbook.
+func newExternalLinkCache(book *xlsxExternalBook) *externalLinkCache {
+	cache := &externalLinkCache{cells: map[int]map[string]xlsxExternalCell{}}
+	if book == nil {
+		return cache
+	}
+	if book.SheetNames != nil {
+		for _, sheetName := range book.SheetNames.SheetName {
+			var name string
+			if sheetName.Val != nil {
+				name = *sheetName.Val
+			}
+			cache.sheets = append(cache.sheets, name)
+		}
+	}
+	if book.DefinedNames != nil {
+		cache.names = book.DefinedNames.DefinedName
+	}
+	if book.SheetDataSet != nil {
+		for _, sheetData := range book.SheetDataSet.SheetData {
+			if cache.cells[sheetData.SheetID] == nil {
+				cache.cells[sheetData.SheetID] = map[string]xlsxExternalCell{}
+			}
+			for _, row := range sheetData.Row {
+				for _, cell := range row.Cell {
+					cache.cells[sheetData.SheetID][cell.R] = cell
+				}
+			}
+		}
+	}
+	return cache
+}
+
+// adjustFormula returns the formula which the references of the breaking
+// external link replaced by the cached values, and the references of the
+// subsequent external links renumbered. The second return value reports
+// whether the formula references the breaking external link.
+func (c *externalLinkCache) adjustFormula(formula string, index int, list bool) (string, bool) {
+	var found bool
+	result := replaceExternalRefs(formula, func(ref externalRef) string {
+		if ref.book == index {
+			found = true
+			return c.value(ref.sheet, ref.ref, list, 0)
+		}
+		if ref.book > index {
+			ref.book--
+		}
+		return ref.String()
+	})
+	return result, found
+}
+
+// getSheetID returns the zero-based index of the external worksheet by given
+// worksheet name.
+func (c *externalLinkCache) getSheetID(sheet string) int {
+	for i, name := range c.sheets {
+		if strings.EqualFold(name, sheet) {
+			return i
+		}
+	}
+	return -1
+}
+
+// value returns the cached value of the external reference in the formula
+// literal form, the range reference will be converted to the array
+// constant, or the comma-separated list in the double quotes for the data
+// validation list.
+func (c *externalLinkCache) value(sheet, ref string, list bool, depth int) string {
+	sheetID := c.getSheetID(sheet)
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		var col, row int
+		if col, row, err = CellNameToCoordinates(strings.ReplaceAll(ref, "$", "")); err == nil {
+			coordinates = []int{col, row, col, row}
+		}
+	}
+	if err != nil {
+		return c.definedNameValue(sheet, ref, list, depth)
+	}
+	_ = sortCoordinates(coordinates)
+	cols, rows := coordinates[2]-coordinates[0]+1, coordinates[3]-coordinates[1]+1
+	if sheetID == -1 || cols*rows > TotalCellChars {
+		return "#REF!"
+	}
+	var items, values []string
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		var cells []string
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			cells = append(cells, c.cellValue(sheetID, cell, list))
+		}
+		values = append(values, cells...)
+		items = append(items, strings.Join(cells, ","))
+	}
+	if list {
+		return `"` + strings.ReplaceAll(strings.Join(values, ","), `"`, `""`) + `"`
+	}
+	if len(values) == 1 {
+		return values[0]
+	}
+	return "{" + strings.Join(items, ";") + "}"
+}
+
+// definedNameValue returns the cached value of the defined name in the
+// external workbook, the worksheet scoped defined name will be used if the
+// worksheet name was specified.
+func (c *externalLinkCache) definedNameValue(sheet, name string, list bool, depth int) string {
+	sheetID := -1
+	if sheet != "" {
+		if sheetID = c.getSheetID(sheet); sheetID == -1 {
+			return "#REF!"
+		}
+	}
+	for _, definedName := range c.names {
+		if !strings.EqualFold(definedName.Name, name) {
+			continue
+		}
+		if (definedName.SheetID == nil) != (sheetID == -1) ||
+			(definedName.SheetID != nil && *definedName.SheetID != sheetID) || depth > 0 {
+			continue
+		}
+		refersTo := strings.TrimPrefix(definedName.RefersTo, "=")
+		idx := strings.LastIndex(refersTo, "!")
+		if idx == -1 {
+			break
+		}
+		return c.value(unquoteSheetName(refersTo[:idx]), refersTo[idx+1:], list, depth+1)
+	}
+	return "#REF!"
+}
+
+// cellValue returns the cached value of the cell in the formula literal form,
+// the raw text will be returned for the data validation list.
+func (c *externalLinkCache) cellValue(sheetID int, cell string, list bool) string {
+	value, ok := c.cells[sheetID][cell]
+	if !ok {
+		if list {
+			return ""
+		}
+		return "0"
+	}
+	switch value.T {
+	case "", "n", "e":
+		if value.V == "" && !list {
+			return "0"
+		}
+		return value.V
+	case "b":
+		if value.V == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	if list {
+		return value.V
+	}
+	return `"` + strings.ReplaceAll(value.V, `"`, `""`) + `"`
+}
+
+// replaceExternalRefs provides a function to replace the external references
+// in the formula by given replace function, the text in the string literals
+// will not be changed.
+func replaceExternalRefs(formula string, fn func(ref externalRef) string) string {
+	var (
+		buf strings.Builder
+		i   int
+	)
+	for i < len(formula) {
+		ch := formula[i]
+		if ch == '"' {
+			end := quotedTextEnd(formula, i, '"')
+			buf.WriteString(formula[i:end])
+			i = end
+			continue
+		}
+		if ref, n, ok := parseExternalRef(formula, i); ok {
+			buf.WriteString(fn(ref))
+			i += n
+			continue
+		}
+		if ch == '\'' {
+			end := quotedTextEnd(formula, i, '\'')
+			buf.WriteString(formula[i:end])
+			i = end
+			continue
+		}
+		buf.WriteByte(ch)
+		i++
+	}
+	return buf.String()
+}
+
+// quotedTextEnd returns the position after the closing quote of the quoted
+// text which starts at the given position, the doubled quotes in the text
+// will be treated as the escaped quote.
+func quotedTextEnd(formula string, start int, quote byte) int {
+	for i := start + 1; i < len(formula); i++ {
+		if formula[i] != quote {
+			continue
+		}
+		if i+1 < len(formula) && formula[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(formula)
+}
+
+// parseExternalRef provides a function to parse the external reference which
+// starts at the given position of the formula, returns the external
+// reference, the length of the reference text, and whether the external
+// reference exists.
+func parseExternalRef(formula string, start int) (externalRef, int, bool) {
+	var (
+		ref externalRef
+		pos int
+		ok  bool
+	)
+	switch formula[start] {
+	case '\'':
+		end := quotedTextEnd(formula, start, '\'')
+		if end >= len(formula) || formula[end] != '!' {
+			return ref, 0, false
+		}
+		if ref.book, ref.sheet, ok = splitExternalBook(strings.ReplaceAll(formula[start+1:end-1], "''", "'")); !ok {
+			return ref, 0, false
+		}
+		ref.quoted, pos = true, end+1
+	case '[':
+		if prev, _ := utf8.DecodeLastRuneInString(formula[:start]); isExternalRefChar(prev) || strings.ContainsRune("[]_.$#\\\"'", prev) {
+			return ref, 0, false
+		}
+		end := strings.IndexByte(formula[start:], '!')
+		if end == -1 {
+			return ref, 0, false
+		}
+		if ref.book, ref.sheet, ok = splitExternalBook(formula[start : start+end]); !ok {
+			return ref, 0, false
+		}
+		for _, r := range ref.sheet {
+			if !isExternalRefChar(r) && r != '_' && r != '.' {
+				return ref, 0, false
+			}
+		}
+		pos = start + end + 1
+	default:
+		return ref, 0, false
+	}
+	refStart := pos
+	if pos < len(formula) && formula[pos] == '#' {
+		if end := strings.IndexByte(formula[pos:], '!'); end != -1 {
+			pos += end + 1
+		}
+	}
+	for pos < len(formula) {
+		r, size := utf8.DecodeRuneInString(formula[pos:])
+		if !isExternalRefChar(r) && !strings.ContainsRune("_$.:\\", r) {
+			break
+		}
+		pos += size
+	}
+	for pos > refStart && formula[pos-1] == ':' {
+		pos--
+	}
+	if pos == refStart {
+		return ref, 0, false
+	}
+	ref.ref = formula[refStart:pos]
+	return ref, pos - start, true
+}
+
+// isExternalRefChar returns whether the rune is a letter or digit which can
+// be used in the sheet name and the reference of the external reference.
+func isExternalRefChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitExternalBook returns the external workbook index and the worksheet
+// name by given text in the format like [1]Sheet1.
+func splitExternalBook(text string) (int, string, bool) {
+	if !strings.HasPrefix(text, "[") {
+		return 0, "", false
+	}
+	end := strings.IndexByte(text, ']')
+	if end < 2 {
+		return 0, "", false
+	}
+	book, err := strconv.Atoi(text[1:end])
+	if err != nil || book < 0 {
+		return 0, "", false
+	}
+	return book, text[end+1:], true
+}
@@ -0,0 +1,282 @@
+package excelize
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// prepareExternalLinks provides a function to create the workbook with two
+// external links for testing.
+func prepareExternalLinks(t *testing.T) *File {
+	f := NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{}
+	for i, content := range []string{
+		`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Sheet1"/><sheetName val="My Sheet"/></sheetNames><definedNames><definedName name="Total" refersTo="=Sheet1!$B$1"/><definedName name="Local" refersTo="='My Sheet'!$A$1" sheetId="1"/></definedNames><sheetDataSet><sheetData sheetId="0"><row r="1"><cell r="A1"><v>5</v></cell><cell r="B1"><v>10</v></cell></row><row r="2"><cell r="A2" t="str"><v>te"xt</v></cell></row><row r="3"><cell r="A3" t="b"><v>1</v></cell></row></sheetData><sheetData sheetId="1"><row r="1"><cell r="A1" t="str"><v>x</v></cell></row></sheetData></sheetDataSet></externalBook></externalLink>`,
+		`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Data"/></sheetNames><sheetDataSet><sheetData sheetId="0"><row r="1"><cell r="A1"><v>7</v></cell></row></sheetData></sheetDataSet></externalBook></externalLink>`,
+	} {
+		name := "xl/externalLinks/externalLink" + strconv.Itoa(i+1) + ".xml"
+		f.Pkg.Store(name, []byte(content))
+		f.Relationships.Store(getExternalLinkRelsPath(name), &xlsxRelationships{Relationships: []xlsxRelationship{
+			{ID: "rId1", Type: SourceRelationshipExternalLinkPath, Target: "Book" + strconv.Itoa(i+1) + ".xlsx", TargetMode: "External"},
+		}})
+		rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, "externalLinks/externalLink"+strconv.Itoa(i+1)+".xml", "")
+		wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: "rId" + strconv.Itoa(rID)})
+		assert.NoError(t, f.setContentTypes("/"+name, ContentTypeSpreadSheetMLExternalLink))
+	}
+	return f
+}
+
+func TestGetExternalLinks(t *testing.T) {
+	f := prepareExternalLinks(t)
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{
+		{
+			Index: 1, Type: "externalBook", Target: "Book1.xlsx", SheetNames: []string{"Sheet1", "My Sheet"},
+			DefinedNames: []ExternalLinkDefinedName{
+				{Name: "Total", RefersTo: "=Sheet1!$B$1"},
+				{Name: "Local", RefersTo: "='My Sheet'!$A$1", Scope: "My Sheet"},
+			},
+		},
+		{Index: 2, Type: "externalBook", Target: "Book2.xlsx", SheetNames: []string{"Data"}},
+	}, links)
+
+	values, err := f.GetExternalLinkCellValues(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLinkCellValue{
+		{Sheet: "Sheet1", Cell: "A1", Type: CellTypeNumber, Value: "5"},
+		{Sheet: "Sheet1", Cell: "B1", Type: CellTypeNumber, Value: "10"},
+		{Sheet: "Sheet1", Cell: "A2", Type: CellTypeInlineString, Value: `te"xt`},
+		{Sheet: "Sheet1", Cell: "A3", Type: CellTypeBool, Value: "1"},
+		{Sheet: "My Sheet", Cell: "A1", Type: CellTypeInlineString, Value: "x"},
+	}, values)
+	_, err = f.GetExternalLinkCellValues(3)
+	assert.EqualError(t, err, "external link 3 does not exist")
+
+	// Test get external links with DDE and OLE links
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><ddeLink ddeService="Excel" ddeTopic="Book1"/></externalLink>`))
+	f.Pkg.Store("xl/externalLinks/externalLink2.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><oleLink progId="Package"/></externalLink>`))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{{Index: 1, Type: "ddeLink"}, {Index: 2, Type: "oleLink"}}, links)
+	values, err = f.GetExternalLinkCellValues(1)
+	assert.NoError(t, err)
+	assert.Nil(t, values)
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLinkTarget(1, "Book1.xlsx"))
+
+	// Test get external links with unsupported charset external link part
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.BreakExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get external links with unsupported charset workbook and relationships
+	for _, part := range []string{defaultXMLPathWorkbook, defaultXMLPathWorkbookRels} {
+		f = prepareExternalLinks(t)
+		if part == defaultXMLPathWorkbook {
+			f.WorkBook = nil
+		} else {
+			f.Relationships.Delete(part)
+		}
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		_, err = f.GetExternalLinks()
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+
+	// Test get external link target with unsupported charset relationships
+	f = prepareExternalLinks(t)
+	f.Relationships.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetExternalLinkTarget(1, "Book3.xlsx"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetExternalLinkTarget(t *testing.T) {
+	f := prepareExternalLinks(t)
+	assert.NoError(t, f.SetExternalLinkTarget(2, "file:///C:\\Data\\Book3.xlsx"))
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, "file:///C:\\Data\\Book3.xlsx", links[1].Target)
+	assert.Equal(t, ErrParameterRequired, f.SetExternalLinkTarget(1, ""))
+	assert.EqualError(t, f.SetExternalLinkTarget(0, "Book3.xlsx"), "external link 0 does not exist")
+	// Test set external link target without relationships
+	f.Relationships.Store("xl/externalLinks/_rels/externalLink1.xml.rels", &xlsxRelationships{})
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLinkTarget(1, "Book3.xlsx"))
+	f.Relationships.Delete("xl/externalLinks/_rels/externalLink1.xml.rels")
+	assert.Equal(t, ErrParameterInvalid, f.SetExternalLinkTarget(1, "Book3.xlsx"))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Empty(t, links[0].Target)
+	assert.NoError(t, f.Close())
+}
+
+func TestBreakExternalLink(t *testing.T) {
+	f := prepareExternalLinks(t)
+	for cell, formula := range map[string]string{
+		"A1": "[1]Sheet1!A1*2",
+		"A2": `'[1]My Sheet'!A1&"x"`,
+		"A3": "[2]Data!A1",
+		"A4": `LEN("[1]Sheet1!A1")+SUM(Table1[1])`,
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].V = "10"
+	ws.SheetData.Row[1].C[0].T, ws.SheetData.Row[1].C[0].V = "str", "xx"
+	ws.SheetData.Row[2].C[0].V = "7"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "[1]Sheet1!A1+1", FormulaOpts{Ref: stringPtr("B1:B3"), Type: stringPtr(STCellFormulaTypeShared)}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "[2]Data!A1+1", FormulaOpts{Ref: stringPtr("C1:C3"), Type: stringPtr(STCellFormulaTypeShared)}))
+	for name, refersTo := range map[string]string{
+		"Ext": "[1]Sheet1!$A$1:$B$1", "ExtName": "[1]!Total", "ExtLocal": "'[1]My Sheet'!Local", "ExtCol": "[1]Sheet1!$A:$A",
+		"ExtText": "[1]Sheet1!$A$2", "ExtBool": "[1]Sheet1!$A$3", "ExtBlank": "[1]Sheet1!$C$9", "ExtSheet": "[1]Sheet2!$A$1",
+		"ExtNoName": "[1]!Unknown", "Other": "[2]Data!$A$1",
+	} {
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: name, RefersTo: refersTo}))
+	}
+	dv := NewDataValidation(true)
+	dv.Sqref = "D1"
+	dv.Type = dataValidationTypeMap[DataValidationTypeList]
+	dv.Formula1 = "[1]Sheet1!$A$1:$A$4"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "D2"
+	assert.NoError(t, dv.SetRange("[1]Sheet1!$A$1", "[2]Data!$A$1", DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURIDataValidations + `" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:dataValidations count="1" xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:dataValidation type="list" allowBlank="1"><x14:formula1><xm:f>&apos;[1]My Sheet&apos;!$A$1</xm:f></x14:formula1><xm:sqref>E1</xm:sqref></x14:dataValidation></x14:dataValidations></ext><ext uri="{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"><xm:f>[2]Data!A1</xm:f></ext>`}
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:ser><c:tx><c:strRef><c:f>[1]Sheet1!$A$2</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>text</c:v></c:pt></c:strCache></c:strRef></c:tx><c:cat><c:multiLvlStrRef><c:f>[1]Sheet1!$A$1:$B$2</c:f><c:multiLvlStrCache><c:ptCount val="2"/><c:lvl><c:pt idx="0"><c:v>a</c:v></c:pt></c:lvl><c:lvl><c:pt idx="0"><c:v>b</c:v></c:pt></c:lvl></c:multiLvlStrCache></c:multiLvlStrRef></c:cat><c:val><c:numRef><c:f>[1]Sheet1!$A$1:$B$1</c:f><c:numCache><c:ptCount val="2"/><c:pt idx="0"><c:v>5</c:v></c:pt></c:numCache></c:numRef></c:val></c:ser><c:ser><c:tx><c:strRef><c:f>[2]Data!$A$1</c:f></c:strRef></c:tx><c:tx><c:strRef><c:f>[1]Sheet1!$A$9</c:f></c:strRef></c:tx><c:cat><c:strRef><c:f>[1]Sheet1!$A$2</c:f></c:strRef></c:cat><c:val><c:numRef><c:f>[2]Data!$A$1</c:f></c:numRef></c:val></c:ser><c:f>[2]Data!$A$1</c:f></c:chartSpace>`))
+
+	assert.NoError(t, f.BreakExternalLink(1))
+	for cell, expected := range map[string]string{"A1": "", "A2": "", "A3": "[1]Data!A1", "A4": `LEN("[1]Sheet1!A1")+SUM(Table1[1])`, "B1": "", "B2": "", "C1": "[1]Data!A1+1", "C2": "[1]Data!A2+1"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	for cell, expected := range map[string]string{"A1": "10", "A2": "xx", "A3": "7"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	cellType, err := f.GetCellType("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	definedNames := map[string]string{}
+	for _, definedName := range f.GetDefinedName() {
+		definedNames[definedName.Name] = definedName.RefersTo
+	}
+	assert.Equal(t, map[string]string{
+		"Ext": "{5,10}", "ExtName": "10", "ExtLocal": `"x"`, "ExtCol": "#REF!", "ExtText": `"te""xt"`, "ExtBool": "TRUE",
+		"ExtBlank": "0", "ExtSheet": "#REF!", "ExtNoName": "#REF!", "Other": "[1]Data!$A$1",
+	}, definedNames)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, `"5,te""xt,TRUE,"`, ws.DataValidations.DataValidation[0].Formula1.Content)
+	assert.Equal(t, "5", dvs[1].Formula1)
+	assert.Equal(t, "[1]Data!$A$1", dvs[1].Formula2)
+	assert.Contains(t, ws.ExtLst.Ext, `<xm:f>"x"</xm:f>`)
+	assert.Contains(t, ws.ExtLst.Ext, `<xm:f>[1]Data!A1</xm:f>`)
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, `<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:ser><c:tx><c:v>text</c:v></c:tx><c:cat><c:strLit><c:ptCount val="2"/><c:pt idx="0"><c:v>a</c:v></c:pt></c:strLit></c:cat><c:val><c:numLit><c:ptCount val="2"/><c:pt idx="0"><c:v>5</c:v></c:pt></c:numLit></c:val></c:ser><c:ser><c:tx><c:strRef><c:f>[1]Data!$A$1</c:f></c:strRef></c:tx><c:tx><c:v></c:v></c:tx><c:cat><c:strLit><c:ptCount val="0"/></c:strLit></c:cat><c:val><c:numRef><c:f>[1]Data!$A$1</c:f></c:numRef></c:val></c:ser><c:f>[1]Data!$A$1</c:f></c:chartSpace>`, string(chart.([]byte)))
+
+	// Test save and reopen the workbook after break the external link
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{{Index: 1, Type: "externalBook", Target: "Book2.xlsx", SheetNames: []string{"Data"}}}, links)
+	_, ok = f.Pkg.Load("xl/externalLinks/externalLink1.xml")
+	assert.False(t, ok)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/xl/externalLinks/externalLink1.xml", override.PartName)
+	}
+	assert.NoError(t, f.BreakExternalLink(1))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Nil(t, links)
+	formula, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	assert.EqualError(t, f.BreakExternalLink(1), "external link 1 does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestBreakExternalLink.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test break external link in the workbook with chart sheet
+	f = prepareExternalLinks(t)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1"))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	assert.NoError(t, f.BreakExternalLink(1))
+	formula, err = f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	assert.NoError(t, f.Close())
+
+	// Test break external link with unsupported charset worksheet
+	f = prepareExternalLinks(t)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.BreakExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test break external link with unsupported charset calculation chain
+	f = prepareExternalLinks(t)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.BreakExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test break external link with unsupported charset shared strings table
+	f = prepareExternalLinks(t)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A2"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].T = "str"
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.BreakExternalLink(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test break external link with unsupported charset workbook relationships
+	f = prepareExternalLinks(t)
+	parts, err := f.getExternalLinkParts()
+	assert.NoError(t, err)
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.EqualError(t, f.removeExternalLink(wb, parts[0], 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestReplaceExternalRefs(t *testing.T) {
+	var refs []string
+	formula := `SUM([1]Sheet1!$A$1:$B$2,'[2]It''s'!A1,[3]!Name,[1]Sheet1!#REF!,'Sheet 1'!A1,[1]Sheet1!A1:[1]Sheet1!B1,"[1]Sheet1!A1",Table1[[#This Row],[1]],[1]Sheet1,'[x]Sheet1'!A1,[1]Sheet 1!A1,[1]Sheet1!,'[1]Sheet1`
+	assert.Equal(t, formula, replaceExternalRefs(formula, func(ref externalRef) string {
+		refs = append(refs, strings.Join([]string{strconv.Itoa(ref.book), ref.sheet, ref.ref}, "|"))
+		return ref.String()
+	}))
+	assert.Equal(t, []string{"1|Sheet1|$A$1:$B$2", "2|It's|A1", "3||Name", "1|Sheet1|#REF!", "1|Sheet1|A1", "1|Sheet1|B1"}, refs)
+	_, _, ok := splitExternalBook("[]Sheet1")
+	assert.False(t, ok)
+	_, _, ok = splitExternalBook("[-1]Sheet1")
+	assert.False(t, ok)
+}
@@ -18,9 +18,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // NewFile provides a function to create new file by default template.
@@ -51,9 +53,50 @@ func NewFile(opts ...Options) *File {
 	f.Sheet.Store("xl/worksheets/sheet1.xml", ws)
 	f.Theme, _ = f.themeReader()
 	f.options = f.getOptions(opts...)
+	f.storage = f.tempStorage()
 	return f
 }
 
+// NewFileFromTemplate provides a function to create a new workbook by given
+// path of the template (.xltx or .xltm) or add-in (.xlam) file. The content
+// type of the main part will be switched back to the workbook, and the VBA
+// project will be preserved for the macro-enabled template and add-in. The
+// created workbook has no path, so use the SaveAs function to save it. For
+// example, create a workbook from the template and save it as a macro-enabled
+// workbook:
+//
+//	f, err := excelize.NewFileFromTemplate("Book1.xltm")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsm"); err != nil {
+//	    fmt.Println(err)
+//	}
+func NewFileFromTemplate(filename string, opts ...Options) (*File, error) {
+	f, err := OpenFile(filename, opts...)
+	if err != nil {
+		return f, err
+	}
+	contentType, err := f.getWorkbookContentType()
+	if err != nil {
+		return f, err
+	}
+	switch contentType {
+	case ContentTypeTemplate:
+		contentType = ContentTypeSheetML
+	case ContentTypeTemplateMacro, ContentTypeAddinMacro:
+		contentType = ContentTypeMacro
+	default:
+		return f, ErrTemplateFileFormat
+	}
+	if contentType == ContentTypeSheetML && f.hasVBAProject() {
+		contentType = ContentTypeMacro
+	}
+	f.Path = ""
+	return f, f.setContentTypePartProjectExtensions(contentType)
+}
+
 // Save provides a function to override the spreadsheet with origin path.
 func (f *File) Save(opts ...Options) error {
 	if f.Path == "" {
@@ -66,14 +109,17 @@ func (f *File) Save(opts ...Options) error {
 }
 
 // SaveAs provides a function to create or update to a spreadsheet at the
-// provided path.
+// provided path. The spreadsheet will be saved as the OpenDocument
+// Spreadsheet (ODS) package if the path has the .ods extension.
 func (f *File) SaveAs(name string, opts ...Options) error {
 	if len(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
 	}
 	f.Path = name
-	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
-		return ErrWorkbookFileFormat
+	if !strings.EqualFold(filepath.Ext(f.Path), odsExtension) {
+		if _, err := f.getContentTypeByPath(f.Path); err != nil {
+			return err
+		}
 	}
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
 	if err != nil {
@@ -83,6 +129,22 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 	return f.Write(file, opts...)
 }
 
+// SaveAsTemplate provides a function to save the workbook as a template
+// (.xltx or .xltm) or add-in (.xlam) file by given path. The content type of
+// the main part will be switched to the template or add-in by the extension
+// of the path. The workbook with VBA project can only be saved as the
+// macro-enabled template or add-in. For example, save the macro-enabled
+// workbook as a template:
+//
+//	err := f.SaveAsTemplate("Book1.xltm")
+func (f *File) SaveAsTemplate(name string, opts ...Options) error {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xlam", ".xltm", ".xltx":
+		return f.SaveAs(name, opts...)
+	}
+	return ErrTemplateFileFormat
+}
+
 // Close closes and cleanup the open temporary file for the spreadsheet.
 func (f *File) Close() error {
 	var err error
@@ -92,14 +154,26 @@ func (f *File) Close() error {
 		}
 	}
 	f.tempFiles.Range(func(k, v interface{}) bool {
-		if err = os.Remove(v.(string)); err != nil {
+		if err = f.tempStorage().Remove(v.(string)); err != nil {
 			return false
 		}
 		return true
 	})
+	f.tempIndexes.Range(func(k, v interface{}) bool {
+		_ = v.(TempFile).Close()
+		if err == nil {
+			err = f.tempStorage().Remove(k.(string))
+		}
+		f.tempIndexes.Delete(k)
+		return true
+	})
 	for _, stream := range f.streams {
 		_ = stream.rawData.Close()
 	}
+	if f.pkgCloser != nil && err == nil {
+		err = f.pkgCloser.Close()
+		f.pkgCloser = nil
+	}
 	return err
 }
 
@@ -114,12 +188,15 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 	for i := range opts {
 		f.options = &opts[i]
 	}
+	if strings.EqualFold(filepath.Ext(f.Path), odsExtension) {
+		return 0, f.writeOpenDocument(w)
+	}
 	if len(f.Path) != 0 {
-		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
-		if !ok {
-			return 0, ErrWorkbookFileFormat
+		contentType, err := f.getContentTypeByPath(f.Path)
+		if err != nil {
+			return 0, err
 		}
-		if err := f.setContentTypePartProjectExtensions(contentType); err != nil {
+		if err = f.setContentTypePartProjectExtensions(contentType); err != nil {
 			return 0, err
 		}
 	}
@@ -136,13 +213,27 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 	return 0, nil
 }
 
+// getContentTypeByPath provides a function to get the content type of the
+// main document part by given path of the workbook, and check if the workbook
+// with VBA project is saved as the file format with macro support.
+func (f *File) getContentTypeByPath(name string) (string, error) {
+	contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return contentType, ErrWorkbookFileFormat
+	}
+	if (contentType == ContentTypeSheetML || contentType == ContentTypeTemplate) && f.hasVBAProject() {
+		return contentType, ErrMacroWorkbookFileFormat
+	}
+	return contentType, nil
+}
+
 // WriteToBuffer provides a function to get bytes.Buffer from the saved file,
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
 
-	if err := f.writeToZip(zw); err != nil {
+	if err := f.writeToZip(zw, nil); err != nil {
 		return buf, zw.Close()
 	}
 
@@ -164,15 +255,31 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
 	zw := zip.NewWriter(w)
-	if err := f.writeToZip(zw); err != nil {
+	if err := f.writeToZip(zw, nil); err != nil {
 		_ = zw.Close()
 		return err
 	}
 	return zw.Close()
 }
 
-// writeToZip provides a function to write to zip.Writer
-func (f *File) writeToZip(zw *zip.Writer) error {
+// writeToZip provides a function to write to zip.Writer, the parts in the
+// given written set which have been written to the zip.Writer will be skipped.
+func (f *File) writeToZip(zw *zip.Writer, written map[string]bool) error {
+	if err := f.serializeParts(); err != nil {
+		return err
+	}
+	return f.writeParts(zw, written)
+}
+
+// serializeParts provides a function to serialize the loaded parts of the
+// workbook to the package before writing them to the zip.Writer.
+func (f *File) serializeParts() error {
+	if err := f.setDocPropsModTime(); err != nil {
+		return err
+	}
+	if err := f.loadStrictWorksheets(); err != nil {
+		return err
+	}
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -186,8 +293,20 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
+	return nil
+}
 
+// writeParts provides a function to write the serialized parts to the
+// zip.Writer, the parts in the given written set which have been written to
+// the zip.Writer will be skipped.
+func (f *File) writeParts(zw *zip.Writer, written map[string]bool) error {
+	if f.deterministic() || f.strict() {
+		return f.writeSortedZip(zw, written)
+	}
 	for path, stream := range f.streams {
+		if written[path] {
+			continue
+		}
 		fi, err := zw.Create(path)
 		if err != nil {
 			return err
@@ -206,7 +325,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		files, tempFiles []string
 	)
 	f.Pkg.Range(func(path, content interface{}) bool {
-		if _, ok := f.streams[path.(string)]; ok {
+		if _, ok := f.streams[path.(string)]; ok || written[path.(string)] {
 			return true
 		}
 		files = append(files, path.(string))
@@ -214,6 +333,14 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(files)))
 	for _, path := range files {
+		if file, ok := f.unchangedPart(path); ok {
+			fh := file.FileHeader
+			fh.Name = path
+			if err = writeRawPart(zw, &fh, file); err != nil {
+				break
+			}
+			continue
+		}
 		var fi io.Writer
 		if fi, err = zw.Create(path); err != nil {
 			break
@@ -222,7 +349,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		_, err = fi.Write(content.([]byte))
 	}
 	f.tempFiles.Range(func(path, content interface{}) bool {
-		if _, ok := f.Pkg.Load(path); ok {
+		if _, ok := f.Pkg.Load(path); ok || written[path.(string)] {
 			return true
 		}
 		tempFiles = append(tempFiles, path.(string))
@@ -230,11 +357,240 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(tempFiles)))
 	for _, path := range tempFiles {
+		if file, ok := f.unchangedPart(path); ok {
+			fh := file.FileHeader
+			fh.Name = path
+			if err = writeRawPart(zw, &fh, file); err != nil {
+				break
+			}
+			continue
+		}
 		var fi io.Writer
 		if fi, err = zw.Create(path); err != nil {
 			break
 		}
 		_, err = fi.Write(f.readBytes(path))
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return f.writeLazyParts(zw, written)
+}
+
+// writeLazyParts provides a function to copy the compressed data of the parts
+// which haven't been loaded to the zip.Writer without decompressing them.
+func (f *File) writeLazyParts(zw *zip.Writer, written map[string]bool) error {
+	var files []string
+	f.lazyParts.Range(func(path, file interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok || written[path.(string)] {
+			return true
+		}
+		if _, ok := f.streams[path.(string)]; ok {
+			return true
+		}
+		if _, ok := f.tempFiles.Load(path); ok {
+			return true
+		}
+		files = append(files, path.(string))
+		return true
+	})
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	for _, path := range files {
+		v, ok := f.lazyParts.Load(path)
+		if !ok {
+			continue
+		}
+		file := v.(*zip.File)
+		fh := file.FileHeader
+		fh.Name = path
+		if err := writeRawPart(zw, &fh, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deterministic returns if the spreadsheet should be saved as a reproducible
+// package.
+func (f *File) deterministic() bool {
+	return f.options != nil && f.options.Deterministic
+}
+
+// strict returns if the spreadsheet should be saved in the ISO/IEC 29500
+// Strict conformance class.
+func (f *File) strict() bool {
+	return f.options != nil && f.options.Strict
+}
+
+// loadStrictWorksheets provides a function to load all worksheets which
+// haven't been loaded and not written by the stream writer before save the
+// spreadsheet in the Strict conformance class, so that the date cells and
+// legacy drawings in them could be converted on save.
+func (f *File) loadStrictWorksheets() error {
+	if !f.strict() {
+		return nil
+	}
+	for _, name := range f.GetSheetList() {
+		sheetXMLPath, ok := f.getSheetXMLPath(name)
+		if !ok {
+			continue
+		}
+		if _, ok = f.streams[sheetXMLPath]; ok {
+			continue
+		}
+		if _, err := f.workSheetReader(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSortedZip provides a function to write all parts to the zip.Writer in
+// the sorted order, the parts in the given written set which have been
+// written will be skipped. The fixed modification time will be used with the
+// Deterministic option, and the parts will be converted to the Strict
+// conformance class with the Strict option.
+func (f *File) writeSortedZip(zw *zip.Writer, written map[string]bool) error {
+	var modTime time.Time
+	if f.deterministic() {
+		modTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if !f.options.ModTime.IsZero() {
+			modTime = f.options.ModTime.UTC()
+		}
+	}
+	strict := f.strict()
+	var (
+		files []string
+		parts = map[string]func() (io.Reader, error){}
+		raws  = map[string]*zip.File{}
+	)
+	f.lazyParts.Range(func(path, file interface{}) bool {
+		raws[path.(string)] = file.(*zip.File)
+		return true
+	})
+	f.tempFiles.Range(func(path, content interface{}) bool {
+		name := path.(string)
+		parts[name] = func() (io.Reader, error) { return bytes.NewReader(f.readBytes(name)), nil }
+		return true
+	})
+	f.Pkg.Range(func(path, content interface{}) bool {
+		parts[path.(string)] = func() (io.Reader, error) { return bytes.NewReader(content.([]byte)), nil }
+		return true
+	})
+	for path, stream := range f.streams {
+		parts[path] = stream.rawData.Reader
+	}
+	for path := range parts {
+		if _, ok := f.streams[path]; ok {
+			continue
+		}
+		if file, ok := f.unchangedPart(path); ok {
+			raws[path] = file
+			delete(parts, path)
+		}
+	}
+	if strict {
+		for path, file := range raws {
+			if _, ok := parts[path]; !ok {
+				parts[path] = readZipFile(file)
+			}
+			delete(raws, path)
+		}
+	}
+	for path := range raws {
+		if _, ok := parts[path]; !ok && !written[path] {
+			files = append(files, path)
+		}
+	}
+	for path := range parts {
+		if !written[path] {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	for _, path := range files {
+		if read, ok := parts[path]; ok {
+			r, err := read()
+			if err != nil {
+				return err
+			}
+			if strict {
+				if r, ok, err = f.strictPart(path, r); err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+			}
+			fi, err := zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: modTime})
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(fi, r); err != nil {
+				return err
+			}
+			continue
+		}
+		fh := raws[path].FileHeader
+		fh.Name, fh.Comment, fh.Modified = path, "", modTime
+		fh.ModifiedDate, fh.ModifiedTime = msDosTime(modTime)
+		fh.Extra = extendedTimestamp(modTime)
+		if err := writeRawPart(zw, &fh, raws[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	regexpStrictConformance     = regexp.MustCompile(` conformance="[^"]*"`)
+	regexpStrictLegacyDrawing   = regexp.MustCompile(`<legacyDrawing(?:HF)?\s[^>]*?(?:/>|>\s*</legacyDrawing(?:HF)?>)`)
+	regexpStrictVMLContentTypes = regexp.MustCompile(`<(?:Default Extension="vml"|Override PartName="[^"]*\.vml")[^>]*?(?:/>|>\s*</(?:Default|Override)>)`)
+	regexpStrictVMLRels         = regexp.MustCompile(`<Relationship\s[^>]*Type="[^"]*/vmlDrawing"[^>]*?(?:/>|>\s*</Relationship>)`)
+	regexpStrictWorkbook        = regexp.MustCompile(`<workbook\b`)
+)
+
+// readZipFile returns a function to read the decompressed content of the given
+// file in the zip archive.
+func readZipFile(file *zip.File) func() (io.Reader, error) {
+	return func() (io.Reader, error) {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(rc)
+		return bytes.NewReader(content), err
+	}
+}
+
+// strictPart provides a function to convert the part in the given path to the
+// Strict conformance class, returns false if the part should be dropped from
+// the Strict package, such as the VML drawing parts.
+func (f *File) strictPart(path string, r io.Reader) (io.Reader, bool, error) {
+	if strings.HasSuffix(path, ".vml") {
+		return nil, false, nil
+	}
+	if !strings.HasSuffix(path, ".xml") && !strings.HasSuffix(path, ".rels") {
+		return r, true, nil
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, err
+	}
+	content = namespaceTransitionalToStrict(content)
+	switch {
+	case strings.HasSuffix(path, ".rels"):
+		content = regexpStrictVMLRels.ReplaceAll(content, nil)
+	case path == defaultXMLPathContentTypes:
+		content = regexpStrictVMLContentTypes.ReplaceAll(content, nil)
+	case path == f.getWorkbookPath():
+		content = regexpStrictConformance.ReplaceAll(content, nil)
+		if loc := regexpStrictWorkbook.FindIndex(content); loc != nil {
+			content = append(content[:loc[1]:loc[1]], append([]byte(` conformance="strict"`), content[loc[1]:]...)...)
+		}
+	case strings.HasPrefix(path, "xl/worksheets/") || strings.HasPrefix(path, "xl/chartsheets/"):
+		content = regexpStrictLegacyDrawing.ReplaceAll(content, nil)
+	}
+	return bytes.NewReader(content), true, nil
 }
@@ -1,13 +1,17 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,3 +99,337 @@ func TestClose(t *testing.T) {
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestWriteDeterministic(t *testing.T) {
+	build := func() *File {
+		f := NewFile()
+		for _, sheet := range []string{"Sheet2", "Sheet3"} {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.AddPicture("Sheet2", "A1", filepath.Join("test", "images", "excel.png"), nil))
+		assert.NoError(t, f.AddComment("Sheet3", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+		sw, err := f.NewStreamWriter("Sheet3")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{1, 2, 3}))
+		assert.NoError(t, sw.Flush())
+		return f
+	}
+	opts := Options{Deterministic: true}
+	f1, f2 := build(), build()
+	buf1, buf2 := new(bytes.Buffer), new(bytes.Buffer)
+	assert.NoError(t, f1.Write(buf1, opts))
+	assert.NoError(t, f2.Write(buf2, opts))
+	assert.Equal(t, buf1.Bytes(), buf2.Bytes())
+	// Test parts are written in the sorted order with the fixed modification time
+	zr, err := zip.NewReader(bytes.NewReader(buf1.Bytes()), int64(buf1.Len()))
+	assert.NoError(t, err)
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+		assert.Equal(t, time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC), file.Modified.UTC())
+	}
+	assert.True(t, sort.StringsAreSorted(names))
+	assert.Equal(t, defaultXMLPathContentTypes, names[0])
+	assert.NoError(t, f1.Close())
+	assert.NoError(t, f2.Close())
+
+	// Test save with the modification time and the parts which haven't been loaded
+	modTime := time.Date(2024, time.March, 1, 8, 30, 0, 0, time.UTC)
+	opts = Options{Deterministic: true, ModTime: modTime}
+	var outputs [][]byte
+	for i := 0; i < 2; i++ {
+		f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LoadSheets: []string{"Sheet1"}})
+		assert.NoError(t, err)
+		buf := new(bytes.Buffer)
+		assert.NoError(t, f.Write(buf, opts))
+		outputs = append(outputs, buf.Bytes())
+		assert.NoError(t, f.Close())
+	}
+	assert.Equal(t, outputs[0], outputs[1])
+	f, err := OpenReader(bytes.NewReader(outputs[0]))
+	assert.NoError(t, err)
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-01T08:30:00Z", props.Modified)
+	assert.NotEqual(t, "2024-03-01T08:30:00Z", props.Created)
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rows)
+	assert.NoError(t, f.Close())
+	zr, err = zip.NewReader(bytes.NewReader(outputs[0]), int64(len(outputs[0])))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		assert.Equal(t, modTime, file.Modified.UTC(), file.Name)
+	}
+
+	// Test save the new workbook with the modification time
+	f = NewFile()
+	buf := new(bytes.Buffer)
+	assert.NoError(t, f.Write(buf, opts))
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	props, err = f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-01T08:30:00Z", props.Created)
+	assert.Equal(t, "2024-03-01T08:30:00Z", props.Modified)
+	assert.NoError(t, f.Close())
+	// Test save the new workbook with the created time has been set
+	f = NewFile()
+	assert.NoError(t, f.SetDocProps(&DocProperties{Created: "2020-01-01T00:00:00Z"}))
+	buf.Reset()
+	assert.NoError(t, f.Write(buf, opts))
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	props, err = f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-01-01T00:00:00Z", props.Created)
+	assert.NoError(t, f.Close())
+
+	// Test save with unsupported charset core properties
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCore, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Write(io.Discard, opts), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSortRelationships(t *testing.T) {
+	rels := sortRelationships(&xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId10"}, {ID: "rId2"}, {ID: "image"}, {ID: "rId1"},
+	}})
+	var IDs []string
+	for _, rel := range rels.Relationships {
+		IDs = append(IDs, rel.ID)
+	}
+	assert.Equal(t, []string{"rId1", "rId2", "rId10", "image"}, IDs)
+}
+
+func TestWritePreserveUnchangedParts(t *testing.T) {
+	rawParts := func(b []byte) map[string][]byte {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		parts := map[string][]byte{}
+		for _, file := range zr.File {
+			rc, err := file.OpenRaw()
+			assert.NoError(t, err)
+			parts[file.Name], err = io.ReadAll(rc)
+			assert.NoError(t, err)
+		}
+		return parts
+	}
+	source, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	origin := rawParts(source)
+	for _, opts := range []Options{
+		{PreserveUnchangedParts: true},
+		{PreserveUnchangedParts: true, LoadSheets: []string{"Sheet1"}},
+		{PreserveUnchangedParts: true, Deterministic: true},
+		{PreserveUnchangedParts: true, UnzipXMLSizeLimit: 128},
+	} {
+		f, err := OpenReader(bytes.NewReader(source), opts)
+		assert.NoError(t, err)
+		// Test read cells, rows and columns without changing the worksheet
+		val, err := f.GetCellValue("Sheet1", "A19")
+		assert.NoError(t, err)
+		assert.Equal(t, "Total:", val)
+		_, err = f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		_, err = f.GetCols("Sheet1")
+		assert.NoError(t, err)
+		_, err = f.SearchSheet("Sheet1", "Total:")
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Changed"))
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		saved := rawParts(buf.Bytes())
+		for _, name := range []string{
+			"xl/worksheets/sheet1.xml", "xl/styles.xml", "xl/theme/theme1.xml",
+			"xl/workbook.xml", "xl/drawings/drawing1.xml", "[Content_Types].xml",
+		} {
+			assert.Equal(t, origin[name], saved[name], name)
+		}
+		assert.NotEqual(t, origin["xl/worksheets/sheet2.xml"], saved["xl/worksheets/sheet2.xml"])
+		f2, err := OpenReader(buf)
+		assert.NoError(t, err)
+		val, err = f2.GetCellValue("Sheet2", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Changed", val)
+		assert.NoError(t, f2.Close())
+		assert.NoError(t, f.Close())
+	}
+	// Test save without preserving the unchanged parts
+	f, err := OpenReader(bytes.NewReader(source))
+	assert.NoError(t, err)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NotEqual(t, origin["xl/styles.xml"], rawParts(buf.Bytes())["xl/styles.xml"])
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteStrict(t *testing.T) {
+	parts := func(b []byte) map[string]string {
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		parts := map[string]string{}
+		for _, file := range zr.File {
+			rc, err := file.Open()
+			assert.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			assert.NoError(t, err)
+			assert.NoError(t, rc.Close())
+			parts[file.Name] = string(content)
+		}
+		return parts
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", time.Date(2024, time.March, 1, 8, 30, 0, 0, time.UTC)))
+	style, err := f.NewStyle(&Style{NumFmt: 20})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFloat("Sheet1", "A3", 0.5, -1, 64))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", 42))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "C1", filepath.Join("test", "images", "excel.png"), nil))
+	expected := map[string]string{}
+	for _, cell := range []string{"A1", "A2", "A3", "A4"} {
+		expected[cell], err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+	}
+	buf := new(bytes.Buffer)
+	assert.NoError(t, f.Write(buf, Options{Strict: true}))
+	saved := parts(buf.Bytes())
+	for name := range saved {
+		assert.False(t, strings.HasSuffix(name, ".vml"), name)
+	}
+	assert.Contains(t, saved["xl/workbook.xml"], `conformance="strict"`)
+	assert.Contains(t, saved["xl/workbook.xml"], StrictNameSpaceSpreadSheet)
+	assert.NotContains(t, saved["xl/workbook.xml"], NameSpaceSpreadSheet.Value)
+	assert.Contains(t, saved["_rels/.rels"], "http://purl.oclc.org/ooxml/officeDocument/relationships/officeDocument")
+	assert.NotContains(t, saved[defaultXMLPathContentTypes], "vml")
+	assert.NotContains(t, saved["xl/worksheets/_rels/sheet1.xml.rels"], "vmlDrawing")
+	assert.NotContains(t, saved["xl/worksheets/sheet1.xml"], "legacyDrawing")
+	assert.Contains(t, saved["xl/worksheets/sheet1.xml"], `t="d"><v>2024-03-01T08:30:00</v>`)
+	assert.Contains(t, saved["xl/worksheets/sheet1.xml"], `t="d"><v>12:00:00</v>`)
+	assert.Contains(t, saved["xl/worksheets/sheet1.xml"], `<c r="A4"><v>42</v>`)
+	assert.Contains(t, saved["xl/drawings/drawing1.xml"], StrictNameSpaceDrawingMLSpreadSheet)
+	// Test the date cells are restored in the workbook after save
+	cellType, err := f.GetCellType("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+
+	// Test open the Strict spreadsheet and read the ISO 8601 date cells
+	f2, err := OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	for cell, val := range expected {
+		result, err := f2.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, val, result, cell)
+	}
+	cellType, err = f2.GetCellType("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeDate, cellType)
+	rows, err := f2.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected["A2"], rows[1][0])
+	pics, err := f2.GetPictures("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	// Test save the Strict spreadsheet in the Transitional conformance class
+	buf.Reset()
+	assert.NoError(t, f2.Write(buf))
+	saved = parts(buf.Bytes())
+	assert.NotContains(t, saved["xl/workbook.xml"], "conformance")
+	assert.Contains(t, saved["xl/workbook.xml"], NameSpaceSpreadSheet.Value)
+	assert.Contains(t, saved["xl/worksheets/sheet1.xml"], `t="d"><v>2024-03-01T08:30:00</v>`)
+	assert.NoError(t, f2.Close())
+	assert.NoError(t, f.Close())
+
+	// Test save the Strict spreadsheet with the worksheets haven't been loaded
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LoadSheets: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.Write(buf, Options{Strict: true}))
+	saved = parts(buf.Bytes())
+	for name, content := range saved {
+		if strings.HasSuffix(name, ".xml") && !strings.HasPrefix(name, "docProps/") && name != defaultXMLPathContentTypes {
+			assert.NotContains(t, content, "http://schemas.openxmlformats.org/spreadsheetml/2006/main", name)
+		}
+	}
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+}
+
+func TestTemplate(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Template"))
+	assert.Equal(t, ErrTemplateFileFormat, f.SaveAsTemplate(filepath.Join("test", "TestTemplate.xlsx")))
+	assert.NoError(t, f.SaveAsTemplate(filepath.Join("test", "TestTemplate.xltx")))
+	contentType, err := f.getWorkbookContentType()
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeTemplate, contentType)
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(file))
+	// Test save the workbook with VBA project without macro support
+	for _, ext := range []string{".xlsx", ".xltx"} {
+		assert.Equal(t, ErrMacroWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestTemplateMacro"+ext)))
+		_, err = os.Stat(filepath.Join("test", "TestTemplateMacro"+ext))
+		assert.True(t, os.IsNotExist(err))
+	}
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	for ext, expected := range map[string]string{".xltm": ContentTypeTemplateMacro, ".xlam": ContentTypeAddinMacro} {
+		assert.NoError(t, f.SaveAsTemplate(filepath.Join("test", "TestTemplate"+ext)))
+		contentType, err = f.getWorkbookContentType()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, contentType)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test create workbook from the template and add-in
+	for ext, expected := range map[string]string{".xltx": ContentTypeSheetML, ".xltm": ContentTypeMacro, ".xlam": ContentTypeMacro} {
+		f, err := NewFileFromTemplate(filepath.Join("test", "TestTemplate"+ext))
+		assert.NoError(t, err)
+		assert.Empty(t, f.Path)
+		assert.Equal(t, ErrSave, f.Save())
+		contentType, err := f.getWorkbookContentType()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, contentType)
+		assert.Equal(t, ext != ".xltx", f.hasVBAProject())
+		value, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Template", value)
+		if ext != ".xltx" {
+			assert.Equal(t, ErrMacroWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestTemplateMacro.xlsx")))
+		}
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTemplate"+ext+".xlsm")))
+		assert.NoError(t, f.Close())
+	}
+
+	// Test create workbook from the workbook which is not template
+	_, err = NewFileFromTemplate(filepath.Join("test", "Book1.xlsx"))
+	assert.Equal(t, ErrTemplateFileFormat, err)
+	// Test create workbook from not exists file
+	_, err = NewFileFromTemplate(filepath.Join("test", "NotExist.xltx"))
+	assert.True(t, os.IsNotExist(err))
+	// Test create workbook from the template with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.getWorkbookContentType()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.False(t, f.hasVBAProject())
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.False(t, f.hasVBAProject())
+	assert.NoError(t, f.Close())
+}
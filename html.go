@@ -0,0 +1,918 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions defined the options for render the worksheet to the HTML table.
+//
+// RangeRef specifies the range of the cells to be rendered, such as "A1:D10",
+// the used range of the worksheet will be rendered by default.
+//
+// RawCellValue specifies if render the raw cell values in the HTML table
+// instead of the formatted values, the RawCellValue option of the workbook
+// will be used when this option is false.
+//
+// ShowHidden specifies if render the hidden rows and columns, by default, the
+// hidden rows and columns will be omitted.
+//
+// ShowGridLines specifies if render the light gray gridlines around the cells
+// without borders.
+//
+// DisableConditionalFormat specifies if ignore the conditional formats of the
+// worksheet. By default, the font and fill of the cell value, top and bottom,
+// above and below average, duplicate and unique values rules, the color
+// scales and the data bars will be rendered.
+type HTMLOptions struct {
+	RangeRef                 string
+	RawCellValue             bool
+	ShowHidden               bool
+	ShowGridLines            bool
+	DisableConditionalFormat bool
+}
+
+// htmlBorderStyles defined the CSS border width and line style by the index
+// of the cell border style.
+var htmlBorderStyles = []string{
+	"none", "1px solid", "2px solid", "1px dashed", "1px dotted", "3px solid",
+	"3px double", "1px dotted", "2px dashed", "1px dashed", "2px dashed",
+	"1px dotted", "2px dotted", "2px dashed",
+}
+
+// htmlHorizontalAlignments defined the CSS text alignment by the horizontal
+// alignment of the cell.
+var htmlHorizontalAlignments = map[string]string{
+	"center": "center", "centerContinuous": "center", "distributed": "justify",
+	"fill": "left", "general": "", "justify": "justify", "left": "left",
+	"right": "right",
+}
+
+// htmlVerticalAlignments defined the CSS vertical alignment by the vertical
+// alignment of the cell.
+var htmlVerticalAlignments = map[string]string{
+	"bottom": "bottom", "center": "middle", "distributed": "middle",
+	"justify": "middle", "top": "top",
+}
+
+// htmlCell directly maps the value, type and style of the cell to be
+// rendered.
+type htmlCell struct {
+	value   string
+	raw     string
+	number  float64
+	numeric bool
+	cellT   string
+	styleID int
+}
+
+// htmlCondFmt directly maps the conditional format rule and the statistics
+// of the numeric values in the range of the rule.
+type htmlCondFmt struct {
+	rects    [][]int
+	options  ConditionalFormatOptions
+	priority int
+	css      string
+	values   []float64
+	counts   map[string]int
+}
+
+// htmlRenderer directly maps the worksheet, the cached cell styles and
+// conditional formats for render the worksheet to the HTML table.
+type htmlRenderer struct {
+	f         *File
+	ws        *xlsxWorksheet
+	sheet     string
+	options   HTMLOptions
+	cells     map[[2]int]*htmlCell
+	links     map[[2]int]htmlLink
+	rngLinks  []htmlLink
+	styles    map[int]string
+	inherited map[string]bool
+	condFmts  []*htmlCondFmt
+}
+
+// htmlLink directly maps the hyperlink of the cell or the range, the index
+// specifies the order of the hyperlinks in the worksheet, and the latter one
+// will be used if the hyperlinks overlap.
+type htmlLink struct {
+	rect   []int
+	target string
+	index  int
+}
+
+// ExportHTML provides a function to render the worksheet or the range of the
+// worksheet to the io.Writer as the HTML table by given worksheet name and
+// the optional settings. The formatted cell values, merged cells, column
+// widths, row heights, hyperlinks, and the font, fill, border and alignment
+// of the cell styles will be rendered with inline CSS, so the table can be
+// embedded into the web pages and emails directly. For example, render the
+// range A1:F20 of the worksheet named 'Sheet1' with gridlines:
+//
+//	var buf bytes.Buffer
+//	if err := f.ExportHTML("Sheet1", &buf, excelize.HTMLOptions{
+//	    RangeRef:      "A1:F20",
+//	    ShowGridLines: true,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExportHTML(sheet string, w io.Writer, opts ...HTMLOptions) error {
+	r := htmlRenderer{
+		f: f, sheet: sheet, cells: map[[2]int]*htmlCell{}, links: map[[2]int]htmlLink{},
+		styles: map[int]string{}, inherited: map[string]bool{},
+	}
+	for _, opt := range opts {
+		r.options = opt
+	}
+	rect, err := r.loadCells()
+	if err != nil {
+		return err
+	}
+	if !r.options.DisableConditionalFormat {
+		if err = r.loadCondFmts(); err != nil {
+			return err
+		}
+	}
+	mergeCells, err := f.GetMergeCells(sheet)
+	if err != nil {
+		return err
+	}
+	var cols, rows []int
+	for col := rect[0]; col <= rect[2] && rect[0] > 0; col++ {
+		visible, err := r.visible(col, 0)
+		if err != nil {
+			return err
+		}
+		if visible {
+			cols = append(cols, col)
+		}
+	}
+	for row := rect[1]; row <= rect[3] && rect[1] > 0; row++ {
+		visible, err := r.visible(0, row)
+		if err != nil {
+			return err
+		}
+		if visible {
+			rows = append(rows, row)
+		}
+	}
+	spans, covered, err := htmlMergeSpans(mergeCells, rect, cols, rows)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if err = r.writeTable(bw, cols, rows, spans, covered); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// visible returns if the column or row is visible, the hidden columns and
+// rows will be treated as visible if the ShowHidden option is set.
+func (r *htmlRenderer) visible(col, row int) (bool, error) {
+	if r.options.ShowHidden {
+		return true, nil
+	}
+	if row == 0 {
+		name, err := ColumnNumberToName(col)
+		if err != nil {
+			return false, err
+		}
+		return r.f.GetColVisible(r.sheet, name)
+	}
+	return r.f.GetRowVisible(r.sheet, row)
+}
+
+// loadCells provides a function to load the values, types and styles of the
+// cells and hyperlinks of the worksheet, and returns the range to be
+// rendered.
+func (r *htmlRenderer) loadCells() ([]int, error) {
+	rect := []int{0, 0, 0, 0}
+	var wholeCols, wholeRows bool
+	if r.options.RangeRef != "" {
+		var err error
+		if rect, wholeCols, wholeRows, err = wholeRangeRefToCoordinates(r.options.RangeRef); err != nil {
+			return rect, err
+		}
+	}
+	r.f.mu.Lock()
+	ws, err := r.f.workSheetReader(r.sheet)
+	if err == nil {
+		_, err = r.f.stylesReader()
+	}
+	r.f.mu.Unlock()
+	if err != nil {
+		return rect, err
+	}
+	r.ws = ws
+	sst, err := r.f.sharedStringsReader()
+	if err != nil {
+		return rect, err
+	}
+	rawCellValue := r.options.RawCellValue || (r.f.options != nil && r.f.options.RawCellValue)
+	used := []int{0, 0, 0, 0}
+	for i := range ws.SheetData.Row {
+		for j := range ws.SheetData.Row[i].C {
+			c := ws.SheetData.Row[i].C[j]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return rect, err
+			}
+			raw, err := c.getValueFrom(r.f, sst, true)
+			if err != nil {
+				return rect, err
+			}
+			cell := &htmlCell{raw: raw, cellT: c.T, styleID: c.S, value: raw}
+			if c.T == "" || c.T == "n" {
+				if cell.number, err = strconv.ParseFloat(raw, 64); err == nil {
+					cell.numeric = true
+				}
+			}
+			if !rawCellValue {
+				if cell.value, err = c.getValueFrom(r.f, sst, false); err != nil {
+					return rect, err
+				}
+			}
+			r.cells[[2]int{col, row}] = cell
+			if raw != "" || c.S != 0 {
+				used = htmlExtendRect(used, col, row)
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for i, link := range ws.Hyperlinks.Hyperlink {
+			ref := link.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, _, _, err := wholeRangeRefToCoordinates(ref)
+			if err != nil {
+				return rect, err
+			}
+			target := "#" + link.Location
+			if link.RID != "" {
+				if target = r.f.getSheetRelationshipsTargetByID(r.sheet, link.RID); link.Location != "" {
+					target += "#" + link.Location
+				}
+			}
+			if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+				r.links[[2]int{coordinates[0], coordinates[1]}] = htmlLink{rect: coordinates, target: target, index: i}
+				continue
+			}
+			r.rngLinks = append(r.rngLinks, htmlLink{rect: coordinates, target: target, index: i})
+		}
+	}
+	if r.options.RangeRef != "" && !wholeCols && !wholeRows {
+		return rect, err
+	}
+	mergeCells, err := r.f.GetMergeCells(r.sheet)
+	for _, mc := range mergeCells {
+		coordinates, err := rangeRefToCoordinates(mc[0])
+		if err != nil {
+			return used, err
+		}
+		used = htmlExtendRect(htmlExtendRect(used, coordinates[0], coordinates[1]), coordinates[2], coordinates[3])
+	}
+	if used[0] == 0 {
+		used = []int{1, 1, 1, 1}
+	}
+	if wholeCols {
+		return []int{rect[0], used[1], rect[2], used[3]}, err
+	}
+	if wholeRows {
+		return []int{used[0], rect[1], used[2], rect[3]}, err
+	}
+	return used, err
+}
+
+// htmlExtendRect returns the range extended to contain the given cell
+// coordinates.
+func htmlExtendRect(rect []int, col, row int) []int {
+	if rect[0] == 0 {
+		return []int{col, row, col, row}
+	}
+	return []int{
+		minInt(rect[0], col), minInt(rect[1], row), maxInt(rect[2], col), maxInt(rect[3], row),
+	}
+}
+
+// htmlMergeSpans provides a function to calculate the column and row spans
+// of the merged cells in the range, the spans only count the rendered
+// columns and rows. The top-left rendered cell of each merged cell will be
+// used to hold the spans, and the other cells of the merged cell will be
+// covered.
+func htmlMergeSpans(mergeCells []MergeCell, rect, cols, rows []int) (map[[2]int][2]int, map[[2]int]bool, error) {
+	spans, covered := map[[2]int][2]int{}, map[[2]int]bool{}
+	count := func(items []int, from, to int) (first, n int) {
+		for _, item := range items {
+			if item >= from && item <= to {
+				if n == 0 {
+					first = item
+				}
+				n++
+			}
+		}
+		return
+	}
+	for _, mc := range mergeCells {
+		coordinates, err := rangeRefToCoordinates(mc[0])
+		if err != nil {
+			return spans, covered, err
+		}
+		_ = sortCoordinates(coordinates)
+		fromCol, fromRow := maxInt(coordinates[0], rect[0]), maxInt(coordinates[1], rect[1])
+		toCol, toRow := minInt(coordinates[2], rect[2]), minInt(coordinates[3], rect[3])
+		firstCol, colSpan := count(cols, fromCol, toCol)
+		firstRow, rowSpan := count(rows, fromRow, toRow)
+		if colSpan == 0 || rowSpan == 0 {
+			continue
+		}
+		for row := fromRow; row <= toRow; row++ {
+			for col := fromCol; col <= toCol; col++ {
+				covered[[2]int{col, row}] = true
+			}
+		}
+		delete(covered, [2]int{firstCol, firstRow})
+		spans[[2]int{firstCol, firstRow}] = [2]int{colSpan, rowSpan}
+	}
+	return spans, covered, nil
+}
+
+// writeTable provides a function to write the HTML table by given rendered
+// columns, rows and the merged cells.
+func (r *htmlRenderer) writeTable(w *bufio.Writer, cols, rows []int, spans map[[2]int][2]int, covered map[[2]int]bool) error {
+	var (
+		tableWidth float64
+		colGroup   strings.Builder
+	)
+	for _, col := range cols {
+		name, _ := ColumnNumberToName(col)
+		width, err := r.f.GetColWidth(r.sheet, name)
+		if err != nil {
+			return err
+		}
+		pixels := convertColWidthToPixels(width)
+		tableWidth += pixels
+		colGroup.WriteString(`<col style="width:` + strconv.FormatFloat(pixels, 'f', -1, 64) + `px">`)
+	}
+	tableStyle := []string{"border-collapse:collapse", "table-layout:fixed", "width:" + strconv.FormatFloat(tableWidth, 'f', -1, 64) + "px"}
+	if style, err := r.f.GetStyle(0); err == nil && style.Font != nil {
+		for _, item := range append(htmlFontCSS(style.Font), htmlTextCSS(r.f, style.Font)...) {
+			tableStyle = append(tableStyle, item)
+			r.inherited[item] = true
+		}
+	}
+	_, _ = w.WriteString(`<table data-sheet="` + html.EscapeString(r.sheet) + `" style="` + strings.Join(tableStyle, ";") + `"><colgroup>`)
+	_, _ = w.WriteString(colGroup.String() + "</colgroup><tbody>")
+	for _, row := range rows {
+		height, err := r.f.GetRowHeight(r.sheet, row)
+		if err != nil {
+			return err
+		}
+		_, _ = w.WriteString(`<tr style="height:` + strconv.FormatFloat(height, 'f', -1, 64) + `pt">`)
+		for _, col := range cols {
+			if covered[[2]int{col, row}] {
+				continue
+			}
+			if err = r.writeCell(w, col, row, spans[[2]int{col, row}]); err != nil {
+				return err
+			}
+		}
+		_, _ = w.WriteString("</tr>")
+	}
+	_, err := w.WriteString("</tbody></table>")
+	return err
+}
+
+// writeCell provides a function to write the table cell by given cell
+// coordinates and the column and row spans of the merged cell.
+func (r *htmlRenderer) writeCell(w *bufio.Writer, col, row int, span [2]int) error {
+	cell, ok := r.cells[[2]int{col, row}]
+	if !ok {
+		cell = &htmlCell{}
+	}
+	css, horizontal, err := r.cellCSS(cell)
+	if err != nil {
+		return err
+	}
+	if !horizontal {
+		if cell.numeric || cell.cellT == "d" {
+			css += ";text-align:right"
+		} else if cell.cellT == "b" || cell.cellT == "e" {
+			css += ";text-align:center"
+		}
+	}
+	condCSS, barOnly := r.condFmtCSS(col, row, cell)
+	_, _ = w.WriteString(`<td style="` + css + condCSS + `"`)
+	if span[0] > 1 {
+		_, _ = w.WriteString(` colspan="` + strconv.Itoa(span[0]) + `"`)
+	}
+	if span[1] > 1 {
+		_, _ = w.WriteString(` rowspan="` + strconv.Itoa(span[1]) + `"`)
+	}
+	_, _ = w.WriteString(">")
+	value := html.EscapeString(cell.value)
+	if barOnly {
+		value = ""
+	}
+	if target, ok := r.link(col, row); ok && htmlSafeLink(target) {
+		value = `<a href="` + html.EscapeString(target) + `" style="color:inherit;text-decoration:inherit">` + value + "</a>"
+	}
+	_, err = w.WriteString(value + "</td>")
+	return err
+}
+
+// link returns the target of the hyperlink by given column and row number,
+// the hyperlink of the single cell is indexed by the cell coordinates, and
+// the hyperlinks of the ranges will be checked if contain the cell.
+func (r *htmlRenderer) link(col, row int) (string, bool) {
+	link, ok := r.links[[2]int{col, row}]
+	for i := len(r.rngLinks) - 1; i >= 0; i-- {
+		rng := r.rngLinks[i]
+		if ok && rng.index < link.index {
+			break
+		}
+		if col >= rng.rect[0] && col <= rng.rect[2] && row >= rng.rect[1] && row <= rng.rect[3] {
+			return rng.target, true
+		}
+	}
+	return link.target, ok
+}
+
+// cellCSS returns the inline CSS of the cell style, and if the horizontal
+// alignment has been specified by the style.
+func (r *htmlRenderer) cellCSS(cell *htmlCell) (string, bool, error) {
+	css, ok := r.styles[cell.styleID]
+	if !ok {
+		style, err := r.f.GetStyle(cell.styleID)
+		if err != nil {
+			return css, false, err
+		}
+		css = htmlStyleCSS(r.f, style, r.options.ShowGridLines, r.inherited)
+		r.styles[cell.styleID] = css
+	}
+	return css, strings.Contains(css, "text-align:"), nil
+}
+
+// htmlColor returns the CSS color by given RGB or ARGB hex color, an empty
+// string will be returned for the invalid color.
+func htmlColor(color string) string {
+	if color = strings.TrimPrefix(color, "#"); len(color) == 8 {
+		color = color[2:]
+	}
+	if len(color) != 6 {
+		return ""
+	}
+	if _, err := strconv.ParseUint(color, 16, 32); err != nil {
+		return ""
+	}
+	return "#" + strings.ToUpper(color)
+}
+
+// htmlSafeLink returns if the hyperlink target is safe to be rendered as the
+// link of the HTML anchor element, only the http, https and mailto links and
+// the internal links start with "#" are allowed.
+func htmlSafeLink(target string) bool {
+	if strings.HasPrefix(target, "#") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(strings.TrimSpace(target), ":")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// htmlFontCSS returns the inline CSS of the font family and size, the
+// characters which may break out of the CSS string will be removed from the
+// font family name.
+func htmlFontCSS(font *Font) []string {
+	var css []string
+	if family := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`'";()\<>&{}`, r) {
+			return -1
+		}
+		return r
+	}, font.Family); family != "" {
+		css = append(css, "font-family:'"+html.EscapeString(family)+"'")
+	}
+	if font.Size > 0 {
+		css = append(css, "font-size:"+strconv.FormatFloat(font.Size, 'f', -1, 64)+"pt")
+	}
+	return css
+}
+
+// htmlTextCSS returns the inline CSS of the font weight, style, decoration
+// and color.
+func htmlTextCSS(f *File, font *Font) []string {
+	var css, decorations []string
+	if font.Bold {
+		css = append(css, "font-weight:bold")
+	}
+	if font.Italic {
+		css = append(css, "font-style:italic")
+	}
+	if font.Underline == "single" || font.Underline == "double" {
+		decorations = append(decorations, "underline")
+	}
+	if font.Strike {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		css = append(css, "text-decoration:"+strings.Join(decorations, " "))
+		if font.Underline == "double" {
+			css = append(css, "text-decoration-style:double")
+		}
+	}
+	if color := font.Color; color != "" || font.ColorTheme != nil || font.ColorIndexed != 0 {
+		if color = htmlColor(f.GetBaseColor(color, font.ColorIndexed, font.ColorTheme)); color != "" {
+			css = append(css, "color:"+color)
+		}
+	}
+	return css
+}
+
+// htmlFillCSS returns the inline CSS of the cell background.
+func htmlFillCSS(fill Fill) []string {
+	var colors []string
+	for _, color := range fill.Color {
+		if color = htmlColor(color); color != "" {
+			colors = append(colors, color)
+		}
+	}
+	if len(colors) == 0 {
+		return nil
+	}
+	if fill.Type == "gradient" && len(colors) > 1 {
+		return []string{"background:linear-gradient(" + strings.Join(colors, ",") + ")"}
+	}
+	if fill.Type == "gradient" || fill.Pattern > 0 {
+		return []string{"background-color:" + colors[0]}
+	}
+	return nil
+}
+
+// htmlStyleCSS provides a function to convert the style definition to the
+// inline CSS of the table cell, the font properties inherited from the table
+// will be omitted.
+func htmlStyleCSS(f *File, style *Style, gridLines bool, inherited map[string]bool) string {
+	css := []string{"padding:0 3px", "overflow:hidden", "vertical-align:bottom"}
+	if gridLines {
+		css = append(css, "border:1px solid #D4D4D4")
+	}
+	if style.Font != nil {
+		for _, item := range append(htmlFontCSS(style.Font), htmlTextCSS(f, style.Font)...) {
+			if !inherited[item] {
+				css = append(css, item)
+			}
+		}
+	}
+	css = append(css, htmlFillCSS(style.Fill)...)
+	for _, border := range style.Border {
+		side := map[string]string{"left": "left", "right": "right", "top": "top", "bottom": "bottom"}[border.Type]
+		if side == "" || border.Style <= 0 || border.Style >= len(htmlBorderStyles) {
+			continue
+		}
+		color := htmlColor(border.Color)
+		if color == "" {
+			color = "#000000"
+		}
+		css = append(css, "border-"+side+":"+htmlBorderStyles[border.Style]+" "+color)
+	}
+	whiteSpace := "pre"
+	if alignment := style.Alignment; alignment != nil {
+		if textAlign := htmlHorizontalAlignments[alignment.Horizontal]; textAlign != "" {
+			css = append(css, "text-align:"+textAlign)
+		}
+		if verticalAlign, ok := htmlVerticalAlignments[alignment.Vertical]; ok {
+			css[2] = "vertical-align:" + verticalAlign
+		}
+		if alignment.Indent > 0 {
+			css[0] = "padding:0 3px 0 " + strconv.Itoa(3+alignment.Indent*9) + "px"
+		}
+		if alignment.WrapText {
+			whiteSpace = "pre-wrap"
+		}
+	}
+	return strings.Join(append(css, "white-space:"+whiteSpace), ";")
+}
+
+// loadCondFmts provides a function to load the conditional formats of the
+// worksheet in the order of the priority, and calculate the statistics of the
+// values in the range of each rule.
+func (r *htmlRenderer) loadCondFmts() error {
+	for _, cf := range r.ws.ConditionalFormatting {
+		var rects [][]int
+		for _, ref := range strings.Fields(cf.SQRef) {
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			rect, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			rects = append(rects, rect)
+		}
+		for _, rule := range cf.CfRule {
+			extractFunc, ok := extractContFmtFunc[rule.Type]
+			if !ok {
+				continue
+			}
+			condFmt := &htmlCondFmt{
+				rects: rects, options: extractFunc(r.f, rule, r.ws.ExtLst), priority: rule.Priority, counts: map[string]int{},
+			}
+			if err := condFmt.loadStyle(r.f); err != nil {
+				return err
+			}
+			for coordinates, cell := range r.cells {
+				if !condFmt.contains(coordinates[0], coordinates[1]) || cell.raw == "" {
+					continue
+				}
+				condFmt.counts[cell.raw]++
+				if cell.numeric {
+					condFmt.values = append(condFmt.values, cell.number)
+				}
+			}
+			sort.Float64s(condFmt.values)
+			r.condFmts = append(r.condFmts, condFmt)
+		}
+	}
+	sort.SliceStable(r.condFmts, func(i, j int) bool {
+		return r.condFmts[i].priority < r.condFmts[j].priority
+	})
+	return nil
+}
+
+// loadStyle provides a function to load the inline CSS of the font and fill
+// by the format of the conditional format.
+func (c *htmlCondFmt) loadStyle(f *File) error {
+	if c.options.Format == nil {
+		return nil
+	}
+	style, err := f.GetConditionalStyle(*c.options.Format)
+	if err != nil {
+		return err
+	}
+	var css []string
+	if style.Font != nil {
+		css = htmlTextCSS(f, style.Font)
+	}
+	if len(style.Fill.Color) > 0 && style.Fill.Pattern == 0 && style.Fill.Type == "pattern" {
+		style.Fill.Pattern = 1
+	}
+	c.css = strings.Join(append(css, htmlFillCSS(style.Fill)...), ";")
+	return nil
+}
+
+// contains returns if the cell is in the range of the conditional format.
+func (c *htmlCondFmt) contains(col, row int) bool {
+	for _, rect := range c.rects {
+		if cellInRange([]int{col, row}, rect) {
+			return true
+		}
+	}
+	return false
+}
+
+// condFmtCSS returns the inline CSS of the matched conditional formats of
+// the cell, and if only the data bar should be shown without the value. The
+// CSS of the rules with higher priority will be written last to override the
+// others.
+func (r *htmlRenderer) condFmtCSS(col, row int, cell *htmlCell) (string, bool) {
+	var (
+		css     []string
+		barOnly bool
+	)
+	for _, condFmt := range r.condFmts {
+		if !condFmt.contains(col, row) || cell.raw == "" {
+			continue
+		}
+		rule, matched := condFmt.apply(cell)
+		if !matched {
+			continue
+		}
+		if rule != "" {
+			css = append([]string{rule}, css...)
+		}
+		barOnly = barOnly || (condFmt.options.Type == "data_bar" && condFmt.options.BarOnly)
+		if condFmt.options.StopIfTrue {
+			break
+		}
+	}
+	if len(css) == 0 {
+		return "", barOnly
+	}
+	return ";" + strings.Join(css, ";"), barOnly
+}
+
+// apply returns the inline CSS of the conditional format for the cell, and
+// if the rule matches the cell.
+func (c *htmlCondFmt) apply(cell *htmlCell) (string, bool) {
+	opts := c.options
+	switch opts.Type {
+	case "cell":
+		return c.css, htmlCompare(cell, criteriaType[opts.Criteria], opts.Value, opts.MinValue, opts.MaxValue)
+	case "top", "bottom":
+		n, err := strconv.Atoi(opts.Value)
+		if err != nil || !cell.numeric || len(c.values) == 0 {
+			return "", false
+		}
+		if opts.Percent {
+			n = int(math.Max(1, math.Floor(float64(len(c.values)*n)/100)))
+		}
+		n = minInt(maxInt(n, 1), len(c.values))
+		if opts.Type == "top" {
+			return c.css, cell.number >= c.values[len(c.values)-n]
+		}
+		return c.css, cell.number <= c.values[n-1]
+	case "average":
+		if !cell.numeric || len(c.values) == 0 {
+			return "", false
+		}
+		var sum float64
+		for _, value := range c.values {
+			sum += value
+		}
+		average := sum / float64(len(c.values))
+		if opts.AboveAverage {
+			return c.css, cell.number > average
+		}
+		return c.css, cell.number < average
+	case "duplicate":
+		return c.css, c.counts[cell.raw] > 1
+	case "unique":
+		return c.css, c.counts[cell.raw] == 1
+	case "2_color_scale", "3_color_scale":
+		return c.colorScale(cell)
+	case "data_bar":
+		return c.dataBar(cell)
+	}
+	return "", false
+}
+
+// htmlCompare returns if the cell value matches the criteria of the cell
+// value conditional format rule, only the numeric and string constants are
+// supported.
+func htmlCompare(cell *htmlCell, operator, value, minValue, maxValue string) bool {
+	parse := func(s string) (float64, bool) {
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return n, err == nil
+	}
+	switch operator {
+	case "between", "notBetween":
+		lower, ok1 := parse(minValue)
+		upper, ok2 := parse(maxValue)
+		if !ok1 || !ok2 || !cell.numeric {
+			return false
+		}
+		lower, upper = math.Min(lower, upper), math.Max(lower, upper)
+		between := cell.number >= lower && cell.number <= upper
+		return between == (operator == "between")
+	case "equal", "notEqual":
+		if n, ok := parse(value); ok && cell.numeric {
+			return (cell.number == n) == (operator == "equal")
+		}
+		if text := strings.TrimSpace(value); len(text) > 1 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+			equal := strings.EqualFold(cell.raw, strings.ReplaceAll(text[1:len(text)-1], `""`, `"`))
+			return equal == (operator == "equal")
+		}
+		return false
+	}
+	n, ok := parse(value)
+	if !ok || !cell.numeric {
+		return false
+	}
+	switch operator {
+	case "greaterThan":
+		return cell.number > n
+	case "greaterThanOrEqual":
+		return cell.number >= n
+	case "lessThan":
+		return cell.number < n
+	case "lessThanOrEqual":
+		return cell.number <= n
+	}
+	return false
+}
+
+// threshold returns the threshold value of the color scale or data bar by
+// given value type and value.
+func (c *htmlCondFmt) threshold(valueType, value string, defaultPercent float64) float64 {
+	lower, upper := c.values[0], c.values[len(c.values)-1]
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		n = defaultPercent
+	}
+	switch valueType {
+	case "min", "autoMin":
+		return lower
+	case "max", "autoMax":
+		return upper
+	case "num":
+		return n
+	case "percentile":
+		pos := n / 100 * float64(len(c.values)-1)
+		i := int(math.Floor(pos))
+		if i >= len(c.values)-1 {
+			return upper
+		}
+		return c.values[i] + (c.values[i+1]-c.values[i])*(pos-float64(i))
+	}
+	return lower + (upper-lower)*n/100
+}
+
+// colorScale returns the inline CSS of the background color for the cell by
+// the color scale conditional format.
+func (c *htmlCondFmt) colorScale(cell *htmlCell) (string, bool) {
+	if !cell.numeric || len(c.values) == 0 {
+		return "", false
+	}
+	opts := c.options
+	stops := []float64{c.threshold(opts.MinType, opts.MinValue, 0), c.threshold(opts.MaxType, opts.MaxValue, 100)}
+	colors := []string{opts.MinColor, opts.MaxColor}
+	if opts.Type == "3_color_scale" {
+		midType := opts.MidType
+		if midType == "" {
+			midType = "percentile"
+		}
+		stops = []float64{stops[0], c.threshold(midType, opts.MidValue, 50), stops[1]}
+		colors = []string{opts.MinColor, opts.MidColor, opts.MaxColor}
+	}
+	i := 0
+	for i < len(stops)-2 && cell.number > stops[i+1] {
+		i++
+	}
+	ratio := 0.0
+	if stops[i+1] > stops[i] {
+		ratio = math.Max(0, math.Min(1, (cell.number-stops[i])/(stops[i+1]-stops[i])))
+	} else if cell.number >= stops[i+1] {
+		ratio = 1
+	}
+	color := htmlMixColor(colors[i], colors[i+1], ratio)
+	if color == "" {
+		return "", false
+	}
+	return "background-color:" + color, true
+}
+
+// htmlMixColor returns the color which linearly interpolated between the
+// given two colors by the ratio.
+func htmlMixColor(from, to string, ratio float64) string {
+	if from, to = htmlColor(from), htmlColor(to); from == "" || to == "" {
+		return ""
+	}
+	a, _ := strconv.ParseUint(from[1:], 16, 32)
+	b, _ := strconv.ParseUint(to[1:], 16, 32)
+	var mixed uint64
+	for shift := 16; shift >= 0; shift -= 8 {
+		x, y := float64(a>>shift&0xFF), float64(b>>shift&0xFF)
+		mixed |= uint64(math.Round(x+(y-x)*ratio)) << shift
+	}
+	return htmlColor(strings.ToUpper(strconv.FormatUint(mixed|1<<24, 16)[1:]))
+}
+
+// dataBar returns the inline CSS of the background gradient for the cell by
+// the data bar conditional format.
+func (c *htmlCondFmt) dataBar(cell *htmlCell) (string, bool) {
+	color := htmlColor(c.options.BarColor)
+	if !cell.numeric || len(c.values) == 0 || color == "" {
+		return "", false
+	}
+	lower := c.threshold(c.options.MinType, c.options.MinValue, 0)
+	upper := c.threshold(c.options.MaxType, c.options.MaxValue, 100)
+	ratio := 1.0
+	if upper > lower {
+		ratio = math.Max(0, math.Min(1, (cell.number-lower)/(upper-lower)))
+	}
+	percent := strconv.FormatFloat(math.Round(10+ratio*90), 'f', -1, 64) + "%"
+	direction := "90deg"
+	if c.options.BarDirection == "rightToLeft" {
+		direction = "270deg"
+	}
+	end := color
+	if !c.options.BarSolid {
+		end = "#FFFFFF"
+	}
+	return "background:linear-gradient(" + direction + "," + color + "," + end + " " + percent + ",transparent " + percent + ")", true
+}
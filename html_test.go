@@ -0,0 +1,213 @@
+package excelize
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name <b>"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1234.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", true))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, 2, 3, 4, 5}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{10, 20, 30, 40, 50}))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Merged\nline"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://example.com/?a=1&b=2", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "Sheet1!A3", "Location"))
+	numFmt := "#,##0.00"
+	styleID, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Color: "FF0000", Underline: "single"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:       []Border{{Type: "bottom", Style: 2, Color: "0000FF"}},
+		Alignment:    &Alignment{Horizontal: "center", Vertical: "top", WrapText: true},
+		CustomNumFmt: &numFmt,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}, Fill: Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A2:E2", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: &format, Value: "3"}}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A3:E3", []ConditionalFormatOptions{{Type: "3_color_scale", Criteria: "=", MinType: "min", MidType: "percentile", MaxType: "max", MinColor: "#F8696B", MidColor: "#FFEB84", MaxColor: "#63BE7B"}}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A2:E2", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"}}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf, HTMLOptions{ShowGridLines: true}))
+	html := buf.String()
+	assert.True(t, strings.HasPrefix(html, `<table data-sheet="Sheet1" style="border-collapse:collapse;table-layout:fixed;width:356px;font-family:'Calibri';font-size:11pt;color:#000000">`))
+	assert.True(t, strings.HasSuffix(html, "</tbody></table>"))
+	assert.Contains(t, html, `<col style="width:146px">`)
+	assert.Contains(t, html, `<tr style="height:30pt">`)
+	assert.Contains(t, html, `<a href="https://example.com/?a=1&amp;b=2" style="color:inherit;text-decoration:inherit">Name &lt;b&gt;</a>`)
+	assert.Contains(t, html, `<a href="#Sheet1!A3" style="color:inherit;text-decoration:inherit">1,234.50</a>`)
+	assert.Contains(t, html, "font-weight:bold;text-decoration:underline;color:#FF0000;background-color:#FFFF00;border-bottom:2px solid #0000FF;text-align:center;white-space:pre-wrap")
+	assert.Contains(t, html, "border:1px solid #D4D4D4")
+	assert.Contains(t, html, "colspan=\"2\">Merged\nline</td>")
+	assert.NotContains(t, html, "TRUE")
+	assert.Contains(t, html, "transparent 78%);color:#9A0511;background-color:#FEC7CE\">4</td>")
+	assert.Contains(t, html, `background-color:#F8696B">10</td>`)
+	assert.Contains(t, html, `background-color:#63BE7B">50</td>`)
+	assert.Equal(t, 3, strings.Count(html, "<tr "))
+
+	// Test render range with hidden columns, raw values and disabled conditional formats
+	buf.Reset()
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf, HTMLOptions{
+		RangeRef: "C1:B2", RawCellValue: true, ShowHidden: true, DisableConditionalFormat: true,
+	}))
+	html = buf.String()
+	assert.Equal(t, 2, strings.Count(html, "<tr "))
+	assert.Equal(t, 2, strings.Count(html, "<col "))
+	assert.Contains(t, html, ">1234.5</a>")
+	assert.Contains(t, html, `text-align:center">1</td>`)
+	assert.NotContains(t, html, "D4D4D4")
+	assert.NotContains(t, html, "#FEC7CE")
+	assert.NotContains(t, html, "linear-gradient")
+
+	// Test render empty worksheet
+	buf.Reset()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ExportHTML("Sheet2", &buf))
+	assert.NotContains(t, buf.String(), "<tr ")
+
+	// Test render with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ExportHTML("Sheet1", &buf, HTMLOptions{RangeRef: "A:B1"}))
+	// Test render with not exist worksheet
+	assert.EqualError(t, f.ExportHTML("SheetN", &buf), "sheet SheetN does not exist")
+	// Test render with invalid sheet name
+	assert.EqualError(t, f.ExportHTML("Sheet:1", &buf), ErrSheetNameInvalid.Error())
+	// Test render with writer error
+	assert.EqualError(t, f.ExportHTML("Sheet1", errorWriter{}), "write error")
+	assert.NoError(t, f.Close())
+
+	// Test render with unsupported charset shared strings
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A"))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportHTML("Sheet1", &buf), "XML syntax error on line 1: invalid UTF-8")
+	// Test render with unsupported charset styles
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A"))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportHTML("Sheet1", &buf), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestExportHTMLUnsafeContent(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", "b", "c", "d", "e"}))
+	for cell, link := range map[string]string{
+		"A1": "javascript:alert(1)",
+		"B1": " JavaScript:alert(1)",
+		"C1": "data:text/html,<script>alert(1)</script>",
+		"D1": "mailto:user@example.com",
+		"E1": "HTTP://example.com",
+	} {
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, link, "External"))
+	}
+	styleID, err := f.NewStyle(&Style{Font: &Font{Family: `x';background:url(http://evil)`}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf))
+	html := buf.String()
+	assert.NotContains(t, strings.ToLower(html), "javascript:")
+	assert.NotContains(t, html, "data:text/html")
+	assert.Contains(t, html, `<a href="mailto:user@example.com"`)
+	assert.Contains(t, html, `<a href="HTTP://example.com"`)
+	assert.Equal(t, 2, strings.Count(html, "<a "))
+	assert.Contains(t, html, `font-family:'xbackground:urlhttp://evil'`)
+	assert.NotContains(t, html, "url(")
+}
+
+func TestExportHTMLRangeHyperlinks(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 3; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{"a", "b", "c"}))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{
+		{Ref: "B3", Location: "Early"},
+		{Ref: "A:A", Location: "Column"},
+		{Ref: "B2:XFD1048576", Location: "Range"},
+		{Ref: "C3", Location: "Cell"},
+	}}
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf))
+	html := buf.String()
+	assert.Equal(t, 3, strings.Count(html, `<a href="#Column"`))
+	assert.Equal(t, 3, strings.Count(html, `<a href="#Range"`))
+	assert.Equal(t, 1, strings.Count(html, `<a href="#Cell"`))
+	assert.NotContains(t, html, "#Early")
+	// Test render with the hyperlink of the whole rows
+	ws.Hyperlinks.Hyperlink = []xlsxHyperlink{{Ref: "2:3", Location: "Rows"}}
+	buf.Reset()
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf))
+	assert.Equal(t, 6, strings.Count(buf.String(), `<a href="#Rows"`))
+	// Test render with invalid hyperlink reference
+	ws.Hyperlinks.Hyperlink = []xlsxHyperlink{{Ref: "A:1"}}
+	assert.Error(t, f.ExportHTML("Sheet1", &buf))
+}
+
+func TestHTMLCondFmt(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{{1, 2, 3, 4, 5}, {"a", "b", "a", "c", "b"}, {-2, 0, 2, 4, 6}} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	format, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"00FF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	for _, c := range []struct {
+		rangeRef string
+		opts     ConditionalFormatOptions
+		expected []string
+	}{
+		{"A1:E1", ConditionalFormatOptions{Type: "top", Criteria: "=", Format: &format, Value: "2"}, []string{"4", "5"}},
+		{"A1:E1", ConditionalFormatOptions{Type: "bottom", Criteria: "=", Format: &format, Value: "40", Percent: true}, []string{"1", "2"}},
+		{"A1:E1", ConditionalFormatOptions{Type: "average", Criteria: "=", Format: &format, AboveAverage: true}, []string{"4", "5"}},
+		{"A1:E1", ConditionalFormatOptions{Type: "cell", Criteria: "between", Format: &format, MinValue: "2", MaxValue: "3"}, []string{"2", "3"}},
+		{"A2:E2", ConditionalFormatOptions{Type: "duplicate", Criteria: "=", Format: &format}, []string{"a", "b", "a", "b"}},
+		{"A2:E2", ConditionalFormatOptions{Type: "unique", Criteria: "=", Format: &format}, []string{"c"}},
+	} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", c.rangeRef, []ConditionalFormatOptions{c.opts}))
+		var buf bytes.Buffer
+		assert.NoError(t, f.ExportHTML("Sheet1", &buf))
+		var matched []string
+		for _, td := range strings.Split(buf.String(), "<td ")[1:] {
+			if strings.Contains(td, "#00FF00") {
+				matched = append(matched, td[strings.Index(td, ">")+1:strings.Index(td, "</td>")])
+			}
+		}
+		assert.Equal(t, c.expected, matched, c.opts.Type)
+		assert.NoError(t, f.UnsetConditionalFormat("Sheet1", c.rangeRef))
+	}
+
+	// Test data bar with negative values and bar only
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A3:E3", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "num", MinValue: "0", MaxType: "num", MaxValue: "4", BarColor: "#638EC6", BarOnly: true, BarSolid: true}}))
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportHTML("Sheet1", &buf, HTMLOptions{RangeRef: "A3:E3"}))
+	assert.Contains(t, buf.String(), "linear-gradient(90deg,#638EC6,#638EC6 55%,transparent 55%)")
+	assert.NotContains(t, buf.String(), ">6</td>")
+
+	assert.Equal(t, "#808080", htmlMixColor("#000000", "#FFFFFF", 0.5))
+	assert.Equal(t, "", htmlColor(""))
+	assert.Equal(t, "#FF0000", htmlColor("FFFF0000"))
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
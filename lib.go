@@ -15,6 +15,8 @@ import (
 	"archive/zip"
 	"bytes"
 	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -24,6 +26,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ReadZipReader extract spreadsheet with given options.
@@ -48,6 +51,17 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
+		if f.options.PreserveUnchangedParts && !v.FileInfo().IsDir() {
+			f.originParts.Store(fileName, &originPart{file: v})
+		}
+		binaryPart := strings.HasSuffix(strings.ToLower(fileName), ".bin")
+		if f.options.LoadSheets != nil && isLazyPart(fileName) && !binaryPart && !v.FileInfo().IsDir() {
+			if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
+				worksheets++
+			}
+			f.lazyParts.Store(fileName, v)
+			continue
+		}
 		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && fileSize > f.options.UnzipXMLSizeLimit {
 			tempFile, err := f.unzipToTemp(v)
 			if tempFile != "" {
@@ -59,7 +73,7 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		}
 		if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
 			worksheets++
-			if fileSize > f.options.UnzipXMLSizeLimit && !v.FileInfo().IsDir() {
+			if fileSize > f.options.UnzipXMLSizeLimit && !binaryPart && !v.FileInfo().IsDir() {
 				tempFile, err := f.unzipToTemp(v)
 				if tempFile != "" {
 					f.tempFiles.Store(fileName, tempFile)
@@ -76,10 +90,42 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 	return fileList, worksheets, nil
 }
 
-// unzipToTemp unzip the zip entity to the system temporary directory and
-// returned the unzipped file path.
+// osTempStorage defined the default temporary storage backend, which stores
+// the temporary files in the system temporary directory.
+type osTempStorage struct{}
+
+// Create creates a new temporary file in the system temporary directory.
+func (osTempStorage) Create() (TempFile, error) {
+	return os.CreateTemp(os.TempDir(), "excelize-")
+}
+
+// Open opens the temporary file by given file path.
+func (osTempStorage) Open(name string) (TempFile, error) {
+	return os.Open(name)
+}
+
+// Remove removes the temporary file by given file path.
+func (osTempStorage) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// tempStorage returns the temporary storage backend of the spreadsheet, the
+// backend will be kept once used to ensure the temporary files created by it
+// can be removed even the options changed on saving the spreadsheet.
+func (f *File) tempStorage() TempStorage {
+	if f.storage == nil {
+		f.storage = osTempStorage{}
+		if f.options != nil && f.options.TempStorage != nil {
+			f.storage = f.options.TempStorage
+		}
+	}
+	return f.storage
+}
+
+// unzipToTemp unzip the zip entity to the temporary storage and returned the
+// unzipped file path.
 func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
-	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	tmp, err := f.tempStorage().Create()
 	if err != nil {
 		return "", err
 	}
@@ -96,9 +142,185 @@ func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
 	return tmp.Name(), tmp.Close()
 }
 
+// lazyPartPrefixes defined the path prefixes of the parts which will be loaded
+// on first access when opening the spreadsheet with the LoadSheets option.
+var lazyPartPrefixes = []string{"xl/worksheets/", "xl/drawings/", "xl/pivotCache/", "xl/media/"}
+
+// isLazyPart returns if the part of the given path can be loaded on first
+// access, the relationships parts will always be loaded on open.
+func isLazyPart(name string) bool {
+	if strings.Contains(name, "/_rels/") {
+		return false
+	}
+	for _, prefix := range lazyPartPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadLazyPart provides a function to decompress the part which hasn't been
+// loaded by given path, the worksheet over the UnzipXMLSizeLimit will be
+// extracted to the temporary storage. The part will be removed from the lazy
+// parts after it has been stored, so that the concurrent readers will wait
+// for the loading instead of treating the part as missing.
+func (f *File) loadLazyPart(name string) {
+	if _, ok := f.lazyParts.Load(name); !ok {
+		return
+	}
+	f.lazyMu.Lock()
+	defer f.lazyMu.Unlock()
+	v, ok := f.lazyParts.Load(name)
+	if !ok {
+		return
+	}
+	defer f.lazyParts.Delete(name)
+	file := v.(*zip.File)
+	if strings.HasPrefix(strings.ToLower(name), "xl/worksheets/sheet") && file.FileInfo().Size() > f.options.UnzipXMLSizeLimit {
+		tempFile, err := f.unzipToTemp(file)
+		if tempFile != "" {
+			f.tempFiles.Store(name, tempFile)
+		}
+		if err == nil {
+			return
+		}
+	}
+	content, _ := readFile(file)
+	f.Pkg.Store(name, content)
+	f.setOriginPartContent(name, content)
+}
+
+// loadLazyParts provides a function to decompress all the parts which haven't
+// been loaded by given path prefix.
+func (f *File) loadLazyParts(prefix string) {
+	f.lazyParts.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), prefix) {
+			f.loadLazyPart(k.(string))
+		}
+		return true
+	})
+}
+
+// hasLazyParts returns if there are parts which haven't been loaded.
+func (f *File) hasLazyParts() bool {
+	var ok bool
+	f.lazyParts.Range(func(k, v interface{}) bool {
+		ok = true
+		return false
+	})
+	return ok
+}
+
+// hasOriginParts returns if there are parts of the original spreadsheet which
+// will be copied verbatim on save.
+func (f *File) hasOriginParts() bool {
+	var ok bool
+	f.originParts.Range(func(k, v interface{}) bool {
+		ok = true
+		return false
+	})
+	return ok
+}
+
+// originPart directly maps the part in the original spreadsheet, the content
+// is the decompressed content of the part which has been loaded in memory.
+type originPart struct {
+	file    *zip.File
+	content []byte
+}
+
+// setOriginPartContent provides a function to set the decompressed content of
+// the original part by given path.
+func (f *File) setOriginPartContent(name string, content []byte) {
+	if v, ok := f.originParts.Load(name); ok {
+		v.(*originPart).content = content
+	}
+}
+
+// unchangedPart returns the part in the original spreadsheet by given path if
+// the content of the part hasn't been changed after open the spreadsheet.
+func (f *File) unchangedPart(name string) (*zip.File, bool) {
+	v, ok := f.originParts.Load(name)
+	if !ok {
+		return nil, false
+	}
+	part := v.(*originPart)
+	if content, ok := f.Pkg.Load(name); ok {
+		b := content.([]byte)
+		return part.file, part.content != nil && len(b) == len(part.content) &&
+			(len(b) == 0 || &b[0] == &part.content[0])
+	}
+	_, ok = f.tempFiles.Load(name)
+	return part.file, ok && part.content == nil
+}
+
+// trackPart provides a function to record the digest of the serialized part
+// by given path after it has been parsed, which used to check if the part has
+// been changed on save with the PreserveUnchangedParts option.
+func (f *File) trackPart(name string, v interface{}) {
+	if f.options == nil || !f.options.PreserveUnchangedParts {
+		return
+	}
+	if output, err := xml.Marshal(v); err == nil {
+		f.partDigests.Store(name, sha256.Sum256(output))
+	}
+}
+
+// isPartUnchanged returns if the serialized content of the part by given path
+// is the same with the one after it has been parsed.
+func (f *File) isPartUnchanged(name string, v interface{}) bool {
+	digest, ok := f.partDigests.Load(name)
+	if !ok {
+		return false
+	}
+	output, err := xml.Marshal(v)
+	return err == nil && digest.([sha256.Size]byte) == sha256.Sum256(output)
+}
+
+// writeRawPart provides a function to copy the compressed data of the part in
+// the original spreadsheet to the zip.Writer with the given file header.
+func writeRawPart(zw *zip.Writer, fh *zip.FileHeader, file *zip.File) error {
+	fi, err := zw.CreateRaw(fh)
+	if err != nil {
+		return err
+	}
+	rc, err := file.OpenRaw()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, rc)
+	return err
+}
+
+// msDosTime returns the MS-DOS date and time of the given time, which used for
+// the modification time of the part in the ZIP archive.
+func msDosTime(t time.Time) (uint16, uint16) {
+	return uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9),
+		uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+}
+
+// extendedTimestamp returns the extended timestamp extra field of the part in
+// the ZIP archive by given modification time.
+func extendedTimestamp(t time.Time) []byte {
+	extra := make([]byte, 9)
+	binary.LittleEndian.PutUint16(extra[0:], 0x5455)
+	binary.LittleEndian.PutUint16(extra[2:], 5)
+	extra[4] = 1
+	binary.LittleEndian.PutUint32(extra[5:], uint32(t.Unix()))
+	return extra
+}
+
+// pkgLoad provides a function to get the part of the package by given path,
+// the part which hasn't been loaded will be decompressed on first access.
+func (f *File) pkgLoad(name string) (interface{}, bool) {
+	f.loadLazyPart(name)
+	return f.Pkg.Load(name)
+}
+
 // readXML provides a function to read XML content as bytes.
 func (f *File) readXML(name string) []byte {
-	if content, _ := f.Pkg.Load(name); content != nil {
+	if content, _ := f.pkgLoad(name); content != nil {
 		return content.([]byte)
 	}
 	if content, ok := f.streams[name]; ok {
@@ -114,22 +336,23 @@ func (f *File) readBytes(name string) []byte {
 		return content
 	}
 	file, err := f.readTemp(name)
-	if err != nil {
+	if err != nil || file == nil {
 		return content
 	}
 	content, _ = io.ReadAll(file)
 	f.Pkg.Store(name, content)
+	f.setOriginPartContent(name, content)
 	_ = file.Close()
 	return content
 }
 
-// readTemp read file from system temporary directory by given path.
-func (f *File) readTemp(name string) (file *os.File, err error) {
+// readTemp read file from the temporary storage by given path.
+func (f *File) readTemp(name string) (file TempFile, err error) {
 	path, ok := f.tempFiles.Load(name)
 	if !ok {
 		return
 	}
-	file, err = os.Open(path.(string))
+	file, err = f.tempStorage().Open(path.(string))
 	return
 }
 
@@ -283,6 +506,36 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	return sign + colName + sign + strconv.Itoa(row), err
 }
 
+// wholeRangeRefToCoordinates returns the sorted coordinates of the range
+// reference, and if the reference is the whole columns like "A:C" or the
+// whole rows like "1:3". The whole columns will be converted to the range from
+// the first row to the last row, and the whole rows will be converted to the
+// range from the first column to the last column.
+func wholeRangeRefToCoordinates(ref string) ([]int, bool, bool, error) {
+	parts := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(parts) == 2 {
+		col1, err1 := ColumnNameToNumber(parts[0])
+		col2, err2 := ColumnNameToNumber(parts[1])
+		if err1 == nil && err2 == nil {
+			return []int{minInt(col1, col2), 1, maxInt(col1, col2), TotalRows}, true, false, nil
+		}
+		row1, err1 := strconv.Atoi(parts[0])
+		row2, err2 := strconv.Atoi(parts[1])
+		if err1 == nil && err2 == nil {
+			if row1 < 1 || row2 < 1 || row1 > TotalRows || row2 > TotalRows {
+				return []int{0, 0, 0, 0}, false, false, ErrMaxRows
+			}
+			return []int{1, minInt(row1, row2), MaxColumns, maxInt(row1, row2)}, false, true, nil
+		}
+	}
+	rect, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return rect, false, false, err
+	}
+	_ = sortCoordinates(rect)
+	return rect, false, false, err
+}
+
 // rangeRefToCoordinates provides a function to convert range reference to a
 // pair of coordinates.
 func rangeRefToCoordinates(ref string) ([]int, error) {
@@ -414,6 +667,33 @@ func inStrSlice(a []string, x string, caseSensitive bool) int {
 	return -1
 }
 
+// minInt returns the smaller of the given integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of the given integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// inIntSlice provides a method to check if an element is present in an int
+// array, and return the index of its location, otherwise return -1.
+func inIntSlice(a []int, x int) int {
+	for idx, n := range a {
+		if x == n {
+			return idx
+		}
+	}
+	return -1
+}
+
 // inFloat64Slice provides a method to check if an element is present in a
 // float64 array, and return the index of its location, otherwise return -1.
 func inFloat64Slice(a []float64, x float64) int {
@@ -555,23 +835,41 @@ func (ext *xlsxExt) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return nil
 }
 
+// strictNameSpaces defined the pairs of the Transitional and Strict namespaces
+// and relationship types, the items which are the prefix of the other items
+// should be placed after them.
+var strictNameSpaces = [][2]string{
+	{NameSpaceDocumentPropertiesVariantTypes.Value, StrictNameSpaceDocumentPropertiesVariantTypes},
+	{NameSpaceDrawingMLChartDrawing, StrictNameSpaceDrawingMLChartDrawing},
+	{NameSpaceDrawingMLChart.Value, StrictNameSpaceDrawingMLChart},
+	{NameSpaceDrawingMLMain, StrictNameSpaceDrawingMLMain},
+	{NameSpaceDrawingMLPicture, StrictNameSpaceDrawingMLPicture},
+	{NameSpaceDrawingMLSpreadSheet.Value, StrictNameSpaceDrawingMLSpreadSheet},
+	{NameSpaceCustomProperties, StrictNameSpaceCustomProperties},
+	{NameSpaceCustomXML, StrictNameSpaceCustomXML},
+	{NameSpaceExtendedProperties, StrictNameSpaceExtendedProperties},
+	{NameSpaceSpreadSheet.Value, StrictNameSpaceSpreadSheet},
+	{SourceRelationshipCustomProperties, StrictSourceRelationshipCustomProperties},
+	{SourceRelationshipExtendProperties, StrictSourceRelationshipExtendProperties},
+	{SourceRelationship.Value, StrictSourceRelationship},
+}
+
 // namespaceStrictToTransitional provides a method to convert Strict and
 // Transitional namespaces.
 func namespaceStrictToTransitional(content []byte) []byte {
-	namespaceTranslationDic := map[string]string{
-		StrictNameSpaceDocumentPropertiesVariantTypes: NameSpaceDocumentPropertiesVariantTypes.Value,
-		StrictNameSpaceDrawingMLMain:                  NameSpaceDrawingMLMain,
-		StrictNameSpaceExtendedProperties:             NameSpaceExtendedProperties,
-		StrictNameSpaceSpreadSheet:                    NameSpaceSpreadSheet.Value,
-		StrictSourceRelationship:                      SourceRelationship.Value,
-		StrictSourceRelationshipChart:                 SourceRelationshipChart,
-		StrictSourceRelationshipComments:              SourceRelationshipComments,
-		StrictSourceRelationshipExtendProperties:      SourceRelationshipExtendProperties,
-		StrictSourceRelationshipImage:                 SourceRelationshipImage,
-		StrictSourceRelationshipOfficeDocument:        SourceRelationshipOfficeDocument,
-	}
-	for s, n := range namespaceTranslationDic {
-		content = bytesReplace(content, []byte(s), []byte(n), -1)
+	for _, ns := range strictNameSpaces {
+		content = bytesReplace(content, []byte(ns[1]), []byte(ns[0]), -1)
+	}
+	return content
+}
+
+// namespaceTransitionalToStrict provides a method to convert Transitional
+// namespaces and relationship types to the Strict conformance, the given
+// content will not be modified.
+func namespaceTransitionalToStrict(content []byte) []byte {
+	content = append([]byte(nil), content...)
+	for _, ns := range strictNameSpaces {
+		content = bytesReplace(content, []byte(ns[0]), []byte(ns[1]), -1)
 	}
 	return content
 }
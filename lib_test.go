@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -390,3 +391,118 @@ func TestUnzipToTemp(t *testing.T) {
 	_, err = f.unzipToTemp(z.File[0])
 	assert.EqualError(t, err, "EOF")
 }
+
+// memTempStorage defined the in-memory temporary storage backend for testing.
+type memTempStorage struct {
+	mu      sync.Mutex
+	files   map[string]*[]byte
+	count   int
+	errOpen error
+}
+
+// memTempFile defined the in-memory temporary file for testing.
+type memTempFile struct {
+	name   string
+	data   *[]byte
+	offset int
+}
+
+func (m *memTempFile) Read(p []byte) (int, error) {
+	if m.offset >= len(*m.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, (*m.data)[m.offset:])
+	m.offset += n
+	return n, nil
+}
+
+func (m *memTempFile) ReadAt(p []byte, off int64) (int, error) {
+	return bytes.NewReader(*m.data).ReadAt(p, off)
+}
+
+func (m *memTempFile) Write(p []byte) (int, error) {
+	*m.data = append(*m.data, p...)
+	return len(p), nil
+}
+
+func (m *memTempFile) Close() error { return nil }
+
+func (m *memTempFile) Name() string { return m.name }
+
+func (s *memTempStorage) Create() (TempFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	name := "mem-" + strconv.Itoa(s.count)
+	s.files[name] = &[]byte{}
+	return &memTempFile{name: name, data: s.files[name]}, nil
+}
+
+func (s *memTempStorage) Open(name string) (TempFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[name]
+	if !ok || s.errOpen != nil {
+		return nil, os.ErrNotExist
+	}
+	return &memTempFile{name: name, data: data}, nil
+}
+
+func (s *memTempStorage) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.files[name]; !ok {
+		return os.ErrNotExist
+	}
+	delete(s.files, name)
+	return nil
+}
+
+func TestTempStorage(t *testing.T) {
+	storage := &memTempStorage{files: map[string]*[]byte{}}
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128, TempStorage: storage})
+	assert.NoError(t, err)
+	sheetXML, ok := f.tempFiles.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, storage.files, sheetXML.(string))
+	// Test rows iterator and shared string index with the temporary storage
+	rows, err := f.GetRows("Sheet2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rows)
+	assert.NotNil(t, f.sharedStringTemp)
+	assert.Contains(t, storage.files, f.sharedStringTemp.Name())
+	// Test columns iterator with the temporary storage
+	cols, err := f.Cols("Sheet2")
+	assert.NoError(t, err)
+	assert.Contains(t, storage.files, cols.index.file.Name())
+	assert.NoError(t, cols.Close())
+	// Test stream writer spill data with the temporary storage
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, _ = sw.rawData.WriteString(strings.Repeat("0", StreamChunkSize))
+	assert.NoError(t, sw.rawData.Sync())
+	assert.Contains(t, storage.files, sw.rawData.tmp.Name())
+	r, err := sw.rawData.Reader()
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte(xml.Header)))
+	assert.True(t, bytes.HasSuffix(b, []byte(strings.Repeat("0", StreamChunkSize))))
+	assert.NoError(t, sw.rawData.Close())
+	// Test save with other options keeps the temporary storage
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTempStorage.xlsx"), Options{}))
+	assert.NoError(t, f.Close())
+	assert.Empty(t, storage.files)
+
+	// Test open temporary file failed
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128, TempStorage: storage})
+	assert.NoError(t, err)
+	storage.errOpen = os.ErrNotExist
+	_, err = f.Rows("Sheet2")
+	assert.Equal(t, os.ErrNotExist, err)
+	storage.errOpen = nil
+	assert.NoError(t, f.Close())
+	// Test the default temporary storage backend
+	f = &File{}
+	assert.Equal(t, osTempStorage{}, f.tempStorage())
+}
@@ -0,0 +1,664 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CellMarshaler is the interface implemented by types that can marshal
+// themselves into a cell value, the returned value will be set to the cell
+// as same as the value of the SetCellValue function.
+type CellMarshaler interface {
+	MarshalCell() (interface{}, error)
+}
+
+// CellUnmarshaler is the interface implemented by types that can unmarshal
+// a cell value of themselves, the value is the raw value of the cell without
+// applying the number format.
+type CellUnmarshaler interface {
+	UnmarshalCell(value string) error
+}
+
+// MarshalOptions defined the options for marshal and unmarshal the struct
+// slice to and from the worksheet rows.
+//
+// StartCell specifies the top-left cell of the header row, the default value
+// is "A1". When marshal the rows by the stream writer, the default start cell
+// will be the first column of the next row of the last written row.
+//
+// NoHeader specifies if the rows have no header row, the data rows will start
+// at the start cell, and the fields will be mapped to the columns in order
+// when unmarshal the rows.
+//
+// HeaderStyleID specifies the style index of the header cells.
+type MarshalOptions struct {
+	StartCell     string
+	NoHeader      bool
+	HeaderStyleID int
+}
+
+// marshalField directly maps the struct field with the column of the
+// worksheet rows.
+type marshalField struct {
+	index     []int
+	name      string
+	header    string
+	col       int
+	fixedCol  bool
+	numFmt    string
+	styleID   int
+	omitEmpty bool
+}
+
+// cellMarshalerType, cellUnmarshalerType and timeType defined the reflect
+// types which will be converted specially on marshal and unmarshal the rows.
+var (
+	cellMarshalerType   = reflect.TypeOf((*CellMarshaler)(nil)).Elem()
+	cellUnmarshalerType = reflect.TypeOf((*CellUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// parseMarshalFields provides a function to parse the exported fields of the
+// given struct type by the "xlsx" struct tags. The first column number is
+// given by col, the fields without column letter in the tag will be placed
+// after the previous field.
+func parseMarshalFields(typ reflect.Type, col int) ([]marshalField, error) {
+	var fields []marshalField
+	var parse func(typ reflect.Type, index []int) error
+	parse = func(typ reflect.Type, index []int) error {
+		for i := 0; i < typ.NumField(); i++ {
+			sf := typ.Field(i)
+			tag, tagged := sf.Tag.Lookup("xlsx")
+			if tag == "-" {
+				continue
+			}
+			idx := append(append([]int{}, index...), i)
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct && !tagged && sf.Type != timeType {
+				if err := parse(sf.Type, idx); err != nil {
+					return err
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			field := marshalField{index: idx, name: sf.Name, header: sf.Name}
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				field.header = opts[0]
+			}
+			for i := 1; i < len(opts); i++ {
+				key, val, _ := strings.Cut(opts[i], "=")
+				switch strings.TrimSpace(key) {
+				case "col":
+					num, err := ColumnNameToNumber(val)
+					if err != nil {
+						return err
+					}
+					field.col, field.fixedCol = num, true
+				case "numfmt":
+					// The number format code may contain commas, so takes the
+					// rest of the tag as the number format
+					field.numFmt = strings.Join(append([]string{val}, opts[i+1:]...), ",")
+					i = len(opts)
+				case "style":
+					styleID, err := strconv.Atoi(val)
+					if err != nil {
+						return err
+					}
+					field.styleID = styleID
+				case "omitempty":
+					field.omitEmpty = true
+				}
+			}
+			fields = append(fields, field)
+		}
+		return nil
+	}
+	if err := parse(typ, nil); err != nil {
+		return fields, err
+	}
+	for i := range fields {
+		if !fields[i].fixedCol {
+			fields[i].col = col
+		}
+		col = fields[i].col + 1
+	}
+	return fields, nil
+}
+
+// marshalRowsType returns the element struct type of the given slice value
+// for marshal the rows.
+func marshalRowsType(v interface{}) (reflect.Value, reflect.Type, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return val, nil, ErrMarshalRows
+	}
+	typ := val.Type().Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return val, nil, ErrMarshalRows
+	}
+	return val, typ, nil
+}
+
+// getMarshalOptions provides a function to parse the start cell coordinates
+// of the marshal options, the given row number will be used if the start cell
+// was not specified.
+func getMarshalOptions(row int, opts ...MarshalOptions) (MarshalOptions, int, int, error) {
+	options := MarshalOptions{}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.StartCell == "" {
+		return options, 1, row, nil
+	}
+	col, row, err := CellNameToCoordinates(options.StartCell)
+	return options, col, row, err
+}
+
+// cellValue returns the cell value of the given struct field, the nil will be
+// returned for the empty pointer and the zero value with the omitempty option.
+func (field *marshalField) cellValue(elem reflect.Value) (interface{}, error) {
+	for elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return nil, nil
+		}
+		elem = elem.Elem()
+	}
+	val := elem.FieldByIndex(field.index)
+	if field.omitEmpty && val.IsZero() {
+		return nil, nil
+	}
+	if val.Type().Implements(cellMarshalerType) {
+		if val.Kind() == reflect.Ptr && val.IsNil() {
+			return nil, nil
+		}
+		return val.Interface().(CellMarshaler).MarshalCell()
+	}
+	if val.CanAddr() && reflect.PtrTo(val.Type()).Implements(cellMarshalerType) {
+		return val.Addr().Interface().(CellMarshaler).MarshalCell()
+	}
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, nil
+		}
+		val = val.Elem()
+	}
+	return val.Interface(), nil
+}
+
+// marshalStyles provides a function to create the styles for the fields with
+// number format, and returns the style index of each field.
+func (f *File) marshalStyles(fields []marshalField) ([]int, error) {
+	styles := make([]int, len(fields))
+	for i, field := range fields {
+		styles[i] = field.styleID
+		if field.numFmt == "" {
+			continue
+		}
+		style := &Style{}
+		if field.styleID != 0 {
+			s, err := f.GetStyle(field.styleID)
+			if err != nil {
+				return styles, err
+			}
+			style = s
+		}
+		style.NumFmt, style.CustomNumFmt = 0, &fields[i].numFmt
+		styleID, err := f.NewStyle(style)
+		if err != nil {
+			return styles, err
+		}
+		styles[i] = styleID
+	}
+	return styles, nil
+}
+
+// MarshalRows provides a function to write a slice of structs to the
+// worksheet rows by given worksheet name, the slice value and the optional
+// settings. The header row will be written with the field names or the names
+// specified by the struct tags, followed by one row per element. The struct
+// field can be specified by the "xlsx" struct tag with a comma-separated list
+// of options, the first option is the header name, and the following options
+// are supported:
+//
+//	 Option    | Description
+//	-----------+-------------------------------------------------------------
+//	 col       | The column letter of the field, such as col=C, the fields
+//	           | without this option will be placed after the previous field
+//	 numfmt    | The custom number format code of the field, such as
+//	           | numfmt=#,##0.00, this option must be the last one, since the
+//	           | number format code may contain commas
+//	 style     | The style index of the field cells, such as style=1
+//	 omitempty | Leave the cell empty if the field has zero value
+//
+// The field with tag "-" will be ignored, and the field of the embedded struct
+// will be treated as the field of the outer struct. The field type which
+// implements the CellMarshaler interface will be converted by the custom
+// converter. For example, write the employees to the worksheet named
+// 'Sheet1':
+//
+//	type Employee struct {
+//	    Name     string    `xlsx:"Employee Name"`
+//	    Salary   float64   `xlsx:"Salary,numfmt=#,##0.00"`
+//	    Birthday time.Time `xlsx:"Birthday,col=D,numfmt=yyyy-mm-dd"`
+//	    Note     string    `xlsx:",omitempty"`
+//	    internal int
+//	}
+//	err := f.MarshalRows("Sheet1", []Employee{
+//	    {Name: "Alice", Salary: 8000, Birthday: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)},
+//	    {Name: "Bob", Salary: 7500.5, Birthday: time.Date(1992, 6, 15, 0, 0, 0, 0, time.UTC), Note: "Intern"},
+//	})
+func (f *File) MarshalRows(sheet string, v interface{}, opts ...MarshalOptions) error {
+	val, typ, err := marshalRowsType(v)
+	if err != nil {
+		return err
+	}
+	options, col, row, err := getMarshalOptions(1, opts...)
+	if err != nil {
+		return err
+	}
+	fields, err := parseMarshalFields(typ, col)
+	if err != nil {
+		return err
+	}
+	styles, err := f.marshalStyles(fields)
+	if err != nil {
+		return err
+	}
+	if !options.NoHeader {
+		for _, field := range fields {
+			cell, err := CoordinatesToCellName(field.col, row)
+			if err != nil {
+				return err
+			}
+			if err = f.SetCellValue(sheet, cell, field.header); err != nil {
+				return err
+			}
+			if options.HeaderStyleID != 0 {
+				if err = f.SetCellStyle(sheet, cell, cell, options.HeaderStyleID); err != nil {
+					return err
+				}
+			}
+		}
+		row++
+	}
+	for i := 0; i < val.Len(); i++ {
+		for _, field := range fields {
+			value, err := field.cellValue(val.Index(i))
+			if err != nil {
+				return err
+			}
+			cell, err := CoordinatesToCellName(field.col, row+i)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				continue
+			}
+			if err = f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+		}
+	}
+	if val.Len() == 0 {
+		return nil
+	}
+	for i, field := range fields {
+		if styles[i] == 0 {
+			continue
+		}
+		top, _ := CoordinatesToCellName(field.col, row)
+		bottom, _ := CoordinatesToCellName(field.col, row+val.Len()-1)
+		if err = f.SetCellStyle(sheet, top, bottom, styles[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalRows provides a function to write a slice of structs to the
+// worksheet rows by the stream writer, the struct tags and the options are
+// the same as the File's MarshalRows function. The rows will be written
+// starting from the next row of the last written row by default, and this
+// function can be called multiple times for writing the rows in batches. Set
+// the NoHeader option for the subsequent batches to avoid writing the header
+// row again. For example:
+//
+//	sw, err := f.NewStreamWriter("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.MarshalRows(employees); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.MarshalRows(moreEmployees, excelize.MarshalOptions{NoHeader: true}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (sw *StreamWriter) MarshalRows(v interface{}, opts ...MarshalOptions) error {
+	val, typ, err := marshalRowsType(v)
+	if err != nil {
+		return err
+	}
+	options, col, row, err := getMarshalOptions(sw.rows+1, opts...)
+	if err != nil {
+		return err
+	}
+	fields, err := parseMarshalFields(typ, col)
+	if err != nil {
+		return err
+	}
+	styles, err := sw.file.marshalStyles(fields)
+	if err != nil {
+		return err
+	}
+	minCol, maxCol := col, col
+	for _, field := range fields {
+		if field.col < minCol {
+			minCol = field.col
+		}
+		if field.col > maxCol {
+			maxCol = field.col
+		}
+	}
+	setRow := func(row int, values func(i int) (Cell, error)) error {
+		cells := make([]interface{}, maxCol-minCol+1)
+		for i, field := range fields {
+			c, err := values(i)
+			if err != nil {
+				return err
+			}
+			if c.Value != nil || c.StyleID != 0 {
+				cells[field.col-minCol] = c
+			}
+		}
+		cell, err := CoordinatesToCellName(minCol, row)
+		if err != nil {
+			return err
+		}
+		return sw.SetRow(cell, cells)
+	}
+	if !options.NoHeader {
+		if err = setRow(row, func(i int) (Cell, error) {
+			return Cell{StyleID: options.HeaderStyleID, Value: fields[i].header}, nil
+		}); err != nil {
+			return err
+		}
+		row++
+	}
+	for r := 0; r < val.Len(); r++ {
+		elem := val.Index(r)
+		if err = setRow(row+r, func(i int) (Cell, error) {
+			value, err := fields[i].cellValue(elem)
+			return Cell{StyleID: styles[i], Value: value}, err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalRows provides a function to read the worksheet rows into a slice
+// of structs by given worksheet name, the pointer to the slice and the
+// optional settings. The struct tags are the same as the MarshalRows
+// function, the fields will be mapped to the columns by the column letter in
+// the tag, or by the header name in the header row. The number format of the
+// cells will be applied for the string fields, and the raw cell values will
+// be converted for the other types of fields, the empty rows will be skipped.
+// The field type which implements the CellUnmarshaler interface will be
+// converted by the custom converter. When the cell value can't be converted
+// to the type of the field, an ErrCellUnmarshal error with the cell reference
+// will be returned. For example, read the employees from the worksheet named
+// 'Sheet1':
+//
+//	var employees []Employee
+//	if err := f.UnmarshalRows("Sheet1", &employees); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) UnmarshalRows(sheet string, v interface{}, opts ...MarshalOptions) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return ErrUnmarshalRows
+	}
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+	typ := elemType
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return ErrUnmarshalRows
+	}
+	options, col, startRow, err := getMarshalOptions(1, opts...)
+	if err != nil {
+		return err
+	}
+	fields, err := parseMarshalFields(typ, col)
+	if err != nil {
+		return err
+	}
+	date1904, err := f.date1904()
+	if err != nil {
+		return err
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	columns := map[int]*marshalField{}
+	if options.NoHeader {
+		for i := range fields {
+			columns[fields[i].col] = &fields[i]
+		}
+	}
+	for row := 1; rows.Next(); row++ {
+		if row < startRow {
+			continue
+		}
+		cells, err := rows.Cells()
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if row == startRow && !options.NoHeader {
+			f.mapHeaderColumns(fields, cells, col, columns)
+			continue
+		}
+		elem := reflect.New(typ).Elem()
+		var ok bool
+		for _, c := range cells {
+			cellCol, _, err := CellNameToCoordinates(c.Cell)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+			field, exist := columns[cellCol]
+			if !exist || c.Value == "" {
+				continue
+			}
+			if err = f.unmarshalCell(elem.FieldByIndex(field.index), c, date1904); err != nil {
+				_ = rows.Close()
+				return ErrCellUnmarshal{Cell: c.Cell, Field: field.name, Err: err}
+			}
+			ok = true
+		}
+		if !ok {
+			continue
+		}
+		if elemType.Kind() == reflect.Ptr {
+			elem = elem.Addr()
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	ptr.Elem().Set(slice)
+	return rows.Close()
+}
+
+// mapHeaderColumns provides a function to map the column numbers to the
+// struct fields by given header row cells, the fields with column letter in
+// the tag will be mapped to the specified column.
+func (f *File) mapHeaderColumns(fields []marshalField, cells []RowCell, startCol int, columns map[int]*marshalField) {
+	headers := map[string]int{}
+	for _, c := range cells {
+		col, _, err := CellNameToCoordinates(c.Cell)
+		if err != nil || col < startCol {
+			continue
+		}
+		if _, ok := headers[c.Value]; !ok {
+			headers[c.Value] = col
+		}
+	}
+	for i := range fields {
+		if fields[i].fixedCol {
+			columns[fields[i].col] = &fields[i]
+			continue
+		}
+		if col, ok := headers[fields[i].header]; ok {
+			columns[col] = &fields[i]
+			continue
+		}
+		for header, col := range headers {
+			if strings.EqualFold(header, fields[i].header) {
+				columns[col] = &fields[i]
+				break
+			}
+		}
+	}
+}
+
+// date1904 returns if the workbook uses the 1904 date system.
+func (f *File) date1904() (bool, error) {
+	wb, err := f.workbookReader()
+	if err != nil || wb == nil || wb.WorkbookPr == nil {
+		return false, err
+	}
+	return wb.WorkbookPr.Date1904, err
+}
+
+// unmarshalCell provides a function to convert the cell value to the given
+// struct field value.
+func (f *File) unmarshalCell(val reflect.Value, c RowCell, date1904 bool) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		if val.Type().Implements(cellUnmarshalerType) {
+			return val.Interface().(CellUnmarshaler).UnmarshalCell(c.Value)
+		}
+		return f.unmarshalCell(val.Elem(), c, date1904)
+	}
+	if reflect.PtrTo(val.Type()).Implements(cellUnmarshalerType) {
+		return val.Addr().Interface().(CellUnmarshaler).UnmarshalCell(c.Value)
+	}
+	if val.Type() == timeType {
+		t, err := parseCellTime(c.Value, date1904)
+		if err == nil {
+			val.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+	switch val.Kind() {
+	case reflect.String:
+		value := c.Value
+		if c.Type != CellTypeSharedString && c.Type != CellTypeInlineString {
+			var err error
+			if value, err = f.formattedValue(&xlsxC{S: c.StyleID, V: c.Value}, false, c.Type); err != nil {
+				return err
+			}
+		}
+		val.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(c.Value)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Type() == reflect.TypeOf(time.Duration(0)) {
+			if days, err := strconv.ParseFloat(c.Value, 64); err == nil {
+				// The duration is stored as the fraction of days in single
+				// precision, so round it to the nearest second
+				val.SetInt(int64(math.Round(days*86400)) * int64(time.Second))
+				return nil
+			}
+			d, err := time.ParseDuration(c.Value)
+			if err != nil {
+				return err
+			}
+			val.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(c.Value, 10, val.Type().Bits())
+		if err != nil {
+			fl, e := strconv.ParseFloat(c.Value, 64)
+			if e != nil || fl != float64(int64(fl)) {
+				return err
+			}
+			n = int64(fl)
+			if val.OverflowInt(n) {
+				return err
+			}
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(c.Value, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		fl, err := strconv.ParseFloat(c.Value, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(fl)
+	default:
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// parseCellTime provides a function to parse the cell value as time, the
+// value can be the Excel serial number or the RFC3339 and ISO 8601 formatted
+// date time string.
+func parseCellTime(value string, date1904 bool) (time.Time, error) {
+	if fl, err := strconv.ParseFloat(value, 64); err == nil {
+		return ExcelDateToTime(fl, date1904)
+	}
+	var (
+		t   time.Time
+		err error
+	)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, err
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,136 @@
+package excelize
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type marshalLevel int
+
+func (l marshalLevel) MarshalCell() (interface{}, error) {
+	if l < 0 {
+		return nil, errors.New("invalid level")
+	}
+	return "L" + strconv.Itoa(int(l)), nil
+}
+
+func (l *marshalLevel) UnmarshalCell(value string) error {
+	n, err := strconv.Atoi(strings.TrimPrefix(value, "L"))
+	*l = marshalLevel(n)
+	return err
+}
+
+type marshalBase struct {
+	ID int `xlsx:"No."`
+}
+
+type marshalEmployee struct {
+	marshalBase
+	Name     string        `xlsx:"Employee Name"`
+	Salary   float64       `xlsx:"Salary,numfmt=#,##0.00"`
+	Birthday time.Time     `xlsx:"Birthday,col=F,numfmt=yyyy-mm-dd"`
+	Level    marshalLevel  `xlsx:"Level"`
+	Manager  *string       `xlsx:"Manager"`
+	Active   bool          `xlsx:"Active,omitempty"`
+	Shift    time.Duration `xlsx:"Shift,col=J"`
+	Skipped  string        `xlsx:"-"`
+	internal int
+}
+
+func TestMarshalRows(t *testing.T) {
+	manager := "Alice"
+	employees := []marshalEmployee{
+		{marshalBase{1}, "Alice", 8000, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), 3, nil, true, 8 * time.Hour, "x", 1},
+		{marshalBase{2}, "Bob", 7500.5, time.Date(1992, 6, 15, 0, 0, 0, 0, time.UTC), 1, &manager, false, 4 * time.Hour, "y", 2},
+	}
+	f := NewFile()
+	assert.NoError(t, f.MarshalRows("Sheet1", employees))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"No.", "Employee Name", "Salary", "", "", "Birthday", "Level", "Manager", "Active", "Shift"},
+		{"1", "Alice", "8,000.00", "", "", "1990-01-01", "L3", "", "TRUE", "08:00:00"},
+		{"2", "Bob", "7,500.50", "", "", "1992-06-15", "L1", "Alice", "", "04:00:00"},
+	}, rows)
+
+	var result []marshalEmployee
+	assert.NoError(t, f.UnmarshalRows("Sheet1", &result))
+	for i := range employees {
+		employees[i].Skipped, employees[i].internal = "", 0
+	}
+	assert.Equal(t, employees, result)
+
+	// Test marshal rows with start cell, header style and pointer elements
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.EqualError(t, f.MarshalRows("Sheet2", []*marshalEmployee{&employees[1]}, MarshalOptions{StartCell: "B3", HeaderStyleID: styleID}), "sheet Sheet2 does not exist")
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.MarshalRows("Sheet2", []*marshalEmployee{&employees[1], nil}, MarshalOptions{StartCell: "B3", HeaderStyleID: styleID}))
+	cellStyle, err := f.GetCellStyle("Sheet2", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyle)
+	var ptrs []*marshalEmployee
+	assert.NoError(t, f.UnmarshalRows("Sheet2", &ptrs, MarshalOptions{StartCell: "B3"}))
+	assert.Equal(t, []*marshalEmployee{&employees[1]}, ptrs)
+
+	// Test unmarshal rows without header
+	assert.NoError(t, f.MarshalRows("Sheet2", []marshalEmployee{employees[0]}, MarshalOptions{StartCell: "A10", NoHeader: true}))
+	result = nil
+	assert.NoError(t, f.UnmarshalRows("Sheet2", &result, MarshalOptions{StartCell: "A10", NoHeader: true}))
+	assert.Equal(t, employees[:1], result)
+
+	// Test marshal rows with invalid values
+	assert.Equal(t, ErrMarshalRows, f.MarshalRows("Sheet1", nil))
+	assert.Equal(t, ErrMarshalRows, f.MarshalRows("Sheet1", []int{1}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.MarshalRows("Sheet1", employees, MarshalOptions{StartCell: "A"}))
+	assert.EqualError(t, f.MarshalRows("Sheet1", []marshalEmployee{{Level: -1}}), "invalid level")
+	assert.EqualError(t, f.MarshalRows("SheetN", employees), "sheet SheetN does not exist")
+	assert.Equal(t, newInvalidColumnNameError("-"), f.MarshalRows("Sheet1", []struct {
+		A int `xlsx:",col=-"`
+	}{}))
+	assert.EqualError(t, f.MarshalRows("Sheet1", []struct {
+		A int `xlsx:",style=x"`
+	}{}), `strconv.Atoi: parsing "x": invalid syntax`)
+	assert.Equal(t, newInvalidStyleID(100), f.MarshalRows("Sheet1", []struct {
+		A int `xlsx:",style=100,numfmt=0.00"`
+	}{}))
+
+	// Test unmarshal rows with invalid values
+	assert.Equal(t, ErrUnmarshalRows, f.UnmarshalRows("Sheet1", result))
+	assert.Equal(t, ErrUnmarshalRows, f.UnmarshalRows("Sheet1", &[]int{}))
+	assert.EqualError(t, f.UnmarshalRows("SheetN", &result), "sheet SheetN does not exist")
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "unknown"))
+	err = f.UnmarshalRows("Sheet1", &result)
+	assert.Equal(t, ErrCellUnmarshal{Cell: "C3", Field: "Salary", Err: &strconv.NumError{Func: "ParseFloat", Num: "unknown", Err: strconv.ErrSyntax}}, err)
+	assert.EqualError(t, err, `cannot unmarshal cell C3 into field Salary: strconv.ParseFloat: parsing "unknown": invalid syntax`)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamMarshalRows(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	employees := []marshalEmployee{
+		{Name: "Alice", Salary: 8000, Birthday: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), Level: 3},
+		{Name: "Bob", Salary: 7500.5, Birthday: time.Date(1992, 6, 15, 0, 0, 0, 0, time.UTC), Level: 1, Active: true},
+	}
+	assert.NoError(t, sw.MarshalRows(employees[:1]))
+	assert.NoError(t, sw.MarshalRows(employees[1:], MarshalOptions{NoHeader: true}))
+	assert.Equal(t, ErrMarshalRows, sw.MarshalRows("employees"))
+	assert.EqualError(t, sw.MarshalRows(employees, MarshalOptions{StartCell: "A1"}), newStreamSetRowError(1).Error())
+	assert.EqualError(t, sw.MarshalRows([]marshalEmployee{{Level: -1}}, MarshalOptions{NoHeader: true}), "invalid level")
+	assert.NoError(t, sw.Flush())
+	var result []marshalEmployee
+	assert.NoError(t, f.UnmarshalRows("Sheet1", &result))
+	assert.Equal(t, employees, result)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamMarshalRows.xlsx")))
+	assert.NoError(t, f.Close())
+}
@@ -4666,6 +4666,21 @@ func (f *File) langNumFmtFuncEnUS(numFmtID int) string {
 	return ""
 }
 
+// isDateTimeNumFmt returns if the number format code contains the date or
+// time tokens, the elapsed time format will not be considered as the date or
+// time number format.
+func isDateTimeNumFmt(fmtCode string) bool {
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(fmtCode) {
+		for _, token := range section.Items {
+			if token.TType == nfp.TokenTypeDateTimes {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // checkDateTimePattern check and validate date and time options field value.
 func (f *File) checkDateTimePattern() error {
 	for _, pattern := range []string{f.options.LongDatePattern, f.options.LongTimePattern, f.options.ShortDatePattern} {
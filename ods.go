@@ -0,0 +1,1864 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xuri/efp"
+	"github.com/xuri/nfp"
+)
+
+const (
+	odsExtension   = ".ods"
+	odsMaxStyleRef = 8
+)
+
+// odsLengthUnits defined the points of the length units in the OpenDocument
+// Spreadsheet (ODS) package.
+var odsLengthUnits = map[string]float64{
+	"cm": 72 / 2.54, "mm": 72 / 25.4, "in": 72, "pc": 12, "pt": 1, "px": 0.75,
+}
+
+// odsBorderStyles defined the width and line style of the border by the
+// index of the cell border style.
+var odsBorderStyles = [][]string{
+	{}, {"0.74pt", "solid"}, {"1.76pt", "solid"}, {"0.74pt", "dashed"},
+	{"0.74pt", "dotted"}, {"2.49pt", "solid"}, {"2.01pt", "double"},
+	{"0.26pt", "solid"}, {"1.76pt", "dashed"}, {"0.74pt", "dashed"},
+	{"1.76pt", "dashed"}, {"0.74pt", "dotted"}, {"1.76pt", "dotted"},
+	{"1.76pt", "dashed"},
+}
+
+// odsHorizontalAlignments defined the horizontal alignment of the cell and
+// the text alignment of the paragraph.
+var odsHorizontalAlignments = map[string]string{
+	"center": "center", "centerContinuous": "center", "distributed": "justify",
+	"fill": "start", "justify": "justify", "left": "start", "right": "end",
+}
+
+// odsVerticalAlignments defined the vertical alignment of the cell.
+var odsVerticalAlignments = map[string]string{
+	"bottom": "bottom", "center": "middle", "distributed": "middle",
+	"justify": "middle", "top": "top",
+}
+
+// odsNumFmtColors defined the colors of the number format.
+var odsNumFmtColors = map[string]string{
+	"#000000": "[Black]", "#0000ff": "[Blue]", "#00ffff": "[Cyan]",
+	"#00ff00": "[Green]", "#ff00ff": "[Magenta]", "#ff0000": "[Red]",
+	"#ffffff": "[White]", "#ffff00": "[Yellow]",
+}
+
+// odsFunctionNames defined the names of the functions in the OpenFormula
+// which are different from the spreadsheet.
+var odsFunctionNames = map[string]string{
+	"LEGACY.CHIDIST": "CHIDIST", "LEGACY.CHIINV": "CHIINV",
+	"LEGACY.FDIST": "FDIST", "LEGACY.FINV": "FINV",
+	"LEGACY.NORMSDIST": "NORMSDIST", "LEGACY.NORMSINV": "NORMSINV",
+	"LEGACY.TDIST": "TDIST", "LEGACY.TINV": "TINV",
+}
+
+// isOpenDocument provides a function to check if the given ZIP archive is an
+// OpenDocument Spreadsheet (ODS) package by the mimetype entry.
+func isOpenDocument(zr *zip.Reader) bool {
+	for _, file := range zr.File {
+		if file.Name == "mimetype" {
+			content, err := readFile(file)
+			return err == nil && strings.TrimSpace(string(content)) == ContentTypeOpenDocumentSpreadsheet
+		}
+	}
+	return false
+}
+
+// odsImporter directly maps the styles of the OpenDocument Spreadsheet (ODS)
+// package for converting the spreadsheet to the workbook.
+type odsImporter struct {
+	f        *File
+	styles   map[string]decodeODSStyle
+	numFmts  map[string]decodeODSNumberStyle
+	styleIDs map[string]int
+}
+
+// convertOpenDocument provides a function to convert the OpenDocument
+// Spreadsheet (ODS) package to the workbook package. The sheet names, cell
+// values, formulas, merged cells, column widths, row heights, basic styles
+// and named ranges will be converted, the other elements, such as the charts
+// and the comments will be ignored.
+func (f *File) convertOpenDocument(zr *zip.Reader) ([]byte, error) {
+	var (
+		doc       decodeODSDocument
+		unzipSize int64
+	)
+	for _, name := range []string{"styles.xml", "content.xml"} {
+		for _, file := range zr.File {
+			if file.Name != name {
+				continue
+			}
+			if unzipSize += file.FileInfo().Size(); unzipSize > f.options.UnzipSizeLimit {
+				return nil, newUnzipSizeLimitError(f.options.UnzipSizeLimit)
+			}
+			content, err := readFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if err = f.xmlNewDecoder(bytes.NewReader(content)).Decode(&doc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(doc.Spreadsheet.Tables) == 0 {
+		return nil, ErrWorkbookFileFormat
+	}
+	imp := odsImporter{
+		f:        NewFile(),
+		styles:   map[string]decodeODSStyle{},
+		numFmts:  map[string]decodeODSNumberStyle{},
+		styleIDs: map[string]int{},
+	}
+	defer imp.f.Close()
+	for _, styles := range []decodeODSStyles{doc.Styles, doc.AutomaticStyles} {
+		for _, style := range styles.Style {
+			imp.styles[style.Name] = style
+		}
+		for _, numFmt := range styles.NumberStyles {
+			if strings.HasSuffix(numFmt.XMLName.Local, "-style") {
+				imp.numFmts[numFmt.Name] = numFmt
+			}
+		}
+	}
+	for i, table := range doc.Spreadsheet.Tables {
+		var err error
+		if i == 0 {
+			err = imp.f.SetSheetName("Sheet1", table.Name)
+		} else {
+			_, err = imp.f.NewSheet(table.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = imp.table(&table); err != nil {
+			return nil, err
+		}
+		if props, _ := imp.properties(table.StyleName); props["display"] == "false" && i > 0 {
+			if err = imp.f.SetSheetVisible(table.Name, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, table := range doc.Spreadsheet.Tables {
+		if err := imp.definedNames(table.NamedExpressions, table.Name); err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			if err := imp.definedNames(doc.Spreadsheet.NamedExpressions, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buf, err := imp.f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// definedNames provides a function to convert the named ranges and named
+// expressions to the defined names by given scope.
+func (imp *odsImporter) definedNames(names decodeODSNamedExpressions, scope string) error {
+	for _, name := range names.NamedRange {
+		if err := imp.f.SetDefinedName(&DefinedName{
+			Name: name.Name, RefersTo: openFormulaRefToRef(name.CellRangeAddress), Scope: scope,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, name := range names.NamedExpression {
+		if err := imp.f.SetDefinedName(&DefinedName{
+			Name: name.Name, RefersTo: openFormulaToFormula(name.Expression), Scope: scope,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// table provides a function to convert the columns, rows and cells of the
+// table to the worksheet.
+func (imp *odsImporter) table(table *decodeODSTable) error {
+	var (
+		sheet        = table.Name
+		col          = 1
+		defaultStyle = map[int]string{}
+	)
+	for _, column := range table.Columns {
+		if col > MaxColumns {
+			break
+		}
+		count := maxInt(column.Repeated, 1)
+		last := minInt(col+count-1, MaxColumns)
+		start, _ := ColumnNumberToName(col)
+		end, _ := ColumnNumberToName(last)
+		props, _ := imp.properties(column.StyleName)
+		if pt, ok := parseODSLength(props["column-width"]); ok {
+			width := (pt*96/72 - 5) / 7
+			if err := imp.f.SetColWidth(sheet, start, end, math.Round(math.Max(width, 0)*100)/100); err != nil {
+				return err
+			}
+		}
+		if column.Visibility == "collapse" {
+			if err := imp.f.SetColVisible(sheet, start+":"+end, false); err != nil {
+				return err
+			}
+		}
+		if column.DefaultCellStyleName != "" && column.DefaultCellStyleName != "Default" {
+			for c := col; c <= last; c++ {
+				defaultStyle[c] = column.DefaultCellStyleName
+			}
+		}
+		col = last + 1
+	}
+	row := 1
+	for _, tr := range table.Rows {
+		if row > TotalRows {
+			break
+		}
+		count := maxInt(tr.Repeated, 1)
+		if !tr.hasContent() {
+			row += count
+			continue
+		}
+		for last := minInt(row+count-1, TotalRows); row <= last; row++ {
+			if err := imp.row(sheet, row, &tr, defaultStyle); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasContent provides a function to check if the row contains any cell with
+// the value, formula, style or merged cells.
+func (row *decodeODSRow) hasContent() bool {
+	if row.Visibility == "collapse" {
+		return true
+	}
+	for _, cell := range row.Cells {
+		if cell.XMLName.Local == "table-cell" && (cell.ValueType != "" ||
+			cell.Formula != "" || len(cell.Paragraphs) > 0 || cell.StyleName != "" ||
+			cell.ColumnsSpanned > 1 || cell.RowsSpanned > 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// row provides a function to convert the row height, visibility and cells
+// of the table row to the worksheet.
+func (imp *odsImporter) row(sheet string, row int, tr *decodeODSRow, defaultStyle map[int]string) error {
+	props, _ := imp.properties(tr.StyleName)
+	if pt, ok := parseODSLength(props["row-height"]); ok && props["use-optimal-row-height"] != "true" {
+		if err := imp.f.SetRowHeight(sheet, row, math.Round(pt*100)/100); err != nil {
+			return err
+		}
+	}
+	if tr.Visibility == "collapse" {
+		if err := imp.f.SetRowVisible(sheet, row, false); err != nil {
+			return err
+		}
+	}
+	col := 1
+	for _, tc := range tr.Cells {
+		if col > MaxColumns {
+			break
+		}
+		count := maxInt(tc.Repeated, 1)
+		if tc.XMLName.Local != "table-cell" {
+			if tc.XMLName.Local == "covered-table-cell" {
+				col += count
+			}
+			continue
+		}
+		for last := minInt(col+count-1, MaxColumns); col <= last; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			styleName := tc.StyleName
+			if styleName == "" {
+				styleName = defaultStyle[col]
+			}
+			if err := imp.cell(sheet, cell, &tc, styleName); err != nil {
+				return err
+			}
+			if tc.ColumnsSpanned > 1 || tc.RowsSpanned > 1 {
+				bottomRight, err := CoordinatesToCellName(
+					minInt(col+maxInt(tc.ColumnsSpanned, 1)-1, MaxColumns),
+					minInt(row+maxInt(tc.RowsSpanned, 1)-1, TotalRows))
+				if err != nil {
+					return err
+				}
+				if err = imp.f.MergeCell(sheet, cell, bottomRight); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// text returns the text of the cell, the paragraphs will be joined with the
+// line breaks.
+func (tc *decodeODSCell) text() string {
+	var lines []string
+	for _, p := range tc.Paragraphs {
+		lines = append(lines, p.Text)
+	}
+	text := strings.Join(lines, "\n")
+	if text == "" && tc.StringValue != nil {
+		text = *tc.StringValue
+	}
+	return text
+}
+
+// cell provides a function to convert the value, formula and style of the
+// table cell to the cell of the worksheet.
+func (imp *odsImporter) cell(sheet, cell string, tc *decodeODSCell, styleName string) error {
+	var (
+		value  interface{}
+		cellT  string
+		numFmt int
+		text   = tc.text()
+	)
+	switch tc.ValueType {
+	case "float", "percentage", "currency":
+		if tc.CalcExtType == "error" {
+			value, cellT = text, "e"
+			break
+		}
+		if num, err := strconv.ParseFloat(tc.Value, 64); err == nil {
+			value = num
+		}
+	case "date":
+		if t, err := parseODSDate(tc.DateValue); err == nil {
+			date1904 := false
+			if wb, err := imp.f.workbookReader(); err == nil && wb.WorkbookPr != nil {
+				date1904 = wb.WorkbookPr.Date1904
+			}
+			num, _ := timeToExcelTime(t, date1904)
+			value, numFmt = num, 22
+			if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+				numFmt = 14
+			}
+		}
+	case "time":
+		if d, err := parseODSDuration(tc.TimeValue); err == nil {
+			value, numFmt = d.Hours()/24, 21
+			if d >= 24*time.Hour {
+				numFmt = 46
+			}
+		}
+	case "boolean":
+		value = tc.BooleanValue == "true"
+	default:
+		if value, cellT = text, "str"; tc.CalcExtType == "error" {
+			cellT = "e"
+		}
+	}
+	if tc.Formula != "" {
+		if err := imp.f.SetCellFormula(sheet, cell, openFormulaToFormula(tc.Formula)); err != nil {
+			return err
+		}
+		ws, err := imp.f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		c, _, _, err := ws.prepareCell(cell)
+		if err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case float64:
+			c.T, c.V = "", strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			c.T, c.V = "b", "0"
+			if v {
+				c.V = "1"
+			}
+		case string:
+			c.T, c.V = cellT, v
+		}
+	} else if value != nil && value != "" {
+		if err := imp.f.SetCellValue(sheet, cell, value); err != nil {
+			return err
+		}
+	}
+	styleID, err := imp.style(styleName, numFmt)
+	if err != nil || styleID == 0 {
+		return err
+	}
+	return imp.f.SetCellStyle(sheet, cell, cell, styleID)
+}
+
+// properties provides a function to get the merged formatting properties and
+// the data style name of the style by given style name, the properties of
+// the parent styles will be inherited.
+func (imp *odsImporter) properties(name string) (map[string]string, string) {
+	var (
+		chain         []decodeODSStyle
+		props         = map[string]string{}
+		dataStyleName string
+	)
+	for i := 0; i < odsMaxStyleRef; i++ {
+		style, ok := imp.styles[name]
+		if !ok {
+			break
+		}
+		chain = append(chain, style)
+		name = style.ParentStyleName
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].DataStyleName != "" {
+			dataStyleName = chain[i].DataStyleName
+		}
+		for _, properties := range chain[i].Properties {
+			for _, attr := range properties.Attrs {
+				if properties.XMLName.Local == "text-properties" && attr.Name.Local == "background-color" {
+					continue
+				}
+				props[attr.Name.Local] = attr.Value
+			}
+		}
+	}
+	return props, dataStyleName
+}
+
+// style provides a function to get the cell style index by given style name
+// and the default number format, the style will be created on first use.
+func (imp *odsImporter) style(name string, numFmt int) (int, error) {
+	key := name + "|" + strconv.Itoa(numFmt)
+	if styleID, ok := imp.styleIDs[key]; ok {
+		return styleID, nil
+	}
+	props, dataStyleName := imp.properties(name)
+	if len(props) == 0 && dataStyleName == "" && numFmt == 0 {
+		return 0, nil
+	}
+	style := newODSCellStyle(props)
+	if code := imp.numberFormat(dataStyleName, 0); code != "" && code != "General" {
+		style.CustomNumFmt = &code
+	} else {
+		style.NumFmt = numFmt
+	}
+	styleID, err := imp.f.NewStyle(style)
+	imp.styleIDs[key] = styleID
+	return styleID, err
+}
+
+// newODSCellStyle provides a function to convert the formatting properties
+// of the cell style to the style definition.
+func newODSCellStyle(props map[string]string) *Style {
+	style := &Style{}
+	if color := parseODSColor(props["background-color"]); color != "" {
+		style.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{color}}
+	}
+	for i, attr := range []string{"border-left", "border-right", "border-top", "border-bottom", "diagonal-bl-tr", "diagonal-tl-br"} {
+		value, ok := props[attr]
+		if !ok && i < 4 {
+			value = props["border"]
+		}
+		if border, ok := parseODSBorder(value); ok {
+			border.Type = styleBorderTypes[i]
+			style.Border = append(style.Border, border)
+		}
+	}
+	var alignment Alignment
+	switch props["text-align"] {
+	case "start", "left":
+		alignment.Horizontal = "left"
+	case "center", "justify":
+		alignment.Horizontal = props["text-align"]
+	case "end", "right":
+		alignment.Horizontal = "right"
+	}
+	if vertical := props["vertical-align"]; vertical == "top" || vertical == "bottom" {
+		alignment.Vertical = vertical
+	} else if vertical == "middle" {
+		alignment.Vertical = "center"
+	}
+	alignment.WrapText = props["wrap-option"] == "wrap"
+	alignment.ShrinkToFit = props["shrink-to-fit"] == "true"
+	if angle, err := strconv.Atoi(strings.TrimSuffix(props["rotation-angle"], "deg")); err == nil {
+		if angle > 0 && angle <= 90 {
+			alignment.TextRotation = angle
+		}
+		if angle >= 270 && angle < 360 {
+			alignment.TextRotation = 90 + 360 - angle
+		}
+	}
+	if alignment != (Alignment{}) {
+		style.Alignment = &alignment
+	}
+	var font Font
+	if font.Family = props["font-name"]; font.Family == "" {
+		font.Family = strings.Trim(props["font-family"], "'\"")
+	}
+	if size, ok := parseODSLength(props["font-size"]); ok && strings.HasSuffix(props["font-size"], "pt") {
+		font.Size = size
+	}
+	if weight := props["font-weight"]; weight == "bold" {
+		font.Bold = true
+	} else if n, err := strconv.Atoi(weight); err == nil && n >= 600 {
+		font.Bold = true
+	}
+	font.Italic = props["font-style"] == "italic" || props["font-style"] == "oblique"
+	font.Color = parseODSColor(props["color"])
+	if underline := props["text-underline-style"]; underline != "" && underline != "none" {
+		if font.Underline = "single"; props["text-underline-type"] == "double" {
+			font.Underline = "double"
+		}
+	}
+	font.Strike = props["text-line-through-style"] != "" && props["text-line-through-style"] != "none"
+	if position := props["text-position"]; strings.HasPrefix(position, "super") {
+		font.VertAlign = "superscript"
+	} else if strings.HasPrefix(position, "sub") {
+		font.VertAlign = "subscript"
+	}
+	if font != (Font{}) {
+		style.Font = &font
+	}
+	return style
+}
+
+// numberFormat provides a function to convert the data style to the number
+// format code by given data style name.
+func (imp *odsImporter) numberFormat(name string, depth int) string {
+	numFmt, ok := imp.numFmts[name]
+	if !ok || depth > odsMaxStyleRef {
+		return ""
+	}
+	var (
+		sb               strings.Builder
+		color, positive  string
+		general, elapsed = false, numFmt.TruncateOnOverflow == "false"
+	)
+	for _, part := range numFmt.Parts {
+		switch part.XMLName.Local {
+		case "map":
+			if strings.HasPrefix(strings.ReplaceAll(part.Condition, " ", ""), "value()>") {
+				positive = imp.numberFormat(part.ApplyStyleName, depth+1)
+			}
+		case "text-properties":
+			color = odsNumFmtColors[strings.ToLower(part.Color)]
+		case "number":
+			if part.DecimalPlaces == nil && part.MinIntegerDigits == nil && !part.Grouping {
+				general = true
+				sb.WriteString("General")
+				continue
+			}
+			sb.WriteString(odsNumberCode(&part))
+		case "scientific-number":
+			sb.WriteString(odsNumberCode(&part) + "E+" + strings.Repeat("0", maxInt(intValue(part.MinExponentDigits, 2), 1)))
+		case "fraction":
+			if part.MinIntegerDigits != nil {
+				sb.WriteString("# ")
+			}
+			sb.WriteString(strings.Repeat("?", maxInt(part.MinNumeratorDigits, 1)) + "/")
+			if part.DenominatorValue != "" {
+				sb.WriteString(part.DenominatorValue)
+			} else {
+				sb.WriteString(strings.Repeat("?", maxInt(part.MinDenominatorDigits, 1)))
+			}
+		case "currency-symbol":
+			sb.WriteString("[$" + part.Text + "]")
+		case "text":
+			sb.WriteString(odsNumFmtLiteral(part.Text))
+		case "text-content":
+			sb.WriteString("@")
+		default:
+			sb.WriteString(odsDateTimeCode(&part, elapsed))
+		}
+	}
+	code := color + sb.String()
+	if general && code == "General" && positive == "" {
+		return ""
+	}
+	if positive != "" {
+		code = positive + ";" + code
+	}
+	return code
+}
+
+// intValue returns the value of the integer pointer, the default value will
+// be returned if the pointer is nil.
+func intValue(n *int, defaultValue int) int {
+	if n == nil {
+		return defaultValue
+	}
+	return *n
+}
+
+// odsNumberCode provides a function to get the number format code of the
+// integer and decimal parts by given number element of the data style.
+func odsNumberCode(part *decodeODSNumberPart) string {
+	var (
+		digits  = intValue(part.MinIntegerDigits, 1)
+		integer = strings.Repeat("0", digits)
+	)
+	if part.Grouping {
+		integer = "#,##" + strings.Repeat("0", maxInt(digits, 1))
+	} else if digits == 0 {
+		integer = "#"
+	}
+	if places := intValue(part.DecimalPlaces, 0); places > 0 {
+		return integer + "." + strings.Repeat("0", places)
+	}
+	return integer
+}
+
+// odsDateTimeCode provides a function to get the number format code by given
+// date and time element of the data style.
+func odsDateTimeCode(part *decodeODSNumberPart, elapsed bool) string {
+	long := part.Style == "long"
+	pick := func(short, longCode string) string {
+		if long {
+			return longCode
+		}
+		return short
+	}
+	switch part.XMLName.Local {
+	case "day":
+		return pick("d", "dd")
+	case "day-of-week":
+		return pick("ddd", "dddd")
+	case "month":
+		if part.Textual {
+			return pick("mmm", "mmmm")
+		}
+		return pick("m", "mm")
+	case "year":
+		return pick("yy", "yyyy")
+	case "hours":
+		if elapsed {
+			return pick("[h]", "[hh]")
+		}
+		return pick("h", "hh")
+	case "minutes":
+		return pick("m", "mm")
+	case "seconds":
+		if places := intValue(part.DecimalPlaces, 0); places > 0 {
+			return pick("s", "ss") + "." + strings.Repeat("0", places)
+		}
+		return pick("s", "ss")
+	case "am-pm":
+		return "AM/PM"
+	}
+	return ""
+}
+
+// odsNumFmtLiteral provides a function to get the literal text of the number
+// format code, the text will be quoted if it contains any character other
+// than spaces, separators and the percent sign.
+func odsNumFmtLiteral(text string) string {
+	if strings.Trim(text, " -/:,.()%") == "" {
+		return text
+	}
+	return `"` + strings.ReplaceAll(text, `"`, `"\""`) + `"`
+}
+
+// parseODSLength provides a function to convert the length with the unit to
+// points.
+func parseODSLength(length string) (float64, bool) {
+	length = strings.TrimSpace(length)
+	for unit, pt := range odsLengthUnits {
+		if strings.HasSuffix(length, unit) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(length, unit), 64)
+			return value * pt, err == nil
+		}
+	}
+	return 0, false
+}
+
+// parseODSColor provides a function to convert the color in the #RRGGBB
+// format to the RGB hex string, an empty string will be returned for the
+// transparent or invalid color.
+func parseODSColor(color string) string {
+	if len(color) != 7 || color[0] != '#' {
+		return ""
+	}
+	if _, err := strconv.ParseUint(color[1:], 16, 32); err != nil {
+		return ""
+	}
+	return strings.ToUpper(color[1:])
+}
+
+// parseODSBorder provides a function to convert the border property which
+// consists of the width, line style and color to the cell border.
+func parseODSBorder(value string) (Border, bool) {
+	var (
+		border    = Border{Color: "000000"}
+		width     float64
+		lineStyle string
+	)
+	for _, field := range strings.Fields(value) {
+		if color := parseODSColor(field); color != "" {
+			border.Color = color
+		} else if pt, ok := parseODSLength(field); ok {
+			width = pt
+		} else {
+			lineStyle = field
+		}
+	}
+	switch lineStyle {
+	case "", "none", "hidden":
+		return border, false
+	case "solid":
+		border.Style = 5
+		for _, s := range []struct {
+			width float64
+			style int
+		}{{0.5, 7}, {1, 1}, {2, 2}} {
+			if width <= s.width {
+				border.Style = s.style
+				break
+			}
+		}
+	case "dashed":
+		if border.Style = 3; width > 1 {
+			border.Style = 8
+		}
+	case "dotted":
+		border.Style = 4
+	case "double":
+		border.Style = 6
+	default:
+		border.Style = 1
+	}
+	return border, true
+}
+
+// parseODSDate provides a function to parse the date value in the ISO 8601
+// date or date time format.
+func parseODSDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{"2006-01-02T15:04:05.999999999", "2006-01-02T15:04:05", "2006-01-02"} {
+		var t time.Time
+		if t, err = time.Parse(layout, strings.TrimSuffix(value, "Z")); err == nil {
+			return t, err
+		}
+	}
+	return time.Time{}, err
+}
+
+// parseODSDuration provides a function to parse the time value in the ISO
+// 8601 duration format, such as PT12H30M00S.
+func parseODSDuration(value string) (time.Duration, error) {
+	value = strings.TrimPrefix(strings.ToUpper(value), "P")
+	days, rest, ok := strings.Cut(value, "T")
+	if !ok {
+		days, rest = value, ""
+	}
+	var duration time.Duration
+	if days != "" {
+		n, err := strconv.ParseFloat(strings.TrimSuffix(days, "D"), 64)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n * float64(24*time.Hour))
+	}
+	if rest == "" {
+		return duration, nil
+	}
+	d, err := time.ParseDuration(strings.ToLower(rest))
+	return duration + d, err
+}
+
+// skipQuoted returns the position after the closing quote of the quoted text
+// starting at the given position, the doubled quotes will be treated as the
+// escaped quote.
+func skipQuoted(runes []rune, i int, quote rune) int {
+	for j := i + 1; j < len(runes); j++ {
+		if runes[j] != quote {
+			continue
+		}
+		if j+1 < len(runes) && runes[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(runes)
+}
+
+// splitUnquoted provides a function to split the string by given separator
+// outside the single quotes.
+func splitUnquoted(s string, sep rune) []string {
+	var (
+		parts []string
+		runes = []rune(s)
+		start int
+	)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\'' {
+			i = skipQuoted(runes, i, '\'') - 1
+			continue
+		}
+		if runes[i] == sep {
+			parts = append(parts, string(runes[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// unquoteSheetName returns the sheet name without the single quotes.
+func unquoteSheetName(name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
+// openFormulaToFormula provides a function to translate the OpenFormula
+// formula of the OpenDocument Spreadsheet (ODS) to the spreadsheet formula.
+// For example, convert of:=SUM([.A1:.B2];[$Sheet2.C3]) to
+// SUM(A1:B2,Sheet2!C3).
+func openFormulaToFormula(formula string) string {
+	if prefix, rest, ok := strings.Cut(formula, ":="); ok && !strings.ContainsAny(prefix, `"'[(`) {
+		formula = rest
+	}
+	var (
+		sb    strings.Builder
+		runes = []rune(strings.TrimPrefix(formula, "="))
+	)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '"':
+			j := skipQuoted(runes, i, '"')
+			sb.WriteString(string(runes[i:j]))
+			i = j - 1
+		case '[':
+			j := i + 1
+			for j < len(runes) && runes[j] != ']' {
+				if runes[j] == '\'' {
+					j = skipQuoted(runes, j, '\'')
+					continue
+				}
+				j++
+			}
+			sb.WriteString(openFormulaRefToRef(string(runes[i+1 : minInt(j, len(runes))])))
+			i = j
+		case ';', '~':
+			sb.WriteByte(',')
+		case '|':
+			sb.WriteByte(';')
+		case '!':
+			sb.WriteByte(' ')
+		default:
+			if !unicode.IsLetter(r) && r != '_' {
+				sb.WriteRune(r)
+				continue
+			}
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == '_') {
+				j++
+			}
+			name := string(runes[i:j])
+			if j < len(runes) && runes[j] == '(' {
+				name = strings.TrimPrefix(name, "COM.MICROSOFT.")
+				if fn, ok := odsFunctionNames[name]; ok {
+					name = fn
+				}
+			}
+			sb.WriteString(name)
+			i = j - 1
+		}
+	}
+	return sb.String()
+}
+
+// openFormulaRefToRef provides a function to convert the cell reference of
+// the OpenFormula to the spreadsheet cell reference. For example, convert
+// $Sheet1.$A$1:.$B$2 to Sheet1!$A$1:$B$2.
+func openFormulaRefToRef(ref string) string {
+	var sheets, cells []string
+	for _, part := range splitUnquoted(ref, ':') {
+		fields := splitUnquoted(part, '.')
+		cells = append(cells, fields[len(fields)-1])
+		sheets = append(sheets, unquoteSheetName(strings.TrimPrefix(strings.Join(fields[:len(fields)-1], "."), "$")))
+	}
+	if sheets[0] == "" {
+		return strings.Join(cells, ":")
+	}
+	sheet := escapeSheetName(sheets[0])
+	if last := sheets[len(sheets)-1]; last != "" && last != sheets[0] {
+		if sheet = sheets[0] + ":" + last; escapeSheetName(sheets[0]) != sheets[0] || escapeSheetName(last) != last {
+			sheet = "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+		}
+		if cells[0] == cells[len(cells)-1] {
+			cells = cells[:1]
+		}
+	}
+	return sheet + "!" + strings.Join(cells, ":")
+}
+
+// isCellRef returns if the given string is a cell reference, a column or a
+// row reference.
+func isCellRef(ref string, wholeColRow bool) bool {
+	ref = strings.ReplaceAll(ref, "$", "")
+	letters := strings.IndexFunc(ref, func(r rune) bool { return r < 'A' || r > 'Z' && r < 'a' || r > 'z' })
+	if letters == -1 {
+		return wholeColRow && len(ref) <= 3
+	}
+	if letters > 3 {
+		return false
+	}
+	if _, err := strconv.Atoi(ref[letters:]); err != nil || strings.ContainsAny(ref[letters:], "+-") {
+		return false
+	}
+	return letters > 0 || wholeColRow
+}
+
+// refToOpenFormulaRef provides a function to convert the spreadsheet cell
+// reference to the cell reference of the OpenFormula, the defined names will
+// be returned as is. For example, convert Sheet1!$A$1:$B$2 to
+// [$Sheet1.$A$1:.$B$2].
+func refToOpenFormulaRef(ref string) string {
+	var (
+		parts  = splitUnquoted(ref, '!')
+		sheets []string
+		cells  = splitUnquoted(parts[len(parts)-1], ':')
+	)
+	if len(parts) > 2 || len(cells) > 2 {
+		return ref
+	}
+	for _, cell := range cells {
+		if !isCellRef(cell, len(cells) == 2) {
+			return ref
+		}
+	}
+	if len(parts) == 2 {
+		for _, sheet := range splitUnquoted(unquoteSheetName(parts[0]), ':') {
+			sheets = append(sheets, "$"+escapeSheetName(unquoteSheetName(sheet)))
+		}
+		if len(sheets) == 2 && len(cells) == 1 {
+			cells = append(cells, cells[0])
+		}
+	}
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, cell := range cells {
+		if i > 0 {
+			sb.WriteByte(':')
+		}
+		if i < len(sheets) {
+			sb.WriteString(sheets[i])
+		}
+		sb.WriteString("." + cell)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// formulaToOpenFormula provides a function to translate the spreadsheet
+// formula to the OpenFormula formula of the OpenDocument Spreadsheet (ODS).
+// For example, convert SUM(A1:B2,Sheet2!C3) to
+// of:=SUM([.A1:.B2];[$Sheet2.C3]).
+func formulaToOpenFormula(formula string) string {
+	var (
+		ps    = efp.ExcelParser()
+		sb    strings.Builder
+		stack []string
+	)
+	sb.WriteString("of:=")
+	for _, token := range ps.Parse(formula) {
+		switch token.TType {
+		case efp.TokenTypeFunction:
+			if token.TSubType == efp.TokenSubTypeStart {
+				stack = append(stack, token.TValue)
+				switch token.TValue {
+				case "ARRAY":
+					sb.WriteByte('{')
+				case "ARRAYROW":
+				default:
+					sb.WriteString(strings.TrimPrefix(strings.TrimPrefix(token.TValue, "_xlfn."), "_xlws.") + "(")
+				}
+				continue
+			}
+			var name string
+			if len(stack) > 0 {
+				name, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+			switch name {
+			case "ARRAY":
+				sb.WriteByte('}')
+			case "ARRAYROW":
+			default:
+				sb.WriteByte(')')
+			}
+		case efp.TokenTypeArgument:
+			if len(stack) > 0 && stack[len(stack)-1] == "ARRAY" {
+				sb.WriteByte('|')
+				continue
+			}
+			sb.WriteByte(';')
+		case efp.TokenTypeSubexpression:
+			if token.TSubType == efp.TokenSubTypeStart {
+				sb.WriteByte('(')
+				continue
+			}
+			sb.WriteByte(')')
+		case efp.TokenTypeOperand:
+			switch token.TSubType {
+			case efp.TokenSubTypeText:
+				sb.WriteString(`"` + strings.ReplaceAll(token.TValue, `"`, `""`) + `"`)
+			case efp.TokenSubTypeRange:
+				sb.WriteString(refToOpenFormulaRef(token.TValue))
+			default:
+				sb.WriteString(token.TValue)
+			}
+		case efp.TokenTypeOperatorInfix:
+			switch token.TSubType {
+			case efp.TokenSubTypeIntersection:
+				sb.WriteByte('!')
+			case efp.TokenSubTypeUnion:
+				sb.WriteByte('~')
+			default:
+				sb.WriteString(token.TValue)
+			}
+		case efp.TokenTypeWhitespace:
+		default:
+			sb.WriteString(token.TValue)
+		}
+	}
+	return sb.String()
+}
+
+// odsExporter directly maps the automatic styles of the OpenDocument
+// Spreadsheet (ODS) package for writing the workbook.
+type odsExporter struct {
+	f          *File
+	content    *odsDocumentContent
+	sst        *xlsxSST
+	date1904   bool
+	cellStyles map[int]string
+	valueTypes map[int]string
+	colStyles  map[string]string
+	rowStyles  map[string]string
+}
+
+// writeOpenDocument provides a function to write the workbook to the
+// io.Writer as the OpenDocument Spreadsheet (ODS) package. The sheet names,
+// cell values, formulas, merged cells, column widths, row heights, basic
+// styles and defined names will be written, the other elements, such as the
+// charts and the comments will be ignored.
+func (f *File) writeOpenDocument(w io.Writer) error {
+	if f.options != nil && f.options.Password != "" {
+		return ErrUnsupportedEncryptMechanism
+	}
+	content, err := f.openDocumentContent()
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	fi, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		_ = zw.Close()
+		return err
+	}
+	if _, err = fi.Write([]byte(ContentTypeOpenDocumentSpreadsheet)); err != nil {
+		_ = zw.Close()
+		return err
+	}
+	for _, part := range []struct {
+		name    string
+		content []byte
+	}{
+		{"META-INF/manifest.xml", []byte(xml.Header + templateODSManifest)},
+		{"content.xml", content},
+		{"styles.xml", []byte(xml.Header + templateODSStyles)},
+		{"meta.xml", []byte(xml.Header + templateODSMeta)},
+	} {
+		if fi, err = zw.Create(part.name); err != nil {
+			break
+		}
+		if _, err = fi.Write(part.content); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		_ = zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// openDocumentContent provides a function to generate the content.xml part
+// of the OpenDocument Spreadsheet (ODS) package.
+func (f *File) openDocumentContent() ([]byte, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	exp := odsExporter{
+		f: f,
+		content: &odsDocumentContent{
+			XMLNSOffice: NameSpaceOpenDocumentOffice,
+			XMLNSStyle:  NameSpaceOpenDocumentStyle,
+			XMLNSText:   NameSpaceOpenDocumentText,
+			XMLNSTable:  NameSpaceOpenDocumentTable,
+			XMLNSNumber: NameSpaceOpenDocumentNumber,
+			XMLNSFO:     NameSpaceOpenDocumentXSLFO,
+			XMLNSOf:     NameSpaceOpenDocumentFormula,
+			Version:     "1.2",
+		},
+		sst:        sst,
+		date1904:   wb.WorkbookPr != nil && wb.WorkbookPr.Date1904,
+		cellStyles: map[int]string{},
+		valueTypes: map[int]string{},
+		colStyles:  map[string]string{},
+		rowStyles:  map[string]string{},
+	}
+	exp.content.AutomaticStyles.Styles = append(exp.content.AutomaticStyles.Styles,
+		odsStyle{Name: "ta1", Family: "table", TableProperties: &odsTableProperties{Display: "true"}},
+		odsStyle{Name: "ta2", Family: "table", TableProperties: &odsTableProperties{Display: "false"}},
+	)
+	sheetNames := f.GetSheetList()
+	for _, sheet := range sheetNames {
+		table, err := exp.table(sheet)
+		if err != nil {
+			return nil, err
+		}
+		exp.content.Spreadsheet.Tables = append(exp.content.Spreadsheet.Tables, *table)
+	}
+	for _, dn := range f.GetDefinedName() {
+		if strings.HasPrefix(dn.Name, "_xlnm.") {
+			continue
+		}
+		names := &exp.content.Spreadsheet.NamedExpressions
+		if idx := inStrSlice(sheetNames, dn.Scope, true); idx != -1 {
+			names = &exp.content.Spreadsheet.Tables[idx].NamedExpressions
+		}
+		if *names == nil {
+			*names = &odsNamedExpressions{}
+		}
+		expression := formulaToOpenFormula(dn.RefersTo)
+		if ref := strings.TrimPrefix(expression, "of:="); strings.HasPrefix(ref, "[") &&
+			strings.HasSuffix(ref, "]") && strings.Count(ref, "[") == 1 {
+			ref = strings.Trim(ref, "[]")
+			(*names).NamedRange = append((*names).NamedRange, odsNamedRange{
+				Name: dn.Name, CellRangeAddress: ref, BaseCellAddress: splitUnquoted(ref, ':')[0],
+			})
+			continue
+		}
+		(*names).NamedExpression = append((*names).NamedExpression, odsNamedExpression{
+			Name: dn.Name, Expression: expression, BaseCellAddress: "$" + escapeSheetName(sheetNames[0]) + ".$A$1",
+		})
+	}
+	output, err := xml.Marshal(exp.content)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+// table provides a function to convert the worksheet to the table of the
+// OpenDocument Spreadsheet (ODS).
+func (exp *odsExporter) table(sheet string) (*odsTable, error) {
+	table := &odsTable{Name: sheet, StyleName: "ta1"}
+	if visible, err := exp.f.GetSheetVisible(sheet); err != nil {
+		return table, err
+	} else if !visible {
+		table.StyleName = "ta2"
+	}
+	mergeCells, err := exp.f.GetMergeCells(sheet)
+	if err != nil {
+		return table, err
+	}
+	exp.f.mu.Lock()
+	ws, err := exp.f.workSheetReader(sheet)
+	exp.f.mu.Unlock()
+	if err != nil {
+		return table, err
+	}
+	var (
+		cells          = map[int]map[int]odsTableCell{}
+		maxCol, maxRow = 1, 0
+		setCell        = func(col, row int, cell odsTableCell) {
+			if cells[row] == nil {
+				cells[row] = map[int]odsTableCell{}
+			}
+			cells[row][col] = cell
+			maxCol, maxRow = maxInt(maxCol, col), maxInt(maxRow, row)
+		}
+	)
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			c := &ws.SheetData.Row[r].C[i]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return table, err
+			}
+			cell, err := exp.cell(sheet, c)
+			if err != nil {
+				return table, err
+			}
+			setCell(col, row, cell)
+		}
+		maxRow = maxInt(maxRow, ws.SheetData.Row[r].R)
+	}
+	for _, mc := range mergeCells {
+		rect, err := rangeRefToCoordinates(mc[0])
+		if err != nil {
+			return table, err
+		}
+		_ = sortCoordinates(rect)
+		for row := rect[1]; row <= rect[3]; row++ {
+			for col := rect[0]; col <= rect[2]; col++ {
+				if col == rect[0] && row == rect[1] {
+					cell := cells[row][col]
+					cell.XMLName.Local = "table:table-cell"
+					cell.ColumnsSpanned, cell.RowsSpanned = rect[2]-rect[0]+1, rect[3]-rect[1]+1
+					setCell(col, row, cell)
+					continue
+				}
+				setCell(col, row, odsTableCell{XMLName: xml.Name{Local: "table:covered-table-cell"}})
+			}
+		}
+	}
+	table.Columns = exp.columns(ws, maxCol)
+	rows := map[int]*xlsxRow{}
+	for r := range ws.SheetData.Row {
+		rows[ws.SheetData.Row[r].R] = &ws.SheetData.Row[r]
+	}
+	var empty int
+	for row := 1; row <= maxRow; row++ {
+		tr := odsTableRow{}
+		if xr, ok := rows[row]; ok {
+			if xr.CustomHeight && xr.Ht != nil {
+				tr.StyleName = exp.rowStyle(*xr.Ht)
+			}
+			if xr.Hidden {
+				tr.Visibility = "collapse"
+			}
+		}
+		if len(cells[row]) == 0 && tr.StyleName == "" && tr.Visibility == "" {
+			empty++
+			continue
+		}
+		if empty > 0 {
+			table.Rows = append(table.Rows, newODSEmptyRow(empty))
+			empty = 0
+		}
+		tr.Cells = newODSRowCells(cells[row])
+		table.Rows = append(table.Rows, tr)
+	}
+	if len(table.Rows) == 0 {
+		table.Rows = append(table.Rows, newODSEmptyRow(1))
+	}
+	return table, nil
+}
+
+// newODSEmptyRow returns the table row without any content by given repeated
+// count.
+func newODSEmptyRow(count int) odsTableRow {
+	row := odsTableRow{Cells: []odsTableCell{{XMLName: xml.Name{Local: "table:table-cell"}}}}
+	if count > 1 {
+		row.Repeated = count
+	}
+	return row
+}
+
+// newODSRowCells returns the ordered cells of the table row, the gaps between
+// the cells will be filled with the repeated empty cells.
+func newODSRowCells(cells map[int]odsTableCell) []odsTableCell {
+	var (
+		cols []int
+		row  []odsTableCell
+	)
+	for col := range cells {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+	for i, col := range cols {
+		cell, prev := cells[col], 0
+		if i > 0 {
+			prev = cols[i-1]
+		}
+		if gap := col - prev - 1; gap > 0 {
+			empty := odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}}
+			if gap > 1 {
+				empty.Repeated = gap
+			}
+			row = append(row, empty)
+		}
+		if l := len(row); l > 0 && isEmptyODSCell(&row[l-1]) && isEmptyODSCell(&cell) {
+			row[l-1].Repeated = maxInt(row[l-1].Repeated, 1) + 1
+			continue
+		}
+		row = append(row, cell)
+	}
+	if len(row) == 0 {
+		row = append(row, odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}})
+	}
+	return row
+}
+
+// isEmptyODSCell returns if the table cell without any value, formula, style
+// and merged cells.
+func isEmptyODSCell(cell *odsTableCell) bool {
+	return cell.XMLName.Local == "table:table-cell" && cell.StyleName == "" &&
+		cell.ValueType == "" && cell.Formula == "" && len(cell.Paragraphs) == 0 &&
+		cell.ColumnsSpanned == 0 && cell.RowsSpanned == 0
+}
+
+// columns provides a function to convert the column widths and visibility of
+// the worksheet to the table columns.
+func (exp *odsExporter) columns(ws *xlsxWorksheet, maxCol int) []odsTableColumn {
+	defaultWidth := defaultColWidth
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		defaultWidth = ws.SheetFormatPr.DefaultColWidth
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.Width != nil || col.Hidden {
+				maxCol = maxInt(maxCol, minInt(col.Max, MaxColumns))
+			}
+		}
+	}
+	var columns []odsTableColumn
+	for col := 1; col <= maxCol; col++ {
+		width, hidden := defaultWidth, false
+		if ws.Cols != nil {
+			for _, c := range ws.Cols.Col {
+				if col >= c.Min && col <= c.Max {
+					if c.Width != nil {
+						width = *c.Width
+					}
+					hidden = c.Hidden
+				}
+			}
+		}
+		column := odsTableColumn{StyleName: exp.colStyle(width), DefaultCellStyleName: "Default"}
+		if hidden {
+			column.Visibility = "collapse"
+		}
+		if l := len(columns); l > 0 && columns[l-1].StyleName == column.StyleName &&
+			columns[l-1].Visibility == column.Visibility {
+			columns[l-1].Repeated = maxInt(columns[l-1].Repeated, 1) + 1
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// colStyle returns the name of the column style by given column width, the
+// style will be created on first use.
+func (exp *odsExporter) colStyle(width float64) string {
+	length := strconv.FormatFloat((width*7+5)/96, 'f', 4, 64) + "in"
+	if name, ok := exp.colStyles[length]; ok {
+		return name
+	}
+	name := "co" + strconv.Itoa(len(exp.colStyles)+1)
+	exp.colStyles[length] = name
+	exp.content.AutomaticStyles.Styles = append(exp.content.AutomaticStyles.Styles, odsStyle{
+		Name: name, Family: "table-column", TableColumnProperties: &odsTableColumnProperties{ColumnWidth: length},
+	})
+	return name
+}
+
+// rowStyle returns the name of the row style by given row height in points,
+// the style will be created on first use.
+func (exp *odsExporter) rowStyle(height float64) string {
+	length := strconv.FormatFloat(height/72, 'f', 4, 64) + "in"
+	if name, ok := exp.rowStyles[length]; ok {
+		return name
+	}
+	name := "ro" + strconv.Itoa(len(exp.rowStyles)+1)
+	exp.rowStyles[length] = name
+	exp.content.AutomaticStyles.Styles = append(exp.content.AutomaticStyles.Styles, odsStyle{
+		Name: name, Family: "table-row", TableRowProperties: &odsTableRowProperties{RowHeight: length, UseOptimalRowHeight: "false"},
+	})
+	return name
+}
+
+// cell provides a function to convert the cell of the worksheet to the table
+// cell of the OpenDocument Spreadsheet (ODS).
+func (exp *odsExporter) cell(sheet string, c *xlsxC) (odsTableCell, error) {
+	cell := odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}}
+	valueType, err := exp.cellStyle(c.S)
+	if err != nil {
+		return cell, err
+	}
+	if c.S != 0 {
+		cell.StyleName = exp.cellStyles[c.S]
+	}
+	if c.F != nil {
+		formula, err := exp.f.GetCellFormula(sheet, c.R)
+		if err != nil {
+			return cell, err
+		}
+		if formula != "" {
+			cell.Formula = formulaToOpenFormula(formula)
+		}
+	}
+	if c.V == "" && c.IS == nil {
+		return cell, nil
+	}
+	cc := *c
+	text, err := cc.getValueFrom(exp.f, exp.sst, false)
+	if err != nil {
+		return cell, err
+	}
+	switch c.T {
+	case "b":
+		cell.ValueType, cell.BooleanValue = "boolean", "false"
+		if c.V == "1" || strings.EqualFold(c.V, "true") {
+			cell.BooleanValue = "true"
+		}
+	case "d":
+		cell.ValueType, cell.DateValue = "date", strings.TrimSuffix(c.V, "Z")
+	case "", "n":
+		num, err := strconv.ParseFloat(c.V, 64)
+		if err != nil {
+			cell.ValueType = "string"
+			break
+		}
+		switch cell.ValueType = valueType; valueType {
+		case "date":
+			cell.DateValue = timeFromExcelTime(num, exp.date1904).Format("2006-01-02T15:04:05")
+		case "time":
+			seconds := int64(math.Round(num * 86400))
+			cell.TimeValue = fmt.Sprintf("PT%02dH%02dM%02dS", seconds/3600, seconds/60%60, seconds%60)
+		default:
+			cell.Value = c.V
+		}
+	default:
+		cell.ValueType = "string"
+		if text, err = cc.getValueFrom(exp.f, exp.sst, true); err != nil {
+			return cell, err
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		cell.Paragraphs = append(cell.Paragraphs, newODSParagraph(line))
+	}
+	return cell, nil
+}
+
+// newODSParagraph provides a function to escape the text of the paragraph,
+// the tabs and the consecutive, leading or trailing spaces will be converted
+// to the tab and space elements.
+func newODSParagraph(text string) odsInnerXML {
+	var (
+		sb    strings.Builder
+		runes = []rune(text)
+	)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\t':
+			sb.WriteString("<text:tab/>")
+		case ' ':
+			j := i
+			for j < len(runes) && runes[j] == ' ' {
+				j++
+			}
+			count := j - i
+			if i > 0 && j < len(runes) {
+				sb.WriteByte(' ')
+				count--
+			}
+			if count == 1 {
+				sb.WriteString("<text:s/>")
+			} else if count > 1 {
+				sb.WriteString(`<text:s text:c="` + strconv.Itoa(count) + `"/>`)
+			}
+			i = j - 1
+		default:
+			_ = xml.EscapeText(&sb, []byte(string(runes[i])))
+		}
+	}
+	return odsInnerXML{Content: sb.String()}
+}
+
+// cellStyle provides a function to convert the cell style to the automatic
+// style of the OpenDocument Spreadsheet (ODS) by given style index, and
+// returns the value type of the number format.
+func (exp *odsExporter) cellStyle(styleID int) (string, error) {
+	if valueType, ok := exp.valueTypes[styleID]; ok {
+		return valueType, nil
+	}
+	style, err := exp.f.GetStyle(styleID)
+	if err != nil {
+		return "float", err
+	}
+	name := "ce" + strconv.Itoa(styleID)
+	valueType, numFmt := newODSNumberStyle("N"+strconv.Itoa(styleID), exp.numFmtCode(style))
+	exp.valueTypes[styleID], exp.cellStyles[styleID] = valueType, name
+	if styleID == 0 {
+		return valueType, err
+	}
+	s := newODSStyle(exp.f, name, style)
+	if numFmt != nil {
+		exp.content.AutomaticStyles.NumberStyles = append(exp.content.AutomaticStyles.NumberStyles, *numFmt)
+		s.DataStyleName = numFmt.Name
+	}
+	exp.content.AutomaticStyles.Styles = append(exp.content.AutomaticStyles.Styles, s)
+	return valueType, err
+}
+
+// numFmtCode returns the number format code of the style.
+func (exp *odsExporter) numFmtCode(style *Style) string {
+	if style.CustomNumFmt != nil {
+		return *style.CustomNumFmt
+	}
+	code, _ := exp.f.getBuiltInNumFmtCode(style.NumFmt)
+	return code
+}
+
+// newODSStyle provides a function to convert the style definition to the
+// automatic cell style of the OpenDocument Spreadsheet (ODS).
+func newODSStyle(f *File, name string, style *Style) odsStyle {
+	var (
+		s         = odsStyle{Name: name, Family: "table-cell", ParentStyleName: "Default"}
+		cellProps odsTableCellProperties
+		textProps odsTextProperties
+	)
+	if len(style.Fill.Color) > 0 && (style.Fill.Type == "gradient" || style.Fill.Pattern > 0) {
+		cellProps.BackgroundColor = "#" + strings.ToLower(style.Fill.Color[0])
+	}
+	for _, border := range style.Border {
+		if border.Style <= 0 || border.Style >= len(odsBorderStyles) {
+			continue
+		}
+		color := "#000000"
+		if border.Color != "" {
+			color = "#" + strings.ToLower(strings.TrimPrefix(border.Color, "#"))
+		}
+		value := strings.Join(odsBorderStyles[border.Style], " ") + " " + color
+		switch border.Type {
+		case "left":
+			cellProps.BorderLeft = value
+		case "right":
+			cellProps.BorderRight = value
+		case "top":
+			cellProps.BorderTop = value
+		case "bottom":
+			cellProps.BorderBottom = value
+		case "diagonalUp":
+			cellProps.DiagonalBlTr = value
+		case "diagonalDown":
+			cellProps.DiagonalTlBr = value
+		}
+	}
+	if alignment := style.Alignment; alignment != nil {
+		if textAlign, ok := odsHorizontalAlignments[alignment.Horizontal]; ok {
+			s.ParagraphProperties = &odsParagraphProperties{TextAlign: textAlign}
+			cellProps.TextAlignSource = "fix"
+		}
+		cellProps.VerticalAlign = odsVerticalAlignments[alignment.Vertical]
+		if alignment.WrapText {
+			cellProps.WrapOption = "wrap"
+		}
+		if alignment.ShrinkToFit {
+			cellProps.ShrinkToFit = "true"
+		}
+		if rotation := alignment.TextRotation; rotation > 0 && rotation <= 90 {
+			cellProps.RotationAngle = strconv.Itoa(rotation)
+		} else if rotation > 90 && rotation <= 180 {
+			cellProps.RotationAngle = strconv.Itoa(360 - (rotation - 90))
+		}
+	}
+	if font := style.Font; font != nil {
+		textProps.FontFamily = font.Family
+		if font.Size > 0 {
+			textProps.FontSize = strconv.FormatFloat(font.Size, 'f', -1, 64) + "pt"
+		}
+		if font.Bold {
+			textProps.FontWeight = "bold"
+		}
+		if font.Italic {
+			textProps.FontStyle = "italic"
+		}
+		if color := font.Color; color != "" || font.ColorTheme != nil {
+			if color = f.GetBaseColor(color, font.ColorIndexed, font.ColorTheme); len(color) == 6 {
+				textProps.Color = "#" + strings.ToLower(color)
+			}
+		}
+		if font.Underline == "single" || font.Underline == "double" {
+			textProps.TextUnderlineStyle, textProps.TextUnderlineWidth, textProps.TextUnderlineColor = "solid", "auto", "font-color"
+			if font.Underline == "double" {
+				textProps.TextUnderlineType = "double"
+			}
+		}
+		if font.Strike {
+			textProps.TextLineThrough = "solid"
+		}
+		if font.VertAlign == "superscript" {
+			textProps.TextPosition = "super 58%"
+		} else if font.VertAlign == "subscript" {
+			textProps.TextPosition = "sub 58%"
+		}
+	}
+	if cellProps != (odsTableCellProperties{}) {
+		s.TableCellProperties = &cellProps
+	}
+	if textProps != (odsTextProperties{}) {
+		s.TextProperties = &textProps
+	}
+	return s
+}
+
+// newODSNumberStyle provides a function to convert the first section of the
+// number format code to the data style of the OpenDocument Spreadsheet
+// (ODS), and returns the value type of the number format. The nil data style
+// will be returned for the general number format.
+func newODSNumberStyle(name, code string) (string, *odsNumberStyle) {
+	if code == "" || strings.EqualFold(code, "General") {
+		return "float", nil
+	}
+	p := nfp.NumberFormatParser()
+	sections := p.Parse(code)
+	if len(sections) == 0 {
+		return "float", nil
+	}
+	var (
+		tokens            = sections[0].Items
+		valueType, format = "float", "number-style"
+		numFmt            = &odsNumberStyle{Name: name}
+		addPart           = func(local string, part odsNumberPart) int {
+			part.XMLName = xml.Name{Local: "number:" + local}
+			numFmt.Parts = append(numFmt.Parts, part)
+			return len(numFmt.Parts) - 1
+		}
+		idx     = -1
+		decimal bool
+	)
+	for i, token := range tokens {
+		switch token.TType {
+		case nfp.TokenTypeDateTimes, nfp.TokenTypeElapsedDateTimes:
+			if c := strings.ToLower(token.TValue)[0]; valueType != "date" {
+				if valueType = "time"; strings.ContainsRune("dye", rune(c)) || c == 'm' && !isODSMinutes(tokens, i) {
+					valueType = "date"
+				}
+			}
+		case nfp.TokenTypePercent:
+			format = "percentage-style"
+		case nfp.TokenTypeCurrencyLanguage:
+			format = "currency-style"
+		case nfp.TokenTypeTextPlaceHolder:
+			format = "text-style"
+		}
+	}
+	dateTime := valueType != "float"
+	if dateTime {
+		format = valueType + "-style"
+	} else if format == "percentage-style" {
+		valueType = "percentage"
+	}
+	for i, token := range tokens {
+		switch token.TType {
+		case nfp.TokenTypeColor:
+			for color, code := range odsNumFmtColors {
+				if strings.EqualFold(code, "["+token.TValue+"]") {
+					numFmt.Parts = append(numFmt.Parts, odsNumberPart{XMLName: xml.Name{Local: "style:text-properties"}, Color: color})
+				}
+			}
+		case nfp.TokenTypeLiteral:
+			if token.TValue != "" {
+				addPart("text", odsNumberPart{Text: token.TValue})
+			}
+		case nfp.TokenTypePercent:
+			addPart("text", odsNumberPart{Text: "%"})
+		case nfp.TokenTypeTextPlaceHolder:
+			addPart("text-content", odsNumberPart{})
+		case nfp.TokenTypeCurrencyLanguage:
+			for _, part := range token.Parts {
+				if part.Token.TType == "CurrencyString" {
+					addPart("currency-symbol", odsNumberPart{Text: part.Token.TValue})
+				}
+			}
+		case nfp.TokenTypeDateTimes, nfp.TokenTypeElapsedDateTimes:
+			if token.TType == nfp.TokenTypeElapsedDateTimes {
+				numFmt.TruncateOnOverflow = "false"
+			}
+			addODSDateTimePart(tokens, i, addPart)
+		case nfp.TokenTypeDecimalPoint:
+			if !dateTime {
+				decimal = true
+			}
+		case nfp.TokenTypeZeroPlaceHolder, nfp.TokenTypeHashPlaceHolder, nfp.TokenTypeDigitalPlaceHolder,
+			nfp.TokenTypeThousandsSeparator:
+			if dateTime {
+				if l := len(numFmt.Parts); l > 0 && numFmt.Parts[l-1].XMLName.Local == "number:seconds" && tokens[i-1].TType == nfp.TokenTypeDecimalPoint {
+					places := len(token.TValue)
+					numFmt.Parts[l-1].DecimalPlaces = &places
+				}
+				continue
+			}
+			if idx == -1 {
+				zero, integer := 0, 0
+				idx = addPart("number", odsNumberPart{DecimalPlaces: &zero, MinIntegerDigits: &integer})
+			}
+			number := &numFmt.Parts[idx]
+			if token.TType == nfp.TokenTypeThousandsSeparator {
+				number.Grouping = true
+				continue
+			}
+			if decimal {
+				*number.DecimalPlaces += len(token.TValue)
+				continue
+			}
+			if token.TType == nfp.TokenTypeZeroPlaceHolder {
+				*number.MinIntegerDigits += len(token.TValue)
+			}
+		case nfp.TokenTypeExponential:
+			if idx != -1 {
+				number := &numFmt.Parts[idx]
+				number.XMLName.Local, decimal = "number:scientific-number", false
+				digits := 2
+				if i+1 < len(tokens) {
+					digits = len(tokens[i+1].TValue)
+				}
+				number.MinExponentDigits = &digits
+				return valueType, finishODSNumberStyle(numFmt, format)
+			}
+		case nfp.TokenTypeFraction:
+			if idx != -1 {
+				return valueType, finishODSNumberStyle(newODSFraction(numFmt, idx, tokens, i), format)
+			}
+		}
+	}
+	if len(numFmt.Parts) == 0 {
+		return valueType, nil
+	}
+	return valueType, finishODSNumberStyle(numFmt, format)
+}
+
+// newODSFraction provides a function to convert the number element at the
+// given index to the fraction element by given tokens and the index of the
+// fraction token. The literal between the integer part and the numerator
+// will be removed.
+func newODSFraction(numFmt *odsNumberStyle, idx int, tokens []nfp.Token, i int) *odsNumberStyle {
+	number := numFmt.Parts[idx]
+	number.XMLName.Local, number.DecimalPlaces = "number:fraction", nil
+	if len(numFmt.Parts) == idx+1 {
+		number.MinIntegerDigits = nil
+	}
+	number.MinNumeratorDigits = maxInt(countDigits(tokens[:i], true), 1)
+	var denominator string
+	for _, token := range tokens[i+1:] {
+		if token.TType != nfp.TokenTypeLiteral && token.TType != nfp.TokenTypeDenominator && token.TType != nfp.TokenTypeZeroPlaceHolder &&
+			token.TType != nfp.TokenTypeDigitalPlaceHolder && token.TType != nfp.TokenTypeHashPlaceHolder {
+			break
+		}
+		denominator += token.TValue
+	}
+	if n, err := strconv.Atoi(denominator); err == nil && n > 0 && denominator[0] != '0' {
+		number.DenominatorValue = denominator
+	} else {
+		number.MinDenominatorDigits = maxInt(countDigits(tokens[i+1:], false), 1)
+	}
+	numFmt.Parts = append(numFmt.Parts[:idx], number)
+	return numFmt
+}
+
+// countDigits returns the number of the digit placeholders at the end or the
+// beginning of the given tokens.
+func countDigits(tokens []nfp.Token, fromEnd bool) int {
+	var count int
+	for i := range tokens {
+		token := tokens[i]
+		if fromEnd {
+			token = tokens[len(tokens)-1-i]
+		}
+		if token.TType != nfp.TokenTypeDigitalPlaceHolder && token.TType != nfp.TokenTypeZeroPlaceHolder &&
+			token.TType != nfp.TokenTypeHashPlaceHolder {
+			break
+		}
+		count += len(token.TValue)
+	}
+	return count
+}
+
+// finishODSNumberStyle set the element name of the data style by given
+// format and returns the data style.
+func finishODSNumberStyle(numFmt *odsNumberStyle, format string) *odsNumberStyle {
+	numFmt.XMLName = xml.Name{Local: "number:" + format}
+	return numFmt
+}
+
+// addODSDateTimePart provides a function to add the date and time element of
+// the data style by given the index of the date and time token.
+func addODSDateTimePart(tokens []nfp.Token, i int, addPart func(local string, part odsNumberPart) int) {
+	token := strings.ToLower(tokens[i].TValue)
+	style := ""
+	if len(token) > 1 {
+		style = "long"
+	}
+	switch token[0] {
+	case 'y', 'e':
+		addPart("year", odsNumberPart{Style: map[bool]string{true: "long"}[len(token) > 2]})
+	case 'd':
+		if len(token) > 2 {
+			addPart("day-of-week", odsNumberPart{Style: map[bool]string{true: "long"}[len(token) > 3]})
+			return
+		}
+		addPart("day", odsNumberPart{Style: style})
+	case 'h':
+		addPart("hours", odsNumberPart{Style: style})
+	case 's':
+		addPart("seconds", odsNumberPart{Style: style})
+	case 'a':
+		addPart("am-pm", odsNumberPart{})
+	case 'm':
+		if len(token) > 2 {
+			addPart("month", odsNumberPart{Style: map[bool]string{true: "long"}[len(token) > 3], Textual: true})
+			return
+		}
+		if isODSMinutes(tokens, i) {
+			addPart("minutes", odsNumberPart{Style: style})
+			return
+		}
+		addPart("month", odsNumberPart{Style: style})
+	}
+}
+
+// isODSMinutes returns if the month token at the given index represents the
+// minutes, which follows the hours or precedes the seconds.
+func isODSMinutes(tokens []nfp.Token, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		if tokens[j].TType == nfp.TokenTypeDateTimes || tokens[j].TType == nfp.TokenTypeElapsedDateTimes {
+			if c := strings.ToLower(tokens[j].TValue)[0]; c == 'h' {
+				return true
+			}
+			break
+		}
+	}
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].TType == nfp.TokenTypeDateTimes || tokens[j].TType == nfp.TokenTypeElapsedDateTimes {
+			return strings.ToLower(tokens[j].TValue)[0] == 's'
+		}
+	}
+	return false
+}
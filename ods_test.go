@@ -0,0 +1,339 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// odsTestContent defined the content.xml part of the OpenDocument
+// Spreadsheet (ODS) package, used for testing.
+const odsTestContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0" office:version="1.2">
+<office:automatic-styles>
+<number:number-style style:name="N4P0" style:volatile="true"><number:number number:decimal-places="2" number:min-integer-digits="1" number:grouping="true"/><number:text> </number:text></number:number-style>
+<number:number-style style:name="N4"><style:text-properties fo:color="#ff0000"/><number:text>-</number:text><number:number number:decimal-places="2" number:min-integer-digits="1" number:grouping="true"/><number:text> </number:text><style:map style:condition="value()&gt;=0" style:apply-style-name="N4P0"/></number:number-style>
+<number:date-style style:name="N37"><number:day number:style="long"/><number:text>.</number:text><number:month number:style="long" number:textual="true"/><number:text>.</number:text><number:year number:style="long"/></number:date-style>
+<number:time-style style:name="N40" number:truncate-on-overflow="false"><number:hours/><number:text>:</number:text><number:minutes number:style="long"/></number:time-style>
+<style:style style:name="co1" style:family="table-column"><style:table-column-properties fo:break-before="auto" style:column-width="2.258cm"/></style:style>
+<style:style style:name="co2" style:family="table-column"><style:table-column-properties style:column-width="1in"/></style:style>
+<style:style style:name="ro1" style:family="table-row"><style:table-row-properties style:row-height="0.452cm" style:use-optimal-row-height="true"/></style:style>
+<style:style style:name="ro2" style:family="table-row"><style:table-row-properties style:row-height="1cm" style:use-optimal-row-height="false"/></style:style>
+<style:style style:name="ta1" style:family="table"><style:table-properties table:display="true"/></style:style>
+<style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"/></style:style>
+<style:style style:name="ce1" style:family="table-cell" style:parent-style-name="Base" style:data-style-name="N4"><style:table-cell-properties fo:background-color="#ffff00" fo:border="0.06pt solid #000000" style:vertical-align="middle" style:rotation-angle="45"/><style:paragraph-properties fo:text-align="center"/><style:text-properties fo:font-style="italic" style:text-underline-style="solid" style:text-underline-type="double" style:text-line-through-style="solid"/></style:style>
+<style:style style:name="ce2" style:family="table-cell" style:data-style-name="N37"/>
+<style:style style:name="ce3" style:family="table-cell" style:data-style-name="N40"/>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+<table:table table:name="Data Sheet" table:style-name="ta1">
+<table:table-column-group><table:table-column table:style-name="co1" table:number-columns-repeated="2" table:default-cell-style-name="Default"/></table:table-column-group>
+<table:table-column table:style-name="co2" table:visibility="collapse" table:default-cell-style-name="ce1"/>
+<table:table-header-rows><table:table-row table:style-name="ro1"><table:table-cell office:value-type="string" calcext:value-type="string"><text:p>Hello<text:s text:c="2"/>World</text:p><text:p><text:span>second</text:span><text:tab/>line</text:p></table:table-cell><table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="2" office:value-type="float" office:value="-1234.5" table:style-name="ce1"><text:p>-1,234.50</text:p></table:table-cell><table:covered-table-cell/></table:table-row></table:table-header-rows>
+<table:table-row table:style-name="ro2"><table:table-cell office:value-type="boolean" office:boolean-value="true"><text:p>TRUE</text:p></table:table-cell><table:covered-table-cell table:number-columns-repeated="2"/></table:table-row>
+<table:table-row table:number-rows-repeated="3"><table:table-cell table:number-columns-repeated="3"/></table:table-row>
+<table:table-row table:visibility="collapse"><table:table-cell table:style-name="ce2" office:value-type="date" office:date-value="2024-02-29"><text:p>29.February.2024</text:p></table:table-cell><table:table-cell table:style-name="ce3" office:value-type="time" office:time-value="PT36H30M00S"><text:p>36:30</text:p></table:table-cell><table:table-cell table:formula="of:=SUM([.A2:.B2];['Other $ Sheet'.$A$1])" office:value-type="float" office:value="3"><text:p>3</text:p></table:table-cell><table:table-cell table:formula="of:=IF([.A2];&quot;yes&quot;;&quot;no&quot;)" office:value-type="string" office:string-value="yes"><text:p>yes</text:p></table:table-cell><table:table-cell table:formula="of:=1/0" office:value-type="float" office:value="0" calcext:value-type="error"><text:p>#DIV/0!</text:p></table:table-cell></table:table-row>
+</table:table>
+<table:table table:name="Other $ Sheet" table:style-name="ta2"><table:table-column/><table:table-row><table:table-cell office:value-type="percentage" office:value="0.25"><text:p>25%</text:p></table:table-cell></table:table-row>
+<table:named-expressions><table:named-range table:name="local" table:cell-range-address="$'Other $ Sheet'.$A$1"/></table:named-expressions></table:table>
+<table:named-expressions><table:named-range table:name="data" table:cell-range-address="$'Data Sheet'.$A$1:.$B$2" table:base-cell-address="$'Data Sheet'.$A$1"/><table:named-expression table:name="expr" table:expression="of:=SUM([$'Data Sheet'.$A$1:.$B$2])*2"/></table:named-expressions>
+</office:spreadsheet></office:body></office:document-content>`
+
+// newODSTestPackage returns the OpenDocument Spreadsheet (ODS) package with
+// the given content.xml part, used for testing.
+func newODSTestPackage(content string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, part := range [][2]string{{"mimetype", ContentTypeOpenDocumentSpreadsheet}, {"content.xml", content}, {"styles.xml", `<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"><office:styles><style:style style:name="Base" style:family="table-cell"><style:text-properties fo:font-weight="bold" fo:font-size="12pt" style:font-name="Arial" fo:color="#0000ff"/></style:style></office:styles></office:document-styles>`}} {
+		fi, _ := zw.Create(part[0])
+		_, _ = fi.Write([]byte(part[1]))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func TestOpenDocument(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello  world\tand\nmore <&>"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 3.25))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", time.Date(2024, 3, 4, 10, 30, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E5", "SUM(B2,'Sheet 2'!A1:B3)*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F6", 0.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "G7", 1.25))
+	assert.NoError(t, f.MergeCell("Sheet1", "H1", "I3"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "K", false))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 10, false))
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet 2", "A1", 42))
+	_, err = f.NewSheet("Hidden")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Hidden", false))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "range", RefersTo: "Sheet1!$A$1:$B$2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "expr", RefersTo: "Sheet1!$B$2*2", Scope: "Sheet 2"}))
+	numFmt := "#,##0.00"
+	style, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Italic: true, Underline: "double", Strike: true, Color: "FF0000", Size: 14, Family: "Arial"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:       []Border{{Type: "left", Style: 2, Color: "0000FF"}, {Type: "diagonalUp", Style: 6}},
+		Alignment:    &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 135},
+		CustomNumFmt: &numFmt,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	for cell, numFmt := range map[string]int{"F6": 10, "G7": 46} {
+		style, err := f.NewStyle(&Style{NumFmt: numFmt})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenDocument.ods")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestOpenDocument.ods"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet 2", "Hidden"}, f.GetSheetList())
+	for cell, expected := range map[string]string{
+		"A1": "Hello  world\tand\nmore <&>", "B2": "3.25", "C3": "TRUE",
+		"D4": "3/4/24 10:30", "F6": "50.00%", "G7": "30:00:00",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2,'Sheet 2'!A1:B3)*2", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "H1:I3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	visible, err := f.GetColVisible("Sheet1", "K")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 10)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetSheetVisible("Hidden")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	styleID, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	s, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Underline: "double", Strike: true, Color: "FF0000", Size: 14, Family: "Arial"}, s.Font)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, s.Fill)
+	assert.Equal(t, []Border{{Type: "left", Color: "0000FF", Style: 2}, {Type: "diagonalUp", Color: "000000", Style: 6}}, s.Border)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 135}, s.Alignment)
+	assert.Equal(t, numFmt, *s.CustomNumFmt)
+	value, err := f.GetCellValue("Sheet 2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", value)
+	assert.Equal(t, []DefinedName{
+		{Name: "range", RefersTo: "Sheet1!$A$1:$B$2", Scope: "Workbook"},
+		{Name: "expr", RefersTo: "Sheet1!$B$2*2", Scope: "Sheet 2"},
+	}, f.GetDefinedName())
+	// Test save the opened OpenDocument Spreadsheet
+	assert.NoError(t, f.Save())
+	assert.NoError(t, f.Close())
+
+	// Test save the OpenDocument Spreadsheet with password
+	f = NewFile()
+	assert.Equal(t, ErrUnsupportedEncryptMechanism, f.SaveAs(filepath.Join("test", "TestOpenDocument.ods"), Options{Password: "password"}))
+	assert.NoError(t, f.Close())
+	// Test save the OpenDocument Spreadsheet with unsupported charset
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SaveAs(filepath.Join("test", "TestOpenDocument.ods")), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SaveAs(filepath.Join("test", "TestOpenDocument.ods")), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.SaveAs(filepath.Join("test", "TestOpenDocument.ods")), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestConvertOpenDocument(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(newODSTestPackage(odsTestContent)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data Sheet", "Other $ Sheet"}, f.GetSheetList())
+	rows, err := f.GetRows("Data Sheet")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Hello  World\nsecond\tline", "-1,234.50"}, {"TRUE"}, nil, nil, nil,
+		{"29.February.2024", "36:30", "3.00 ", "yes", "#DIV/0!"},
+	}, rows)
+	for cell, expected := range map[string][]string{
+		"C6": {"SUM(A2:B2,'Other $ Sheet'!$A$1)", "3"},
+		"D6": {`IF(A2,"yes","no")`, "yes"},
+		"E6": {"1/0", "#DIV/0!"},
+	} {
+		formula, err := f.GetCellFormula("Data Sheet", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], formula)
+		value, err := f.GetCellValue("Data Sheet", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], value)
+	}
+	mergeCells, err := f.GetMergeCells("Data Sheet")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B1:C2", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	for col, expected := range map[string]float64{"A": 11.48, "B": 11.48, "C": 13, "D": defaultColWidth} {
+		width, err := f.GetColWidth("Data Sheet", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	visible, err := f.GetColVisible("Data Sheet", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data Sheet", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 28.35, height)
+	visible, err = f.GetRowVisible("Data Sheet", 6)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	styleID, err := f.GetCellStyle("Data Sheet", "B1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Underline: "double", Strike: true, Color: "0000FF", Size: 12, Family: "Arial"}, style.Font)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, style.Fill)
+	assert.Len(t, style.Border, 4)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", TextRotation: 45}, style.Alignment)
+	assert.Equal(t, "#,##0.00 ;[Red]-#,##0.00 ", *style.CustomNumFmt)
+	for cell, expected := range map[string]string{"A6": "dd.mmmm.yyyy", "B6": "[h]:mm"} {
+		styleID, err := f.GetCellStyle("Data Sheet", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, *style.CustomNumFmt)
+	}
+	visible, err = f.GetSheetVisible("Other $ Sheet")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	value, err := f.GetCellValue("Other $ Sheet", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.25", value)
+	assert.Equal(t, []DefinedName{
+		{Name: "data", RefersTo: "'Data Sheet'!$A$1:$B$2", Scope: "Workbook"},
+		{Name: "expr", RefersTo: "SUM('Data Sheet'!$A$1:$B$2)*2", Scope: "Workbook"},
+		{Name: "local", RefersTo: "'Other $ Sheet'!$A$1", Scope: "Other $ Sheet"},
+	}, f.GetDefinedName())
+	assert.NoError(t, f.Close())
+
+	// Test open the OpenDocument Spreadsheet with invalid content
+	for content, expected := range map[string]string{
+		"<office:document-content>":  "XML syntax error on line 1: unexpected EOF",
+		"<office:document-content/>": ErrWorkbookFileFormat.Error(),
+		`<document-content><body><spreadsheet><table table:name="a:b"/></spreadsheet></body></document-content>`:                                                    ErrSheetNameInvalid.Error(),
+		`<document-content><body><spreadsheet><table table:name="a"/><table table:name="` + strings.Repeat("a", 32) + `"/></spreadsheet></body></document-content>`: ErrSheetNameLength.Error(),
+	} {
+		_, err = OpenReader(bytes.NewReader(newODSTestPackage(content)))
+		assert.EqualError(t, err, expected)
+	}
+	_, err = OpenReader(bytes.NewReader(newODSTestPackage(odsTestContent)), Options{UnzipSizeLimit: 100, UnzipXMLSizeLimit: 100})
+	assert.EqualError(t, err, newUnzipSizeLimitError(100).Error())
+}
+
+func TestOpenFormulaToFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"of:=SUM([.A1:.B2];[$Sheet2.C3])":              "SUM(A1:B2,Sheet2!C3)",
+		"of:=[$'My Sheet'.A1]+[$Sheet2.B1:$Sheet3.B1]": "'My Sheet'!A1+Sheet2:Sheet3!B1",
+		"of:=STDEV.S({1;2|3;4})":                       "STDEV.S({1,2;3,4})",
+		"of:=[.A1:.B2]![.B1:.C3]":                      "A1:B2 B1:C3",
+		"of:=SUM(([.A1]~[.B2]))":                       "SUM((A1,B2))",
+		`of:=IF([.A1]="a;b""";TRUE();#N/A)`:            `IF(A1="a;b""",TRUE(),#N/A)`,
+		"of:=COM.MICROSOFT.CONCAT([.A1];1)":            "CONCAT(A1,1)",
+		"of:=LEGACY.NORMSDIST(1)":                      "NORMSDIST(1)",
+		"=[$'It''s'.$A$1:.$B$2]":                       "'It''s'!$A$1:$B$2",
+		"of:=['Sheet 1'.A1:'Sheet 2'.A1]":              "'Sheet 1:Sheet 2'!A1",
+	} {
+		assert.Equal(t, expected, openFormulaToFormula(formula), formula)
+	}
+}
+
+func TestFormulaToOpenFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"SUM(A1:B2,Sheet2!C3)":           "of:=SUM([.A1:.B2];[$Sheet2.C3])",
+		"'My Sheet'!A1+Sheet2:Sheet3!B1": "of:=[$'My Sheet'.A1]+[$Sheet2.B1:$Sheet3.B1]",
+		"_xlfn.STDEV.S({1,2;3,4})":       "of:=STDEV.S({1;2|3;4})",
+		"A1:B2 B1:C3":                    "of:=[.A1:.B2]![.B1:.C3]",
+		"SUM((A1,B2))":                   "of:=SUM(([.A1]~[.B2]))",
+		`IF(A1="a""b",TRUE,#N/A)`:        `of:=IF([.A1]="a""b";TRUE;#N/A)`,
+		"range*2":                        "of:=range*2",
+		"SUM(A:A,1:1)":                   "of:=SUM([.A:.A];[.1:.1])",
+		"-A1%":                           "of:=-[.A1]%",
+	} {
+		assert.Equal(t, expected, formulaToOpenFormula(formula), formula)
+	}
+}
+
+func TestNewODSNumberStyle(t *testing.T) {
+	imp := odsImporter{numFmts: map[string]decodeODSNumberStyle{}}
+	for code, expected := range map[string][]string{
+		"0":               {"float", "number-style", "0"},
+		"#,##0.00":        {"float", "number-style", "#,##0.00"},
+		"0.0%":            {"percentage", "percentage-style", "0.0%"},
+		"0.00E+00":        {"float", "number-style", "0.00E+00"},
+		"# ?/?":           {"float", "number-style", "# ?/?"},
+		"?/100":           {"float", "number-style", "?/100"},
+		"yyyy-mm-dd":      {"date", "date-style", "yyyy-mm-dd"},
+		"d-mmm-yy":        {"date", "date-style", "d-mmm-yy"},
+		"dddd":            {"date", "date-style", "dddd"},
+		"h:mm:ss AM/PM":   {"time", "time-style", "h:mm:ss AM/PM"},
+		"[h]:mm:ss":       {"time", "time-style", "[h]:mm:ss"},
+		"mm:ss.0":         {"time", "time-style", "mm:ss.0"},
+		"@":               {"float", "text-style", "@"},
+		`"$"#,##0.00`:     {"float", "number-style", `"$"#,##0.00`},
+		"[$€-2] #,##0.00": {"float", "currency-style", "[$€] #,##0.00"},
+		"[Red]0.00":       {"float", "number-style", "[Red]0.00"},
+	} {
+		valueType, numFmt := newODSNumberStyle("N1", code)
+		assert.Equal(t, expected[0], valueType, code)
+		assert.Equal(t, "number:"+expected[1], numFmt.XMLName.Local, code)
+		output, err := xml.Marshal(numFmt)
+		assert.NoError(t, err)
+		var style decodeODSNumberStyle
+		assert.NoError(t, xml.Unmarshal(output, &style))
+		imp.numFmts[code] = style
+		assert.Equal(t, expected[2], imp.numberFormat(code, 0), code)
+	}
+	valueType, numFmt := newODSNumberStyle("N1", "General")
+	assert.Equal(t, "float", valueType)
+	assert.Nil(t, numFmt)
+	assert.Empty(t, imp.numberFormat("N1", 0))
+}
+
+func TestParseODSDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"PT12H30M00S": 12*time.Hour + 30*time.Minute, "P1DT2H": 26 * time.Hour, "P2D": 48 * time.Hour,
+	} {
+		duration, err := parseODSDuration(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, duration, value)
+	}
+	for _, value := range []string{"PXDT1H", "PT1X"} {
+		_, err := parseODSDuration(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,600 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// embeddedPackageTypes defined the ProgID and the content type of the Office
+// Open XML documents by the file extension, these documents will be embedded
+// as the package parts instead of the OLE objects.
+var embeddedPackageTypes = map[string][2]string{
+	".docm": {"Word.DocumentMacroEnabled.12", "application/vnd.ms-word.document.macroEnabled.12"},
+	".docx": {"Word.Document.12", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".pptm": {"PowerPoint.ShowMacroEnabled.12", "application/vnd.ms-powerpoint.presentation.macroEnabled.12"},
+	".pptx": {"PowerPoint.Show.12", "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".xlsm": {"Excel.SheetMacroEnabled.12", "application/vnd.ms-excel.sheet.macroEnabled.12"},
+	".xlsx": {"Excel.Sheet.12", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
+// packagerCLSID defined the class identifier of the OLE Packager, which used
+// to wrap the embedded files in the OLE10Native stream.
+var packagerCLSID = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+// AddEmbeddedObject provides the method to embed a file as an OLE object in a
+// worksheet by given worksheet name and embedded object settings. The Office
+// Open XML documents (DOCM, DOCX, PPTM, PPTX, XLSM and XLSX) will be embedded
+// as the package parts with their ProgID, and the other files will be wrapped
+// in the OLE Package object. The ProgID of the object can be specified by the
+// ProgID field. The object will be represented by the given picture, which
+// placed over the cells like the AddPicture function with the format settings
+// of the picture, a default icon will be used if the picture is not
+// specified. Set the DisplayAsIcon field to true to show the object as an
+// icon, otherwise the picture will be treated as the preview of the object
+// content. Note that the VML shapes of the embedded objects, which are only
+// used by the Excel 2007 will not be created. For example, embed a PDF file
+// shown as an icon in the cell B2 on Sheet1:
+//
+//	file, err := os.ReadFile("report.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	icon, err := os.ReadFile("icon.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddEmbeddedObject("Sheet1", excelize.EmbeddedObject{
+//	    Cell:          "B2",
+//	    FileName:      "report.pdf",
+//	    File:          file,
+//	    DisplayAsIcon: true,
+//	    Picture:       &excelize.Picture{Extension: ".png", File: icon},
+//	})
+func (f *File) AddEmbeddedObject(sheet string, obj EmbeddedObject) error {
+	if obj.FileName == "" || len(obj.File) == 0 {
+		return ErrParameterRequired
+	}
+	pic := obj.Picture
+	if pic == nil {
+		pic = &Picture{Extension: ".png", File: defaultEmbeddedObjectIcon()}
+	}
+	ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
+	if !ok {
+		return ErrImgExt
+	}
+	opts := parseGraphicOptions(pic.Format)
+	if opts.Positioning != "" && inStrSlice(supportedPositioning, opts.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
+	img, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(obj.Cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	width, height := int(float64(img.Width)*opts.ScaleX), int(float64(img.Height)*opts.ScaleY)
+	if opts.AutoFit {
+		if width, height, col, row, err = f.drawingResize(sheet, obj.Cell, float64(img.Width), float64(img.Height), opts); err != nil {
+			return err
+		}
+	}
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	objects, err := f.decodeOleObjects(ws)
+	if err != nil {
+		return err
+	}
+	shapeID, err := f.getMaxShapeID(sheet, ws, objects)
+	if err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	relType, progID := SourceRelationshipOLEObject, "Package"
+	contentType, content := ContentTypeOLEObject, newOLEPackage(obj.FileName, obj.File)
+	partName := "xl/embeddings/oleObject%d.bin"
+	if pkgType, ok := embeddedPackageTypes[strings.ToLower(path.Ext(obj.FileName))]; ok {
+		relType, progID, contentType, content = SourceRelationshipPackage, pkgType[0], pkgType[1], obj.File
+		partName = "xl/embeddings/package%d" + strings.ToLower(path.Ext(obj.FileName))
+	}
+	if obj.ProgID != "" {
+		progID = obj.ProgID
+	}
+	partName = f.getEmbeddingPartName(partName)
+	f.Pkg.Store(partName, content)
+	if err = f.setContentTypes("/"+partName, contentType); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	rID := f.addRels(sheetRels, relType, strings.Replace(partName, "xl", "..", 1), "")
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(pic.File, ext), "xl")
+	picRID := f.addRels(sheetRels, SourceRelationshipImage, mediaStr, "")
+	oleObject := xlsxOleObject{
+		ProgID: progID, DvAspect: "DVASPECT_CONTENT",
+		ShapeID: shapeID + 1, RID: "rId" + strconv.Itoa(rID),
+	}
+	if obj.DisplayAsIcon {
+		oleObject.DvAspect = "DVASPECT_ICON"
+	}
+	fallback := oleObject
+	oleObject.ObjectPr = &xlsxObjectPr{
+		DefaultSize: boolPtr(false),
+		AutoPict:    boolPtr(false),
+		AltText:     opts.AltText,
+		RID:         "rId" + strconv.Itoa(picRID),
+		Anchor: xlsxObjectAnchor{
+			MoveWithCells: opts.Positioning != "absolute",
+			SizeWithCells: opts.Positioning == "" || opts.Positioning == "twoCell",
+			From:          xlsxFrom{Col: colStart, ColOff: opts.OffsetX * EMU, Row: rowStart, RowOff: opts.OffsetY * EMU},
+			To:            xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		},
+	}
+	if !*opts.Locked {
+		oleObject.ObjectPr.Locked = opts.Locked
+	}
+	if !*opts.PrintObject {
+		oleObject.ObjectPr.Print = opts.PrintObject
+	}
+	output, err := xml.Marshal(xlsxOleObjectContent{
+		XMLNSMC:  SourceRelationshipCompatibility.Value,
+		Choice:   xlsxOleObjectChoice{Requires: NameSpaceSpreadSheetX14.Name.Local, OleObject: oleObject},
+		Fallback: xlsxOleObjectFallback{OleObject: fallback},
+	})
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxInnerXML{}
+	}
+	ws.OleObjects.Content += string(output)
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	f.addSheetNameSpace(sheet, NameSpaceDrawingMLSpreadSheet)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// GetEmbeddedObjects provides a function to get all embedded OLE objects and
+// files in a worksheet by given worksheet name. The payload of the objects
+// will be extracted from the OLE Package objects, and the content of the
+// other OLE objects will be returned as the compound file, the anchor cell
+// and the icon or preview picture of the objects will also be returned. The
+// file name of the objects will be reduced to the base name without any
+// directory. For example, save the embedded objects to the "objects"
+// directory:
+//
+//	objects, err := f.GetEmbeddedObjects("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, obj := range objects {
+//	    name := filepath.Join("objects", filepath.Base(obj.FileName))
+//	    if err := os.WriteFile(name, obj.File, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetEmbeddedObjects(sheet string) ([]EmbeddedObject, error) {
+	var embeddedObjects []EmbeddedObject
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return embeddedObjects, err
+	}
+	f.mu.Unlock()
+	objects, err := f.decodeOleObjects(ws)
+	if err != nil {
+		return embeddedObjects, err
+	}
+	for _, object := range objects {
+		rel := f.getSheetRelationshipByID(sheet, object.RID)
+		if rel == nil {
+			continue
+		}
+		obj := EmbeddedObject{
+			ProgID:        object.ProgID,
+			FileName:      path.Base(rel.Target),
+			DisplayAsIcon: object.DvAspect == "DVASPECT_ICON",
+		}
+		if content, ok := f.pkgLoad(getSheetRelationshipsTargetPath(rel.Target)); ok {
+			obj.File = content.([]byte)
+		}
+		if rel.Type == SourceRelationshipOLEObject {
+			if name, file, ok := extractOLEObject(obj.File); ok {
+				obj.File = file
+				if name = filepath.Base(strings.ReplaceAll(name, "\\", "/")); name != "." && name != ".." && name != string(filepath.Separator) {
+					obj.FileName = name
+				}
+			}
+		}
+		if err = f.getEmbeddedObjectAnchor(sheet, ws, object, &obj); err != nil {
+			return embeddedObjects, err
+		}
+		embeddedObjects = append(embeddedObjects, obj)
+	}
+	return embeddedObjects, err
+}
+
+// decodeOleObjects provides a function to parse the embedded objects in the
+// oleObjects element of the worksheet, the oleObject in the Choice element
+// will be used if the embedded object has the AlternateContent element.
+func (f *File) decodeOleObjects(ws *xlsxWorksheet) ([]decodeOleObject, error) {
+	var (
+		objects    []decodeOleObject
+		oleObjects decodeOleObjects
+	)
+	if ws.OleObjects == nil {
+		return objects, nil
+	}
+	if err := f.xmlNewDecoder(strings.NewReader("<oleObjects>" + ws.OleObjects.Content + "</oleObjects>")).
+		Decode(&oleObjects); err != nil && err != io.EOF {
+		return objects, err
+	}
+	objects = append(objects, oleObjects.OleObject...)
+	for _, content := range oleObjects.AlternateContent {
+		if content.Choice != nil && len(content.Choice.OleObject) > 0 {
+			objects = append(objects, content.Choice.OleObject...)
+			continue
+		}
+		if content.Fallback != nil {
+			objects = append(objects, content.Fallback.OleObject...)
+		}
+	}
+	return objects, nil
+}
+
+// getMaxShapeID provides a function to get the maximum shape ID of the
+// embedded objects and the VML shapes in the worksheet, the shape ID of the
+// new embedded object should be greater than it to avoid referencing the
+// existing VML shapes.
+func (f *File) getMaxShapeID(sheet string, ws *xlsxWorksheet, objects []decodeOleObject) (int, error) {
+	shapeID := 1024
+	for _, object := range objects {
+		shapeID = maxInt(shapeID, object.ShapeID)
+	}
+	if ws.LegacyDrawing == nil {
+		return shapeID, nil
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, sp := range vml.Shape {
+			if ID, err := strconv.Atoi(strings.TrimPrefix(sp.ID, "_x0000_s")); err == nil {
+				shapeID = maxInt(shapeID, ID)
+			}
+		}
+		return shapeID, nil
+	}
+	vml, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil || vml == nil {
+		return shapeID, err
+	}
+	for _, sp := range vml.Shape {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(sp.ID, "_x0000_s")); err == nil {
+			shapeID = maxInt(shapeID, ID)
+		}
+	}
+	return shapeID, err
+}
+
+// getEmbeddedObjectAnchor provides a function to get the anchor cell and the
+// picture of the embedded object by the objectPr element, or by the VML shape
+// of the object if the objectPr element doesn't exist.
+func (f *File) getEmbeddedObjectAnchor(sheet string, ws *xlsxWorksheet, object decodeOleObject, obj *EmbeddedObject) error {
+	if objectPr := object.ObjectPr; objectPr != nil {
+		anchor := objectPr.Anchor
+		cell, err := CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+		if err != nil {
+			return err
+		}
+		obj.Cell = cell
+		format := &GraphicOptions{
+			AltText:     objectPr.AltText,
+			PrintObject: objectPr.Print,
+			Locked:      objectPr.Locked,
+			OffsetX:     anchor.From.ColOff / EMU,
+			OffsetY:     anchor.From.RowOff / EMU,
+		}
+		if !anchor.SizeWithCells {
+			format.Positioning = "oneCell"
+			if !anchor.MoveWithCells {
+				format.Positioning = "absolute"
+			}
+		}
+		if rel := f.getSheetRelationshipByID(sheet, objectPr.RID); rel != nil {
+			obj.Picture = f.getEmbeddedObjectPicture(getSheetRelationshipsTargetPath(rel.Target), format)
+		}
+		return err
+	}
+	if ws.LegacyDrawing == nil {
+		return nil
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	vml, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil || vml == nil {
+		return err
+	}
+	for _, sp := range vml.Shape {
+		if sp.ID != fmt.Sprintf("_x0000_s%d", object.ShapeID) {
+			continue
+		}
+		var shapeVal decodeOleShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil {
+			return err
+		}
+		col, row, err := extractAnchorCell(shapeVal.ClientData.Anchor)
+		if err != nil {
+			return err
+		}
+		if obj.Cell, err = CoordinatesToCellName(col+1, row+1); err != nil {
+			return err
+		}
+		vmlRels := strings.Replace(drawingVML, "xl/drawings/", "xl/drawings/_rels/", 1) + ".rels"
+		if rel := f.getDrawingRelationships(vmlRels, shapeVal.ImageData.RelID); rel != nil {
+			obj.Picture = f.getEmbeddedObjectPicture(strings.ReplaceAll(rel.Target, "..", "xl"), nil)
+		}
+		break
+	}
+	return err
+}
+
+// getEmbeddedObjectPicture provides a function to get the picture of the
+// embedded object by given part path of the picture and format settings.
+func (f *File) getEmbeddedObjectPicture(name string, format *GraphicOptions) *Picture {
+	content, ok := f.pkgLoad(name)
+	if !ok {
+		return nil
+	}
+	return &Picture{Extension: strings.ToLower(path.Ext(name)), File: content.([]byte), Format: format}
+}
+
+// getSheetRelationshipByID provides a function to get the relationship in
+// the worksheet relationships part by given worksheet name and relationship
+// ID.
+func (f *File) getSheetRelationshipByID(sheet, rID string) *xlsxRelationship {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels")
+	if rels == nil {
+		return nil
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.ID == rID {
+			rel := rel
+			return &rel
+		}
+	}
+	return nil
+}
+
+// getSheetRelationshipsTargetPath returns the part path of the relationship
+// target in the worksheet relationships part.
+func getSheetRelationshipsTargetPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join("xl/worksheets", target)
+}
+
+// getEmbeddingPartName provides a function to get an unused part name of the
+// embedded object by given part name format which contains the index verb.
+func (f *File) getEmbeddingPartName(format string) string {
+	f.loadLazyParts("xl/embeddings/")
+	for idx := 1; ; idx++ {
+		name := fmt.Sprintf(format, idx)
+		if _, ok := f.Pkg.Load(name); !ok {
+			return name
+		}
+	}
+}
+
+// newOLEPackage provides a function to wrap the file in the OLE Package
+// object, which is a compound file with the CompObj stream and the
+// OLE10Native stream contains the file name and file content.
+func newOLEPackage(name string, file []byte) []byte {
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: packagerCLSID}},
+	}
+	compObj := new(bytes.Buffer)
+	compObj.Write([]byte{0x01, 0x00, 0xFE, 0xFF, 0x03, 0x0A, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF})
+	compObj.Write(packagerCLSID)
+	for _, val := range []string{"OLE Package", "", "Package"} {
+		if val == "" {
+			_ = binary.Write(compObj, binary.LittleEndian, uint32(0))
+			continue
+		}
+		_ = binary.Write(compObj, binary.LittleEndian, uint32(len(val)+1))
+		compObj.WriteString(val + "\x00")
+	}
+	_ = binary.Write(compObj, binary.LittleEndian, []uint32{0x71B239F4, 0, 0, 0})
+	compoundFile.put("\x01CompObj", compObj.Bytes())
+	compoundFile.put("\x01Ole10Native", newOle10Native(name, file))
+	return compoundFile.write()
+}
+
+// newOle10Native provides a function to create the OLE10Native stream by given
+// file name and file content. The ANSI file name will be written with the
+// question mark for the non-ASCII characters, and the Unicode file name will
+// be written after the file content.
+func newOle10Native(name string, file []byte) []byte {
+	ansi := []byte(strings.Map(func(r rune) rune {
+		if r > 0x7F {
+			return '?'
+		}
+		return r
+	}, name))
+	ansi = append(ansi, 0)
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, uint16(2))
+	buf.Write(ansi)
+	buf.Write(ansi)
+	_ = binary.Write(buf, binary.LittleEndian, []uint32{0x00030000, uint32(len(ansi))})
+	buf.Write(ansi)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(file)))
+	buf.Write(file)
+	unicode := utf16.Encode([]rune(name))
+	for i := 0; i < 3; i++ {
+		_ = binary.Write(buf, binary.LittleEndian, uint32(len(unicode)))
+		_ = binary.Write(buf, binary.LittleEndian, unicode)
+	}
+	stream := make([]byte, 4, 4+buf.Len())
+	binary.LittleEndian.PutUint32(stream, uint32(buf.Len()))
+	return append(stream, buf.Bytes()...)
+}
+
+// extractOLEObject provides a function to extract the file name and payload
+// from the OLE object compound file. The file in the OLE10Native stream of the
+// OLE Package object, or the content of the Package and CONTENTS stream will
+// be returned.
+func extractOLEObject(content []byte) (string, []byte, bool) {
+	doc, err := mscfb.New(bytes.NewReader(content))
+	if err != nil {
+		return "", nil, false
+	}
+	var payload []byte
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		name := strings.TrimPrefix(entry.Name, "\x01")
+		if name != "Ole10Native" && name != "Package" && name != "CONTENTS" {
+			continue
+		}
+		buf := make([]byte, entry.Size)
+		if _, err = io.ReadFull(doc, buf); err != nil {
+			return "", nil, false
+		}
+		if name == "Ole10Native" {
+			name, file := parseOle10Native(buf)
+			return name, file, true
+		}
+		payload = buf
+	}
+	return "", payload, payload != nil
+}
+
+// parseOle10Native provides a function to parse the file name and file
+// content in the OLE10Native stream, the native data of the stream will be
+// returned if the stream is not created by the OLE Packager.
+func parseOle10Native(stream []byte) (string, []byte) {
+	if len(stream) < 4 {
+		return "", stream
+	}
+	data := stream[4:]
+	if size := int(binary.LittleEndian.Uint32(stream)); size <= len(data) {
+		data = data[:size]
+	}
+	native, buf := data, data
+	readString := func() (string, bool) {
+		idx := bytes.IndexByte(buf, 0)
+		if idx == -1 {
+			return "", false
+		}
+		val := string(buf[:idx])
+		buf = buf[idx+1:]
+		return val, true
+	}
+	readUint32 := func() (int, bool) {
+		if len(buf) < 4 {
+			return 0, false
+		}
+		val := int(binary.LittleEndian.Uint32(buf))
+		buf = buf[4:]
+		return val, true
+	}
+	if len(buf) < 2 || binary.LittleEndian.Uint16(buf) != 2 {
+		return "", native
+	}
+	buf = buf[2:]
+	name, ok := readString()
+	if _, ok2 := readString(); !ok || !ok2 {
+		return "", native
+	}
+	if _, ok = readUint32(); !ok {
+		return "", native
+	}
+	size, ok := readUint32()
+	if !ok || size > len(buf) {
+		return "", native
+	}
+	buf = buf[size:]
+	if size, ok = readUint32(); !ok || size > len(buf) {
+		return "", native
+	}
+	file := buf[:size]
+	buf = buf[size:]
+	// Use the Unicode file name if it exists after the file content
+	if size, ok = readUint32(); !ok || size*2 > len(buf) {
+		return name, file
+	}
+	buf = buf[size*2:]
+	if size, ok = readUint32(); ok && size > 0 && size*2 <= len(buf) {
+		unicode := make([]uint16, size)
+		for i := range unicode {
+			unicode[i] = binary.LittleEndian.Uint16(buf[i*2:])
+		}
+		name = string(utf16.Decode(unicode))
+	}
+	return name, file
+}
+
+// defaultEmbeddedObjectIcon returns the PNG image of a blank document, which
+// used as the default icon of the embedded object.
+func defaultEmbeddedObjectIcon() []byte {
+	const width, height, fold = 32, 40, 10
+	var (
+		img    = image.NewNRGBA(image.Rect(0, 0, width, height))
+		border = color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}
+		buf    = new(bytes.Buffer)
+	)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			switch dx := x - (width - fold); {
+			case dx > y:
+				continue
+			case x == 0 || y == 0 || x == width-1 || y == height-1 || dx == y || (dx >= 0 && y == fold) || x == width-fold && y < fold:
+				img.Set(x, y, border)
+			default:
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+	_ = png.Encode(buf, img)
+	return buf.Bytes()
+}
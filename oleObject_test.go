@@ -0,0 +1,241 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedObject(t *testing.T) {
+	f := NewFile()
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	book := NewFile()
+	assert.NoError(t, book.SetCellValue("Sheet1", "A1", "Embedded"))
+	buf, err := book.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, book.Close())
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{
+		Cell: "B2", FileName: "report.pdf", File: pdf, DisplayAsIcon: true,
+		Picture: &Picture{Extension: ".png", File: icon, Format: &GraphicOptions{
+			AltText: "Report", OffsetX: 10, OffsetY: 5, Positioning: "oneCell", PrintObject: boolPtr(false),
+		}},
+	}))
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "E2", FileName: "data.xlsx", File: buf.Bytes()}))
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{
+		Cell: "H2", FileName: "报告.txt", File: []byte("text"), ProgID: "Package",
+		Picture: &Picture{Extension: ".png", File: icon, Format: &GraphicOptions{Positioning: "absolute", Locked: boolPtr(false)}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestEmbeddedObject.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get embedded objects from the saved spreadsheet
+	content, err := os.ReadFile(filepath.Join("test", "TestEmbeddedObject.xlsx"))
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	parts := map[string]string{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		assert.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+		parts[file.Name] = string(b)
+	}
+	assert.Contains(t, parts[defaultXMLPathContentTypes], `<Override PartName="/xl/embeddings/oleObject1.bin" ContentType="application/vnd.openxmlformats-officedocument.oleObject">`)
+	assert.Contains(t, parts[defaultXMLPathContentTypes], `<Override PartName="/xl/embeddings/package1.xlsx" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet">`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<oleObject progId="Package" dvAspect="DVASPECT_ICON" shapeId="1026" r:id="rId3">`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<from><xdr:col>1</xdr:col>`)
+
+	f, err = OpenReader(bytes.NewReader(content))
+	assert.NoError(t, err)
+	objects, err := f.GetEmbeddedObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "B2", objects[0].Cell)
+	assert.Equal(t, "Package", objects[0].ProgID)
+	assert.Equal(t, "report.pdf", objects[0].FileName)
+	assert.Equal(t, pdf, objects[0].File)
+	assert.True(t, objects[0].DisplayAsIcon)
+	assert.Equal(t, icon, objects[0].Picture.File)
+	assert.Equal(t, ".png", objects[0].Picture.Extension)
+	assert.Equal(t, &GraphicOptions{
+		AltText: "Report", OffsetX: 10, OffsetY: 5, Positioning: "oneCell", PrintObject: boolPtr(false),
+	}, objects[0].Picture.Format)
+	assert.Equal(t, "E2", objects[1].Cell)
+	assert.Equal(t, "Excel.Sheet.12", objects[1].ProgID)
+	assert.Equal(t, "package1.xlsx", objects[1].FileName)
+	assert.False(t, objects[1].DisplayAsIcon)
+	assert.Equal(t, defaultEmbeddedObjectIcon(), objects[1].Picture.File)
+	embedded, err := OpenReader(bytes.NewReader(objects[1].File))
+	assert.NoError(t, err)
+	val, err := embedded.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Embedded", val)
+	assert.NoError(t, embedded.Close())
+	assert.Equal(t, "H2", objects[2].Cell)
+	assert.Equal(t, "报告.txt", objects[2].FileName)
+	assert.Equal(t, []byte("text"), objects[2].File)
+	assert.Equal(t, "absolute", objects[2].Picture.Format.Positioning)
+	assert.False(t, *objects[2].Picture.Format.Locked)
+
+	// Test add embedded object to the worksheet which has embedded objects
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "K2", FileName: "notes.txt", File: []byte("notes")}))
+	objects, err = f.GetEmbeddedObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+	assert.Equal(t, "notes.txt", objects[3].FileName)
+	// Test get embedded objects with the directory in the file name
+	for i, name := range []string{"..\\..\\evil.txt", "../dir/evil.txt", "C:\\Windows\\evil.txt"} {
+		assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "N2", FileName: name, File: []byte("evil")}))
+		objects, err = f.GetEmbeddedObjects("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, objects, 5+i)
+		assert.Equal(t, "evil.txt", objects[4+i].FileName, name)
+	}
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "N2", FileName: "..", File: []byte("evil")}))
+	objects, err = f.GetEmbeddedObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "oleObject7.bin", objects[7].FileName)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, ws.OleObjects.Content, `shapeId="1029"`)
+	assert.Contains(t, ws.OleObjects.Content, `r:id="rId9"`)
+	// Test get embedded objects with unsupported charset worksheet
+	ws.OleObjects.Content = "<oleObject"
+	_, err = f.GetEmbeddedObjects("Sheet1")
+	assert.Error(t, err)
+	assert.Error(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes")}))
+	assert.NoError(t, f.Close())
+
+	// Test add embedded object with invalid parameters
+	f = NewFile()
+	assert.Equal(t, ErrParameterRequired, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "A1"}))
+	obj := EmbeddedObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes")}
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.AddEmbeddedObject("SheetN", obj))
+	obj.Picture = &Picture{Extension: ".jpg", File: []byte("jpg")}
+	assert.EqualError(t, f.AddEmbeddedObject("Sheet1", obj), image.ErrFormat.Error())
+	obj.Picture = &Picture{Extension: ".txt", File: icon}
+	assert.Equal(t, ErrImgExt, f.AddEmbeddedObject("Sheet1", obj))
+	obj.Picture = &Picture{Extension: ".png", File: icon, Format: &GraphicOptions{Positioning: "x"}}
+	assert.Equal(t, ErrParameterInvalid, f.AddEmbeddedObject("Sheet1", obj))
+	obj.Picture, obj.Cell = nil, "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddEmbeddedObject("Sheet1", obj))
+	obj.Cell = "A1"
+	obj.Picture = &Picture{Extension: ".png", File: icon, Format: &GraphicOptions{AutoFit: true}}
+	assert.NoError(t, f.AddEmbeddedObject("Sheet1", obj))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C2"))
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	obj.Cell = "B1"
+	assert.Error(t, f.AddEmbeddedObject("Sheet2", obj))
+	_, err = f.GetEmbeddedObjects("Sheet2")
+	assert.Error(t, err)
+	_, err = f.GetEmbeddedObjects("SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetEmbeddedObjectsWithVML(t *testing.T) {
+	f := NewFile()
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.LegacyDrawing = &xlsxLegacyDrawing{RID: "rId1"}
+	ws.OleObjects = &xlsxInnerXML{Content: `<oleObject progId="Package" shapeId="1025" r:id="rId2"/><oleObject progId="Package" shapeId="1026" r:id="rId3"/><oleObject progId="Package" shapeId="1027" r:id="rId4"/>`}
+	f.Relationships.Store("xl/worksheets/_rels/sheet1.xml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipDrawingVML, Target: "../drawings/vmlDrawing1.vml"},
+		{ID: "rId2", Type: SourceRelationshipOLEObject, Target: "../embeddings/oleObject1.bin"},
+		{ID: "rId3", Type: SourceRelationshipOLEObject, Target: "/xl/embeddings/oleObject2.bin"},
+	}})
+	f.Relationships.Store("xl/drawings/_rels/vmlDrawing1.vml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipImage, Target: "../media/image1.png"},
+	}})
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", []byte(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:schemas-microsoft-com:office:excel"><v:shapetype id="_x0000_t75"></v:shapetype><v:shape id="_x0000_s1025" type="#_x0000_t75"><v:imagedata o:relid="rId1"/><x:ClientData ObjectType="Pict"><x:Anchor>2, 0, 3, 0, 4, 0, 6, 0</x:Anchor></x:ClientData></v:shape></xml>`))
+	f.Pkg.Store("xl/media/image1.png", icon)
+	f.Pkg.Store("xl/embeddings/oleObject1.bin", newOLEPackage("data.csv", []byte("a,b")))
+	// Test get embedded object with the CONTENTS stream
+	compoundFile := &cfb{paths: []string{"Root Entry/"}, sectors: []sector{{name: "Root Entry", typeID: 5}}}
+	compoundFile.put("CONTENTS", []byte("%PDF-1.4"))
+	f.Pkg.Store("xl/embeddings/oleObject2.bin", compoundFile.write())
+	objects, err := f.GetEmbeddedObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+	assert.Equal(t, "C4", objects[0].Cell)
+	assert.Equal(t, "data.csv", objects[0].FileName)
+	assert.Equal(t, []byte("a,b"), objects[0].File)
+	assert.Equal(t, icon, objects[0].Picture.File)
+	assert.Equal(t, "", objects[1].Cell)
+	assert.Equal(t, "oleObject2.bin", objects[1].FileName)
+	assert.Equal(t, []byte("%PDF-1.4"), objects[1].File)
+	// Test get embedded objects with invalid VML anchor
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", []byte(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:x="urn:schemas-microsoft-com:office:excel"><v:shape id="_x0000_s1025"><x:ClientData><x:Anchor>A</x:Anchor></x:ClientData></v:shape></xml>`))
+	f.DecodeVMLDrawing = map[string]*decodeVmlDrawing{}
+	_, err = f.GetEmbeddedObjects("Sheet1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", []byte(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:x="urn:schemas-microsoft-com:office:excel"><v:shape id="_x0000_s1025"><x:ClientData><x:Anchor>-2, 0, 0, 0, 0, 0, 0, 0</x:Anchor></x:ClientData></v:shape></xml>`))
+	f.DecodeVMLDrawing = map[string]*decodeVmlDrawing{}
+	_, err = f.GetEmbeddedObjects("Sheet1")
+	assert.Error(t, err)
+	// Test add embedded object with unsupported charset VML drawing
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	f.DecodeVMLDrawing = map[string]*decodeVmlDrawing{}
+	assert.Error(t, f.AddEmbeddedObject("Sheet1", EmbeddedObject{Cell: "A1", FileName: "a.txt", File: []byte("a")}))
+	f.DecodeVMLDrawing = map[string]*decodeVmlDrawing{}
+	_, err = f.GetEmbeddedObjects("Sheet1")
+	assert.Error(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestParseOle10Native(t *testing.T) {
+	native := newOle10Native("a.txt", []byte("a"))
+	name, file := parseOle10Native(native)
+	assert.Equal(t, "a.txt", name)
+	assert.Equal(t, []byte("a"), file)
+	// Test parse the stream without the Unicode file name
+	size := 4 + 2 + 6 + 6 + 8 + 6 + 4 + 1
+	name, file = parseOle10Native(native[:size])
+	assert.Equal(t, "a.txt", name)
+	assert.Equal(t, []byte("a"), file)
+	// Test parse the native data which isn't created by the OLE Packager
+	name, file = parseOle10Native([]byte{3, 0, 0, 0, 1, 0, 2})
+	assert.Equal(t, "", name)
+	assert.Equal(t, []byte{1, 0, 2}, file)
+	name, file = parseOle10Native([]byte{1})
+	assert.Equal(t, "", name)
+	assert.Equal(t, []byte{1}, file)
+	for _, stream := range [][]byte{native[:8], native[:14], native[:19], native[:23], native[:26], native[:33]} {
+		stream = append([]byte(nil), stream...)
+		binary.LittleEndian.PutUint32(stream, uint32(len(stream)-4))
+		name, file = parseOle10Native(stream)
+		assert.Equal(t, "", name)
+		assert.Equal(t, stream[4:], file)
+	}
+	_, _, ok := extractOLEObject([]byte("ole"))
+	assert.False(t, ok)
+}
+
+func TestDefaultEmbeddedObjectIcon(t *testing.T) {
+	img, ext, err := image.DecodeConfig(bytes.NewReader(defaultEmbeddedObjectIcon()))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", ext)
+	assert.Equal(t, 32, img.Width)
+	assert.Equal(t, 40, img.Height)
+	assert.True(t, strings.HasPrefix(string(defaultEmbeddedObjectIcon()), "\x89PNG"))
+}
@@ -0,0 +1,1300 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// PDFOptions defined the options for render the worksheet to the PDF
+// document.
+//
+// RangeRef specifies the range of the cells to be rendered, such as "A1:D10".
+// The whole columns like "A:C" and the whole rows like "1:3" will be limited
+// to the used range of the worksheet. By default, the print area of the
+// worksheet will be rendered, and the used range of the worksheet will be
+// rendered if the print area is not defined.
+//
+// RawCellValue specifies if draw the raw cell values on the PDF pages, by
+// default, the cell values will be drawn with the number format applied, or
+// as raw values if the workbook was opened with the RawCellValue option.
+//
+// ShowGridLines specifies if render the gridlines around the cells, the
+// gridlines will also be rendered when the print gridlines option of the
+// worksheet is set.
+type PDFOptions struct {
+	RangeRef      string
+	RawCellValue  bool
+	ShowGridLines bool
+}
+
+// pdfPaperSizes defined the width and height in points of the paper by the
+// paper size index of the page layout.
+var pdfPaperSizes = map[int][2]float64{
+	1: {612, 792}, 2: {612, 792}, 3: {792, 1224}, 4: {1224, 792}, 5: {612, 1008},
+	6: {396, 612}, 7: {522, 756}, 8: {841.89, 1190.55}, 9: {595.28, 841.89},
+	10: {595.28, 841.89}, 11: {419.53, 595.28}, 12: {708.66, 1000.63},
+	13: {498.9, 708.66}, 14: {612, 936}, 15: {609.45, 779.53}, 16: {720, 1008},
+	17: {792, 1224}, 18: {612, 792}, 19: {279, 639}, 20: {297, 684}, 21: {324, 747},
+	22: {342, 792}, 23: {360, 828}, 24: {1224, 1584}, 25: {1584, 2448},
+	26: {2448, 3168}, 27: {311.81, 623.62}, 28: {459.21, 649.13},
+	29: {918.43, 1298.27}, 30: {649.13, 918.43}, 31: {323.15, 459.21},
+	32: {323.15, 649.13}, 33: {708.66, 1000.63}, 34: {498.9, 708.66},
+	35: {498.9, 354.33}, 36: {311.81, 651.97}, 37: {279, 540}, 38: {261, 468},
+	39: {1071, 792}, 40: {612, 864}, 41: {612, 936}, 42: {708.66, 1000.63},
+	43: {283.46, 419.53}, 44: {648, 792}, 45: {720, 792}, 46: {1080, 792},
+	47: {623.62, 623.62}, 50: {667.8, 864}, 51: {667.8, 1080}, 52: {841.68, 1296},
+	53: {668.98, 912.76}, 54: {595.8, 792}, 55: {595.28, 841.89}, 56: {667.8, 864},
+	57: {643.46, 1009.13}, 58: {864.57, 1380.47}, 59: {612, 913.68},
+	60: {595.28, 935.43}, 61: {419.53, 595.28}, 62: {515.91, 728.5},
+	63: {912.76, 1261.42}, 64: {493.23, 666.14}, 65: {569.76, 782.36},
+	66: {1190.55, 1683.78}, 67: {841.89, 1190.55}, 68: {912.76, 1261.42},
+	69: {566.93, 419.53}, 70: {297.64, 419.53}, 75: {792, 612},
+	76: {1190.55, 841.89}, 77: {841.89, 595.28}, 78: {595.28, 419.53},
+}
+
+// pdfFontNames defined the names of the standard Type 1 fonts, the index of
+// the font is calculated by the family, bold and italic of the font.
+var pdfFontNames = []string{
+	"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique",
+	"Times-Roman", "Times-Bold", "Times-Italic", "Times-BoldItalic",
+	"Courier", "Courier-Bold", "Courier-Oblique", "Courier-BoldOblique",
+}
+
+// pdfFontWidths defined the glyph widths of the printable ASCII characters
+// of the regular and bold Helvetica and Times fonts in thousandths of the
+// font size.
+var pdfFontWidths = [][]int{
+	{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+	{
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	},
+	{
+		250, 333, 555, 500, 500, 1000, 833, 278, 333, 333, 500, 570, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 333, 333, 570, 570, 570, 500,
+		930, 722, 667, 722, 722, 667, 611, 778, 778, 389, 500, 778, 667, 944, 722, 778,
+		611, 778, 722, 556, 667, 722, 722, 1000, 722, 722, 667, 333, 278, 333, 581, 500,
+		333, 500, 556, 444, 556, 444, 333, 500, 556, 278, 333, 556, 278, 833, 556, 500,
+		556, 556, 444, 389, 333, 556, 500, 722, 500, 500, 444, 394, 220, 394, 520,
+	},
+}
+
+// pdfBorderStyles defined the line width in points and the dash pattern by
+// the index of the cell border style.
+var pdfBorderStyles = []struct {
+	width float64
+	dash  string
+}{
+	{0, ""}, {0.5, ""}, {1, ""}, {0.5, "[3 1]"}, {0.5, "[1 1]"}, {1.5, ""},
+	{0.5, ""}, {0.25, ""}, {1, "[4 2]"}, {0.5, "[3 1 1 1]"}, {1, "[4 2 1 2]"},
+	{0.5, "[3 1 1 1 1 1]"}, {1, "[4 2 1 2 1 2]"}, {1, "[4 1 2 1]"},
+}
+
+// pdfDocument directly maps the objects of the PDF document, the object
+// number is the index of the object plus one.
+type pdfDocument struct {
+	objects [][]byte
+}
+
+// pdfPageSetup directly maps the paper size, margins in points, the scale,
+// page order and header and footer settings of the printed pages.
+type pdfPageSetup struct {
+	width, height               float64
+	left, right, top, bottom    float64
+	header, footer              float64
+	scale                       float64
+	centerH, centerV, downFirst bool
+	firstNumber                 int
+	headerFooter                *HeaderFooterOptions
+}
+
+// pdfPage directly maps the visible columns and rows printed on a page.
+type pdfPage struct {
+	cols, rows []int
+}
+
+// pdfBox directly maps the cell or merged cell to be drawn on a page, the
+// position and size are in points relative to the top-left of the page
+// content.
+type pdfBox struct {
+	x, y, w, h    float64
+	col, row      int
+	endCol        int
+	endRow        int
+	merged        bool
+	cell          *htmlCell
+	style         *Style
+	overflowWidth float64
+}
+
+// pdfImage directly maps the image XObject name, object number and the size
+// in pixels of the image.
+type pdfImage struct {
+	name          string
+	id            int
+	width, height float64
+}
+
+// pdfPicture directly maps the image, the zero-based column, offset, row and
+// offset of the cell anchors, and the position and size in points relative
+// to the top-left of the rendered range.
+type pdfPicture struct {
+	image      *pdfImage
+	from, to   []int
+	x, y, w, h float64
+}
+
+// pdfHeaderFooterSection directly maps the text and font of the left, center
+// or right section of the header or footer.
+type pdfHeaderFooterSection struct {
+	text string
+	font Font
+}
+
+// pdfRenderer directly maps the worksheet, the layout of the rendered range
+// and the resources of the PDF document. The cell values are loaded by the
+// cell loader of the HTML renderer.
+type pdfRenderer struct {
+	htmlRenderer
+	doc         *pdfDocument
+	gridLines   bool
+	mono        bool
+	defaultFont Font
+	cellStyles  map[int]*Style
+	fonts       map[int]int
+	images      map[string]*pdfImage
+	pictures    []*pdfPicture
+	cols, rows  []int
+	colX, rowY  map[int]float64
+	colW, rowH  map[int]float64
+	merges      [][]int
+	covered     map[[2]int]bool
+}
+
+// ExportPDF provides a function to render the worksheet to the io.Writer as
+// the PDF document by given worksheet name and the optional settings. The
+// rendered range will be laid out by the paper size, orientation, margins,
+// scaling, fit to page, page order, page breaks, centering and header and
+// footer of the worksheet, and the cell values with the number format,
+// fonts, fills, borders, alignment, merged cells and pictures will be drawn
+// on the pages. The pictures in EMF, WMF and SVG format will be ignored, and
+// the fonts will be mapped to the standard Helvetica, Times and Courier fonts
+// of the PDF document. Note that the standard fonts only support the
+// characters in the Windows-1252 encoding, the other characters, such as
+// Chinese, Japanese, Korean, Cyrillic and Greek characters will be rendered
+// as the question mark, please use the ExportHTML function for the worksheet
+// which contains these characters. For example, render the print area of the
+// worksheet named 'Sheet1' to the file:
+//
+//	out, err := os.Create("Book1.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer out.Close()
+//	if err := f.ExportPDF("Sheet1", out); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExportPDF(sheet string, w io.Writer, opts ...PDFOptions) error {
+	var options PDFOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	r := pdfRenderer{
+		htmlRenderer: htmlRenderer{
+			f: f, sheet: sheet, cells: map[[2]int]*htmlCell{}, links: map[[2]int]htmlLink{},
+			options: HTMLOptions{RangeRef: options.RangeRef, RawCellValue: options.RawCellValue},
+		},
+		doc: &pdfDocument{}, gridLines: options.ShowGridLines, cellStyles: map[int]*Style{},
+		fonts: map[int]int{}, images: map[string]*pdfImage{}, colX: map[int]float64{},
+		rowY: map[int]float64{}, colW: map[int]float64{}, rowH: map[int]float64{},
+		covered: map[[2]int]bool{},
+	}
+	if r.options.RangeRef == "" {
+		r.options.RangeRef = f.getPrintArea(sheet)
+	}
+	rect, err := r.loadCells()
+	if err != nil {
+		return err
+	}
+	r.doc.add([]byte("<</Type /Catalog /Pages 2 0 R>>"))
+	r.doc.add(nil)
+	r.doc.add(nil)
+	if rect, err = r.loadPictures(rect); err != nil {
+		return err
+	}
+	if err = r.loadLayout(rect); err != nil {
+		return err
+	}
+	r.placePictures()
+	setup, err := r.loadPageSetup()
+	if err != nil {
+		return err
+	}
+	pages := r.paginate(setup)
+	var kids []string
+	for idx, page := range pages {
+		content, err := r.renderPage(setup, page, idx, len(pages))
+		if err != nil {
+			return err
+		}
+		contentID := r.doc.add(pdfStream("", content, ""))
+		kids = append(kids, strconv.Itoa(r.doc.add([]byte(fmt.Sprintf(
+			"<</Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources 3 0 R /Contents %d 0 R>>",
+			pdfNum(setup.width), pdfNum(setup.height), contentID))))+" 0 R")
+	}
+	r.doc.set(2, []byte("<</Type /Pages /Kids ["+strings.Join(kids, " ")+"] /Count "+strconv.Itoa(len(kids))+">>"))
+	var fonts, images strings.Builder
+	for idx := range pdfFontNames {
+		if id, ok := r.fonts[idx]; ok {
+			fonts.WriteString(fmt.Sprintf("/F%d %d 0 R", idx, id))
+		}
+	}
+	for i := 1; i <= len(r.images); i++ {
+		for _, img := range r.images {
+			if img.name == "Im"+strconv.Itoa(i) {
+				images.WriteString(fmt.Sprintf("/%s %d 0 R", img.name, img.id))
+			}
+		}
+	}
+	r.doc.set(3, []byte("<</ProcSet [/PDF /Text /ImageB /ImageC] /Font <<"+fonts.String()+">> /XObject <<"+images.String()+">>>>"))
+	return r.doc.writeTo(w)
+}
+
+// getPrintArea returns the first area of the print area defined name of the
+// worksheet without the worksheet name and absolute reference signs, an
+// empty string will be returned if the print area is not defined.
+func (f *File) getPrintArea(sheet string) string {
+	for _, dn := range f.GetDefinedName() {
+		if dn.Name != builtInDefinedNames[0] || dn.Scope != sheet {
+			continue
+		}
+		var quoted bool
+		for i, c := range dn.RefersTo {
+			if c == '\'' {
+				quoted = !quoted
+			}
+			if c == '!' && !quoted {
+				ref := strings.ReplaceAll(strings.SplitN(dn.RefersTo[i+1:], ",", 2)[0], "$", "")
+				if !strings.Contains(ref, ":") {
+					ref += ":" + ref
+				}
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+// loadPictures provides a function to load the pictures anchored in the
+// worksheet drawing, and returns the rendered range extended to contain the
+// pictures if the range is not specified.
+func (r *pdfRenderer) loadPictures(rect []int) ([]int, error) {
+	if r.ws.Drawing == nil {
+		return rect, nil
+	}
+	target := r.f.getSheetRelationshipsTargetByID(r.sheet, r.ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _, err := r.f.drawingParser(drawingXML)
+	if err != nil {
+		return rect, err
+	}
+	cb := func(a *xdrCellAnchor, rel *xlsxRelationship) {
+		pic := &pdfPicture{from: []int{a.From.Col, a.From.ColOff, a.From.Row, a.From.RowOff}}
+		if a.To != nil {
+			pic.to = []int{a.To.Col, a.To.ColOff, a.To.Row, a.To.RowOff}
+		}
+		r.addPicture(pic, rel.Target)
+	}
+	cb2 := func(a *decodeCellAnchor, rel *xlsxRelationship) {
+		pic := &pdfPicture{from: []int{a.From.Col, a.From.ColOff, a.From.Row, a.From.RowOff}}
+		if a.To != nil {
+			pic.to = []int{a.To.Col, a.To.ColOff, a.To.Row, a.To.RowOff}
+		}
+		r.addPicture(pic, rel.Target)
+	}
+	cond := func(from *xlsxFrom) bool { return true }
+	cond2 := func(from *decodeFrom) bool { return true }
+	wsDr.mu.Lock()
+	for _, a := range append(wsDr.TwoCellAnchor, wsDr.OneCellAnchor...) {
+		r.f.extractCellAnchor(a, drawingRelationships, cond, cb, cond2, cb2)
+	}
+	wsDr.mu.Unlock()
+	for _, pic := range r.pictures {
+		if r.options.RangeRef != "" {
+			break
+		}
+		rect = htmlExtendRect(rect, pic.from[0]+1, pic.from[2]+1)
+		if pic.to != nil {
+			rect = htmlExtendRect(rect, pic.to[0]+1, pic.to[2]+1)
+		}
+	}
+	return rect, nil
+}
+
+// addPicture provides a function to add the picture with the image by given
+// relationship target of the drawing, the picture with the invalid or
+// unsupported image will be ignored.
+func (r *pdfRenderer) addPicture(pic *pdfPicture, target string) {
+	path := filepath.ToSlash(filepath.Clean("xl/drawings/" + target))
+	if pic.image = r.images[path]; pic.image == nil {
+		buffer, ok := r.f.pkgLoad(path)
+		if !ok {
+			return
+		}
+		img, err := r.addImage(path, buffer.([]byte))
+		if err != nil {
+			return
+		}
+		r.images[path], pic.image = img, img
+	}
+	r.pictures = append(r.pictures, pic)
+}
+
+// placePictures provides a function to calculate the positions and sizes of
+// the pictures by the cell anchors, the picture without the ending anchor
+// will be placed in the original size of the image.
+func (r *pdfRenderer) placePictures() {
+	emuToPoints := func(emu int) float64 { return float64(emu) / float64(EMU) * 0.75 }
+	for _, pic := range r.pictures {
+		pic.x = r.offset(r.cols, r.colX, r.colW, pic.from[0]+1, emuToPoints(pic.from[1]))
+		pic.y = r.offset(r.rows, r.rowY, r.rowH, pic.from[2]+1, emuToPoints(pic.from[3]))
+		pic.w, pic.h = pic.image.width*0.75, pic.image.height*0.75
+		if pic.to != nil {
+			pic.w = r.offset(r.cols, r.colX, r.colW, pic.to[0]+1, emuToPoints(pic.to[1])) - pic.x
+			pic.h = r.offset(r.rows, r.rowY, r.rowH, pic.to[2]+1, emuToPoints(pic.to[3])) - pic.y
+		}
+	}
+}
+
+// offset returns the position in points of the column or row with the offset
+// relative to the top-left of the rendered range, the hidden columns and rows
+// will be collapsed.
+func (r *pdfRenderer) offset(list []int, starts, sizes map[int]float64, num int, offset float64) float64 {
+	for _, n := range list {
+		if n == num {
+			return starts[n] + math.Min(offset, sizes[n])
+		}
+		if n > num {
+			return starts[n]
+		}
+	}
+	if len(list) == 0 {
+		return 0
+	}
+	last := list[len(list)-1]
+	return starts[last] + sizes[last]
+}
+
+// loadLayout provides a function to load the visible columns and rows, the
+// column widths and row heights, and the merged cells in the rendered range.
+// The cells covered by the merged cells will be clipped to the rendered
+// range.
+func (r *pdfRenderer) loadLayout(rect []int) error {
+	var x, y float64
+	for col := rect[0]; col <= rect[2] && rect[0] > 0; col++ {
+		visible, err := r.visible(col, 0)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+		r.cols = append(r.cols, col)
+		r.colX[col], r.colW[col] = x, float64(r.f.getColWidth(r.sheet, col))*0.75
+		x += r.colW[col]
+	}
+	for row := rect[1]; row <= rect[3] && rect[1] > 0; row++ {
+		visible, err := r.visible(0, row)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+		height, err := r.f.GetRowHeight(r.sheet, row)
+		if err != nil {
+			return err
+		}
+		r.rows = append(r.rows, row)
+		r.rowY[row], r.rowH[row] = y, height
+		y += height
+	}
+	mergeCells, err := r.f.GetMergeCells(r.sheet)
+	if err != nil {
+		return err
+	}
+	for _, mc := range mergeCells {
+		coordinates, err := rangeRefToCoordinates(mc[0])
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		r.merges = append(r.merges, coordinates)
+		if rect[0] == 0 {
+			continue
+		}
+		for row := maxInt(coordinates[1], rect[1]); row <= minInt(coordinates[3], rect[3]); row++ {
+			for col := maxInt(coordinates[0], rect[0]); col <= minInt(coordinates[2], rect[2]); col++ {
+				r.covered[[2]int{col, row}] = col != coordinates[0] || row != coordinates[1]
+			}
+		}
+	}
+	return err
+}
+
+// loadPageSetup provides a function to load the paper size, orientation,
+// margins, scaling, page order, centering, gridlines and header and footer
+// settings of the worksheet.
+func (r *pdfRenderer) loadPageSetup() (*pdfPageSetup, error) {
+	layout, err := r.f.GetPageLayout(r.sheet)
+	if err != nil {
+		return nil, err
+	}
+	margins, err := r.f.GetPageMargins(r.sheet)
+	if err != nil {
+		return nil, err
+	}
+	setup := &pdfPageSetup{
+		left: *margins.Left * 72, right: *margins.Right * 72, top: *margins.Top * 72,
+		bottom: *margins.Bottom * 72, header: *margins.Header * 72, footer: *margins.Footer * 72,
+		scale: float64(*layout.AdjustTo) / 100, firstNumber: int(*layout.FirstPageNumber), downFirst: true,
+	}
+	size, ok := pdfPaperSizes[*layout.Size]
+	if !ok {
+		size = pdfPaperSizes[1]
+	}
+	if setup.width, setup.height = size[0], size[1]; *layout.Orientation == "landscape" {
+		setup.width, setup.height = size[1], size[0]
+	}
+	if margins.Horizontally != nil {
+		setup.centerH = *margins.Horizontally
+	}
+	if margins.Vertically != nil {
+		setup.centerV = *margins.Vertically
+	}
+	if layout.BlackAndWhite != nil {
+		r.mono = *layout.BlackAndWhite
+	}
+	if r.ws.PageSetUp != nil {
+		setup.downFirst = r.ws.PageSetUp.PageOrder != "overThenDown"
+	}
+	if r.ws.PrintOptions != nil && r.ws.PrintOptions.GridLines {
+		r.gridLines = true
+	}
+	if r.ws.SheetPr != nil && r.ws.SheetPr.PageSetUpPr != nil && r.ws.SheetPr.PageSetUpPr.FitToPage {
+		fitWidth, fitHeight := 1, 1
+		if layout.FitToWidth != nil {
+			fitWidth = *layout.FitToWidth
+		}
+		if layout.FitToHeight != nil {
+			fitHeight = *layout.FitToHeight
+		}
+		setup.scale = 1
+		if width := r.total(r.cols, r.colX, r.colW); fitWidth > 0 && width > 0 {
+			setup.scale = math.Min(setup.scale, (setup.width-setup.left-setup.right)*float64(fitWidth)/width)
+		}
+		if height := r.total(r.rows, r.rowY, r.rowH); fitHeight > 0 && height > 0 {
+			setup.scale = math.Min(setup.scale, (setup.height-setup.top-setup.bottom)*float64(fitHeight)/height)
+		}
+	}
+	if setup.headerFooter, err = r.f.GetHeaderFooter(r.sheet); err != nil {
+		return setup, err
+	}
+	if style, err := r.f.GetStyle(0); err == nil && style.Font != nil {
+		r.defaultFont = *style.Font
+	}
+	if r.defaultFont.Size == 0 {
+		r.defaultFont.Size = 11
+	}
+	return setup, err
+}
+
+// total returns the total width or height in points of the given columns or
+// rows.
+func (r *pdfRenderer) total(list []int, starts, sizes map[int]float64) float64 {
+	if len(list) == 0 {
+		return 0
+	}
+	last := list[len(list)-1]
+	return starts[last] + sizes[last] - starts[list[0]]
+}
+
+// paginate provides a function to split the visible columns and rows into
+// pages by the printable area, scale and manual page breaks, and returns the
+// pages in the page order of the worksheet.
+func (r *pdfRenderer) paginate(setup *pdfPageSetup) []pdfPage {
+	split := func(list []int, sizes map[int]float64, limit float64, brks []*xlsxBrk) [][]int {
+		breaks := map[int]bool{}
+		for _, brk := range brks {
+			breaks[brk.ID] = true
+		}
+		var (
+			groups [][]int
+			sum    float64
+		)
+		for i, n := range list {
+			if i == 0 || sum+sizes[n] > limit || breaks[list[i-1]] {
+				groups, sum = append(groups, nil), 0
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], n)
+			sum += sizes[n]
+		}
+		if len(groups) == 0 {
+			groups = append(groups, nil)
+		}
+		return groups
+	}
+	var colBrks, rowBrks []*xlsxBrk
+	if r.ws.ColBreaks != nil {
+		colBrks = r.ws.ColBreaks.Brk
+	}
+	if r.ws.RowBreaks != nil {
+		rowBrks = r.ws.RowBreaks.Brk
+	}
+	colGroups := split(r.cols, r.colW, (setup.width-setup.left-setup.right)/setup.scale, colBrks)
+	rowGroups := split(r.rows, r.rowH, (setup.height-setup.top-setup.bottom)/setup.scale, rowBrks)
+	var pages []pdfPage
+	if setup.downFirst {
+		for _, cols := range colGroups {
+			for _, rows := range rowGroups {
+				pages = append(pages, pdfPage{cols: cols, rows: rows})
+			}
+		}
+		return pages
+	}
+	for _, rows := range rowGroups {
+		for _, cols := range colGroups {
+			pages = append(pages, pdfPage{cols: cols, rows: rows})
+		}
+	}
+	return pages
+}
+
+// cellStyle returns the style definition of the cell by given style index.
+func (r *pdfRenderer) cellStyle(styleID int) (*Style, error) {
+	if style, ok := r.cellStyles[styleID]; ok {
+		return style, nil
+	}
+	style, err := r.f.GetStyle(styleID)
+	if err != nil {
+		return style, err
+	}
+	if style.Font == nil {
+		font := r.defaultFont
+		style.Font = &font
+	}
+	if style.Font.Size == 0 {
+		style.Font.Size = r.defaultFont.Size
+	}
+	r.cellStyles[styleID] = style
+	return style, err
+}
+
+// pageBoxes provides a function to collect the cells and merged cells to be
+// drawn on the page, the positions are relative to the top-left of the page.
+func (r *pdfRenderer) pageBoxes(page pdfPage) ([]*pdfBox, error) {
+	var boxes []*pdfBox
+	if len(page.cols) == 0 || len(page.rows) == 0 {
+		return boxes, nil
+	}
+	originX, originY := r.colX[page.cols[0]], r.rowY[page.rows[0]]
+	inPage := func(list []int, start, end int) bool {
+		for _, n := range list {
+			if n >= start && n <= end {
+				return true
+			}
+		}
+		return false
+	}
+	newBox := func(col, row, endCol, endRow int) (*pdfBox, error) {
+		box := &pdfBox{col: col, row: row, endCol: endCol, endRow: endRow, cell: r.cells[[2]int{col, row}]}
+		styleID := 0
+		if box.cell != nil {
+			styleID = box.cell.styleID
+		}
+		style, err := r.cellStyle(styleID)
+		if err != nil {
+			return box, err
+		}
+		box.style = style
+		box.x = r.offset(r.cols, r.colX, r.colW, col, 0) - originX
+		box.y = r.offset(r.rows, r.rowY, r.rowH, row, 0) - originY
+		box.w = r.offset(r.cols, r.colX, r.colW, endCol+1, 0) - originX - box.x
+		box.h = r.offset(r.rows, r.rowY, r.rowH, endRow+1, 0) - originY - box.y
+		return box, err
+	}
+	for _, mc := range r.merges {
+		if !inPage(page.cols, mc[0], mc[2]) || !inPage(page.rows, mc[1], mc[3]) {
+			continue
+		}
+		box, err := newBox(mc[0], mc[1], mc[2], mc[3])
+		if err != nil {
+			return boxes, err
+		}
+		if box.w > 0 && box.h > 0 {
+			box.merged = true
+			boxes = append(boxes, box)
+		}
+	}
+	for _, row := range page.rows {
+		for i, col := range page.cols {
+			if _, ok := r.covered[[2]int{col, row}]; ok {
+				continue
+			}
+			box, err := newBox(col, row, col, row)
+			if err != nil {
+				return boxes, err
+			}
+			box.overflowWidth = box.w
+			for _, next := range page.cols[i+1:] {
+				cell := r.cells[[2]int{next, row}]
+				if _, merged := r.covered[[2]int{next, row}]; merged || (cell != nil && cell.value != "") {
+					break
+				}
+				box.overflowWidth += r.colW[next]
+			}
+			boxes = append(boxes, box)
+		}
+	}
+	return boxes, nil
+}
+
+// renderPage provides a function to render the fills, gridlines, borders,
+// cell values and pictures of the page, and the header and footer, returns
+// the content stream of the page.
+func (r *pdfRenderer) renderPage(setup *pdfPageSetup, page pdfPage, idx, total int) ([]byte, error) {
+	var buf bytes.Buffer
+	boxes, err := r.pageBoxes(page)
+	if err != nil {
+		return nil, err
+	}
+	width, height := r.total(page.cols, r.colX, r.colW), r.total(page.rows, r.rowY, r.rowH)
+	x, y := setup.left, setup.top
+	if setup.centerH {
+		x += math.Max((setup.width-setup.left-setup.right-width*setup.scale)/2, 0)
+	}
+	if setup.centerV {
+		y += math.Max((setup.height-setup.top-setup.bottom-height*setup.scale)/2, 0)
+	}
+	fmt.Fprintf(&buf, "q %s 0 0 %s %s %s cm 0 0 %s %s re W n\n", pdfNum(setup.scale), pdfNum(-setup.scale),
+		pdfNum(x), pdfNum(setup.height-y), pdfNum(width), pdfNum(height))
+	for _, box := range boxes {
+		r.drawFill(&buf, box)
+	}
+	if r.gridLines {
+		buf.WriteString("q 0.25 w 0.831 0.831 0.831 RG\n")
+		for _, box := range boxes {
+			fmt.Fprintf(&buf, "%s %s %s %s re S\n", pdfNum(box.x), pdfNum(box.y), pdfNum(box.w), pdfNum(box.h))
+		}
+		buf.WriteString("Q\n")
+	}
+	for _, box := range boxes {
+		if err = r.drawBorders(&buf, box); err != nil {
+			return nil, err
+		}
+	}
+	for _, box := range boxes {
+		r.drawValue(&buf, box)
+	}
+	if len(page.cols) > 0 && len(page.rows) > 0 {
+		originX, originY := r.colX[page.cols[0]], r.rowY[page.rows[0]]
+		for _, pic := range r.pictures {
+			if pic.w <= 0 || pic.h <= 0 || pic.x+pic.w <= originX || pic.x >= originX+width ||
+				pic.y+pic.h <= originY || pic.y >= originY+height {
+				continue
+			}
+			fmt.Fprintf(&buf, "q %s 0 0 %s %s %s cm /%s Do Q\n", pdfNum(pic.w), pdfNum(-pic.h),
+				pdfNum(pic.x-originX), pdfNum(pic.y-originY+pic.h), pic.image.name)
+		}
+	}
+	buf.WriteString("Q\n")
+	r.drawHeaderFooter(&buf, setup, idx, total)
+	return buf.Bytes(), nil
+}
+
+// drawFill provides a function to draw the background of the cell.
+func (r *pdfRenderer) drawFill(buf *bytes.Buffer, box *pdfBox) {
+	fill := box.style.Fill
+	if r.mono || len(fill.Color) == 0 || (fill.Type != "gradient" && fill.Pattern <= 0) {
+		return
+	}
+	if color := pdfColor(fill.Color[0]); color != "" {
+		fmt.Fprintf(buf, "%s rg %s %s %s %s re f\n", color, pdfNum(box.x), pdfNum(box.y), pdfNum(box.w), pdfNum(box.h))
+	}
+}
+
+// drawBorders provides a function to draw the borders of the cell, the right
+// and bottom borders of the merged cell are taken from the cells at the
+// top-right and bottom-left of the merged cell.
+func (r *pdfRenderer) drawBorders(buf *bytes.Buffer, box *pdfBox) error {
+	sides := map[string]Border{}
+	for _, border := range box.style.Border {
+		sides[border.Type] = border
+	}
+	for side, cell := range map[string][2]int{"right": {box.endCol, box.row}, "bottom": {box.col, box.endRow}} {
+		if !box.merged {
+			continue
+		}
+		delete(sides, side)
+		styleID := 0
+		if c := r.cells[cell]; c != nil {
+			styleID = c.styleID
+		}
+		style, err := r.cellStyle(styleID)
+		if err != nil {
+			return err
+		}
+		for _, border := range style.Border {
+			if border.Type == side {
+				sides[side] = border
+			}
+		}
+	}
+	x1, y1, x2, y2 := box.x, box.y, box.x+box.w, box.y+box.h
+	for _, side := range []string{"left", "right", "top", "bottom", "diagonalDown", "diagonalUp"} {
+		border, ok := sides[side]
+		if !ok || border.Style <= 0 || border.Style >= len(pdfBorderStyles) {
+			continue
+		}
+		line := map[string][]float64{
+			"left": {x1, y1, x1, y2}, "right": {x2, y1, x2, y2}, "top": {x1, y1, x2, y1},
+			"bottom": {x1, y2, x2, y2}, "diagonalDown": {x1, y1, x2, y2}, "diagonalUp": {x1, y2, x2, y1},
+		}[side]
+		color := pdfColor(border.Color)
+		if color == "" || r.mono {
+			color = "0 0 0"
+		}
+		style := pdfBorderStyles[border.Style]
+		fmt.Fprintf(buf, "q %s w %s RG [] 0 d\n", pdfNum(style.width), color)
+		if style.dash != "" {
+			fmt.Fprintf(buf, "%s 0 d\n", style.dash)
+		}
+		offsets := []float64{0}
+		if border.Style == 6 {
+			offsets = []float64{-0.75, 0.75}
+		}
+		for _, offset := range offsets {
+			dx, dy := offset, 0.0
+			if line[1] == line[3] {
+				dx, dy = 0, offset
+			}
+			fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(line[0]+dx), pdfNum(line[1]+dy),
+				pdfNum(line[2]+dx), pdfNum(line[3]+dy))
+		}
+		buf.WriteString("Q\n")
+	}
+	return nil
+}
+
+// drawValue provides a function to draw the value of the cell with the font
+// and alignment of the cell style, the text will be clipped by the cell, and
+// the left aligned text without wrapping can overflow into the adjacent
+// empty cells.
+func (r *pdfRenderer) drawValue(buf *bytes.Buffer, box *pdfBox) {
+	if box.cell == nil || box.cell.value == "" {
+		return
+	}
+	font, alignment := box.style.Font, box.style.Alignment
+	if alignment == nil {
+		alignment = &Alignment{}
+	}
+	horizontal := alignment.Horizontal
+	if horizontal == "" || horizontal == "general" {
+		horizontal = "left"
+		if box.cell.numeric || box.cell.cellT == "d" {
+			horizontal = "right"
+		}
+		if box.cell.cellT == "b" || box.cell.cellT == "e" {
+			horizontal = "center"
+		}
+	}
+	if horizontal == "centerContinuous" {
+		horizontal = "center"
+	}
+	fontIdx := pdfFontIndex(font)
+	measure := func(text string) float64 {
+		return pdfTextWidth(fontIdx, font.Size, pdfEncodeText(text))
+	}
+	padding, indent := 2.0, float64(alignment.Indent)*9
+	textWidth := box.w - padding*2 - indent
+	var lines []string
+	for _, line := range strings.Split(box.cell.value, "\n") {
+		if alignment.WrapText {
+			lines = append(lines, pdfWrapText(line, textWidth, measure)...)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if !alignment.WrapText && box.cell.numeric && !box.merged && len(lines) == 1 && measure(lines[0]) > textWidth {
+		lines[0] = strings.Repeat("#", int(math.Max(textWidth/measure("#"), 1)))
+	}
+	clipWidth := box.w
+	if horizontal == "left" && !alignment.WrapText && !box.merged {
+		clipWidth = box.overflowWidth
+	}
+	lineHeight := font.Size * 1.2
+	top := box.y + box.h - float64(len(lines))*lineHeight - 1
+	switch alignment.Vertical {
+	case "top":
+		top = box.y + 1
+	case "center", "distributed", "justify":
+		top = box.y + (box.h-float64(len(lines))*lineHeight)/2
+	}
+	fmt.Fprintf(buf, "q %s %s %s %s re W n\n", pdfNum(box.x), pdfNum(box.y), pdfNum(clipWidth), pdfNum(box.h))
+	for i, line := range lines {
+		width, x := measure(line), box.x+padding+indent
+		switch horizontal {
+		case "right":
+			x = box.x + box.w - padding - indent - width
+		case "center", "distributed", "justify":
+			x = box.x + (box.w-width)/2
+		}
+		r.drawText(buf, font, fontIdx, line, x, top+float64(i)*lineHeight+font.Size, width)
+	}
+	buf.WriteString("Q\n")
+}
+
+// drawText provides a function to draw the single line text at the given
+// position of the baseline with the font color, underline and strikethrough.
+func (r *pdfRenderer) drawText(buf *bytes.Buffer, font *Font, fontIdx int, text string, x, baseline, width float64) {
+	color := "0 0 0"
+	if c := pdfColor(r.f.GetBaseColor(font.Color, font.ColorIndexed, font.ColorTheme)); c != "" && !r.mono {
+		color = c
+	}
+	if _, ok := r.fonts[fontIdx]; !ok {
+		r.fonts[fontIdx] = r.doc.add([]byte("<</Type /Font /Subtype /Type1 /BaseFont /" + pdfFontNames[fontIdx] + " /Encoding /WinAnsiEncoding>>"))
+	}
+	fmt.Fprintf(buf, "BT /F%d %s Tf %s rg 1 0 0 -1 %s %s Tm %s Tj ET\n", fontIdx, pdfNum(font.Size), color,
+		pdfNum(x), pdfNum(baseline), pdfEscape(pdfEncodeText(text)))
+	var decorations []float64
+	if font.Underline == "single" || font.Underline == "double" {
+		decorations = append(decorations, baseline+font.Size*0.1)
+		if font.Underline == "double" {
+			decorations = append(decorations, baseline+font.Size*0.2)
+		}
+	}
+	if font.Strike {
+		decorations = append(decorations, baseline-font.Size*0.3)
+	}
+	for _, y := range decorations {
+		fmt.Fprintf(buf, "q %s w %s RG %s %s m %s %s l S Q\n", pdfNum(font.Size*0.05), color,
+			pdfNum(x), pdfNum(y), pdfNum(x+width), pdfNum(y))
+	}
+}
+
+// drawHeaderFooter provides a function to draw the header and footer of the
+// page by given page index and total pages count.
+func (r *pdfRenderer) drawHeaderFooter(buf *bytes.Buffer, setup *pdfPageSetup, idx, total int) {
+	hf := setup.headerFooter
+	if hf == nil {
+		return
+	}
+	number := setup.firstNumber + idx
+	header, footer := hf.OddHeader, hf.OddFooter
+	if hf.DifferentOddEven && number%2 == 0 {
+		header, footer = hf.EvenHeader, hf.EvenFooter
+	}
+	if hf.DifferentFirst && idx == 0 {
+		header, footer = hf.FirstHeader, hf.FirstFooter
+	}
+	fmt.Fprintf(buf, "q 1 0 0 -1 0 %s cm\n", pdfNum(setup.height))
+	for i, format := range []string{header, footer} {
+		for pos, section := range r.parseHeaderFooter(format, number, total+setup.firstNumber-1) {
+			if section.text == "" {
+				continue
+			}
+			font, lines := section.font, strings.Split(section.text, "\n")
+			fontIdx, lineHeight := pdfFontIndex(&font), font.Size*1.2
+			y := setup.header + font.Size
+			if i == 1 {
+				y = setup.height - setup.footer - font.Size*0.2 - float64(len(lines)-1)*lineHeight
+			}
+			for _, line := range lines {
+				width := pdfTextWidth(fontIdx, font.Size, pdfEncodeText(line))
+				x := []float64{setup.left, (setup.width - width) / 2, setup.width - setup.right - width}[pos]
+				r.drawText(buf, &font, fontIdx, line, x, y, width)
+				y += lineHeight
+			}
+		}
+	}
+	buf.WriteString("Q\n")
+}
+
+// parseHeaderFooter provides a function to parse the header or footer format
+// codes into the left, center and right sections by given page number and
+// total pages count. The section, page number, pages count, date, time,
+// worksheet name, file name and path, font name and style, font size, color,
+// bold, italic, underline and strikethrough codes are supported.
+func (r *pdfRenderer) parseHeaderFooter(format string, number, total int) [3]pdfHeaderFooterSection {
+	var sections [3]pdfHeaderFooterSection
+	for i := range sections {
+		sections[i].font = Font{Family: r.defaultFont.Family, Size: r.defaultFont.Size}
+	}
+	runes, sec := []rune(format), 1
+	for i := 0; i < len(runes); i++ {
+		section := &sections[sec]
+		if runes[i] != '&' || i+1 == len(runes) {
+			section.text += string(runes[i])
+			continue
+		}
+		i++
+		switch c := runes[i]; c {
+		case 'L', 'C', 'R':
+			sec = strings.IndexRune("LCR", c)
+		case 'P':
+			section.text += strconv.Itoa(number)
+		case 'N':
+			section.text += strconv.Itoa(total)
+		case 'D':
+			section.text += time.Now().Format("1/2/2006")
+		case 'T':
+			section.text += time.Now().Format("3:04 PM")
+		case 'A':
+			section.text += r.sheet
+		case 'F':
+			section.text += filepath.Base(r.f.Path)
+		case 'Z':
+			section.text += filepath.Dir(r.f.Path) + string(filepath.Separator)
+		case 'B':
+			section.font.Bold = !section.font.Bold
+		case 'I':
+			section.font.Italic = !section.font.Italic
+		case 'U', 'E':
+			if section.font.Underline = "single"; c == 'E' {
+				section.font.Underline = "double"
+			}
+		case 'S':
+			section.font.Strike = !section.font.Strike
+		case '&':
+			section.text += "&"
+		case '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			spec := strings.SplitN(string(runes[i+1:minInt(end, len(runes))]), ",", 2)
+			if spec[0] != "-" {
+				section.font.Family = spec[0]
+			}
+			if len(spec) > 1 {
+				section.font.Bold = strings.Contains(spec[1], "Bold")
+				section.font.Italic = strings.Contains(spec[1], "Italic")
+			}
+			i = end
+		case 'K':
+			if i+6 < len(runes) {
+				section.font.Color = string(runes[i+1 : i+7])
+			}
+			i += 6
+		default:
+			if unicode.IsDigit(c) {
+				end := i
+				for end < len(runes) && unicode.IsDigit(runes[end]) {
+					end++
+				}
+				size, _ := strconv.Atoi(string(runes[i:end]))
+				section.font.Size, i = float64(size), end-1
+			}
+		}
+	}
+	return sections
+}
+
+// addImage provides a function to add the image XObject by given picture
+// part path and content, the JPEG image will be embedded directly, and the
+// PNG, GIF, BMP and TIFF image will be decoded and compressed with the alpha
+// channel as the soft mask. This function returns the resource name, object
+// number and size of the image XObject.
+func (r *pdfRenderer) addImage(path string, data []byte) (*pdfImage, error) {
+	var (
+		img      image.Image
+		err      error
+		dict     string
+		pixels   []byte
+		filter   string
+		bounds   image.Rectangle
+		softMask int
+	)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		colorSpace := "/DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			colorSpace = "/DeviceCMYK /Decode [1 0 1 0 1 0 1 0]"
+		}
+		bounds, pixels, filter = image.Rect(0, 0, cfg.Width, cfg.Height), data, "DCTDecode"
+		dict = "/ColorSpace " + colorSpace
+	case ".png":
+		img, err = png.Decode(bytes.NewReader(data))
+	case ".gif":
+		img, err = gif.Decode(bytes.NewReader(data))
+	case ".bmp":
+		img, err = bmp.Decode(bytes.NewReader(data))
+	case ".tif", ".tiff":
+		img, err = tiff.Decode(bytes.NewReader(data))
+	default:
+		err = ErrImgExt
+	}
+	if err != nil {
+		return nil, err
+	}
+	if img != nil {
+		bounds = img.Bounds()
+		var alpha []byte
+		opaque := true
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				pixels = append(pixels, c.R, c.G, c.B)
+				alpha = append(alpha, c.A)
+				opaque = opaque && c.A == 255
+			}
+		}
+		if !opaque {
+			softMask = r.doc.add(pdfStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8",
+				bounds.Dx(), bounds.Dy()), alpha, ""))
+			dict = fmt.Sprintf(" /SMask %d 0 R", softMask)
+		}
+		dict = "/ColorSpace /DeviceRGB" + dict
+	}
+	return &pdfImage{
+		name: "Im" + strconv.Itoa(len(r.images)+1),
+		id: r.doc.add(pdfStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /BitsPerComponent 8 %s",
+			bounds.Dx(), bounds.Dy(), dict), pixels, filter)),
+		width: float64(bounds.Dx()), height: float64(bounds.Dy()),
+	}, err
+}
+
+// add provides a function to append the object to the PDF document, and
+// returns the object number.
+func (d *pdfDocument) add(obj []byte) int {
+	d.objects = append(d.objects, obj)
+	return len(d.objects)
+}
+
+// set provides a function to replace the object by given object number.
+func (d *pdfDocument) set(id int, obj []byte) {
+	d.objects[id-1] = obj
+}
+
+// writeTo provides a function to write the objects, cross-reference table
+// and trailer of the PDF document to the io.Writer.
+func (d *pdfDocument) writeTo(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(d.objects))
+	for i, obj := range d.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<</Size %d /Root 1 0 R>>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfStream returns the stream object by given stream dictionary entries,
+// data and filter, the data will be compressed with the Flate filter if the
+// filter is not specified.
+func pdfStream(dict string, data []byte, filter string) []byte {
+	if filter == "" {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write(data)
+		_ = zw.Close()
+		data, filter = buf.Bytes(), "FlateDecode"
+	}
+	return append([]byte(fmt.Sprintf("<<%s /Length %d /Filter /%s>>\nstream\n", dict, len(data), filter)),
+		append(data, []byte("\nendstream")...)...)
+}
+
+// pdfNum returns the shortest representation of the number in the PDF
+// content stream with at most 3 decimal places.
+func pdfNum(num float64) string {
+	s := strings.TrimRight(strings.TrimRight(strconv.FormatFloat(num, 'f', 3, 64), "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// pdfColor returns the RGB color operands by given RGB or ARGB hex color, an
+// empty string will be returned for the invalid color.
+func pdfColor(color string) string {
+	if color = htmlColor(color); color == "" {
+		return color
+	}
+	var rgb []string
+	for i := 1; i < 7; i += 2 {
+		c, _ := strconv.ParseUint(color[i:i+2], 16, 8)
+		rgb = append(rgb, pdfNum(float64(c)/255))
+	}
+	return strings.Join(rgb, " ")
+}
+
+// pdfFontIndex returns the index of the standard font by given font, the
+// serif and monospace font families will be mapped to the Times and Courier
+// font, and the others will be mapped to the Helvetica font.
+func pdfFontIndex(font *Font) int {
+	var idx int
+	family := strings.ToLower(font.Family)
+	for _, name := range []string{"times", "cambria", "georgia", "garamond", "serif", "roman", "book", "song", "ming"} {
+		if strings.Contains(family, name) && !strings.Contains(family, "sans") {
+			idx = 4
+		}
+	}
+	for _, name := range []string{"courier", "consolas", "mono", "console"} {
+		if strings.Contains(family, name) {
+			idx = 8
+		}
+	}
+	if font.Bold {
+		idx++
+	}
+	if font.Italic {
+		idx += 2
+	}
+	return idx
+}
+
+// pdfTextWidth returns the width in points of the encoded text by given font
+// index and font size.
+func pdfTextWidth(fontIdx int, size float64, text []byte) float64 {
+	var width int
+	for _, c := range text {
+		switch {
+		case fontIdx >= 8:
+			width += 600
+		case c >= 32 && c <= 126:
+			width += pdfFontWidths[fontIdx/4*2+fontIdx%2][c-32]
+		default:
+			width += pdfFontWidths[fontIdx/4*2+fontIdx%2]['0'-32]
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+// pdfEncodeText returns the text in the Windows-1252 encoding, and the
+// characters which not supported by the encoding will be replaced by the
+// question mark.
+func pdfEncodeText(text string) []byte {
+	var encoded []byte
+	for _, c := range text {
+		b, ok := charmap.Windows1252.EncodeRune(c)
+		if !ok {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+// pdfEscape returns the literal string of the PDF content stream by given
+// encoded text.
+func pdfEscape(text []byte) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, c := range text {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 32 || c > 126:
+			sb.WriteString(fmt.Sprintf("\\%03o", c))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// pdfWrapText provides a function to break the text into lines which fit the
+// given width by the words, and the word wider than the width will be broken
+// by the characters.
+func pdfWrapText(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Split(text, " ") {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if measure(candidate) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, c := range word {
+			if line != "" && measure(line+string(c)) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(c)
+		}
+	}
+	return append(lines, line)
+}
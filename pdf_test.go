@@ -0,0 +1,274 @@
+package excelize
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPDF(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name (x) ü € 中"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1234.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "long text overflowing into next cells"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{10, 20, 30, 40, 12345678901}))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Merged\nline"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	numFmt := "#,##0.00"
+	styleID, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Color: "FF0000", Underline: "double", Strike: true, Family: "Times New Roman"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:       []Border{{Type: "bottom", Style: 6, Color: "0000FF"}, {Type: "left", Style: 3}, {Type: "diagonalDown", Style: 1}},
+		Alignment:    &Alignment{Horizontal: "center", Vertical: "top", WrapText: true},
+		CustomNumFmt: &numFmt,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	styleID, err = f.NewStyle(&Style{
+		Font:      &Font{Family: "Courier New", Italic: true},
+		Border:    []Border{{Type: "right", Style: 2, Color: "00FF00"}, {Type: "bottom", Style: 5}},
+		Alignment: &Alignment{Horizontal: "right", Vertical: "center", Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E1", "E2", styleID))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "G2", "G2", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "G2", "Courier"))
+	assert.NoError(t, f.AddPicture("Sheet1", "B5", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "F5", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "F15", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A20"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A25", "Second page"))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		OddHeader: "&L&BBold &A&RPage &P of &N",
+		OddFooter: "&C&\"Courier,Bold Italic\"&14&KFF0000Footer && more&U&S&E",
+	}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportPDF("Sheet1", &buf, PDFOptions{ShowGridLines: true}))
+	objects, contents := parsePDF(t, buf.Bytes())
+	assert.Contains(t, objects[1], "/Type /Pages /Kids [")
+	assert.Contains(t, objects[1], "/Count 2")
+	assert.Len(t, contents, 2)
+	for _, font := range []string{"Helvetica", "Helvetica-Bold", "Times-Bold", "Courier-Oblique", "Courier-BoldOblique"} {
+		assert.Contains(t, strings.Join(objects, "\n"), "/BaseFont /"+font+" ")
+	}
+	assert.Contains(t, strings.Join(objects, "\n"), "/SMask")
+	assert.Contains(t, strings.Join(objects, "\n"), "/Filter /DCTDecode")
+	assert.Contains(t, contents[0], "q 1 0 0 -1 50.4 738 cm 0 0 493.5 300 re W n")
+	assert.Contains(t, contents[0], `(Name \(x\) \374 \200 ?) Tj`)
+	assert.Contains(t, contents[0], "1 1 0 rg 109.5 0 48 30 re f")
+	assert.Contains(t, contents[0], "(1,234.50) Tj")
+	assert.Contains(t, contents[0], "1 0 0 rg")
+	assert.Contains(t, contents[0], "(TRUE) Tj")
+	assert.Contains(t, contents[0], "q 0 30 205.5 15 re W n")
+	assert.Contains(t, contents[0], "(#######) Tj")
+	assert.Contains(t, contents[0], "q 205.5 0 96 45 re W n")
+	assert.Contains(t, contents[0], "0.831 0.831 0.831 RG")
+	assert.Contains(t, contents[0], "[3 1] 0 d")
+	assert.Contains(t, contents[0], "109.5 29.25 m 157.5 29.25 l S")
+	assert.Contains(t, contents[0], "0 0 1 RG")
+	assert.Contains(t, contents[0], "0 1 0 RG")
+	assert.Contains(t, contents[0], "/Im1 Do")
+	assert.Contains(t, contents[0], "/Im2 Do")
+	assert.Equal(t, 2, strings.Count(contents[0], "/Im2 Do"))
+	assert.NotContains(t, contents[0], "/Im3 Do")
+	assert.Contains(t, contents[0], "(Bold Sheet1) Tj")
+	assert.Contains(t, contents[0], "(Page 1 of 2) Tj")
+	assert.Contains(t, contents[0], "/F11 14 Tf 1 0 0 rg")
+	assert.Contains(t, contents[0], "(Footer & more) Tj")
+	assert.Contains(t, contents[0], "/F10 11 Tf")
+	assert.Contains(t, contents[1], "/Im2 Do")
+	assert.Contains(t, contents[1], "(Second page) Tj")
+	assert.Contains(t, contents[1], "(Page 2 of 2) Tj")
+
+	// Test render the print area with the page setup
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "'Sheet1'!$A$1:$B$3,Sheet1!$D$1", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
+		Size: intPtr(9), Orientation: stringPtr("landscape"), FirstPageNumber: uintPtr(3), BlackAndWhite: boolPtr(true),
+	}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{
+		Left: float64Ptr(1), Top: float64Ptr(1), Horizontally: boolPtr(true), Vertically: boolPtr(true),
+	}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		DifferentFirst: true, FirstHeader: "&CFirst &P&\"-,Italic\"", OddHeader: "&COdd",
+	}))
+	buf.Reset()
+	assert.NoError(t, f.ExportPDF("Sheet1", &buf))
+	objects, contents = parsePDF(t, buf.Bytes())
+	assert.Len(t, contents, 1)
+	assert.Contains(t, strings.Join(objects, "\n"), "/MediaBox [0 0 841.89 595.28]")
+	assert.Contains(t, contents[0], "q 1 0 0 -1 378.195 291.64 cm 0 0 157.5 60 re W n")
+	assert.Contains(t, contents[0], "(First 3) Tj")
+	assert.NotContains(t, contents[0], "1 1 0 rg")
+	assert.NotContains(t, contents[0], "1 0 0 rg")
+	assert.NotContains(t, contents[0], "0.831 0.831 0.831 RG")
+	assert.NotContains(t, contents[0], "Do Q")
+
+	// Test render with fit to page, page order and gridlines of the worksheet
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "_xlnm.Print_Area", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{FitToPage: boolPtr(true)}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToHeight: intPtr(0), FitToWidth: intPtr(1)}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).PageSetUp.PageOrder = "overThenDown"
+	ws.(*xlsxWorksheet).PrintOptions.GridLines = true
+	buf.Reset()
+	assert.NoError(t, f.ExportPDF("Sheet1", &buf, PDFOptions{RangeRef: "A1:Z30", RawCellValue: true}))
+	_, contents = parsePDF(t, buf.Bytes())
+	assert.Len(t, contents, 2)
+	assert.Contains(t, contents[0], "0.831 0.831 0.831 RG")
+	assert.Contains(t, contents[0], "(1234.5) Tj")
+	assert.Contains(t, contents[0], "q 0.588 0 0 -0.588 72 349.829 cm")
+	// Test render empty worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.ExportPDF("Sheet2", &buf))
+	_, contents = parsePDF(t, buf.Bytes())
+	assert.Len(t, contents, 1)
+	// Test render with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ExportPDF("Sheet1", &buf, PDFOptions{RangeRef: "A:B1"}))
+	// Test render with not exist worksheet
+	assert.EqualError(t, f.ExportPDF("SheetN", &buf), "sheet SheetN does not exist")
+	// Test render with writer error
+	assert.EqualError(t, f.ExportPDF("Sheet1", errorWriter{}), "write error")
+	// Test render with invalid style
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.Equal(t, newInvalidStyleID(100), f.ExportPDF("Sheet1", &buf))
+	assert.NoError(t, f.Close())
+
+	// Test render the picture of the workbook opened with the LoadSheets option
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "Z2000"))
+	b, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(b, Options{LoadSheets: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.ExportPDF("Sheet1", &buf, PDFOptions{RangeRef: "A1:C3"}))
+	_, contents = parsePDF(t, buf.Bytes())
+	assert.Len(t, contents, 1)
+	assert.Contains(t, contents[0], "/Im1 Do")
+	r := pdfRenderer{
+		htmlRenderer: htmlRenderer{f: f, sheet: "Sheet1"}, colX: map[int]float64{},
+		rowY: map[int]float64{}, colW: map[int]float64{}, rowH: map[int]float64{}, covered: map[[2]int]bool{},
+	}
+	assert.NoError(t, r.loadLayout([]int{1, 1, 3, 3}))
+	assert.Len(t, r.covered, 9)
+	assert.NoError(t, f.Close())
+
+	// Test render with unsupported charset drawing
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportPDF("Sheet1", &buf), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestExportPDFWholeRangeRef(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", "B1", "C1"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A2", "B2", "C2"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"A3", "B3", "C3"}))
+	for _, c := range []struct {
+		refersTo           string
+		contains, excludes []string
+	}{
+		{refersTo: "Sheet1!$A:$B", contains: []string{"(A1) Tj", "(B3) Tj"}, excludes: []string{"(C1) Tj"}},
+		{refersTo: "Sheet1!$C:$C", contains: []string{"(C1) Tj", "(C3) Tj"}, excludes: []string{"(A1) Tj"}},
+		{refersTo: "Sheet1!$1:$2", contains: []string{"(A1) Tj", "(C2) Tj"}, excludes: []string{"(A3) Tj"}},
+		{refersTo: "Sheet1!$3:$3", contains: []string{"(A3) Tj", "(C3) Tj"}, excludes: []string{"(A1) Tj"}},
+	} {
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: c.refersTo, Scope: "Sheet1"}))
+		var buf bytes.Buffer
+		assert.NoError(t, f.ExportPDF("Sheet1", &buf), c.refersTo)
+		_, contents := parsePDF(t, buf.Bytes())
+		assert.Len(t, contents, 1, c.refersTo)
+		for _, text := range c.contains {
+			assert.Contains(t, contents[0], text, c.refersTo)
+		}
+		for _, text := range c.excludes {
+			assert.NotContains(t, contents[0], text, c.refersTo)
+		}
+		assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "_xlnm.Print_Area", Scope: "Sheet1"}))
+	}
+	// Test render the whole columns of the empty worksheet
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ExportPDF("Sheet2", io.Discard, PDFOptions{RangeRef: "B:C"}))
+	// Test render with invalid whole columns and rows range reference
+	for _, ref := range []string{"A:1", "1:A", "0:1", "1:1048577"} {
+		assert.Error(t, f.ExportPDF("Sheet1", io.Discard, PDFOptions{RangeRef: ref}), ref)
+	}
+}
+
+func TestPDFAddImage(t *testing.T) {
+	r := pdfRenderer{doc: &pdfDocument{}, images: map[string]*pdfImage{}}
+	for _, ext := range []string{".gif", ".bmp", ".tif"} {
+		img, err := r.addImage("image"+ext, nil)
+		assert.Error(t, err, ext)
+		assert.Nil(t, img)
+	}
+	_, err := r.addImage("image.jpg", nil)
+	assert.Error(t, err)
+	_, err = r.addImage("image.svg", nil)
+	assert.Equal(t, ErrImgExt, err)
+}
+
+func TestPDFWrapText(t *testing.T) {
+	measure := func(text string) float64 { return float64(len(text)) }
+	assert.Equal(t, []string{"abc", "de", "fghij", "klm"}, pdfWrapText("abc de fghijklm", 5, measure))
+	assert.Equal(t, []string{""}, pdfWrapText("", 5, measure))
+	assert.Equal(t, "0", pdfNum(-0.0001))
+	assert.Equal(t, "", pdfColor("invalid"))
+	assert.Equal(t, 8, pdfFontIndex(&Font{Family: "Consolas"}))
+	assert.Equal(t, 7, pdfFontIndex(&Font{Family: "Georgia", Bold: true, Italic: true}))
+	assert.Equal(t, 0, pdfFontIndex(&Font{Family: "Microsoft Sans Serif"}))
+}
+
+// parsePDF provides a function to verify the cross-reference table of the
+// PDF document, and returns the objects and decompressed page contents.
+func parsePDF(t *testing.T, data []byte) ([]string, []string) {
+	var objects, contents []string
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if !assert.Len(t, m, 2) {
+		return objects, contents
+	}
+	xref, err := strconv.Atoi(string(m[1]))
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+	for i, entry := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1) {
+		offset, err := strconv.Atoi(string(entry[1]))
+		assert.NoError(t, err)
+		obj := data[offset:]
+		assert.True(t, bytes.HasPrefix(obj, []byte(strconv.Itoa(i+1)+" 0 obj\n")))
+		obj = obj[:bytes.Index(obj, []byte("\nendobj\n"))]
+		if idx := bytes.Index(obj, []byte(">>\nstream\n")); idx != -1 {
+			dict := string(obj[:idx+2])
+			if !strings.Contains(dict, "/Subtype /Image") {
+				zr, err := zlib.NewReader(bytes.NewReader(obj[idx+10:]))
+				assert.NoError(t, err)
+				content, err := io.ReadAll(zr)
+				assert.NoError(t, err)
+				contents = append(contents, string(content))
+			}
+			objects = append(objects, dict)
+			continue
+		}
+		objects = append(objects, string(obj))
+	}
+	return objects, contents
+}
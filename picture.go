@@ -317,6 +317,7 @@ func (f *File) addSheetPicture(sheet string, rID int) error {
 // folder xl/drawings.
 func (f *File) countDrawings() int {
 	drawings := map[string]struct{}{}
+	f.loadLazyParts("xl/drawings/")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/drawings/drawing") {
 			drawings[k.(string)] = struct{}{}
@@ -415,6 +416,7 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 // folder xl/media/image.
 func (f *File) countMedia() int {
 	count := 0
+	f.loadLazyParts("xl/media/")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/media/image") {
 			count++
@@ -580,6 +582,7 @@ func (f *File) DeletePicture(sheet, cell string) error {
 	f.Pkg.Range(checkPicRef)
 	if !used {
 		f.Pkg.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
+		f.lazyParts.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
 	}
 	f.deleteDrawingRels(drawingRels, rID)
 	return err
@@ -598,7 +601,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	cond2 := func(from *decodeFrom) bool { return from.Col == col && from.Row == row }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
-		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
+		if buffer, _ := f.pkgLoad(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
 			pics = append(pics, pic)
@@ -606,7 +609,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
-		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
+		if buffer, _ := f.pkgLoad(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
 			pics = append(pics, pic)
@@ -687,7 +690,7 @@ func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
 // serialize structure.
 func (f *File) drawingsWriter() {
 	f.Drawings.Range(func(path, d interface{}) bool {
-		if d != nil {
+		if d != nil && !f.isPartUnchanged(path.(string), d) {
 			v, _ := xml.Marshal(d.(*xlsxWsDr))
 			f.saveFileList(path.(string), v)
 		}
@@ -756,14 +759,14 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 	cond := func(from *xlsxFrom) bool { return true }
 	cond2 := func(from *decodeFrom) bool { return true }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
-		if _, ok := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
+		if _, ok := f.pkgLoad(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
 		}
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
-		if _, ok := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
+		if _, ok := f.pkgLoad(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); ok {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
@@ -918,7 +921,7 @@ func (f *File) getCellImages(sheet, cell string) ([]Picture, error) {
 			return "", true, err
 		}
 		pic.Extension = filepath.Ext(r.Target)
-		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
+		if buffer, _ := f.pkgLoad(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pics = append(pics, pic)
 		}
@@ -955,7 +958,7 @@ func (f *File) getDispImages(sheet, cell string) ([]Picture, error) {
 			for _, r := range rels.Relationships {
 				if r.ID == cellImg.Pic.BlipFill.Blip.Embed {
 					pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypeDISPIMG}
-					if buffer, _ := f.Pkg.Load("xl/" + r.Target); buffer != nil {
+					if buffer, _ := f.pkgLoad("xl/" + r.Target); buffer != nil {
 						pic.File = buffer.([]byte)
 						pic.Format.AltText = cellImg.Pic.NvPicPr.CNvPr.Descr
 						pics = append(pics, pic)
@@ -614,6 +614,7 @@ func (f *File) countPivotTables() int {
 // count storage in the folder xl/pivotCache.
 func (f *File) countPivotCache() int {
 	count := 0
+	f.loadLazyParts("xl/pivotCache/")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
 			count++
@@ -841,7 +842,7 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 // pivotTableReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotTables/pivotTable%d.xml.
 func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error) {
-	content, ok := f.Pkg.Load(path)
+	content, ok := f.pkgLoad(path)
 	pivotTable := &xlsxPivotTableDefinition{}
 	if ok && content != nil {
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
@@ -855,7 +856,7 @@ func (f *File) pivotTableReader(path string) (*xlsxPivotTableDefinition, error)
 // pivotCacheReader provides a function to get the pointer to the structure
 // after deserialization of xl/pivotCache/pivotCacheDefinition%d.xml.
 func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error) {
-	content, ok := f.Pkg.Load(path)
+	content, ok := f.pkgLoad(path)
 	pivotCache := &xlsxPivotCacheDefinition{}
 	if ok && content != nil {
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
@@ -918,6 +919,7 @@ func (f *File) genPivotCacheDefinitionID() int {
 		decodeExtLst                  = new(decodeExtLst)
 		decodeX14PivotCacheDefinition = new(decodeX14PivotCacheDefinition)
 	)
+	f.loadLazyParts("xl/pivotCache/")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
 			pc, err := f.pivotCacheReader(k.(string))
@@ -16,7 +16,6 @@ import (
 	"encoding/xml"
 	"io"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 
@@ -88,11 +87,23 @@ type Rows struct {
 	needClose, rawCellValue bool
 	sheet                   string
 	f                       *File
-	tempFile                *os.File
+	tempFile                TempFile
 	sst                     *xlsxSST
 	decoder                 *xml.Decoder
 	token                   xml.Token
 	curRowOpts, seekRowOpts RowOpts
+	hyperlinks              map[string]string
+	rangeHyperlinks         []rowsHyperlinkRange
+	rowHyperlinks           []rowsHyperlinkRange
+	rowHyperlinksNum        int
+	sharedFormulas          map[int][2]string
+}
+
+// rowsHyperlinkRange defined the range and the target of the hyperlink which
+// set on a range of cells for the rows iterator.
+type rowsHyperlinkRange struct {
+	rect   []int
+	target string
 }
 
 // Next will return true if it finds the next row element.
@@ -149,14 +160,80 @@ func (rows *Rows) Close() error {
 // data as a stream, returns each cell in a row as is, and will not skip empty
 // rows in the tail of the worksheet.
 func (rows *Rows) Columns(opts ...Options) ([]string, error) {
+	var rowIterator rowXMLIterator
+	rows.iterateRow(&rowIterator, opts...)
+	return rowIterator.cells, rowIterator.err
+}
+
+// RowCell defines the typed cell returned by the rows iterator. The Value is
+// the raw value of the cell without applying the number format, shared string
+// and inline string cells will be resolved as the text of the string. The
+// Formula is the formula text of the cell, for the cells which belongs to a
+// shared formula, the formula will be derived from the master cell of the
+// shared formula, the FormulaType, FormulaRef and SharedIndex specifies the
+// formula type, the range reference and the shared group index of the cell
+// formula. The HyperLink is the link address or the location of the cell
+// hyperlink.
+type RowCell struct {
+	Cell        string
+	Value       string
+	Type        CellType
+	StyleID     int
+	Formula     string
+	FormulaType string
+	FormulaRef  string
+	SharedIndex *int
+	HyperLink   string
+}
+
+// Cells return the current row's cells with the cell reference, raw value,
+// data type, style index, formula and hyperlink of each cell. This fetches the
+// worksheet data as a stream with the same memory usage as the Columns
+// function, the cells without any value, formula or style will be skipped. For
+// example, get the type and formula of each cell on a worksheet named
+// 'Sheet1':
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    cells, err := rows.Cells()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    for _, cell := range cells {
+//	        fmt.Println(cell.Cell, cell.Type, cell.Value, cell.Formula)
+//	    }
+//	}
+//	if err = rows.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// Note that the hyperlinks of the worksheet are stored after the cells data,
+// the worksheet will be scanned once more to find the hyperlinks on the first
+// call of this function.
+func (rows *Rows) Cells(opts ...Options) ([]RowCell, error) {
+	rowIterator := rowXMLIterator{typed: true}
+	if rows.hyperlinks == nil {
+		if rowIterator.err = rows.loadHyperlinks(); rowIterator.err != nil {
+			return nil, rowIterator.err
+		}
+	}
+	rows.iterateRow(&rowIterator, opts...)
+	return rowIterator.rowCells, rowIterator.err
+}
+
+// iterateRow parse the cells of the current row by given row iterator.
+func (rows *Rows) iterateRow(rowIterator *rowXMLIterator, opts ...Options) {
 	if rows.curRow > rows.seekRow {
-		return nil, nil
+		return
 	}
-	var rowIterator rowXMLIterator
 	var token xml.Token
 	rows.rawCellValue = rows.f.getOptions(opts...).RawCellValue
 	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
-		return rowIterator.cells, rowIterator.err
+		return
 	}
 	for {
 		if rows.token != nil {
@@ -178,21 +255,111 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				rows.seekRowOpts = extractRowOpts(xmlElement.Attr)
 				if rows.curRow > rows.seekRow {
 					rows.token = nil
-					return rowIterator.cells, rowIterator.err
+					return
 				}
 			}
-			if rows.rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
+			if rows.rowXMLHandler(rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
 				rows.token = nil
-				return rowIterator.cells, rowIterator.err
+				return
 			}
 			rows.token = nil
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
-				return rowIterator.cells, rowIterator.err
+				return
 			}
 		}
 	}
-	return rowIterator.cells, rowIterator.err
+}
+
+// loadHyperlinks scan the worksheet and load the hyperlinks of the worksheet
+// for the rows iterator.
+func (rows *Rows) loadHyperlinks() error {
+	rows.hyperlinks, rows.rangeHyperlinks, rows.rowHyperlinksNum = map[string]string{}, nil, 0
+	needClose, decoder, tempFile, err := rows.f.xmlDecoder(rows.sheet)
+	if needClose && err == nil {
+		defer tempFile.Close()
+	}
+	if err != nil {
+		return err
+	}
+	var hyperlinks xlsxHyperlinks
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		if xmlElement, ok := token.(xml.StartElement); ok {
+			if xmlElement.Name.Local == "sheetData" {
+				if err = decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if xmlElement.Name.Local == "hyperlinks" {
+				if err = decoder.DecodeElement(&hyperlinks, &xmlElement); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(rows.sheet, "xl/worksheets/") + ".rels"
+	sheetRels, err := rows.f.relsReader(rels)
+	if err != nil {
+		return err
+	}
+	for _, link := range hyperlinks.Hyperlink {
+		target := link.Location
+		if link.RID != "" && sheetRels != nil {
+			sheetRels.mu.Lock()
+			for _, rel := range sheetRels.Relationships {
+				if rel.ID == link.RID {
+					target = rel.Target
+				}
+			}
+			sheetRels.mu.Unlock()
+		}
+		if !strings.Contains(link.Ref, ":") {
+			rows.hyperlinks[link.Ref] = target
+			continue
+		}
+		coordinates, _, _, err := wholeRangeRefToCoordinates(link.Ref)
+		if err != nil {
+			continue
+		}
+		rows.rangeHyperlinks = append(rows.rangeHyperlinks, rowsHyperlinkRange{rect: coordinates, target: target})
+	}
+	return err
+}
+
+// getHyperLink returns the hyperlink of the cell by given cell reference, the
+// hyperlinks which set on a range of cells will be filtered by the row number
+// once for each row, and then looked up by the column number.
+func (rows *Rows) getHyperLink(cell string) string {
+	if link, ok := rows.hyperlinks[cell]; ok {
+		return link
+	}
+	if len(rows.rangeHyperlinks) == 0 {
+		return ""
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return ""
+	}
+	if row != rows.rowHyperlinksNum {
+		rows.rowHyperlinks, rows.rowHyperlinksNum = rows.rowHyperlinks[:0], row
+		for _, link := range rows.rangeHyperlinks {
+			if row >= link.rect[1] && row <= link.rect[3] {
+				rows.rowHyperlinks = append(rows.rowHyperlinks, link)
+			}
+		}
+	}
+	for _, link := range rows.rowHyperlinks {
+		if col >= link.rect[0] && col <= link.rect[2] {
+			return link.target
+		}
+	}
+	return ""
 }
 
 // extractRowOpts extract row element attributes.
@@ -222,8 +389,10 @@ func appendSpace(l int, s []string) []string {
 type rowXMLIterator struct {
 	err              error
 	inElement        string
+	typed            bool
 	cellCol, cellRow int
 	cells            []string
+	rowCells         []RowCell
 }
 
 // rowXMLHandler parse the row XML element of the worksheet.
@@ -237,6 +406,10 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 				return
 			}
 		}
+		if rowIterator.typed {
+			rows.appendRowCell(rowIterator, &colCell)
+			return
+		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
 		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
@@ -244,6 +417,29 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 	}
 }
 
+// appendRowCell append the typed cell to the row iterator by given cell.
+func (rows *Rows) appendRowCell(rowIterator *rowXMLIterator, c *xlsxC) {
+	if c.R == "" {
+		c.R, rowIterator.err = CoordinatesToCellName(rowIterator.cellCol, rows.curRow)
+	}
+	val, _ := c.getValueFrom(rows.f, rows.sst, true)
+	if val == "" && c.F == nil && c.S == 0 {
+		return
+	}
+	cell := RowCell{Cell: c.R, Value: val, Type: cellTypes[c.T], StyleID: c.S, HyperLink: rows.getHyperLink(c.R)}
+	if c.F != nil {
+		cell.Formula, cell.FormulaType, cell.FormulaRef, cell.SharedIndex = c.F.Content, c.F.T, c.F.Ref, c.F.Si
+		if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+			if c.F.Ref != "" {
+				rows.sharedFormulas[*c.F.Si] = [2]string{c.R, c.F.Content}
+			} else if master, ok := rows.sharedFormulas[*c.F.Si]; ok {
+				cell.Formula = shiftSharedFormula(master[0], master[1], c.R)
+			}
+		}
+	}
+	rowIterator.rowCells = append(rowIterator.rowCells, cell)
+}
+
 // Rows returns a rows iterator, used for streaming reading data for a
 // worksheet with a large data. This function is concurrency safe. For
 // example:
@@ -279,11 +475,13 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 		ws.mu.Lock()
 		defer ws.mu.Unlock()
 		// Flush data
-		output, _ := xml.Marshal(ws)
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		if !f.isPartUnchanged(name, ws) {
+			output, _ := xml.Marshal(ws)
+			f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		}
 	}
 	var err error
-	rows := Rows{f: f, sheet: name}
+	rows := Rows{f: f, sheet: name, sharedFormulas: map[int][2]string{}}
 	rows.needClose, rows.decoder, rows.tempFile, err = f.xmlDecoder(name)
 	return &rows, err
 }
@@ -309,7 +507,9 @@ func (f *File) getFromStringItem(index int) string {
 		}()
 	}
 	f.sharedStringItem = [][]uint{}
-	f.sharedStringTemp, _ = os.CreateTemp(os.TempDir(), "excelize-")
+	if f.sharedStringTemp, err = f.tempStorage().Create(); err != nil {
+		return strconv.Itoa(index)
+	}
 	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
 	var (
 		inElement string
@@ -328,7 +528,7 @@ func (f *File) getFromStringItem(index int) string {
 				_ = decoder.DecodeElement(&si, &xmlElement)
 
 				startIdx := offset
-				n, _ := f.sharedStringTemp.WriteString(si.String())
+				n, _ := io.WriteString(f.sharedStringTemp, si.String())
 				offset += uint(n)
 				f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
 				i++
@@ -339,17 +539,19 @@ func (f *File) getFromStringItem(index int) string {
 }
 
 // xmlDecoder creates XML decoder by given path in the zip from memory data
-// or system temporary file.
-func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
+// or temporary file.
+func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, TempFile, error) {
 	var (
 		content  []byte
 		err      error
-		tempFile *os.File
+		tempFile TempFile
 	)
 	if content = f.readXML(name); len(content) > 0 {
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
-	tempFile, err = f.readTemp(name)
+	if tempFile, err = f.readTemp(name); tempFile == nil {
+		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
+	}
 	return true, f.xmlNewDecoder(tempFile), tempFile, err
 }
 
@@ -454,6 +656,7 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 		if sharedStrings.UniqueCount == 0 {
 			sharedStrings.UniqueCount = sharedStrings.Count
 		}
+		f.trackPart(defaultXMLPathSharedStrings, &sharedStrings)
 		f.SharedStrings = &sharedStrings
 		for i := range sharedStrings.SI {
 			if sharedStrings.SI[i].T != nil {
@@ -247,6 +247,98 @@ func TestColumns(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRowsCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 12.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", true))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "B1*2"))
+	formulaType, ref := STCellFormulaTypeShared, "A3:B3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "A1&B1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", style))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "Sheet1!A2", "Location"))
+
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var cells [][]RowCell
+	for rows.Next() {
+		row, err := rows.Cells()
+		assert.NoError(t, err)
+		cells = append(cells, row)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Len(t, cells, 3)
+	assert.Equal(t, []RowCell{
+		{Cell: "A1", Value: "text", Type: CellTypeSharedString, HyperLink: "https://github.com/xuri/excelize"},
+		{Cell: "B1", Value: "12.5", HyperLink: "Sheet1!A2"},
+		{Cell: "C1", Value: "1", Type: CellTypeBool},
+	}, cells[0])
+	assert.Equal(t, []RowCell{
+		{Cell: "A2", Type: CellTypeFormula, Formula: "B1*2"},
+		{Cell: "C2", StyleID: style},
+	}, cells[1])
+	assert.Len(t, cells[2], 2)
+	assert.Equal(t, "A1&B1", cells[2][0].Formula)
+	assert.Equal(t, "A3:B3", cells[2][0].FormulaRef)
+	assert.Equal(t, STCellFormulaTypeShared, cells[2][1].FormulaType)
+	assert.Equal(t, "B1&C1", cells[2][1].Formula)
+	assert.Equal(t, 0, *cells[2][1].SharedIndex)
+
+	// Test get cells with the hyperlinks set on a range of cells
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink,
+		xlsxHyperlink{Ref: "$A:$A", Location: "Sheet1!D2"}, xlsxHyperlink{Ref: "C3:B2", Location: "Sheet1!D1"},
+		xlsxHyperlink{Ref: "1:1", Location: "Sheet1!D3"}, xlsxHyperlink{Ref: "A:1", Location: "Sheet1!D4"})
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var links [][]string
+	for rows.Next() {
+		row, err := rows.Cells()
+		assert.NoError(t, err)
+		var link []string
+		for _, cell := range row {
+			link = append(link, cell.HyperLink)
+		}
+		links = append(links, link)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, [][]string{
+		{"https://github.com/xuri/excelize", "Sheet1!A2", "Sheet1!D3"},
+		{"Sheet1!D2", "Sheet1!D1"},
+		{"Sheet1!D2", "Sheet1!D1"},
+	}, links)
+	ws.Hyperlinks.Hyperlink = ws.Hyperlinks.Hyperlink[:2]
+
+	// Test get cells with invalid cell reference
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	rows.decoder = f.xmlNewDecoder(bytes.NewReader([]byte(`<worksheet><sheetData><row r="1"><c r="A" t="s"><v>0</v></c></row></sheetData></worksheet>`)))
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cells without cell reference
+	rows.decoder = f.xmlNewDecoder(bytes.NewReader([]byte(`<worksheet><sheetData><row r="2"><c t="s"><v>0</v></c><c><v>1</v></c></row></sheetData></worksheet>`)))
+	assert.True(t, rows.Next())
+	row, err := rows.Cells()
+	assert.NoError(t, err)
+	assert.Equal(t, []RowCell{{Cell: "A2", Value: "text", Type: CellTypeSharedString}, {Cell: "B2", Value: "1"}}, row)
+	assert.NoError(t, rows.Close())
+
+	// Test get cells with unsupported charset worksheet relationships
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, rows.Close())
+}
+
 func TestSharedStringsReader(t *testing.T) {
 	f := NewFile()
 	// Test read shared string with unsupported charset
@@ -76,6 +76,7 @@ func (f *File) contentTypesReader() (*xlsxTypes, error) {
 			Decode(f.ContentTypes); err != nil && err != io.EOF {
 			return f.ContentTypes, err
 		}
+		f.trackPart(defaultXMLPathContentTypes, f.ContentTypes)
 	}
 	return f.ContentTypes, nil
 }
@@ -83,8 +84,22 @@ func (f *File) contentTypesReader() (*xlsxTypes, error) {
 // contentTypesWriter provides a function to save [Content_Types].xml after
 // serialize structure.
 func (f *File) contentTypesWriter() {
-	if f.ContentTypes != nil {
-		output, _ := xml.Marshal(f.ContentTypes)
+	if f.ContentTypes != nil && !f.isPartUnchanged(defaultXMLPathContentTypes, f.ContentTypes) {
+		content := f.ContentTypes
+		if f.deterministic() {
+			content = &xlsxTypes{
+				XMLName:   f.ContentTypes.XMLName,
+				Defaults:  append([]xlsxDefault(nil), f.ContentTypes.Defaults...),
+				Overrides: append([]xlsxOverride(nil), f.ContentTypes.Overrides...),
+			}
+			sort.SliceStable(content.Defaults, func(i, j int) bool {
+				return content.Defaults[i].Extension < content.Defaults[j].Extension
+			})
+			sort.SliceStable(content.Overrides, func(i, j int) bool {
+				return content.Overrides[i].PartName < content.Overrides[j].PartName
+			})
+		}
+		output, _ := xml.Marshal(content)
 		f.saveFileList(defaultXMLPathContentTypes, output)
 	}
 }
@@ -137,13 +152,21 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 // serialize structure.
 func (f *File) workSheetWriter() {
 	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
+		arr        []byte
+		buffer     = bytes.NewBuffer(arr)
+		encoder    = xml.NewEncoder(buffer)
+		strict     = f.strict()
+		dateStyles map[int]bool
 	)
+	if strict {
+		dateStyles = f.getDateStyles()
+	}
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
 			sheet := ws.(*xlsxWorksheet)
+			if !strict && f.isPartUnchanged(p.(string), sheet) {
+				return true
+			}
 			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
 				_ = f.mergeOverlapCells(sheet)
 			}
@@ -161,11 +184,18 @@ func (f *File) workSheetWriter() {
 				}
 			}
 			sheet.DecodeAlternateContent = nil
+			restore := func() {}
+			if strict {
+				restore = f.setStrictDateCells(sheet, dateStyles)
+			}
 			// reusing buffer
 			_ = encoder.Encode(sheet)
+			restore()
 			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
+			// Keep the worksheet in memory with the Strict option, since the
+			// date cells in the saved part have been converted.
 			_, ok := f.checked.Load(p.(string))
-			if ok {
+			if ok && !strict {
 				f.Sheet.Delete(p.(string))
 				f.checked.Store(p.(string), false)
 			}
@@ -175,6 +205,64 @@ func (f *File) workSheetWriter() {
 	})
 }
 
+// getDateStyles returns the set of the cell style indexes with the date or
+// time number format.
+func (f *File) getDateStyles() map[int]bool {
+	dateStyles := map[int]bool{}
+	styleSheet, err := f.stylesReader()
+	if err != nil || styleSheet == nil || styleSheet.CellXfs == nil {
+		return dateStyles
+	}
+	for idx, xf := range styleSheet.CellXfs.Xf {
+		if xf.NumFmtID == nil || *xf.NumFmtID == 0 {
+			continue
+		}
+		fmtCode, ok := styleSheet.getCustomNumFmtCode(*xf.NumFmtID)
+		if !ok {
+			fmtCode, ok = f.getBuiltInNumFmtCode(*xf.NumFmtID)
+		}
+		if ok && isDateTimeNumFmt(fmtCode) {
+			dateStyles[idx] = true
+		}
+	}
+	return dateStyles
+}
+
+// setStrictDateCells provides a function to store the numeric cells with the
+// date or time number format as the ISO 8601 date cells for saving the
+// worksheet in the Strict conformance class, returns a function to restore
+// the cells after the worksheet has been serialized.
+func (f *File) setStrictDateCells(ws *xlsxWorksheet, dateStyles map[int]bool) func() {
+	var (
+		cells       []*xlsxC
+		types, vals []string
+	)
+	date1904, _ := f.date1904()
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			if !dateStyles[cell.S] || cell.F != nil || (cell.T != "" && cell.T != "n") {
+				continue
+			}
+			num, err := strconv.ParseFloat(cell.V, 64)
+			if err != nil || num < 0 {
+				continue
+			}
+			cells, types, vals = append(cells, cell), append(types, cell.T), append(vals, cell.V)
+			layout := "2006-01-02T15:04:05.999"
+			if num < 1 {
+				layout = "15:04:05.999"
+			}
+			cell.T, cell.V = "d", timeFromExcelTime(num, date1904).Format(layout)
+		}
+	}
+	return func() {
+		for i, cell := range cells {
+			cell.T, cell.V = types[i], vals[i]
+		}
+	}
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -247,8 +335,12 @@ func (f *File) setSheet(index int, name string) {
 // serialize structure.
 func (f *File) relsWriter() {
 	f.Relationships.Range(func(path, rel interface{}) bool {
-		if rel != nil {
-			output, _ := xml.Marshal(rel.(*xlsxRelationships))
+		if rel != nil && !f.isPartUnchanged(path.(string), rel) {
+			rels := rel.(*xlsxRelationships)
+			if f.deterministic() {
+				rels = sortRelationships(rels)
+			}
+			output, _ := xml.Marshal(rels)
 			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
 				output = f.replaceNameSpaceBytes(path.(string), output)
 			}
@@ -258,6 +350,24 @@ func (f *File) relsWriter() {
 	})
 }
 
+// sortRelationships returns a copy of the given relationships sorted by the
+// relationship ID.
+func sortRelationships(rels *xlsxRelationships) *xlsxRelationships {
+	sorted := &xlsxRelationships{XMLName: rels.XMLName, Relationships: append([]xlsxRelationship(nil), rels.Relationships...)}
+	sort.SliceStable(sorted.Relationships, func(i, j int) bool {
+		a, errA := strconv.Atoi(strings.TrimPrefix(sorted.Relationships[i].ID, "rId"))
+		b, errB := strconv.Atoi(strings.TrimPrefix(sorted.Relationships[j].ID, "rId"))
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		if errA == nil || errB == nil {
+			return errA == nil
+		}
+		return sorted.Relationships[i].ID < sorted.Relationships[j].ID
+	})
+	return sorted
+}
+
 // replaceRelationshipsBytes; Some tools that read spreadsheet files have very
 // strict requirements about the structure of the input XML. This function is
 // a horrible hack to fix that after the XML marshalling is completed.
@@ -483,6 +593,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.Pkg.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.lazyParts.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
@@ -589,6 +702,7 @@ func (f *File) DeleteSheet(sheet string) error {
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
+		f.lazyParts.Delete(sheetXML)
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
@@ -719,7 +833,7 @@ func (f *File) copySheet(from, to int) error {
 	f.Sheet.Store(sheetXMLPath, worksheet)
 	toRels := "xl/worksheets/_rels/sheet" + toSheetID + ".xml.rels"
 	fromRels := "xl/worksheets/_rels/sheet" + strconv.Itoa(f.getSheetID(fromSheet)) + ".xml.rels"
-	if rels, ok := f.Pkg.Load(fromRels); ok && rels != nil {
+	if rels, ok := f.pkgLoad(fromRels); ok && rels != nil {
 		f.Pkg.Store(toRels, rels.([]byte))
 	}
 	fromSheetXMLPath, _ := f.getSheetXMLPath(fromSheet)
@@ -1036,7 +1150,7 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
+	if ws, ok := f.Sheet.Load(name); ok && ws != nil && !f.isPartUnchanged(name, ws) {
 		// Flush data
 		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
@@ -1930,6 +2044,7 @@ func (f *File) relsReader(path string) (*xlsxRelationships, error) {
 				Decode(&c); err != nil && err != io.EOF {
 				return nil, err
 			}
+			f.trackPart(path, &c)
 			f.Relationships.Store(path, &c)
 		}
 	}
@@ -434,7 +434,7 @@ func (f *File) setSlicerCache(sheet string, colIdx int, opts *SlicerOptions, tab
 // slicerReader provides a function to get the pointer to the structure
 // after deserialization of xl/slicers/slicer%d.xml.
 func (f *File) slicerReader(slicerXML string) (*xlsxSlicers, error) {
-	content, ok := f.Pkg.Load(slicerXML)
+	content, ok := f.pkgLoad(slicerXML)
 	slicer := &xlsxSlicers{
 		XMLNSXMC:  SourceRelationshipCompatibility.Value,
 		XMLNSX:    NameSpaceSpreadSheet.Value,
@@ -452,7 +452,7 @@ func (f *File) slicerReader(slicerXML string) (*xlsxSlicers, error) {
 // timelineReader provides a function to get the pointer to the structure
 // after deserialization of xl/timelines/timeline%d.xml.
 func (f *File) timelineReader(timelineXML string) (*xlsxTimelines, error) {
-	content, ok := f.Pkg.Load(timelineXML)
+	content, ok := f.pkgLoad(timelineXML)
 	timeline := &xlsxTimelines{
 		XMLNSXMC:  SourceRelationshipCompatibility.Value,
 		XMLNSX:    NameSpaceSpreadSheet.Value,
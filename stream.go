@@ -12,14 +12,16 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
+	"crypto/sha256"
 	"encoding/xml"
 	"fmt"
 	"io"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -35,7 +37,22 @@ type StreamWriter struct {
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
+	hyperlinksCount int
+	hyperlinks      strings.Builder
 	tableParts      string
+	startRow        int
+}
+
+// StreamWriterOptions defined the options for the stream writer.
+//
+// StartRow specifies the row number to start streaming rows, when it's greater
+// than 1, the stream writer works in append mode: the existing rows before
+// the given row number, the column settings and the merged cells above this
+// row will be kept, and the new rows must be set starting from this row. The
+// existing rows and merged cells at and after this row will be replaced. By default,
+// the stream writer replaces the existing contents of the worksheet.
+type StreamWriterOptions struct {
+	StartRow int
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -112,7 +129,17 @@ type StreamWriter struct {
 //	err := sw.SetRow("A1", []interface{}{
 //	    excelize.Cell{Value: 1}},
 //	    excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
-func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+//
+// Keep the existing header rows 1 to 3 of a pre-formatted worksheet, and
+// stream the new rows starting from row 4:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamWriterOptions{StartRow: 4})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.SetRow("A4", []interface{}{1, 2, 3})
+func (f *File) NewStreamWriter(sheet string, opts ...StreamWriterOptions) (*StreamWriter, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -124,12 +151,25 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 		file:    f,
 		Sheet:   sheet,
 		SheetID: sheetID,
+		rawData: bufferedWriter{storage: f.tempStorage()},
+	}
+	for _, opt := range opts {
+		if opt.StartRow < 0 || opt.StartRow > TotalRows {
+			return nil, newInvalidRowNumberError(opt.StartRow)
+		}
+		sw.startRow = opt.StartRow
 	}
 	var err error
 	sw.worksheet, err = f.workSheetReader(sheet)
 	if err != nil {
 		return nil, err
 	}
+	if sw.startRow > 1 {
+		sw.prepareAppendMode()
+	}
+	if sw.worksheet.Hyperlinks != nil {
+		sw.hyperlinksCount = len(sw.worksheet.Hyperlinks.Hyperlink)
+	}
 
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	if f.streams == nil {
@@ -452,6 +492,15 @@ func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
 	if minVal > maxVal {
 		minVal, maxVal = maxVal, minVal
 	}
+	if sw.startRow > 1 && sw.worksheet.Cols != nil {
+		sw.worksheet.Cols.Col = flatCols(xlsxCol{
+			Min:         minVal,
+			Max:         maxVal,
+			Width:       float64Ptr(width),
+			CustomWidth: true,
+		}, sw.worksheet.Cols.Col, replaceColWidth)
+		return nil
+	}
 
 	sw.cols.WriteString(`<col min="`)
 	sw.cols.WriteString(strconv.Itoa(minVal))
@@ -498,6 +547,103 @@ func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
 	return nil
 }
 
+// SetCellHyperLink provides a function to set cell hyperlink by given cell
+// reference and link URL address for the StreamWriter. The linkType and
+// options are the same as the File.SetCellHyperLink function. Note that the
+// hyperlinks will be written into the worksheet on the 'Flush' function, and
+// the function doesn't check duplicate hyperlinks of the same cell. For
+// example, set external link for cell A2:
+//
+//	display, tooltip := "https://github.com/xuri/excelize", "Excelize on GitHub"
+//	err := sw.SetCellHyperLink("A2", "https://github.com/xuri/excelize",
+//	    "External", excelize.HyperlinkOpts{Display: &display, Tooltip: &tooltip})
+func (sw *StreamWriter) SetCellHyperLink(cell, link, linkType string, opts ...HyperlinkOpts) error {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	if sw.hyperlinksCount >= TotalSheetHyperlinks {
+		return ErrTotalSheetHyperlinks
+	}
+	linkData := xlsxHyperlink{Ref: strings.ToUpper(cell)}
+	switch linkType {
+	case "External":
+		sheetPath := sw.file.sheetMap[sw.Sheet]
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+		linkData.RID = "rId" + strconv.Itoa(sw.file.addRels(sheetRels, SourceRelationshipHyperLink, link, linkType))
+	case "Location":
+		linkData.Location = link
+	default:
+		return newInvalidLinkTypeError(linkType)
+	}
+	for _, o := range opts {
+		if o.Display != nil {
+			linkData.Display = *o.Display
+		}
+		if o.Tooltip != nil {
+			linkData.Tooltip = *o.Tooltip
+		}
+	}
+	sw.hyperlinksCount++
+	writeHyperlink(&sw.hyperlinks, linkData)
+	return nil
+}
+
+// writeHyperlink writes the hyperlink XML element to the given builder.
+func writeHyperlink(w *strings.Builder, link xlsxHyperlink) {
+	_, _ = w.WriteString(`<hyperlink ref="`)
+	_, _ = w.WriteString(link.Ref)
+	_, _ = w.WriteString(`"`)
+	for _, attr := range [][2]string{
+		{"location", link.Location}, {"display", link.Display},
+		{"tooltip", link.Tooltip}, {"r:id", link.RID},
+	} {
+		if attr[1] != "" {
+			_, _ = w.WriteString(` ` + attr[0] + `="`)
+			_ = xml.EscapeText(w, []byte(attr[1]))
+			_, _ = w.WriteString(`"`)
+		}
+	}
+	_, _ = w.WriteString(`/>`)
+}
+
+// AddComment provides the method to add comment in a worksheet for the
+// StreamWriter, the options are the same as the File.AddComment function.
+// The comments will be saved in the comments part of the worksheet, and the
+// legacy drawing of the worksheet will be written on the 'Flush' function.
+// For example, add a comment in Sheet1!A1:
+//
+//	err := sw.AddComment(excelize.Comment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Text:   "This is a comment.",
+//	})
+func (sw *StreamWriter) AddComment(opts Comment) error {
+	return sw.file.AddComment(sw.Sheet, opts)
+}
+
+// AddDataValidation provides a function to set data validation on a range of
+// the worksheet for the StreamWriter, the data validation will be written on
+// the 'Flush' function. For example, set dropdown list validation on
+// Sheet1!A2:A1048576:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A2:A1048576"
+//	if err := dv.SetDropList([]string{"1", "2", "3"}); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err := sw.AddDataValidation(dv)
+func (sw *StreamWriter) AddDataValidation(dv *DataValidation) error {
+	return sw.file.AddDataValidation(sw.Sheet, dv)
+}
+
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value for the StreamWriter, the range reference and options
+// are the same as the File.SetConditionalFormat function. The conditional
+// formats will be written on the 'Flush' function.
+func (sw *StreamWriter) SetConditionalFormat(rangeRef string, opts []ConditionalFormatOptions) error {
+	return sw.file.SetConditionalFormat(sw.Sheet, rangeRef, opts)
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -639,21 +785,83 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 	_, _ = buf.WriteString(`</c>`)
 }
 
+// prepareAppendMode keeps the merged cells above the start row of the
+// worksheet for the stream writer in append mode, and ensures the row number of the new rows is
+// greater than or equal to the start row.
+func (sw *StreamWriter) prepareAppendMode() {
+	sw.rows = sw.startRow - 1
+	if sw.worksheet.MergeCells != nil {
+		for _, mergeCell := range sw.worksheet.MergeCells.Cells {
+			if mergeCell == nil || mergeCell.Ref == "" {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil || sortCoordinates(coordinates) != nil || coordinates[3] >= sw.startRow {
+				continue
+			}
+			sw.mergeCellsCount++
+			_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+			_, _ = sw.mergeCells.WriteString(mergeCell.Ref)
+			_, _ = sw.mergeCells.WriteString(`"/>`)
+		}
+	}
+}
+
+// writeExistingCols writes the existing column settings before the sheetData
+// element in append mode.
+func (sw *StreamWriter) writeExistingCols() {
+	if sw.startRow < 2 || sw.worksheet.Cols == nil {
+		return
+	}
+	sw.file.mergeExpandedCols(sw.worksheet)
+	enc := xml.NewEncoder(&sw.rawData)
+	for _, col := range sw.worksheet.Cols.Col {
+		_ = enc.EncodeElement(col, xml.StartElement{Name: xml.Name{Local: "col"}})
+	}
+}
+
+// writeExistingRows writes the existing rows before the start row of the
+// stream writer in append mode, and release the rows of the worksheet.
+func (sw *StreamWriter) writeExistingRows() {
+	if sw.startRow < 2 {
+		return
+	}
+	enc := xml.NewEncoder(&sw.rawData)
+	for _, row := range trimRow(&sw.worksheet.SheetData) {
+		if row.R < sw.startRow {
+			_ = enc.EncodeElement(row, xml.StartElement{Name: xml.Name{Local: "row"}})
+		}
+	}
+	sw.worksheet.SheetData.Row = nil
+}
+
 // writeSheetData prepares the element preceding sheetData and writes the
 // sheetData XML start element to the buffer.
 func (sw *StreamWriter) writeSheetData() {
 	if !sw.sheetWritten {
 		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
+		if sw.cols.Len() > 0 || (sw.startRow > 1 && sw.worksheet.Cols != nil && len(sw.worksheet.Cols.Col) > 0) {
 			_, _ = sw.rawData.WriteString("<cols>")
+			sw.writeExistingCols()
 			_, _ = sw.rawData.WriteString(sw.cols.String())
 			_, _ = sw.rawData.WriteString("</cols>")
 		}
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
+		sw.writeExistingRows()
 		sw.sheetWritten = true
 	}
 }
 
+// discard provides a function to close the stream writer which hasn't been
+// flushed and remove it from the workbook, so the worksheet will be saved
+// with the original content.
+func (sw *StreamWriter) discard() {
+	if sheetXMLPath, ok := sw.file.getSheetXMLPath(sw.Sheet); ok && sw.file.streams[sheetXMLPath] == sw {
+		delete(sw.file.streams, sheetXMLPath)
+	}
+	_ = sw.rawData.Close()
+}
+
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
 	sw.writeSheetData()
@@ -668,9 +876,11 @@ func (sw *StreamWriter) Flush() error {
 		_, _ = mergeCells.WriteString(`</mergeCells>`)
 	}
 	_, _ = sw.rawData.WriteString(mergeCells.String())
-	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 19)
+	sw.writeHyperlinks()
+	bulkAppendFields(&sw.rawData, sw.worksheet, 21, 38)
 	_, _ = sw.rawData.WriteString(sw.tableParts)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 41)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
 	if err := sw.rawData.Flush(); err != nil {
 		return err
@@ -680,10 +890,213 @@ func (sw *StreamWriter) Flush() error {
 	sw.file.Sheet.Delete(sheetPath)
 	sw.file.checked.Delete(sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
+	if sw.rawData.dst != nil {
+		delete(sw.file.streams, sheetPath)
+	}
+	return nil
+}
 
+// PackageWriter defined the type of package writer, which writes the
+// spreadsheet package to an io.Writer directly without any temporary files.
+type PackageWriter struct {
+	file    *File
+	zw      *zip.Writer
+	current *StreamWriter
+	written map[string]bool
+	digests map[string][sha256.Size]byte
+}
+
+// NewPackageWriter returns package writer struct by given io.Writer, used for
+// writing the spreadsheet package with large amounts of data directly to the
+// io.Writer, such as an HTTP response or an object storage uploading stream.
+// The other parts of the workbook, such as the content types, workbook,
+// relationships, styles and shared strings, will be written first when
+// creating the first stream writer of the package writer, and then the
+// worksheets created by the NewStreamWriter function of the package writer
+// will be written into the package as the rows are produced, without using
+// any temporary files on disk. So please create the styles, comments and the
+// other settings of the workbook before creating the first stream writer, the
+// 'Close' function of the package writer will return an error if the parts
+// which have been written were changed after that. Only one stream writer of
+// the package writer can be used at the same time, you must call the 'Flush'
+// function of the stream writer before creating the next one, and call the
+// 'Close' function of the package writer to write the worksheets which
+// haven't been streamed and finish writing the package. Note that the package
+// writer doesn't support encrypting the workbook with password, the
+// 'NewStreamWriter' and 'Close' functions of the package writer will return
+// an error when the password has been set in the options of the workbook. The
+// 'AddTable' function of the stream writer is not supported because the rows
+// can't be read back, and the streamed worksheets can't be read or saved
+// again after the stream writer flushed. For example:
+//
+//	f := excelize.NewFile()
+//	defer func() {
+//	    if err := f.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	styleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	pw := f.NewPackageWriter(w)
+//	sw, err := pw.NewStreamWriter("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.SetRow("A1", []interface{}{
+//	    excelize.Cell{StyleID: styleID, Value: "ID"}}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rowID := 2; rowID <= 102400; rowID++ {
+//	    cell, _ := excelize.CoordinatesToCellName(1, rowID)
+//	    if err := sw.SetRow(cell, []interface{}{rowID}); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := pw.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) NewPackageWriter(w io.Writer) *PackageWriter {
+	return &PackageWriter{file: f, zw: zip.NewWriter(w), written: map[string]bool{}}
+}
+
+// NewStreamWriter returns stream writer struct by given worksheet name and
+// options for the package writer, the rows of the worksheet will be written
+// into the package directly.
+func (pw *PackageWriter) NewStreamWriter(sheet string, opts ...StreamWriterOptions) (*StreamWriter, error) {
+	if pw.zw == nil {
+		return nil, ErrPackageWriterClosed
+	}
+	if pw.file.options != nil && pw.file.options.Password != "" {
+		return nil, ErrPackageWriterEncrypt
+	}
+	if pw.current != nil {
+		if _, ok := pw.file.streams[pw.file.sheetMap[pw.current.Sheet]]; ok {
+			return nil, ErrStreamNotFlushed
+		}
+	}
+	if err := pw.writeParts(); err != nil {
+		return nil, err
+	}
+	sw, err := pw.file.NewStreamWriter(sheet, opts...)
+	if err != nil {
+		return sw, err
+	}
+	sheetPath := pw.file.sheetMap[sw.Sheet]
+	if sw.rawData.dst, err = pw.zw.Create(sheetPath); err != nil {
+		delete(pw.file.streams, sheetPath)
+		return nil, err
+	}
+	pw.current, pw.written[sheetPath] = sw, true
+	return sw, err
+}
+
+// writeParts provides a function to write the parts of the workbook except
+// the worksheets and their relationships to the package once, and record the
+// digests of the written parts for checking if they were changed on close.
+func (pw *PackageWriter) writeParts() error {
+	if pw.digests != nil {
+		return nil
+	}
+	f := pw.file
+	if err := f.serializeParts(); err != nil {
+		return err
+	}
+	deferred, written := map[string]bool{}, map[string]bool{}
+	collect := func(path string) {
+		if strings.HasPrefix(path, "xl/worksheets/") || pw.written[path] {
+			deferred[path] = true
+			return
+		}
+		written[path] = true
+	}
+	pw.digests = map[string][sha256.Size]byte{}
+	f.Pkg.Range(func(path, content interface{}) bool {
+		if collect(path.(string)); written[path.(string)] {
+			pw.digests[path.(string)] = sha256.Sum256(content.([]byte))
+		}
+		return true
+	})
+	for _, parts := range []*sync.Map{&f.tempFiles, &f.lazyParts} {
+		parts.Range(func(path, content interface{}) bool {
+			collect(path.(string))
+			return true
+		})
+	}
+	for path := range f.streams {
+		collect(path)
+	}
+	if err := f.writeParts(pw.zw, deferred); err != nil {
+		return err
+	}
+	for path := range written {
+		pw.written[path] = true
+	}
 	return nil
 }
 
+// Close writes the worksheets which haven't been streamed and finishes writing
+// the package, the ErrPackageWriterPartChanged error will be returned if the
+// parts which have been written were changed.
+func (pw *PackageWriter) Close() error {
+	if pw.zw == nil {
+		return ErrPackageWriterClosed
+	}
+	if pw.file.options != nil && pw.file.options.Password != "" {
+		return ErrPackageWriterEncrypt
+	}
+	if pw.current != nil {
+		if _, ok := pw.file.streams[pw.file.sheetMap[pw.current.Sheet]]; ok {
+			return ErrStreamNotFlushed
+		}
+	}
+	err := pw.writeParts()
+	zw := pw.zw
+	pw.zw = nil
+	if err == nil {
+		err = pw.file.serializeParts()
+	}
+	for path, digest := range pw.digests {
+		if content, ok := pw.file.Pkg.Load(path); ok && err == nil && sha256.Sum256(content.([]byte)) != digest {
+			err = ErrPackageWriterPartChanged
+		}
+	}
+	if err == nil {
+		err = pw.file.writeParts(zw, pw.written)
+	}
+	if err != nil {
+		_ = zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// writeHyperlinks writes the hyperlinks of the worksheet and the hyperlinks
+// set by the StreamWriter to the buffer.
+func (sw *StreamWriter) writeHyperlinks() {
+	hyperlinks := strings.Builder{}
+	if sw.worksheet.Hyperlinks != nil {
+		for _, link := range sw.worksheet.Hyperlinks.Hyperlink {
+			writeHyperlink(&hyperlinks, link)
+		}
+	}
+	if hyperlinks.Len()+sw.hyperlinks.Len() > 0 {
+		_, _ = sw.rawData.WriteString(`<hyperlinks>`)
+		_, _ = sw.rawData.WriteString(hyperlinks.String())
+		_, _ = sw.rawData.WriteString(sw.hyperlinks.String())
+		_, _ = sw.rawData.WriteString(`</hyperlinks>`)
+	}
+}
+
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
 // names order range.
 func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
@@ -701,8 +1114,11 @@ func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 // is written to the temp file with Sync, which may return an error.
 // Therefore, Sync should be periodically called and the error checked.
 type bufferedWriter struct {
-	tmp *os.File
-	buf bytes.Buffer
+	storage TempStorage
+	tmp     TempFile
+	dst     io.Writer
+	size    int64
+	buf     bytes.Buffer
 }
 
 // Write to the in-memory buffer. The error is always nil.
@@ -717,18 +1133,17 @@ func (bw *bufferedWriter) WriteString(p string) (n int, err error) {
 
 // Reader provides read-access to the underlying buffer/file.
 func (bw *bufferedWriter) Reader() (io.Reader, error) {
+	if bw.dst != nil {
+		return nil, ErrStreamDirectRead
+	}
 	if bw.tmp == nil {
 		return bytes.NewReader(bw.buf.Bytes()), nil
 	}
 	if err := bw.Flush(); err != nil {
 		return nil, err
 	}
-	fi, err := bw.tmp.Stat()
-	if err != nil {
-		return nil, err
-	}
-	// os.File.ReadAt does not affect the cursor position and is safe to use here
-	return io.NewSectionReader(bw.tmp, 0, fi.Size()), nil
+	// ReadAt does not affect the cursor position and is safe to use here
+	return io.NewSectionReader(bw.tmp, 0, bw.size), nil
 }
 
 // Sync will write the in-memory buffer to a temp file, if the in-memory
@@ -738,8 +1153,14 @@ func (bw *bufferedWriter) Sync() (err error) {
 	if bw.buf.Len() < StreamChunkSize {
 		return nil
 	}
+	if bw.dst != nil {
+		return bw.Flush()
+	}
 	if bw.tmp == nil {
-		bw.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+		if bw.storage == nil {
+			bw.storage = osTempStorage{}
+		}
+		bw.tmp, err = bw.storage.Create()
 		if err != nil {
 			// can not use local storage
 			return nil
@@ -748,13 +1169,18 @@ func (bw *bufferedWriter) Sync() (err error) {
 	return bw.Flush()
 }
 
-// Flush the entire in-memory buffer to the temp file, if a temp file is being
-// used.
+// Flush the entire in-memory buffer to the destination writer or the temp
+// file, if a destination writer or temp file is being used.
 func (bw *bufferedWriter) Flush() error {
+	if bw.dst != nil {
+		_, err := bw.buf.WriteTo(bw.dst)
+		return err
+	}
 	if bw.tmp == nil {
 		return nil
 	}
-	_, err := bw.buf.WriteTo(bw.tmp)
+	n, err := bw.buf.WriteTo(bw.tmp)
+	bw.size += n
 	if err != nil {
 		return err
 	}
@@ -768,6 +1194,9 @@ func (bw *bufferedWriter) Close() error {
 	if bw.tmp == nil {
 		return nil
 	}
-	defer os.Remove(bw.tmp.Name())
+	if bw.storage == nil {
+		bw.storage = osTempStorage{}
+	}
+	defer bw.storage.Remove(bw.tmp.Name())
 	return bw.tmp.Close()
 }
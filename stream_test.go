@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"math/rand"
@@ -245,6 +247,81 @@ func TestStreamMergeCells(t *testing.T) {
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamMergeCells.xlsx")))
 }
 
+func TestStreamWorksheetFeatures(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Link", "Status", "Value"}))
+	assert.NoError(t, sw.AddComment(Comment{Cell: "A1", Author: "Excelize", Text: "Header comment"}))
+	for row := 2; row <= 5; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{"Excelize", "Open", row}))
+		display, tooltip := "Excelize <GitHub>", "Excelize & GitHub"
+		assert.NoError(t, sw.SetCellHyperLink(cell, "https://github.com/xuri/excelize", "External",
+			HyperlinkOpts{Display: &display, Tooltip: &tooltip}))
+	}
+	assert.NoError(t, sw.SetCellHyperLink("C2", "Sheet1!A1", "Location"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B2:B5"
+	assert.NoError(t, dv.SetDropList([]string{"Open", "Closed"}))
+	assert.NoError(t, sw.AddDataValidation(dv))
+	assert.NoError(t, sw.SetConditionalFormat("C2:C5", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"},
+	}))
+	// Test set hyperlink with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.SetCellHyperLink("A", "Sheet1!A1", "Location"))
+	// Test set hyperlink with invalid link type
+	assert.Equal(t, newInvalidLinkTypeError(""), sw.SetCellHyperLink("A1", "Sheet1!A1", ""))
+	// Test set hyperlink over the maximum limit hyperlinks
+	sw.hyperlinksCount = TotalSheetHyperlinks
+	assert.Equal(t, ErrTotalSheetHyperlinks, sw.SetCellHyperLink("A1", "Sheet1!A1", "Location"))
+	sw.hyperlinksCount = 5
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWorksheetFeatures.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test set hyperlink over the maximum limit with the existing hyperlinks
+	f = NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks = &xlsxHyperlinks{Hyperlink: make([]xlsxHyperlink, TotalSheetHyperlinks)}
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTotalSheetHyperlinks, sw.SetCellHyperLink("A1", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamWorksheetFeatures.xlsx"))
+	assert.NoError(t, err)
+	link, target, err := f.GetCellHyperLink("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	link, target, err = f.GetCellHyperLink("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet1!A1", target)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Excelize <GitHub>", ws.Hyperlinks.Hyperlink[0].Display)
+	assert.Equal(t, "Excelize & GitHub", ws.Hyperlinks.Hyperlink[0].Tooltip)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Header comment", comments[0].Text)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "B2:B5", dvs[0].Sqref)
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats["C2:C5"], 1)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Excelize", "Open", "5"}, rows[4])
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamInsertPageBreak(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -273,6 +350,222 @@ func TestNewStreamWriter(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestStreamWriterAppendMode(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Report"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Name", "Count", "Total"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "SUM(B3:B5)"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 30))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "D", 15))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Old", 1}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B4"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B3:B100"
+	assert.NoError(t, dv.SetRange(0, 100, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	sw, err := f.NewStreamWriter("Sheet1", StreamWriterOptions{StartRow: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(2, 3, 20))
+	// Test set row before the start row
+	assert.Equal(t, newStreamSetRowError(2), sw.SetRow("A2", []interface{}{"Name"}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"New", 2}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{"New", 3}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWriterAppendMode.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamWriterAppendMode.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Report"}, {"Name", "Count", "Total", ""}, {"New", "2"}, {"New", "3"}}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B3:B5)", formula)
+	height, err := f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, width)
+	width, err = f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	width, err = f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, width)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i, col := range ws.Cols.Col {
+		for _, c := range ws.Cols.Col[i+1:] {
+			assert.False(t, col.Min <= c.Max && c.Min <= col.Max, "overlapping column ranges")
+		}
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A1", mergeCells[0].GetStartAxis())
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.NoError(t, f.Close())
+
+	// Test append mode with invalid start row
+	f = NewFile()
+	_, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{StartRow: -1})
+	assert.Equal(t, newInvalidRowNumberError(-1), err)
+	_, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{StartRow: TotalRows + 1})
+	assert.Equal(t, newInvalidRowNumberError(TotalRows+1), err)
+	assert.NoError(t, f.Close())
+}
+
+func TestPackageWriter(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Sheet2"))
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	pw := f.NewPackageWriter(buf)
+	sw, err := pw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	// Test create stream writer before the previous stream writer flushed
+	_, err = pw.NewStreamWriter("Sheet3")
+	assert.Equal(t, ErrStreamNotFlushed, err)
+	assert.Equal(t, ErrStreamNotFlushed, pw.Close())
+	text := strings.Repeat("A", 1024*10)
+	for rowID := 1; rowID <= StreamChunkSize/len(text)+10; rowID++ {
+		cell, err := CoordinatesToCellName(1, rowID)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{rowID, text}))
+	}
+	assert.Nil(t, sw.rawData.tmp)
+	assert.Less(t, sw.rawData.buf.Len(), StreamChunkSize)
+	// Test add table with the rows which have been written to the package
+	assert.Equal(t, ErrStreamDirectRead, sw.AddTable(&Table{Range: "A1:B2"}))
+	assert.NoError(t, sw.SetRow("A2000", []interface{}{Cell{StyleID: style, Value: "Total"}}))
+	assert.NoError(t, sw.SetCellHyperLink("A2000", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, sw.Flush())
+	sw, err = pw.NewStreamWriter("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("B2", []interface{}{"Sheet3"}))
+	assert.NoError(t, sw.Flush())
+	// Test create stream writer with not exist worksheet
+	_, err = pw.NewStreamWriter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, pw.Close())
+	// Test use the closed package writer
+	_, err = pw.NewStreamWriter("Sheet1")
+	assert.Equal(t, ErrPackageWriterClosed, err)
+	assert.Equal(t, ErrPackageWriterClosed, pw.Close())
+	assert.NoError(t, f.Close())
+
+	// Test the other parts are written before the streamed worksheets
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	order := map[string]int{}
+	for i, zf := range zr.File {
+		order[zf.Name] = i
+	}
+	for _, name := range []string{defaultXMLPathContentTypes, defaultXMLPathWorkbook, defaultXMLPathWorkbookRels, "_rels/.rels", defaultXMLPathStyles} {
+		assert.Less(t, order[name], order["xl/worksheets/sheet1.xml"], name)
+	}
+	assert.Less(t, order["xl/worksheets/sheet1.xml"], order["xl/worksheets/sheet3.xml"])
+	assert.Less(t, order["xl/worksheets/sheet3.xml"], order["xl/worksheets/sheet2.xml"])
+	assert.Less(t, order["xl/worksheets/sheet3.xml"], order["xl/worksheets/_rels/sheet1.xml.rels"])
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for _, expected := range [][3]string{
+		{"Sheet1", "A1", "1"}, {"Sheet1", "B1", text}, {"Sheet1", "A2000", "Total"},
+		{"Sheet2", "A1", "Sheet2"}, {"Sheet3", "B2", "Sheet3"},
+	} {
+		val, err := f.GetCellValue(expected[0], expected[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], val)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A2000")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	link, target, err := f.GetCellHyperLink("Sheet1", "A2000")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	assert.NoError(t, f.Close())
+
+	// Test change the written parts after the stream writer created
+	f = NewFile()
+	pw = f.NewPackageWriter(new(bytes.Buffer))
+	sw, err = pw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, ErrPackageWriterPartChanged, pw.Close())
+	assert.NoError(t, f.Close())
+	// Test package writer with the deterministic and strict options
+	for _, opts := range []Options{
+		{Deterministic: true, ModTime: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, {Strict: true},
+	} {
+		f = NewFile(opts)
+		buf.Reset()
+		pw = f.NewPackageWriter(buf)
+		sw, err = pw.NewStreamWriter("Sheet1")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{"A1"}))
+		assert.NoError(t, sw.Flush())
+		assert.NoError(t, pw.Close())
+		assert.NoError(t, f.Close())
+		f, err = OpenReader(buf)
+		assert.NoError(t, err)
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "A1", val)
+		assert.NoError(t, f.Close())
+	}
+	// Test close the package writer without stream writer
+	f = NewFile()
+	buf.Reset()
+	pw = f.NewPackageWriter(buf)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, pw.Close())
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", val)
+	assert.NoError(t, f.Close())
+
+	// Test package writer with password protection
+	f = NewFile(Options{Password: "password"})
+	buf.Reset()
+	pw = f.NewPackageWriter(buf)
+	_, err = pw.NewStreamWriter("Sheet1")
+	assert.Equal(t, ErrPackageWriterEncrypt, err)
+	assert.Equal(t, ErrPackageWriterEncrypt, pw.Close())
+	assert.Zero(t, buf.Len())
+	assert.NoError(t, f.Close())
+
+	// Test create stream writer with invalid part name
+	f = NewFile()
+	f.sheetMap["Sheet1"] = strings.Repeat("s", 1<<16)
+	pw = f.NewPackageWriter(new(bytes.Buffer))
+	_, err = pw.NewStreamWriter("Sheet1")
+	assert.EqualError(t, err, "zip: FileHeader.Name too long")
+	// Test close package writer with invalid part name
+	f = NewFile()
+	f.Pkg.Store("/d/", []byte("s"))
+	pw = f.NewPackageWriter(new(bytes.Buffer))
+	assert.EqualError(t, pw.Close(), "zip: write to directory")
+}
+
 func TestStreamMarshalAttrs(t *testing.T) {
 	var r *RowOpts
 	attrs, err := r.marshalAttrs()
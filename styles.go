@@ -32,6 +32,7 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 			Decode(f.Styles); err != nil && err != io.EOF {
 			return f.Styles, err
 		}
+		f.trackPart(defaultXMLPathStyles, f.Styles)
 	}
 	return f.Styles, nil
 }
@@ -39,7 +40,7 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 // styleSheetWriter provides a function to save xl/styles.xml after serialize
 // structure.
 func (f *File) styleSheetWriter() {
-	if f.Styles != nil {
+	if f.Styles != nil && !f.isPartUnchanged(defaultXMLPathStyles, f.Styles) {
 		output, _ := xml.Marshal(f.Styles)
 		f.saveFileList(defaultXMLPathStyles, f.replaceNameSpaceBytes(defaultXMLPathStyles, output))
 	}
@@ -67,7 +68,7 @@ func (f *File) themeWriter() {
 			ExtLst: c.ExtLst,
 		}
 	}
-	if f.Theme != nil {
+	if f.Theme != nil && !f.isPartUnchanged(defaultXMLPathTheme, f.Theme) {
 		output, _ := xml.Marshal(xlsxTheme{
 			XMLNSa: NameSpaceDrawingML.Value,
 			XMLNSr: SourceRelationship.Value,
@@ -116,7 +117,7 @@ func (f *File) themeWriter() {
 // sharedStringsWriter provides a function to save xl/sharedStrings.xml after
 // serialize structure.
 func (f *File) sharedStringsWriter() {
-	if f.SharedStrings != nil {
+	if f.SharedStrings != nil && !f.isPartUnchanged(defaultXMLPathSharedStrings, f.SharedStrings) {
 		output, _ := xml.Marshal(f.SharedStrings)
 		f.saveFileList(defaultXMLPathSharedStrings, f.replaceNameSpaceBytes(defaultXMLPathSharedStrings, output))
 	}
@@ -3473,7 +3474,7 @@ func getPaletteColor(color string) string {
 // themeReader provides a function to get the pointer to the xl/theme/theme1.xml
 // structure after deserialization.
 func (f *File) themeReader() (*decodeTheme, error) {
-	if _, ok := f.Pkg.Load(defaultXMLPathTheme); !ok {
+	if _, ok := f.pkgLoad(defaultXMLPathTheme); !ok {
 		return nil, nil
 	}
 	theme := decodeTheme{}
@@ -3481,6 +3482,7 @@ func (f *File) themeReader() (*decodeTheme, error) {
 		Decode(&theme); err != nil && err != io.EOF {
 		return &theme, err
 	}
+	f.trackPart(defaultXMLPathTheme, &theme)
 	return &theme, nil
 }
 
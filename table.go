@@ -139,7 +139,7 @@ func (f *File) GetTables(sheet string) ([]Table, error) {
 		if tbl != nil {
 			target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
 			tableXML := strings.ReplaceAll(target, "..", "xl")
-			content, ok := f.Pkg.Load(tableXML)
+			content, ok := f.pkgLoad(tableXML)
 			if !ok {
 				continue
 			}
@@ -335,13 +335,14 @@ func (f *File) countComments() int {
 // after deserialization of xl/comments%d.xml.
 func (f *File) commentsReader(path string) (*xlsxComments, error) {
 	if f.Comments[path] == nil {
-		content, ok := f.Pkg.Load(path)
+		content, ok := f.pkgLoad(path)
 		if ok && content != nil {
 			f.Comments[path] = new(xlsxComments)
 			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
 				Decode(f.Comments[path]); err != nil && err != io.EOF {
 				return nil, err
 			}
+			f.trackPart(path, f.Comments[path])
 		}
 	}
 	return f.Comments[path], nil
@@ -351,7 +352,7 @@ func (f *File) commentsReader(path string) (*xlsxComments, error) {
 // serialize structure.
 func (f *File) commentsWriter() {
 	for path, c := range f.Comments {
-		if c != nil {
+		if c != nil && !f.isPartUnchanged(path, c) {
 			v, _ := xml.Marshal(c)
 			f.saveFileList(path, v)
 		}
@@ -463,6 +464,7 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 // in the folder xl/drawings.
 func (f *File) countVMLDrawing() int {
 	drawings := map[string]struct{}{}
+	f.loadLazyParts("xl/drawings/")
 	f.Pkg.Range(func(k, v interface{}) bool {
 		if strings.Contains(k.(string), "xl/drawings/vmlDrawing") {
 			drawings[k.(string)] = struct{}{}
@@ -481,7 +483,7 @@ func (f *File) countVMLDrawing() int {
 // structure after deserialization of xl/drawings/vmlDrawing%d.xml.
 func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
 	if f.DecodeVMLDrawing[path] == nil {
-		c, ok := f.Pkg.Load(path)
+		c, ok := f.pkgLoad(path)
 		if ok && c != nil {
 			f.DecodeVMLDrawing[path] = new(decodeVmlDrawing)
 			if err := f.xmlNewDecoder(bytes.NewReader(bytesReplace(namespaceStrictToTransitional(c.([]byte)), []byte("<br>\r\n"), []byte("<br></br>\r\n"), -1))).
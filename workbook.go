@@ -202,7 +202,14 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 			if attrs == nil {
 				attrs = []xml.Attr{}
 			}
-			attrs = append(attrs.([]xml.Attr), getRootElement(d)...)
+			// The workbook will be serialized in the Transitional namespaces,
+			// the Strict conformance class will be set with the Strict option
+			// on save.
+			for _, attr := range getRootElement(d) {
+				if attr.Name.Local != "conformance" {
+					attrs = append(attrs.([]xml.Attr), attr)
+				}
+			}
 			f.xmlAttr.Store(wbPath, attrs)
 			f.addNameSpaces(wbPath, SourceRelationship)
 		}
@@ -210,6 +217,8 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 			Decode(f.WorkBook); err != nil && err != io.EOF {
 			return f.WorkBook, err
 		}
+		f.WorkBook.Conformance = ""
+		f.trackPart(wbPath, f.WorkBook)
 	}
 	return f.WorkBook, err
 }
@@ -217,7 +226,7 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 // workBookWriter provides a function to save workbook.xml after serialize
 // structure.
 func (f *File) workBookWriter() {
-	if f.WorkBook != nil {
+	if f.WorkBook != nil && !f.isPartUnchanged(f.getWorkbookPath(), f.WorkBook) {
 		if f.WorkBook.DecodeAlternateContent != nil {
 			f.WorkBook.AlternateContent = &xlsxAlternateContent{
 				Content: f.WorkBook.DecodeAlternateContent.Content,
@@ -0,0 +1,1321 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rc4"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	legacyBlockSize       = 1024
+	legacyDefaultPassword = "VelvetSweatshop"
+	legacyMaxColumns      = 256
+	legacyTotalRows       = 65536
+	legacyWorkbookStream  = "Workbook"
+)
+
+// Record types of the BIFF8 records in the legacy workbook (XLS) stream, the
+// names are corresponding to the MS-XLS specification.
+const (
+	rtFormula          = 0x0006
+	rtEOF              = 0x000A
+	rtExternSheet      = 0x0017
+	rtLbl              = 0x0018
+	rtDate1904         = 0x0022
+	rtFilePass         = 0x002F
+	rtFont             = 0x0031
+	rtContinue         = 0x003C
+	rtDefColWidth      = 0x0055
+	rtColInfo          = 0x007D
+	rtBoundSheet8      = 0x0085
+	rtPalette          = 0x0092
+	rtMulRk            = 0x00BD
+	rtMulBlank         = 0x00BE
+	rtXF               = 0x00E0
+	rtInterfaceHdr     = 0x00E1
+	rtMergeCells       = 0x00E5
+	rtSST              = 0x00FC
+	rtLabelSst         = 0x00FD
+	rtRRDHead          = 0x0138
+	rtUsrExcl          = 0x0194
+	rtFileLock         = 0x0195
+	rtRRDInfo          = 0x0196
+	rtSupBook          = 0x01AE
+	rtDimensions       = 0x0200
+	rtBlank            = 0x0201
+	rtNumber           = 0x0203
+	rtLabel            = 0x0204
+	rtBoolErr          = 0x0205
+	rtString           = 0x0207
+	rtRow              = 0x0208
+	rtArray            = 0x0221
+	rtDefaultRowHeight = 0x0225
+	rtRK               = 0x027E
+	rtFormat           = 0x041E
+	rtShrFmla          = 0x04BC
+	rtBOF              = 0x0809
+)
+
+// legacyUnencryptedRecords defined the records which never be encrypted in
+// the encrypted legacy workbook stream.
+var legacyUnencryptedRecords = map[int]bool{
+	rtBOF: true, rtFilePass: true, rtUsrExcl: true, rtFileLock: true,
+	rtInterfaceHdr: true, rtRRDInfo: true, rtRRDHead: true,
+}
+
+// legacyBuiltInNames defined the names of the built-in defined names in the
+// legacy workbook by the built-in name indexes.
+var legacyBuiltInNames = []string{
+	"Consolidate_Area", "Auto_Open", "Auto_Close", "Extract", "Database", "Criteria",
+	"Print_Area", "Print_Titles", "Recorder", "Data_Form", "Auto_Activate",
+	"Auto_Deactivate", "Sheet_Title", "_FilterDatabase",
+}
+
+// legacyStreamReader directly maps the reader for the records of the stream
+// in the legacy workbook.
+type legacyStreamReader struct {
+	data []byte
+	off  int
+}
+
+// header provides a function to read the type and the size of the record at
+// the current offset, and returns false if the record is truncated.
+func (sr *legacyStreamReader) header() (int, int, bool) {
+	if sr.off+4 > len(sr.data) {
+		return 0, 0, false
+	}
+	recordType := int(binary.LittleEndian.Uint16(sr.data[sr.off:]))
+	size := int(binary.LittleEndian.Uint16(sr.data[sr.off+2:]))
+	return recordType, size, sr.off+4+size <= len(sr.data)
+}
+
+// next provides a function to read the type and the data of the next record,
+// the data of the following continue records will be merged into it, and
+// returns io.EOF at the end of the stream.
+func (sr *legacyStreamReader) next() (int, *legacyRecord, error) {
+	if sr.off >= len(sr.data) {
+		return 0, nil, io.EOF
+	}
+	recordType, size, ok := sr.header()
+	if !ok {
+		return recordType, nil, newInvalidBinaryRecordError(legacyWorkbookStream, recordType)
+	}
+	record := &legacyRecord{binaryRecord: binaryRecord{data: sr.data[sr.off+4 : sr.off+4+size]}}
+	sr.off += 4 + size
+	for {
+		continueType, size, ok := sr.header()
+		if !ok || continueType != rtContinue {
+			break
+		}
+		record.breaks = append(record.breaks, len(record.data))
+		record.data = append(record.data[:len(record.data):len(record.data)], sr.data[sr.off+4:sr.off+4+size]...)
+		sr.off += 4 + size
+	}
+	return recordType, record, nil
+}
+
+// legacyRecord directly maps the record in the legacy workbook, the offsets
+// of the merged continue records will be kept for reading the strings which
+// characters continued in the next record.
+type legacyRecord struct {
+	binaryRecord
+	breaks []int
+}
+
+// chars returns the next characters of the string with the given number of
+// characters and character size flag. The character size flag will be read
+// again when the characters continued in the next record.
+func (r *legacyRecord) chars(count int, highByte bool) string {
+	u := make([]uint16, 0, count)
+	for len(u) < count && !r.invalid {
+		i := sort.SearchInts(r.breaks, r.off)
+		if i < len(r.breaks) && r.breaks[i] == r.off {
+			highByte = r.u8()&1 == 1
+			i++
+		}
+		end, width := len(r.data), 1
+		if i < len(r.breaks) {
+			end = r.breaks[i]
+		}
+		if highByte {
+			width = 2
+		}
+		n := (end - r.off) / width
+		if n > count-len(u) {
+			n = count - len(u)
+		}
+		if n <= 0 {
+			r.invalid = true
+			break
+		}
+		b := r.bytes(n * width)
+		for j := 0; j < n; j++ {
+			if highByte {
+				u = append(u, binary.LittleEndian.Uint16(b[j*2:]))
+				continue
+			}
+			u = append(u, uint16(b[j]))
+		}
+	}
+	return string(utf16.Decode(u))
+}
+
+// unicodeString returns the next ShortXLUnicodeString or XLUnicodeString of
+// the record data by given size of the character count field.
+func (r *legacyRecord) unicodeString(cchSize int) string {
+	count := int(r.u8())
+	if cchSize == 2 {
+		count |= int(r.u8()) << 8
+	}
+	return r.chars(count, r.u8()&1 == 1)
+}
+
+// richString returns the next XLUnicodeRichExtendedString of the record data,
+// the formatting runs and the phonetic data of the string will be ignored.
+func (r *legacyRecord) richString() string {
+	count, flags := int(r.u16()), r.u8()
+	var runs, ext int
+	if flags&8 != 0 {
+		runs = int(r.u16())
+	}
+	if flags&4 != 0 {
+		ext = int(r.u32())
+	}
+	s := r.chars(count, flags&1 == 1)
+	r.bytes(runs*4 + ext)
+	return s
+}
+
+// arrayConstant returns the next array constant of the extra data of the
+// parsed formula tokens in the formula text form.
+func (r *legacyRecord) arrayConstant() string {
+	cols, rows := int(r.u8())+1, int(r.u16())+1
+	var lines []string
+	for i := 0; i < rows && !r.invalid; i++ {
+		var values []string
+		for j := 0; j < cols && !r.invalid; j++ {
+			switch r.u8() {
+			case 0x01:
+				values = append(values, formatBinaryNumber(r.f64()))
+			case 0x02:
+				values = append(values, "\""+strings.ReplaceAll(r.unicodeString(2), "\"", "\"\"")+"\"")
+			case 0x04:
+				values = append(values, strings.ToUpper(strconv.FormatBool(r.u8() == 1)))
+				r.bytes(7)
+			case 0x10:
+				values = append(values, binaryErrorValues[r.u8()])
+				r.bytes(7)
+			default:
+				r.bytes(8)
+				values = append(values, "")
+			}
+		}
+		lines = append(lines, strings.Join(values, ","))
+	}
+	return "{" + strings.Join(lines, ";") + "}"
+}
+
+// legacyXf directly maps the cell format record of the legacy workbook, the
+// fill and border of the cell format are kept separately.
+type legacyXf struct {
+	xf     xlsxXf
+	style  bool
+	parent int
+	fill   *xlsxFill
+	border *xlsxBorder
+}
+
+// legacyWorkbook directly maps the globals substream of the legacy workbook,
+// the sheets, supporting links, sheet range references and defined names are
+// stored in the same structure with the binary workbook, so that the 3D
+// references in the formulas can be resolved in the same way.
+type legacyWorkbook struct {
+	binaryWorkbook
+	stream  []byte
+	offsets []int
+	types   []byte
+	sst     *xlsxSST
+	numFmts []*xlsxNumFmt
+	fonts   []*xlsxFont
+	palette []xlsxColor
+	xfs     []legacyXf
+	xfMap   map[int]int
+}
+
+// readCompoundFile provides a function to read the workbook package from the
+// compound file binary, which contains the encrypted workbook package or the
+// legacy workbook (XLS) stream. The legacy workbook will be converted to the
+// equivalent workbook package.
+func (f *File) readCompoundFile(raw []byte) ([]byte, error) {
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	if err != nil {
+		return nil, ErrWorkbookFileFormat
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Name != legacyWorkbookStream || len(entry.Path) > 0 {
+			continue
+		}
+		stream := make([]byte, entry.Size)
+		if _, err = io.ReadFull(doc, stream); err != nil {
+			return nil, ErrWorkbookFileFormat
+		}
+		return f.convertLegacyWorkbook(stream)
+	}
+	packageBuf, err := Decrypt(raw, f.options)
+	if err != nil {
+		return nil, ErrWorkbookFileFormat
+	}
+	return packageBuf, nil
+}
+
+// convertLegacyWorkbook provides a function to convert the BIFF8 workbook
+// stream of the legacy workbook (XLS) to the workbook package. The sheet
+// names, cell values, shared strings, number formats, merged cells, basic
+// styles, formulas and defined names will be converted, the other records,
+// such as the charts and the comments will be ignored.
+func (f *File) convertLegacyWorkbook(stream []byte) ([]byte, error) {
+	if len(stream) < 6 || binary.LittleEndian.Uint16(stream) != rtBOF ||
+		binary.LittleEndian.Uint16(stream[4:]) != 0x0600 {
+		return nil, ErrWorkbookFileFormat
+	}
+	if err := decryptLegacyWorkbook(stream, f.options.Password); err != nil {
+		return nil, err
+	}
+	wb, err := parseLegacyWorkbook(stream)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		parts        = map[string][]byte{}
+		contentTypes = new(xlsxTypes)
+		tplWbRels    = new(xlsxRelationships)
+		wbRels       = new(xlsxRelationships)
+		workbook     = &xlsxWorkbook{WorkbookPr: &xlsxWorkbookPr{Date1904: wb.date1904}}
+		sheetIndex   = map[int]int{}
+	)
+	_ = xml.Unmarshal([]byte(templateContentTypes), contentTypes)
+	_ = xml.Unmarshal([]byte(templateWorkbookRels), tplWbRels)
+	var overrides []xlsxOverride
+	for _, override := range contentTypes.Overrides {
+		if override.ContentType != ContentTypeSpreadSheetMLWorksheet {
+			overrides = append(overrides, override)
+		}
+	}
+	contentTypes.Overrides = overrides
+	styleSheet := wb.styleSheet()
+	for i, sheet := range wb.sheets {
+		if wb.types[i] != 0 {
+			continue
+		}
+		ws, err := wb.parseWorksheet(wb.offsets[i])
+		if err != nil {
+			return nil, err
+		}
+		sheetIndex[i] = len(workbook.Sheets.Sheet)
+		name, rID := "xl/worksheets/sheet"+strconv.Itoa(len(workbook.Sheets.Sheet)+1)+".xml", "rId"+strconv.Itoa(len(wbRels.Relationships)+1)
+		if err = setBinaryConvertedPart(parts, name, ws); err != nil {
+			return nil, err
+		}
+		contentTypes.Overrides = append(contentTypes.Overrides, xlsxOverride{PartName: "/" + name, ContentType: ContentTypeSpreadSheetMLWorksheet})
+		wbRels.Relationships = append(wbRels.Relationships, xlsxRelationship{ID: rID, Type: SourceRelationshipWorkSheet, Target: strings.TrimPrefix(name, "xl/")})
+		state := []string{"", "hidden", "veryHidden"}
+		entry := xlsxSheet{Name: sheet.name, SheetID: len(workbook.Sheets.Sheet) + 1, ID: rID}
+		if sheet.state < uint32(len(state)) {
+			entry.State = state[sheet.state]
+		}
+		workbook.Sheets.Sheet = append(workbook.Sheets.Sheet, entry)
+	}
+	if len(workbook.Sheets.Sheet) == 0 {
+		return nil, ErrWorkbookFileFormat
+	}
+	workbook.DefinedNames = wb.definedNames(sheetIndex)
+	for _, rel := range tplWbRels.Relationships {
+		if rel.Type != SourceRelationshipWorkSheet {
+			rel.ID = "rId" + strconv.Itoa(len(wbRels.Relationships)+1)
+			wbRels.Relationships = append(wbRels.Relationships, rel)
+		}
+	}
+	wbRels.Relationships = append(wbRels.Relationships, xlsxRelationship{
+		ID: "rId" + strconv.Itoa(len(wbRels.Relationships)+1), Type: SourceRelationshipSharedStrings, Target: "sharedStrings.xml",
+	})
+	contentTypes.Overrides = append(contentTypes.Overrides, xlsxOverride{
+		PartName: "/" + defaultXMLPathSharedStrings, ContentType: ContentTypeSpreadSheetMLSharedStrings,
+	})
+	wb.sst.UniqueCount = len(wb.sst.SI)
+	if wb.sst.Count < wb.sst.UniqueCount {
+		wb.sst.Count = wb.sst.UniqueCount
+	}
+	for _, v := range []struct {
+		name  string
+		value interface{}
+	}{
+		{defaultXMLPathContentTypes, contentTypes},
+		{defaultXMLPathWorkbook, workbook},
+		{defaultXMLPathWorkbookRels, wbRels},
+		{defaultXMLPathSharedStrings, wb.sst},
+		{defaultXMLPathStyles, styleSheet},
+	} {
+		if err = setBinaryConvertedPart(parts, v.name, v.value); err != nil {
+			return nil, err
+		}
+	}
+	parts["_rels/.rels"] = []byte(xml.Header + templateRels)
+	parts[defaultXMLPathDocPropsApp] = []byte(xml.Header + templateDocpropsApp)
+	parts[defaultXMLPathDocPropsCore] = []byte(xml.Header + templateDocpropsCore)
+	parts[defaultXMLPathTheme] = []byte(xml.Header + templateTheme)
+	return writeLegacyConvertedParts(parts)
+}
+
+// writeLegacyConvertedParts provides a function to write the converted parts
+// of the legacy workbook to the workbook package.
+func writeLegacyConvertedParts(parts map[string][]byte) ([]byte, error) {
+	var (
+		buf   = new(bytes.Buffer)
+		zw    = zip.NewWriter(buf)
+		names []string
+	)
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fi, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = fi.Write(parts[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decryptLegacyWorkbook provides a function to decrypt the encrypted legacy
+// workbook stream in place by given password. The default password will be
+// used if the password is empty. The record headers and the unencrypted
+// records will be kept.
+func decryptLegacyWorkbook(stream []byte, password string) error {
+	var decrypt func(data []byte, pos, size int)
+	for off := 0; off+4 <= len(stream); {
+		recordType := int(binary.LittleEndian.Uint16(stream[off:]))
+		size, pos := int(binary.LittleEndian.Uint16(stream[off+2:])), off+4
+		if pos+size > len(stream) {
+			return newInvalidBinaryRecordError(legacyWorkbookStream, recordType)
+		}
+		switch {
+		case recordType == rtFilePass && decrypt == nil:
+			if password == "" {
+				password = legacyDefaultPassword
+			}
+			var err error
+			if decrypt, err = newLegacyDecrypter(stream[pos:pos+size], password); err != nil {
+				return err
+			}
+		case decrypt == nil || legacyUnencryptedRecords[recordType]:
+		case recordType == rtBoundSheet8 && size >= 4:
+			decrypt(stream[pos+4:pos+size], pos+4, size)
+		default:
+			decrypt(stream[pos:pos+size], pos, size)
+		}
+		off = pos + size
+	}
+	return nil
+}
+
+// newLegacyDecrypter provides a function to verify the password by given
+// encryption information of the file pass record, and returns the function
+// for decrypting the record data at the given stream position. Support the
+// XOR obfuscation, RC4 encryption and RC4 CryptoAPI encryption.
+func newLegacyDecrypter(data []byte, password string) (func(data []byte, pos, size int), error) {
+	r := &binaryRecord{data: data}
+	if r.u16() == 0 {
+		key, verifier := r.u16(), r.u16()
+		passwd := legacyXORPassword(password)
+		if r.invalid || legacyXORKey(passwd) != key || legacyXORVerifier(passwd) != verifier {
+			return nil, ErrWorkbookPassword
+		}
+		array := legacyXORArray(passwd, key)
+		return func(data []byte, pos, size int) {
+			for i := range data {
+				b := data[i] ^ array[(pos+i+size)%16]
+				data[i] = b>>5 | b<<3
+			}
+		}, nil
+	}
+	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
+	passwordBuffer, err := encoder.Bytes([]byte(password))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		major, minor                 = r.u16(), r.u16()
+		keyFunc                      func(block uint32) []byte
+		hashAlgorithm                string
+		salt, verifier, verifierHash []byte
+	)
+	switch {
+	case major == 1 && minor == 1:
+		salt, verifier, verifierHash = r.bytes(16), r.bytes(16), r.bytes(16)
+		hashAlgorithm, keyFunc = "md5", legacyRC4Key(passwordBuffer, salt)
+	case major >= 2 && major <= 4 && minor == 2:
+		_ = r.u32()
+		header := &binaryRecord{data: r.bytes(int(r.u32()))}
+		_, _ = header.u32(), header.u32()
+		algID, _, keySize := header.u32(), header.u32(), header.u32()
+		salt = r.bytes(int(r.u32()))
+		verifier = r.bytes(16)
+		verifierHash = r.bytes(int(r.u32()))
+		if header.invalid || algID != 0x6801 {
+			return nil, ErrUnsupportedEncryptMechanism
+		}
+		hashAlgorithm, keyFunc = "sha1", legacyCryptoAPIKey(passwordBuffer, salt, int(keySize))
+	default:
+		return nil, ErrUnsupportedEncryptMechanism
+	}
+	if r.invalid {
+		return nil, ErrUnsupportedEncryptMechanism
+	}
+	cipher, _ := rc4.NewCipher(keyFunc(0))
+	decrypted := make([]byte, len(verifier)+len(verifierHash))
+	cipher.XORKeyStream(decrypted, append(append([]byte{}, verifier...), verifierHash...))
+	if !bytes.HasPrefix(hashing(hashAlgorithm, decrypted[:len(verifier)]), decrypted[len(verifier):]) {
+		return nil, ErrWorkbookPassword
+	}
+	return legacyRC4Decrypter(keyFunc), nil
+}
+
+// legacyRC4Key provides a function to create the function for generating the
+// RC4 encryption key of the given block number by given password and salt.
+func legacyRC4Key(password, salt []byte) func(block uint32) []byte {
+	h0 := hashing("md5", password)
+	var buf []byte
+	for i := 0; i < 16; i++ {
+		buf = append(append(buf, h0[:5]...), salt...)
+	}
+	h1 := hashing("md5", buf)
+	return func(block uint32) []byte {
+		return hashing("md5", h1[:5], createUInt32LEBuffer(int(block), 4))
+	}
+}
+
+// legacyCryptoAPIKey provides a function to create the function for
+// generating the RC4 CryptoAPI encryption key of the given block number by
+// given password, salt and key size in bits.
+func legacyCryptoAPIKey(password, salt []byte, keySize int) func(block uint32) []byte {
+	h0 := hashing("sha1", salt, password)
+	if keySize == 0 {
+		keySize = 40
+	}
+	return func(block uint32) []byte {
+		key := hashing("sha1", h0, createUInt32LEBuffer(int(block), 4))
+		if keySize == 40 {
+			return append(key[:5:5], make([]byte, 11)...)
+		}
+		return key[:keySize/8]
+	}
+}
+
+// legacyRC4Decrypter provides a function to create the function for
+// decrypting the data at the given stream position, the RC4 cipher will be
+// re-keyed for each block of the stream.
+func legacyRC4Decrypter(keyFunc func(block uint32) []byte) func(data []byte, pos, size int) {
+	var (
+		cipher        *rc4.Cipher
+		block, offset int
+	)
+	return func(data []byte, pos, _ int) {
+		for len(data) > 0 {
+			b, o := pos/legacyBlockSize, pos%legacyBlockSize
+			if cipher == nil || b != block || o < offset {
+				cipher, _ = rc4.NewCipher(keyFunc(uint32(b)))
+				block, offset = b, 0
+			}
+			skip := make([]byte, o-offset)
+			cipher.XORKeyStream(skip, skip)
+			n := legacyBlockSize - o
+			if n > len(data) {
+				n = len(data)
+			}
+			cipher.XORKeyStream(data[:n], data[:n])
+			pos, offset, data = pos+n, o+n, data[n:]
+		}
+	}
+}
+
+// legacyXORPassword provides a function to convert the password to the bytes
+// for the XOR obfuscation, the password will be truncated to 15 characters.
+func legacyXORPassword(password string) []byte {
+	var passwd []byte
+	for _, c := range utf16.Encode([]rune(password)) {
+		if len(passwd) == 15 {
+			break
+		}
+		if c&0xFF != 0 {
+			passwd = append(passwd, byte(c))
+			continue
+		}
+		passwd = append(passwd, byte(c>>8))
+	}
+	return passwd
+}
+
+// legacyXORKey provides a function to create the key of the XOR obfuscation
+// by given password bytes.
+func legacyXORKey(password []byte) uint16 {
+	if len(password) == 0 {
+		return 0
+	}
+	var key, base, end uint16 = 0, 0x8000, 0xFFFF
+	for i := len(password) - 1; i >= 0; i-- {
+		char := password[i] & 0x7F
+		for bit := 0; bit < 8; bit++ {
+			if base = base<<1 | base>>15; base&1 != 0 {
+				base ^= 0x1020
+			}
+			if char&1 != 0 {
+				key ^= base
+			}
+			char >>= 1
+			if end = end<<1 | end>>15; end&1 != 0 {
+				end ^= 0x1020
+			}
+		}
+	}
+	return key ^ end
+}
+
+// legacyXORVerifier provides a function to create the password verifier of
+// the XOR obfuscation by given password bytes.
+func legacyXORVerifier(password []byte) uint16 {
+	runes := make([]rune, len(password))
+	for i, b := range password {
+		runes[i] = rune(b)
+	}
+	verifier, _ := strconv.ParseUint(genSheetPasswd(string(runes)), 16, 16)
+	return uint16(verifier)
+}
+
+// legacyXORArray provides a function to create the XOR obfuscation array by
+// given password bytes and the key of the XOR obfuscation.
+func legacyXORArray(password []byte, key uint16) []byte {
+	array := make([]byte, 16)
+	copy(array, password)
+	copy(array[len(password):], []byte{0xBB, 0xFF, 0xFF, 0xBA, 0xFF, 0xFF, 0xB9, 0x80, 0x00, 0xBE, 0x0F, 0x00, 0xBF, 0x0F, 0x00})
+	for i := range array {
+		b := array[i] ^ byte(key)
+		if i&1 == 1 {
+			b = array[i] ^ byte(key>>8)
+		}
+		array[i] = b<<2 | b>>6
+	}
+	return array
+}
+
+// parseLegacyWorkbook provides a function to parse the globals substream of
+// the legacy workbook.
+func parseLegacyWorkbook(stream []byte) (*legacyWorkbook, error) {
+	wb, sr := &legacyWorkbook{stream: stream, sst: &xlsxSST{}}, &legacyStreamReader{data: stream}
+	for {
+		recordType, r, err := sr.next()
+		if err == io.EOF || recordType == rtEOF {
+			break
+		}
+		if err != nil {
+			return wb, err
+		}
+		switch recordType {
+		case rtDate1904:
+			wb.date1904 = r.u16() == 1
+		case rtBoundSheet8:
+			offset, state, sheetType := int(r.u32()), r.u8(), r.u8()
+			wb.sheets = append(wb.sheets, binarySheet{state: uint32(state & 3), name: r.unicodeString(1)})
+			wb.offsets, wb.types = append(wb.offsets, offset), append(wb.types, sheetType)
+		case rtSupBook:
+			_, count := r.u16(), r.u16()
+			wb.supBooks = append(wb.supBooks, count == 0x0401)
+		case rtExternSheet:
+			count := int(r.u16())
+			for i := 0; i < count && !r.invalid; i++ {
+				wb.externSheets = append(wb.externSheets, binaryExternSheet{
+					supBook: int(r.u16()), first: int(int16(r.u16())), last: int(int16(r.u16())),
+				})
+			}
+		case rtLbl:
+			wb.names = append(wb.names, parseLegacyName(r))
+		case rtSST:
+			sst := wb.sst
+			sst.Count = int(r.u32())
+			count := int(r.u32())
+			for i := 0; i < count && r.off < len(r.data) && !r.invalid; i++ {
+				si := xlsxSI{T: &xlsxT{}}
+				si.T.Val, si.T.Space = trimCellValue(r.richString(), false)
+				sst.SI = append(sst.SI, si)
+			}
+		case rtFormat:
+			wb.numFmts = append(wb.numFmts, &xlsxNumFmt{NumFmtID: int(r.u16()), FormatCode: r.unicodeString(2)})
+		case rtFont:
+			wb.fonts = append(wb.fonts, parseLegacyFont(r))
+		case rtPalette:
+			count := int(r.u16())
+			for i := 0; i < count && !r.invalid; i++ {
+				if b := r.bytes(4); b != nil {
+					wb.palette = append(wb.palette, xlsxColor{RGB: "FF" + strings.ToUpper(hex.EncodeToString(b[:3]))})
+				}
+			}
+		case rtXF:
+			wb.xfs = append(wb.xfs, parseLegacyXf(r))
+		}
+		if r.invalid {
+			return wb, newInvalidBinaryRecordError(legacyWorkbookStream, recordType)
+		}
+	}
+	return wb, nil
+}
+
+// parseLegacyName provides a function to parse the defined name record of
+// the legacy workbook.
+func parseLegacyName(r *legacyRecord) binaryName {
+	flags, _, count, size := r.u16(), r.u8(), int(r.u8()), int(r.u16())
+	_ = r.u16()
+	name := binaryName{hidden: flags&1 != 0, fn: flags&2 != 0, scope: int(r.u16()) - 1}
+	r.bytes(4)
+	name.name = r.chars(count, r.u8()&1 == 1)
+	if flags&0x20 != 0 && len(name.name) == 1 && int(name.name[0]) < len(legacyBuiltInNames) {
+		name.name = "_xlnm." + legacyBuiltInNames[name.name[0]]
+	}
+	name.rgce = r.bytes(size)
+	if !r.invalid {
+		name.rgcb = r.data[r.off:]
+	}
+	return name
+}
+
+// parseLegacyFont provides a function to parse the font record of the legacy
+// workbook.
+func parseLegacyFont(r *legacyRecord) *xlsxFont {
+	height, flags, color, weight := r.u16(), r.u16(), r.u16(), r.u16()
+	_, underline, family, charset := r.u16(), r.u8(), r.u8(), r.u8()
+	_ = r.u8()
+	font := &xlsxFont{Sz: &attrValFloat{Val: float64Ptr(float64(height) / 20)}}
+	for _, v := range []struct {
+		bit uint16
+		val **attrValBool
+	}{{2, &font.I}, {8, &font.Strike}, {16, &font.Outline}, {32, &font.Shadow}, {64, &font.Condense}, {128, &font.Extend}} {
+		if flags&v.bit != 0 {
+			*v.val = &attrValBool{Val: boolPtr(true)}
+		}
+	}
+	if weight >= 700 {
+		font.B = &attrValBool{Val: boolPtr(true)}
+	}
+	if u, ok := map[byte]string{1: "single", 2: "double", 0x21: "singleAccounting", 0x22: "doubleAccounting"}[underline]; ok {
+		font.U = &attrValString{Val: stringPtr(u)}
+	}
+	if color != 0x7FFF {
+		font.Color = &xlsxColor{Indexed: int(color)}
+	}
+	if name := r.unicodeString(1); name != "" {
+		font.Name = &attrValString{Val: stringPtr(name)}
+	}
+	if family > 0 {
+		font.Family = &attrValInt{Val: intPtr(int(family))}
+	}
+	if charset > 0 {
+		font.Charset = &attrValInt{Val: intPtr(int(charset))}
+	}
+	return font
+}
+
+// parseLegacyXf provides a function to parse the cell format record of the
+// legacy workbook.
+func parseLegacyXf(r *legacyRecord) legacyXf {
+	fontID, numFmtID, flags := int(r.u16()), int(r.u16()), r.u16()
+	align, rotation, indent, apply := r.u8(), r.u8(), r.u8(), r.u8()
+	border1, border2, fill := r.u32(), r.u32(), r.u16()
+	if fontID > 4 {
+		fontID-- // the font index 4 is omitted in the legacy workbook
+	}
+	xf := legacyXf{style: flags&4 != 0, parent: int(flags >> 4)}
+	xf.xf = xlsxXf{NumFmtID: intPtr(numFmtID), FontID: intPtr(fontID)}
+	if !xf.style {
+		for i, v := range []**bool{
+			&xf.xf.ApplyNumberFormat, &xf.xf.ApplyFont, &xf.xf.ApplyAlignment, &xf.xf.ApplyBorder, &xf.xf.ApplyFill, &xf.xf.ApplyProtection,
+		} {
+			if apply&(4<<i) != 0 {
+				*v = boolPtr(true)
+			}
+		}
+	}
+	alignment := xlsxAlignment{
+		Horizontal:      []string{"", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}[align&7],
+		Indent:          int(indent & 0xF),
+		JustifyLastLine: align&0x80 != 0,
+		ReadingOrder:    uint64(indent >> 6 & 3),
+		ShrinkToFit:     indent&0x10 != 0,
+		TextRotation:    int(rotation),
+		WrapText:        align&8 != 0,
+	}
+	if vertical := int(align >> 4 & 7); vertical < 5 {
+		alignment.Vertical = []string{"top", "center", "", "justify", "distributed"}[vertical]
+	}
+	if alignment != (xlsxAlignment{}) {
+		xf.xf.Alignment = &alignment
+	}
+	if locked, hidden := flags&1 != 0, flags&2 != 0; !locked || hidden {
+		xf.xf.Protection = &xlsxProtection{Locked: boolPtr(locked), Hidden: boolPtr(hidden)}
+	}
+	xf.border = &xlsxBorder{DiagonalDown: border1>>30&1 != 0, DiagonalUp: border1>>31&1 != 0}
+	for _, line := range []struct {
+		line         *xlsxLine
+		style, color uint32
+	}{
+		{&xf.border.Left, border1 & 0xF, border1 >> 16 & 0x7F},
+		{&xf.border.Right, border1 >> 4 & 0xF, border1 >> 23 & 0x7F},
+		{&xf.border.Top, border1 >> 8 & 0xF, border2 & 0x7F},
+		{&xf.border.Bottom, border1 >> 12 & 0xF, border2 >> 7 & 0x7F},
+		{&xf.border.Diagonal, border2 >> 21 & 0xF, border2 >> 14 & 0x7F},
+	} {
+		if line.style > 0 && line.style < uint32(len(binaryBorderStyles)) {
+			line.line.Style, line.line.Color = binaryBorderStyles[line.style], &xlsxColor{Indexed: int(line.color)}
+		}
+	}
+	xf.fill = &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: binaryFillPatterns[0]}}
+	if pattern := border2 >> 26 & 0x3F; pattern > 0 && pattern < uint32(len(binaryFillPatterns)) {
+		xf.fill.PatternFill = &xlsxPatternFill{
+			PatternType: binaryFillPatterns[pattern],
+			FgColor:     &xlsxColor{Indexed: int(fill & 0x7F)},
+			BgColor:     &xlsxColor{Indexed: int(fill >> 7 & 0x7F)},
+		}
+	}
+	return xf
+}
+
+// styleSheet provides a function to create the style sheet by the fonts,
+// number formats, palette and cell formats of the legacy workbook, and map
+// the indexes of the cell formats to the indexes of the cell formats in the
+// style sheet.
+func (wb *legacyWorkbook) styleSheet() *xlsxStyleSheet {
+	styleSheet := &xlsxStyleSheet{
+		Fonts: &xlsxFonts{Font: wb.fonts},
+		Fills: &xlsxFills{Fill: []*xlsxFill{
+			{PatternFill: &xlsxPatternFill{PatternType: "none"}}, {PatternFill: &xlsxPatternFill{PatternType: "gray125"}},
+		}},
+		Borders:      &xlsxBorders{Border: []*xlsxBorder{{}}},
+		CellStyleXfs: &xlsxCellStyleXfs{},
+		CellXfs:      &xlsxCellXfs{},
+		CellStyles:   &xlsxCellStyles{CellStyle: []*xlsxCellStyle{{Name: "Normal", BuiltInID: intPtr(0)}}},
+	}
+	if len(wb.numFmts) > 0 {
+		styleSheet.NumFmts = &xlsxNumFmts{NumFmt: wb.numFmts, Count: len(wb.numFmts)}
+	}
+	if len(styleSheet.Fonts.Font) == 0 {
+		styleSheet.Fonts.Font = []*xlsxFont{{Sz: &attrValFloat{Val: float64Ptr(10)}, Name: &attrValString{Val: stringPtr("Arial")}}}
+	}
+	if len(wb.palette) > 0 {
+		colors := &xlsxIndexedColors{}
+		for _, color := range IndexedColorMapping[:8] {
+			colors.RgbColor = append(colors.RgbColor, xlsxColor{RGB: "FF" + color})
+		}
+		styleSheet.Colors = &xlsxStyleColors{IndexedColors: colors}
+		colors.RgbColor = append(colors.RgbColor, wb.palette...)
+	}
+	fills, borders := map[string]int{}, map[string]int{}
+	index := func(indexes map[string]int, v interface{}, add func()) *int {
+		key, _ := xml.Marshal(v)
+		if idx, ok := indexes[string(key)]; ok {
+			return intPtr(idx)
+		}
+		indexes[string(key)] = len(indexes)
+		add()
+		return intPtr(indexes[string(key)])
+	}
+	for _, fill := range styleSheet.Fills.Fill {
+		index(fills, fill, func() {})
+	}
+	index(borders, styleSheet.Borders.Border[0], func() {})
+	styleIDs := map[int]int{}
+	for i, xf := range wb.xfs {
+		if xf.style {
+			styleIDs[i] = len(styleIDs)
+		}
+	}
+	wb.xfMap = map[int]int{}
+	for i := range wb.xfs {
+		xf := wb.xfs[i]
+		if *xf.xf.FontID >= len(styleSheet.Fonts.Font) {
+			xf.xf.FontID = intPtr(0)
+		}
+		xf.xf.FillID = index(fills, xf.fill, func() { styleSheet.Fills.Fill = append(styleSheet.Fills.Fill, xf.fill) })
+		xf.xf.BorderID = index(borders, xf.border, func() { styleSheet.Borders.Border = append(styleSheet.Borders.Border, xf.border) })
+		if xf.style {
+			styleSheet.CellStyleXfs.Xf = append(styleSheet.CellStyleXfs.Xf, xf.xf)
+			continue
+		}
+		xf.xf.XfID = intPtr(styleIDs[xf.parent])
+		wb.xfMap[i] = len(styleSheet.CellXfs.Xf)
+		styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xf.xf)
+	}
+	if len(styleSheet.CellStyleXfs.Xf) == 0 {
+		styleSheet.CellStyleXfs.Xf = []xlsxXf{{NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0)}}
+	}
+	if len(styleSheet.CellXfs.Xf) == 0 {
+		styleSheet.CellXfs.Xf = []xlsxXf{{NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0), XfID: intPtr(0)}}
+	}
+	styleSheet.Fonts.Count, styleSheet.Fills.Count = len(styleSheet.Fonts.Font), len(styleSheet.Fills.Fill)
+	styleSheet.Borders.Count, styleSheet.CellStyles.Count = len(styleSheet.Borders.Border), len(styleSheet.CellStyles.CellStyle)
+	styleSheet.CellStyleXfs.Count, styleSheet.CellXfs.Count = len(styleSheet.CellStyleXfs.Xf), len(styleSheet.CellXfs.Xf)
+	return styleSheet
+}
+
+// definedNames provides a function to convert the defined names of the
+// legacy workbook by given map of the sheet indexes, the defined names which
+// formula can't be parsed will be ignored.
+func (wb *legacyWorkbook) definedNames(sheetIndex map[int]int) *xlsxDefinedNames {
+	var definedNames []xlsxDefinedName
+	for _, name := range wb.names {
+		formula, ok := wb.parseFormula(name.rgce, name.rgcb, 0, 0, false)
+		if !ok || name.fn {
+			continue
+		}
+		definedName := xlsxDefinedName{Name: name.name, Hidden: name.hidden, Data: formula}
+		if name.scope >= 0 {
+			idx, ok := sheetIndex[name.scope]
+			if !ok {
+				continue
+			}
+			definedName.LocalSheetID = intPtr(idx)
+		}
+		definedNames = append(definedNames, definedName)
+	}
+	if definedNames == nil {
+		return nil
+	}
+	return &xlsxDefinedNames{DefinedName: definedNames}
+}
+
+// legacyCellRef provides a function to get the cell reference by given row
+// and column of the parsed formula token in the legacy workbook. The
+// relative row and column will be calculated by the base cell if the offset
+// is true.
+func legacyCellRef(row, col uint16, baseRow, baseCol int, offset bool) (int, int, string, string) {
+	r, c := int(row), int(col&0x3FFF)
+	colAbs, rowAbs := "$", "$"
+	if col&0x4000 != 0 {
+		colAbs = ""
+		if offset {
+			c = ((baseCol+int(int8(col)))%legacyMaxColumns + legacyMaxColumns) % legacyMaxColumns
+		}
+	}
+	if col&0x8000 != 0 {
+		rowAbs = ""
+		if offset {
+			r = ((baseRow+int(int16(row)))%legacyTotalRows + legacyTotalRows) % legacyTotalRows
+		}
+	}
+	return r, c, colAbs, rowAbs
+}
+
+// parseFormula provides a function to convert the parsed formula tokens and
+// the extra data of them in the legacy workbook to the formula text. The
+// relative references of the shared formula will be calculated by the given
+// base cell if the offset is true. The false will be returned if the formula
+// tokens are invalid or unsupported.
+func (wb *legacyWorkbook) parseFormula(rgce, rgcb []byte, baseRow, baseCol int, offset bool) (string, bool) {
+	r, extra, stack := &legacyRecord{binaryRecord: binaryRecord{data: rgce}}, &legacyRecord{binaryRecord: binaryRecord{data: rgcb}}, []string{}
+	pop := func(n int) []string {
+		if n > len(stack) {
+			r.invalid = true
+			return make([]string, n)
+		}
+		args := append([]string{}, stack[len(stack)-n:]...)
+		stack = stack[:len(stack)-n]
+		return args
+	}
+	push := func(s string) { stack = append(stack, s) }
+	ref := func(ptg byte, count int) string {
+		rows, cols := make([]uint16, count), make([]uint16, count)
+		for i := range rows {
+			rows[i] = r.u16()
+		}
+		for i := range cols {
+			cols[i] = r.u16()
+		}
+		return formatFormulaRef(count, legacyTotalRows, legacyMaxColumns, func(i int) (int, int, string, string) {
+			return legacyCellRef(rows[i], cols[i], baseRow, baseCol, offset && (ptg == 0x2C || ptg == 0x2D))
+		})
+	}
+	for r.off < len(r.data) && !r.invalid {
+		ptg := r.u8()
+		if op, ok := binaryOperators[ptg]; ok {
+			args := pop(2)
+			push(args[0] + op + args[1])
+			continue
+		}
+		if ptg >= 0x20 {
+			ptg = ptg&0x1F | 0x20
+		}
+		switch ptg {
+		case 0x12, 0x13:
+			push(map[byte]string{0x12: "+", 0x13: "-"}[ptg] + pop(1)[0])
+		case 0x14:
+			push(pop(1)[0] + "%")
+		case 0x15:
+			push("(" + pop(1)[0] + ")")
+		case 0x16:
+			push("")
+		case 0x17:
+			push("\"" + strings.ReplaceAll(r.unicodeString(1), "\"", "\"\"") + "\"")
+		case 0x19:
+			switch attr := r.u8(); attr {
+			case 0x04:
+				r.bytes(int(r.u16()+1) * 2)
+			case 0x10:
+				_ = r.u16()
+				push("SUM(" + pop(1)[0] + ")")
+			default:
+				_ = r.u16()
+			}
+		case 0x1C:
+			push(binaryErrorValues[r.u8()])
+		case 0x1D:
+			push(strings.ToUpper(strconv.FormatBool(r.u8() == 1)))
+		case 0x1E:
+			push(strconv.Itoa(int(r.u16())))
+		case 0x1F:
+			push(formatBinaryNumber(r.f64()))
+		case 0x20:
+			r.bytes(7)
+			push(extra.arrayConstant())
+			r.invalid = r.invalid || extra.invalid
+		case 0x21:
+			fn, ok := binaryFunctions[r.u16()]
+			if !ok || fn.args < 0 {
+				return "", false
+			}
+			push(fn.name + "(" + strings.Join(pop(fn.args), ",") + ")")
+		case 0x22:
+			argc, tab := int(r.u8()), r.u16()&0x7FFF
+			fn, ok := binaryFunctions[tab]
+			if !ok {
+				return "", false
+			}
+			push(fn.name + "(" + strings.Join(pop(argc), ",") + ")")
+		case 0x23:
+			idx := int(r.u32())
+			if idx < 1 || idx > len(wb.names) {
+				return "", false
+			}
+			push(wb.names[idx-1].name)
+		case 0x24, 0x2C:
+			push(ref(ptg, 1))
+		case 0x25, 0x2D:
+			push(ref(ptg, 2))
+		case 0x26, 0x27, 0x28:
+			r.bytes(6)
+		case 0x29:
+			_ = r.u16()
+		case 0x2A:
+			r.bytes(4)
+			push(formulaErrorREF)
+		case 0x2B:
+			r.bytes(8)
+			push(formulaErrorREF)
+		case 0x3A, 0x3B, 0x3C, 0x3D:
+			prefix, ok := wb.sheetPrefix(int(r.u16()))
+			if !ok {
+				return "", false
+			}
+			switch ptg {
+			case 0x3A:
+				push(prefix + ref(ptg, 1))
+			case 0x3B:
+				push(prefix + ref(ptg, 2))
+			default:
+				r.bytes(map[byte]int{0x3C: 4, 0x3D: 8}[ptg])
+				push(prefix + formulaErrorREF)
+			}
+		default:
+			return "", false
+		}
+	}
+	if r.invalid || len(stack) != 1 {
+		return "", false
+	}
+	return stack[0], true
+}
+
+// legacyCell directly maps the cell in the worksheet of the legacy workbook,
+// the anchor row of the shared or array formula will be kept if the formula
+// of the cell referenced to it.
+type legacyCell struct {
+	col    int
+	c      xlsxC
+	anchor int
+	linked bool
+}
+
+// parseWorksheet provides a function to parse the worksheet substream of the
+// legacy workbook at the given stream offset.
+func (wb *legacyWorkbook) parseWorksheet(offset int) (*xlsxWorksheet, error) {
+	var (
+		ws             = &xlsxWorksheet{}
+		sr             = &legacyStreamReader{data: wb.stream, off: offset}
+		rows           = map[int]xlsxRow{}
+		cells          = map[int][]legacyCell{}
+		arrays, shared []binaryFormula
+		mergeCells     = &xlsxMergeCells{}
+		pending        *legacyCell
+		depth          int
+	)
+	add := func(row, col, xf int, c xlsxC) (*legacyCell, bool) {
+		var err error
+		if c.R, err = CoordinatesToCellName(col+1, row+1); err != nil {
+			return nil, false
+		}
+		c.S = wb.xfMap[xf]
+		cells[row] = append(cells[row], legacyCell{col: col, c: c})
+		return &cells[row][len(cells[row])-1], true
+	}
+	for {
+		recordType, r, err := sr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ws, err
+		}
+		if recordType == rtBOF {
+			depth++
+		}
+		if recordType == rtEOF {
+			if depth--; depth <= 0 {
+				break
+			}
+		}
+		if depth != 1 {
+			continue
+		}
+		ok := true
+		switch recordType {
+		case rtDimensions:
+			r1, r2, c1, c2 := int(r.u32()), int(r.u32()), int(r.u16()), int(r.u16())
+			if r2 > r1 && c2 > c1 {
+				ws.Dimension = &xlsxDimension{Ref: formatBinaryRange(r1, r2-1, c1, c2-1)}
+			}
+		case rtDefaultRowHeight:
+			flags, height := r.u16(), r.u16()
+			if ws.SheetFormatPr == nil {
+				ws.SheetFormatPr = &xlsxSheetFormatPr{}
+			}
+			ws.SheetFormatPr.DefaultRowHeight = float64(height) / 20
+			ws.SheetFormatPr.CustomHeight, ws.SheetFormatPr.ZeroHeight = flags&1 != 0, flags&2 != 0
+		case rtDefColWidth:
+			if ws.SheetFormatPr == nil {
+				ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: 15}
+			}
+			ws.SheetFormatPr.BaseColWidth = uint8(r.u16())
+		case rtColInfo:
+			c1, c2, width, style, flags := int(r.u16()), int(r.u16()), r.u16(), int(r.u16()), r.u16()
+			if ws.Cols == nil {
+				ws.Cols = &xlsxCols{}
+			}
+			ws.Cols.Col = append(ws.Cols.Col, xlsxCol{
+				Min: c1 + 1, Max: c2 + 1, Width: float64Ptr(float64(width) / 256), Style: wb.xfMap[style],
+				Hidden: flags&1 != 0, CustomWidth: flags&2 != 0, BestFit: flags&4 != 0,
+				OutlineLevel: uint8(flags >> 8 & 7), Collapsed: flags&0x1000 != 0,
+			})
+		case rtRow:
+			rowNum, _, _, height := int(r.u16()), r.u16(), r.u16(), r.u16()
+			r.bytes(4)
+			flags, _, style := r.u8(), r.u8(), int(r.u16()&0xFFF)
+			row := xlsxRow{R: rowNum + 1, OutlineLevel: flags & 7, Collapsed: flags&0x10 != 0, Hidden: flags&0x20 != 0}
+			if row.CustomHeight = flags&0x40 != 0; row.CustomHeight {
+				row.Ht = float64Ptr(float64(height&0x7FFF) / 20)
+			}
+			if row.CustomFormat = flags&0x80 != 0; row.CustomFormat {
+				row.S = wb.xfMap[style]
+			}
+			rows[rowNum] = row
+		case rtBlank, rtNumber, rtRK, rtLabelSst, rtLabel, rtBoolErr, rtFormula:
+			row, col, xf := int(r.u16()), int(r.u16()), int(r.u16())
+			c, rgce, rgcb := xlsxC{}, []byte(nil), []byte(nil)
+			switch recordType {
+			case rtNumber:
+				c.V = strconv.FormatFloat(r.f64(), 'f', -1, 64)
+			case rtRK:
+				c.V = strconv.FormatFloat(decodeRKNumber(r.u32()), 'f', -1, 64)
+			case rtLabelSst:
+				c.T, c.V = "s", strconv.Itoa(int(r.u32()))
+			case rtLabel:
+				c.T, c.IS = "inlineStr", &xlsxSI{T: &xlsxT{}}
+				c.IS.T.Val, c.IS.T.Space = trimCellValue(r.unicodeString(2), false)
+			case rtBoolErr:
+				if value, isErr := r.u8(), r.u8() == 1; isErr {
+					c.T, c.V = "e", binaryErrorValues[value]
+				} else {
+					c.T, c.V = "b", strconv.Itoa(int(value&1))
+				}
+			case rtFormula:
+				rgce, rgcb = parseLegacyFormulaValue(r, &c)
+			}
+			if r.invalid {
+				break
+			}
+			var cell *legacyCell
+			if cell, ok = add(row, col, xf, c); !ok || recordType != rtFormula {
+				pending = nil
+				break
+			}
+			if pending = nil; c.T == "str" && c.V == "" {
+				pending = cell
+			}
+			if len(rgce) == 5 && rgce[0] == 0x01 {
+				cell.anchor, cell.linked = int(binary.LittleEndian.Uint16(rgce[1:])), true
+			} else if formula, ok := wb.parseFormula(rgce, rgcb, row, col, false); ok {
+				cell.c.F = &xlsxF{Content: formula}
+			}
+		case rtString:
+			if value := r.unicodeString(2); pending != nil {
+				pending.c.V, pending.c.XMLSpace = trimCellValue(value, false)
+			}
+			pending = nil
+		case rtMulRk, rtMulBlank:
+			row, col := int(r.u16()), int(r.u16())
+			size := map[int]int{rtMulRk: 6, rtMulBlank: 2}[recordType]
+			for i := 0; i < (len(r.data)-6)/size && ok; i++ {
+				c, xf := xlsxC{}, int(r.u16())
+				if recordType == rtMulRk {
+					c.V = strconv.FormatFloat(decodeRKNumber(r.u32()), 'f', -1, 64)
+				}
+				_, ok = add(row, col+i, xf, c)
+			}
+			pending = nil
+		case rtShrFmla, rtArray:
+			r1, r2, c1, c2 := int(r.u16()), int(r.u16()), int(r.u8()), int(r.u8())
+			if recordType == rtArray {
+				r.bytes(6)
+			} else {
+				r.bytes(2)
+			}
+			rgce := r.bytes(int(r.u16()))
+			var rgcb []byte
+			if !r.invalid {
+				rgcb = r.data[r.off:]
+			}
+			formula, ok := wb.parseFormula(rgce, rgcb, r1, c1, true)
+			fml := binaryFormula{rect: [4]int{r1, r2, c1, c2}, formula: formula, ok: ok}
+			if recordType == rtArray {
+				arrays = append(arrays, fml)
+			} else {
+				shared = append(shared, fml)
+			}
+		case rtMergeCells:
+			count := int(r.u16())
+			for i := 0; i < count && !r.invalid; i++ {
+				r1, r2, c1, c2 := int(r.u16()), int(r.u16()), int(r.u16()), int(r.u16())
+				mergeCells.Cells = append(mergeCells.Cells, &xlsxMergeCell{Ref: formatBinaryRange(r1, r2, c1, c2)})
+			}
+		}
+		if r.invalid || !ok {
+			return ws, newInvalidBinaryRecordError(legacyWorkbookStream, recordType)
+		}
+	}
+	setBinaryFormulaCells(ws, wb.sheetData(ws, rows, cells), arrays, shared)
+	if len(mergeCells.Cells) > 0 {
+		mergeCells.Count = len(mergeCells.Cells)
+		ws.MergeCells = mergeCells
+	}
+	return ws, nil
+}
+
+// parseLegacyFormulaValue provides a function to parse the cached value of
+// the formula cell record in the legacy workbook, and returns the parsed
+// formula tokens and the extra data of them. The string value of the formula
+// will be stored in the following string record.
+func parseLegacyFormulaValue(r *legacyRecord, c *xlsxC) ([]byte, []byte) {
+	value := r.bytes(8)
+	_, _ = r.u16(), r.u32()
+	rgce := r.bytes(int(r.u16()))
+	if r.invalid {
+		return nil, nil
+	}
+	if value[6] != 0xFF || value[7] != 0xFF {
+		c.V = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(value)), 'f', -1, 64)
+		return rgce, r.data[r.off:]
+	}
+	switch value[0] {
+	case 0x00, 0x03:
+		c.T = "str"
+	case 0x01:
+		c.T, c.V = "b", strconv.Itoa(int(value[2]&1))
+	case 0x02:
+		c.T, c.V = "e", binaryErrorValues[value[2]]
+	}
+	return rgce, r.data[r.off:]
+}
+
+// sheetData provides a function to set the rows and cells of the worksheet
+// in the legacy workbook in order, and returns the cells which formula
+// referenced to the shared formulas or array formulas.
+func (wb *legacyWorkbook) sheetData(ws *xlsxWorksheet, rows map[int]xlsxRow, cells map[int][]legacyCell) []binaryFormulaCell {
+	var (
+		rowNums      []int
+		formulaCells []binaryFormulaCell
+	)
+	for rowNum := range rows {
+		rowNums = append(rowNums, rowNum)
+	}
+	for rowNum := range cells {
+		if _, ok := rows[rowNum]; !ok {
+			rowNums = append(rowNums, rowNum)
+		}
+	}
+	sort.Ints(rowNums)
+	for _, rowNum := range rowNums {
+		row, ok := rows[rowNum]
+		if !ok {
+			row = xlsxRow{R: rowNum + 1}
+		}
+		rowCells := cells[rowNum]
+		sort.SliceStable(rowCells, func(i, j int) bool { return rowCells[i].col < rowCells[j].col })
+		for _, cell := range rowCells {
+			if len(row.C) > 0 && rowCells[len(row.C)-1].col == cell.col {
+				continue
+			}
+			if cell.linked {
+				formulaCells = append(formulaCells, binaryFormulaCell{
+					row: len(ws.SheetData.Row), cell: len(row.C), r: rowNum, c: cell.col, anchor: cell.anchor,
+				})
+			}
+			row.C = append(row.C, cell.c)
+		}
+		ws.SheetData.Row = append(ws.SheetData.Row, row)
+	}
+	return formulaCells
+}
@@ -0,0 +1,470 @@
+package excelize
+
+import (
+	"bytes"
+	"crypto/rc4"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// legacyRecords is a helper for building the records of the legacy workbook
+// stream, used for testing.
+type legacyRecords struct {
+	bytes.Buffer
+}
+
+// add encodes the record by given record type and the fields of the record.
+func (b *legacyRecords) add(recordType int, fields ...interface{}) *legacyRecords {
+	var data bytes.Buffer
+	for _, field := range fields {
+		_ = binary.Write(&data, binary.LittleEndian, field)
+	}
+	_ = binary.Write(&b.Buffer, binary.LittleEndian, []uint16{uint16(recordType), uint16(data.Len())})
+	b.Write(data.Bytes())
+	return b
+}
+
+// legacyString returns the compressed XLUnicodeString or ShortXLUnicodeString
+// by given string and the size of the character count field.
+func legacyString(s string, cchSize int) []byte {
+	b := []byte{byte(len(s))}
+	if cchSize == 2 {
+		b = append(b, byte(len(s)>>8))
+	}
+	return append(append(b, 0), s...)
+}
+
+// legacyTokens concatenates the parsed formula tokens and returns the fields
+// with the size of the tokens.
+func legacyTokens(rgce ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, v := range rgce {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return append([]byte{byte(buf.Len()), byte(buf.Len() >> 8)}, buf.Bytes()...)
+}
+
+// legacyFormula returns the fields of the formula cell record by given
+// cached value and parsed formula tokens.
+func legacyFormula(row, col, xf uint16, value []byte, rgce []byte, rgcb ...byte) []interface{} {
+	return []interface{}{row, col, xf, value, uint16(0), uint32(0), rgce, rgcb}
+}
+
+// legacyNumber returns the cached number value of the formula cell record.
+func legacyNumber(v float64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return b
+}
+
+// prepareLegacyWorkbook returns the legacy workbook stream for testing, the
+// file pass record will be written after the BOF record if it's not nil.
+func prepareLegacyWorkbook(filePass []byte) []byte {
+	globals, bof := new(legacyRecords), []interface{}{uint16(0x0600), uint16(0x0005), uint16(0x0DBB), uint16(0x07CC), uint32(0), uint32(0x0206)}
+	globals.add(rtBOF, bof...)
+	if filePass != nil {
+		globals.add(rtFilePass, filePass)
+	}
+	globals.add(rtInterfaceHdr, uint16(0x04B0)).add(rtDate1904, uint16(0))
+	palette := []interface{}{uint16(56)}
+	for i, color := range IndexedColorMapping[8:64] {
+		rgb, _ := hex.DecodeString(color)
+		if i == 2 {
+			rgb = []byte{0x12, 0x34, 0x56}
+		}
+		palette = append(palette, append(rgb, 0))
+	}
+	globals.add(rtPalette, palette...)
+	for i := 0; i < 4; i++ {
+		globals.add(rtFont, uint16(200), uint16(0), uint16(0x7FFF), uint16(400), uint16(0), uint8(0), uint8(0), uint8(0), uint8(0), legacyString("Arial", 1))
+	}
+	globals.add(rtFont, uint16(240), uint16(2|8), uint16(10), uint16(700), uint16(0), uint8(1), uint8(1), uint8(0), uint8(0), legacyString("Times New Roman", 1))
+	globals.add(rtFormat, uint16(164), legacyString("0.000", 2))
+	for i := 0; i < 15; i++ {
+		globals.add(rtXF, uint16(0), uint16(0), uint16(0xFFF5), uint8(0x20), uint8(0), uint8(0), uint8(0), uint32(0), uint32(0), uint16(0x20C0))
+	}
+	globals.add(rtXF, uint16(0), uint16(0), uint16(0x0001), uint8(0x20), uint8(0), uint8(0), uint8(0), uint32(0), uint32(0), uint16(0x20C0))
+	globals.add(rtXF, uint16(5), uint16(14), uint16(0x0002), uint8(0x1A), uint8(45), uint8(1), uint8(0xFC),
+		uint32(0x00086100), uint32(1<<26|8<<7|8), uint16(0x41<<7|13))
+	globals.add(rtXF, uint16(0), uint16(164), uint16(0x0001), uint8(0x20), uint8(0), uint8(0), uint8(0x04), uint32(0), uint32(0), uint16(0x20C0))
+	var offsets []int
+	for _, sheet := range []struct {
+		name         string
+		state, sheet uint8
+	}{{"Sheet1", 0, 0}, {"Data Sheet", 1, 0}, {"Chart1", 0, 2}} {
+		offsets = append(offsets, globals.Len()+4)
+		globals.add(rtBoundSheet8, uint32(0), sheet.state, sheet.sheet, legacyString(sheet.name, 1))
+	}
+	globals.add(rtSupBook, uint16(3), uint16(0x0401))
+	globals.add(rtExternSheet, uint16(2), []uint16{0, 1, 1}, []uint16{0, 0, 0})
+	globals.add(rtLbl, uint16(0), uint8(0), uint8(5), uint16(7), uint16(0), uint16(0), uint32(0), uint8(0), []byte("Total"),
+		legacyTokens(uint8(0x3A), uint16(0), uint16(0), uint16(0))[2:])
+	globals.add(rtLbl, uint16(0x20), uint8(0), uint8(1), uint16(11), uint16(0), uint16(1), uint32(0), uint8(0), uint8(6),
+		legacyTokens(uint8(0x3B), uint16(1), uint16(0), uint16(1), uint16(0), uint16(1))[2:])
+	globals.add(rtLbl, uint16(2), uint8(0), uint8(4), uint16(3), uint16(0), uint16(0), uint32(0), uint8(0), []byte("Func"),
+		legacyTokens(uint8(0x1E), uint16(1))[2:])
+	globals.add(rtSST, uint32(4), uint32(3), uint16(4), uint8(0), []byte("Name"), uint16(11), uint8(0), []byte("Hello "))
+	globals.add(rtContinue, uint8(1), []uint16{'W', 'o', 'r', 'l', 'd'}, uint16(4), uint8(8), uint16(1), []byte("Ri"))
+	globals.add(rtContinue, uint8(0), []byte("ch"), uint16(0), uint16(0))
+	globals.add(rtEOF)
+
+	sheet := new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0010), uint16(0x0DBB), uint16(0x07CC), uint32(0), uint32(0x0206))
+	sheet.add(rtDefaultRowHeight, uint16(0), uint16(300)).add(rtDefColWidth, uint16(8))
+	sheet.add(rtColInfo, uint16(1), uint16(1), uint16(20*256), uint16(15), uint16(2), uint16(0))
+	sheet.add(rtDimensions, uint32(0), uint32(6), uint16(0), uint16(5), uint16(0))
+	sheet.add(rtRow, uint16(0), uint16(0), uint16(4), uint16(600), uint32(0), uint8(0x40), uint8(1), uint16(15))
+	sheet.add(rtRow, uint16(2), uint16(0), uint16(4), uint16(300), uint32(0), uint8(0x20), uint8(1), uint16(15))
+	sheet.add(rtLabelSst, uint16(0), uint16(0), uint16(15), uint32(0))
+	sheet.add(rtLabel, uint16(0), uint16(1), uint16(15), legacyString("Inline", 2))
+	sheet.add(rtNumber, uint16(0), uint16(2), uint16(17), legacyNumber(100))
+	sheet.add(rtRK, uint16(0), uint16(3), uint16(15), uint32(150<<2|3))
+	sheet.add(rtNumber, uint16(1), uint16(0), uint16(16), legacyNumber(45352))
+	sheet.add(rtBoolErr, uint16(1), uint16(1), uint16(15), uint8(1), uint8(0))
+	sheet.add(rtBoolErr, uint16(1), uint16(2), uint16(15), uint8(0x07), uint8(1))
+	sheet.add(rtFormula, legacyFormula(1, 3, 15, legacyNumber(45452),
+		legacyTokens(uint8(0x24), uint16(0), uint16(0xC002), uint8(0x24), uint16(1), uint16(0), uint8(0x03)))...)
+	sheet.add(rtFormula, legacyFormula(2, 0, 15, []byte{0, 0, 0, 0, 0, 0, 0xFF, 0xFF},
+		legacyTokens(uint8(0x24), uint16(0), uint16(0xC001), uint8(0x17), legacyString("!", 1), uint8(0x08)))...)
+	sheet.add(rtString, legacyString("Inline!", 2))
+	sheet.add(rtFormula, legacyFormula(2, 1, 15, legacyNumber(101.5),
+		legacyTokens(uint8(0x25), uint16(0), uint16(0), uint16(0xC002), uint16(0xC003), uint8(0x19), uint8(0x10), uint16(0)))...)
+	sheet.add(rtFormula, legacyFormula(2, 2, 15, []byte{1, 0, 1, 0, 0, 0, 0xFF, 0xFF},
+		legacyTokens(uint8(0x5A), uint16(0), uint16(0), uint16(0xC000), uint8(0x41), uint16(128)))...)
+	sheet.add(rtFormula, legacyFormula(2, 3, 15, legacyNumber(42), legacyTokens(uint8(0x23), uint32(1)))...)
+	sheet.add(rtFormula, legacyFormula(3, 0, 15, legacyNumber(200), legacyTokens(uint8(0x01), uint16(3), uint16(0)))...)
+	sheet.add(rtShrFmla, uint16(3), uint16(3), uint8(0), uint8(1), uint8(0), uint8(2),
+		legacyTokens(uint8(0x2C), uint16(0xFFFD), uint16(0xC002), uint8(0x1E), uint16(2), uint8(0x05)))
+	sheet.add(rtFormula, legacyFormula(3, 1, 15, legacyNumber(3), legacyTokens(uint8(0x01), uint16(3), uint16(0)))...)
+	sheet.add(rtFormula, legacyFormula(4, 0, 15, legacyNumber(203), legacyTokens(uint8(0x01), uint16(4), uint16(0)))...)
+	sheet.add(rtArray, uint16(4), uint16(4), uint8(0), uint8(1), uint16(0), uint32(0),
+		legacyTokens(uint8(0x45), uint16(0), uint16(0), uint16(2), uint16(3), uint8(0x1E), uint16(2), uint8(0x05), uint8(0x42), uint8(1), uint16(4)))
+	sheet.add(rtFormula, legacyFormula(4, 1, 15, legacyNumber(0), legacyTokens(uint8(0x01), uint16(4), uint16(0)))...)
+	sheet.add(rtFormula, legacyFormula(4, 2, 15, legacyNumber(3),
+		legacyTokens(uint8(0x60), make([]byte, 7), uint8(0x42), uint8(1), uint16(4)),
+		append(append([]byte{1, 1, 0, 0x01}, legacyNumber(1)...), append([]byte{0x01}, legacyNumber(2)...)...)...)...)
+	sheet.add(rtContinue, []byte{0x02}, legacyString("a", 2), []byte{0x04, 1, 0, 0, 0, 0, 0, 0, 0})
+	sheet.add(rtMulBlank, uint16(4), uint16(3), uint16(16), uint16(16), uint16(4))
+	sheet.add(rtMulRk, uint16(5), uint16(0), uint16(15), uint32(200<<2|2), uint16(15), uint32(3<<2|3), uint16(1))
+	sheet.add(rtMergeCells, uint16(1), uint16(6), uint16(6), uint16(0), uint16(1))
+	sheet.add(rtEOF)
+
+	dataSheet := new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0010), uint16(0x0DBB), uint16(0x07CC), uint32(0), uint32(0x0206))
+	dataSheet.add(rtNumber, uint16(0), uint16(0), uint16(17), legacyNumber(42)).add(rtEOF)
+	chartSheet := new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0020), uint16(0x0DBB), uint16(0x07CC), uint32(0), uint32(0x0206)).add(rtEOF)
+
+	stream := globals.Bytes()
+	for i, substream := range []*legacyRecords{sheet, dataSheet, chartSheet} {
+		binary.LittleEndian.PutUint32(stream[offsets[i]:], uint32(len(stream)))
+		stream = append(stream, substream.Bytes()...)
+	}
+	return stream
+}
+
+// encryptLegacyWorkbook encrypts the records after the file pass record of
+// the legacy workbook stream in place by given encrypt function.
+func encryptLegacyWorkbook(stream []byte, encrypt func(data []byte, pos, size int)) {
+	var encrypted bool
+	for off := 0; off+4 <= len(stream); {
+		recordType := int(binary.LittleEndian.Uint16(stream[off:]))
+		size, pos := int(binary.LittleEndian.Uint16(stream[off+2:])), off+4
+		switch {
+		case recordType == rtFilePass:
+			encrypted = true
+		case !encrypted || legacyUnencryptedRecords[recordType]:
+		case recordType == rtBoundSheet8:
+			encrypt(stream[pos+4:pos+size], pos+4, size)
+		default:
+			encrypt(stream[pos:pos+size], pos, size)
+		}
+		off = pos + size
+	}
+}
+
+// legacyCompoundFile returns the compound file with the given legacy
+// workbook stream.
+func legacyCompoundFile(stream []byte) []byte {
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	compoundFile.put(legacyWorkbookStream, stream)
+	return compoundFile.write()
+}
+
+// prepareEncryptedLegacyWorkbook returns the legacy workbook encrypted by the
+// given encryption type and password for testing.
+func prepareEncryptedLegacyWorkbook(t *testing.T, encryption, password string) []byte {
+	pw, err := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().Bytes([]byte(password))
+	assert.NoError(t, err)
+	salt := bytes.Repeat([]byte{0x5A}, 16)
+	verifier := []byte("0123456789ABCDEF")
+	rc4FilePass := func(keyFunc func(block uint32) []byte, hashAlgorithm string) []byte {
+		cipher, _ := rc4.NewCipher(keyFunc(0))
+		data := append(append([]byte{}, verifier...), hashing(hashAlgorithm, verifier)...)
+		cipher.XORKeyStream(data, data)
+		return data
+	}
+	var (
+		filePass []byte
+		encrypt  func(data []byte, pos, size int)
+	)
+	switch encryption {
+	case "xor":
+		passwd := legacyXORPassword(password)
+		key := legacyXORKey(passwd)
+		filePass = []byte{0, 0, byte(key), byte(key >> 8), 0, 0}
+		binary.LittleEndian.PutUint16(filePass[4:], legacyXORVerifier(passwd))
+		array := legacyXORArray(passwd, key)
+		encrypt = func(data []byte, pos, size int) {
+			for i, b := range data {
+				data[i] = (b<<5 | b>>3) ^ array[(pos+i+size)%16]
+			}
+		}
+	case "rc4":
+		keyFunc := legacyRC4Key(pw, salt)
+		filePass = append(append([]byte{1, 0, 1, 0, 1, 0}, salt...), rc4FilePass(keyFunc, "md5")...)
+		encrypt = legacyRC4Decrypter(keyFunc)
+	case "cryptoapi":
+		keyFunc := legacyCryptoAPIKey(pw, salt, 128)
+		header := new(legacyRecords)
+		_ = binary.Write(header, binary.LittleEndian, []uint32{0x24, 0, 0x6801, 0x8004, 128, 1, 0, 0})
+		header.WriteString("CSP\x00")
+		data, buf := rc4FilePass(keyFunc, "sha1"), new(legacyRecords)
+		_ = binary.Write(buf, binary.LittleEndian, []uint16{1, 4, 2})
+		_ = binary.Write(buf, binary.LittleEndian, []uint32{0x24, uint32(header.Len())})
+		_ = binary.Write(buf, binary.LittleEndian, header.Bytes())
+		_ = binary.Write(buf, binary.LittleEndian, uint32(len(salt)))
+		_ = binary.Write(buf, binary.LittleEndian, append(salt, data[:16]...))
+		_ = binary.Write(buf, binary.LittleEndian, uint32(20))
+		_ = binary.Write(buf, binary.LittleEndian, data[16:])
+		filePass = buf.Bytes()
+		encrypt = legacyRC4Decrypter(keyFunc)
+	}
+	stream := prepareLegacyWorkbook(filePass)
+	encryptLegacyWorkbook(stream, encrypt)
+	return legacyCompoundFile(stream)
+}
+
+func TestOpenLegacyWorkbook(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(legacyCompoundFile(prepareLegacyWorkbook(nil))))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Data Sheet"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Data Sheet")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Inline", "100.000", "1.5"},
+		{"03-01-24", "TRUE", "#DIV/0!", "45452"},
+		{"Inline!", "101.5", "TRUE", "42"},
+		{"200", "3"},
+		{"203", "0", "3"},
+		{"200", "0.03"},
+	}, rows)
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello World", sst.SI[1].T.Val)
+	assert.Equal(t, "Rich", sst.SI[2].T.Val)
+	for cell, expected := range map[string]string{
+		"D2": "C1+$A$2", "A3": "B1&\"!\"", "B3": "SUM(C1:D1)", "C3": "ISNUMBER('Data Sheet'!A1)",
+		"D3": "Total", "A4": "C1*2", "B4": "D1*2", "A5": "SUM($C$1:$D$1*2)", "B5": "",
+		"C5": "SUM({1,2;\"a\",TRUE})",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	result, err := f.CalcCellValue("Sheet1", "D2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45452", result)
+	assert.Equal(t, []DefinedName{
+		{Name: "Total", RefersTo: "'Data Sheet'!$A$1", Scope: "Workbook"},
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$2", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+
+	// Test get cell styles, column widths, row heights and merged cells
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.True(t, style.Font.Bold)
+	assert.True(t, style.Font.Italic)
+	assert.True(t, style.Font.Strike)
+	assert.Equal(t, "single", style.Font.Underline)
+	assert.Equal(t, "Times New Roman", style.Font.Family)
+	assert.Equal(t, 12.0, style.Font.Size)
+	assert.Equal(t, 10, style.Font.ColorIndexed)
+	assert.Equal(t, "123456", f.GetBaseColor("", style.Font.ColorIndexed, nil))
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, style.Fill)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 1}, {Type: "bottom", Color: "000000", Style: 6}}, style.Border)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 45, Indent: 1}, style.Alignment)
+	assert.Equal(t, &Protection{Hidden: true}, style.Protection)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A7:B7", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	value, err := f.GetCellValue("Data Sheet", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42.000", value)
+
+	// Test save the legacy workbook as the XLSX file
+	assert.Equal(t, ErrWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestOpenLegacyWorkbook.xls")))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenLegacyWorkbook.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestOpenLegacyWorkbook.xlsx"))
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Inline!", value)
+	assert.NoError(t, f.Close())
+
+	// Test open the legacy workbook by the io.ReaderAt
+	data := legacyCompoundFile(prepareLegacyWorkbook(nil))
+	f, err = OpenReaderAt(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", value)
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenLegacyWorkbookFile(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "LegacyWorkbook.xls"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Test sheet 1", "Test sheet 2", "Sheet3"}, f.GetSheetList())
+	for sheet, expected := range map[string][][]string{
+		"Test sheet 1": {{"Test1", "Lorem", "Ipsum"}, {"Avocado", "1", "2"}, {"", "3", "5"}, {"", "4", "7"}},
+		"Test sheet 2": {{"Test2"}},
+		"Sheet3":       {},
+	} {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows, sheet)
+	}
+	cellType, err := f.GetCellType("Test sheet 1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	cellType, err = f.GetCellType("Test sheet 1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	styleID, err := f.GetCellStyle("Test sheet 1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Calibri", style.Font.Family)
+	assert.Equal(t, 11.0, style.Font.Size)
+	width, err := f.GetColWidth("Test sheet 1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 9.140625, width)
+	mergeCells, err := f.GetMergeCells("Test sheet 1")
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenLegacyWorkbookFile.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenEncryptedLegacyWorkbook(t *testing.T) {
+	for _, encryption := range []string{"xor", "rc4", "cryptoapi"} {
+		f, err := OpenReader(bytes.NewReader(prepareEncryptedLegacyWorkbook(t, encryption, "password")), Options{Password: "password"})
+		assert.NoError(t, err, encryption)
+		value, err := f.GetCellValue("Sheet1", "A3")
+		assert.NoError(t, err, encryption)
+		assert.Equal(t, "Inline!", value, encryption)
+		value, err = f.GetCellValue("Sheet1", "B1")
+		assert.NoError(t, err, encryption)
+		assert.Equal(t, "Inline", value, encryption)
+		assert.Equal(t, []string{"Sheet1", "Data Sheet"}, f.GetSheetList(), encryption)
+		assert.NoError(t, f.Close())
+
+		// Test open the encrypted legacy workbook with the wrong password
+		_, err = OpenReader(bytes.NewReader(prepareEncryptedLegacyWorkbook(t, encryption, "password")), Options{Password: "passwd"})
+		assert.Equal(t, ErrWorkbookPassword, err, encryption)
+	}
+	// Test open the legacy workbook encrypted with the default password
+	f, err := OpenReader(bytes.NewReader(prepareEncryptedLegacyWorkbook(t, "rc4", legacyDefaultPassword)))
+	assert.NoError(t, err)
+	value, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", value)
+	assert.NoError(t, f.Close())
+
+	// Test open the legacy workbook with unsupported encryption
+	for _, filePass := range [][]byte{{1, 0, 3, 0, 3, 0}, {1, 0, 1, 0, 1, 0}, {1, 0, 4, 0, 2, 0, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}} {
+		_, err = OpenReader(bytes.NewReader(legacyCompoundFile(prepareLegacyWorkbook(filePass))))
+		assert.Equal(t, ErrUnsupportedEncryptMechanism, err)
+	}
+}
+
+func TestOpenLegacyWorkbookError(t *testing.T) {
+	// Test open the compound file without the workbook stream
+	_, err := OpenReader(bytes.NewReader(legacyCompoundFile(nil)[:512]))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open the workbook stream in the unsupported BIFF version
+	stream := prepareLegacyWorkbook(nil)
+	binary.LittleEndian.PutUint16(stream[4:], 0x0500)
+	_, err = OpenReader(bytes.NewReader(legacyCompoundFile(stream)))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open the workbook stream with truncated records
+	stream = prepareLegacyWorkbook(nil)
+	for _, stream := range [][]byte{
+		stream[:len(stream)-30],
+		new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0005)).add(rtFont, uint16(200)).Bytes(),
+		append(new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0005)).Bytes(), 0x31, 0x00, 0x10, 0x00),
+	} {
+		_, err = OpenReader(bytes.NewReader(legacyCompoundFile(stream)))
+		assert.Contains(t, err.Error(), "in binary part Workbook")
+	}
+	// Test open the workbook stream without worksheets
+	_, err = OpenReader(bytes.NewReader(legacyCompoundFile(new(legacyRecords).add(rtBOF, uint16(0x0600), uint16(0x0005)).add(rtEOF).Bytes())))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+}
+
+func TestParseLegacyFormula(t *testing.T) {
+	wb := &legacyWorkbook{}
+	for _, c := range []struct {
+		rgce     []interface{}
+		expected string
+		ok       bool
+	}{
+		{[]interface{}{uint8(0x1E), uint16(1), uint8(0x1F), 0.5, uint8(0x04)}, "1-0.5", true},
+		{[]interface{}{uint8(0x1D), uint8(1), uint8(0x12), uint8(0x15), uint8(0x14)}, "(+TRUE)%", true},
+		{[]interface{}{uint8(0x1C), uint8(0x2A), uint8(0x16), uint8(0x42), uint8(2), uint16(1)}, "IF(#N/A,)", true},
+		{[]interface{}{uint8(0x44), uint16(0), uint16(0x8000)}, "$A1", true},
+		{[]interface{}{uint8(0x45), uint16(0), uint16(65535), uint16(0), uint16(1)}, "$A:$B", true},
+		{[]interface{}{uint8(0x2A), uint32(0), uint8(0x2B), make([]byte, 8), uint8(0x11)}, "#REF!:#REF!", true},
+		{[]interface{}{uint8(0x26), make([]byte, 6), uint8(0x1E), uint16(1), uint8(0x29), uint16(0)}, "1", true},
+		{[]interface{}{uint8(0x19), uint8(0x04), uint16(1), uint32(0), uint8(0x1E), uint16(1)}, "1", true},
+		{[]interface{}{uint8(0x03)}, "", false},
+		{[]interface{}{uint8(0x21), uint16(1)}, "", false},
+		{[]interface{}{uint8(0x21), uint16(0xFFFF)}, "", false},
+		{[]interface{}{uint8(0x22), uint8(0), uint16(0xFFFF)}, "", false},
+		{[]interface{}{uint8(0x23), uint32(1)}, "", false},
+		{[]interface{}{uint8(0x3A), uint16(0), uint32(0)}, "", false},
+		{[]interface{}{uint8(0x18)}, "", false},
+	} {
+		formula, ok := wb.parseFormula(legacyTokens(c.rgce...)[2:], nil, 0, 0, false)
+		assert.Equal(t, c.ok, ok, c.expected)
+		assert.Equal(t, c.expected, formula)
+	}
+	r, c, colAbs, rowAbs := legacyCellRef(0xFFFF, 0xC0FF, 0, 0, true)
+	assert.Equal(t, []interface{}{65535, 255, "", ""}, []interface{}{r, c, colAbs, rowAbs})
+}
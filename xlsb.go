@@ -0,0 +1,1294 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Content types of the parts in the binary workbook (XLSB) package.
+const (
+	contentTypeBinarySharedStrings = "application/vnd.ms-excel.sharedStrings"
+	contentTypeBinaryStyles        = "application/vnd.ms-excel.styles"
+	contentTypeBinaryWorkbook      = "application/vnd.ms-excel.sheet.binary.macroEnabled.main"
+	contentTypeBinaryWorksheet     = "application/vnd.ms-excel.worksheet"
+	contentTypePrinterSettings     = "application/vnd.openxmlformats-officedocument.spreadsheetml.printerSettings"
+)
+
+// Record types of the BIFF12 records in the binary workbook (XLSB) parts,
+// the names are corresponding to the MS-XLSB specification.
+const (
+	brtRowHdr            = 0
+	brtCellBlank         = 1
+	brtCellRk            = 2
+	brtCellError         = 3
+	brtCellBool          = 4
+	brtCellReal          = 5
+	brtCellSt            = 6
+	brtCellIsst          = 7
+	brtFmlaString        = 8
+	brtFmlaNum           = 9
+	brtFmlaBool          = 10
+	brtFmlaError         = 11
+	brtSSTItem           = 19
+	brtName              = 39
+	brtFont              = 43
+	brtFmt               = 44
+	brtFill              = 45
+	brtBorder            = 46
+	brtXF                = 47
+	brtStyle             = 48
+	brtColInfo           = 60
+	brtWsDim             = 148
+	brtWbProp            = 153
+	brtBundleSh          = 156
+	brtBeginSst          = 159
+	brtMergeCell         = 176
+	brtSupBookSrc        = 355
+	brtSupSelf           = 357
+	brtSupSame           = 358
+	brtExternSheet       = 362
+	brtArrFmla           = 426
+	brtShrFmla           = 427
+	brtHLink             = 494
+	brtBeginCellXFs      = 617
+	brtEndCellXFs        = 618
+	brtBeginCellStyleXFs = 626
+	brtEndCellStyleXFs   = 627
+	brtSupAddin          = 667
+)
+
+// binaryErrorValues defined the error values of the binary workbook by the
+// error codes.
+var binaryErrorValues = map[byte]string{
+	0x00: formulaErrorNULL,
+	0x07: formulaErrorDIV,
+	0x0F: formulaErrorVALUE,
+	0x17: formulaErrorREF,
+	0x1D: formulaErrorNAME,
+	0x24: formulaErrorNUM,
+	0x2A: formulaErrorNA,
+	0x2B: formulaErrorGETTINGDATA,
+}
+
+// binaryOperators defined the binary operators of the parsed formula tokens
+// by the token types.
+var binaryOperators = map[byte]string{
+	0x03: "+", 0x04: "-", 0x05: "*", 0x06: "/", 0x07: "^", 0x08: "&",
+	0x09: "<", 0x0A: "<=", 0x0B: "=", 0x0C: ">=", 0x0D: ">", 0x0E: "<>",
+	0x0F: " ", 0x10: ",", 0x11: ":",
+}
+
+// binaryFillPatterns defined the pattern types of the cell fill by the
+// pattern indexes.
+var binaryFillPatterns = []string{
+	"none", "solid", "mediumGray", "darkGray", "lightGray", "darkHorizontal", "darkVertical",
+	"darkDown", "darkUp", "darkGrid", "darkTrellis", "lightHorizontal", "lightVertical",
+	"lightDown", "lightUp", "lightGrid", "lightTrellis", "gray125", "gray0625",
+}
+
+// binaryBorderStyles defined the line styles of the cell border by the style
+// indexes.
+var binaryBorderStyles = []string{
+	"", "thin", "medium", "dashed", "dotted", "thick", "double", "hair", "mediumDashed",
+	"dashDot", "mediumDashDot", "dashDotDot", "mediumDashDotDot", "slantDashDot",
+}
+
+// binaryFunction directly maps the name and the number of arguments of the
+// built-in function in the parsed formula tokens, the -1 number of arguments
+// means the function takes the variable number of arguments.
+type binaryFunction struct {
+	name string
+	args int
+}
+
+// binaryFunctions defined the built-in functions of the parsed formula tokens
+// by the function indexes.
+var binaryFunctions = map[uint16]binaryFunction{
+	0: {"COUNT", -1}, 1: {"IF", -1}, 2: {"ISNA", 1}, 3: {"ISERROR", 1},
+	4: {"SUM", -1}, 5: {"AVERAGE", -1}, 6: {"MIN", -1}, 7: {"MAX", -1},
+	8: {"ROW", -1}, 9: {"COLUMN", -1}, 10: {"NA", 0}, 11: {"NPV", -1},
+	12: {"STDEV", -1}, 13: {"DOLLAR", -1}, 14: {"FIXED", -1}, 15: {"SIN", 1},
+	16: {"COS", 1}, 17: {"TAN", 1}, 18: {"ATAN", 1}, 19: {"PI", 0},
+	20: {"SQRT", 1}, 21: {"EXP", 1}, 22: {"LN", 1}, 23: {"LOG10", 1},
+	24: {"ABS", 1}, 25: {"INT", 1}, 26: {"SIGN", 1}, 27: {"ROUND", 2},
+	28: {"LOOKUP", -1}, 29: {"INDEX", -1}, 30: {"REPT", 2}, 31: {"MID", 3},
+	32: {"LEN", 1}, 33: {"VALUE", 1}, 34: {"TRUE", 0}, 35: {"FALSE", 0},
+	36: {"AND", -1}, 37: {"OR", -1}, 38: {"NOT", 1}, 39: {"MOD", 2},
+	40: {"DCOUNT", 3}, 41: {"DSUM", 3}, 42: {"DAVERAGE", 3}, 43: {"DMIN", 3},
+	44: {"DMAX", 3}, 45: {"DSTDEV", 3}, 46: {"VAR", -1}, 47: {"DVAR", 3},
+	48: {"TEXT", 2}, 49: {"LINEST", -1}, 50: {"TREND", -1}, 51: {"LOGEST", -1},
+	52: {"GROWTH", -1}, 56: {"PV", -1}, 57: {"FV", -1}, 58: {"NPER", -1},
+	59: {"PMT", -1}, 60: {"RATE", -1}, 61: {"MIRR", 3}, 62: {"IRR", -1},
+	63: {"RAND", 0}, 64: {"MATCH", -1}, 65: {"DATE", 3}, 66: {"TIME", 3},
+	67: {"DAY", 1}, 68: {"MONTH", 1}, 69: {"YEAR", 1}, 70: {"WEEKDAY", -1},
+	71: {"HOUR", 1}, 72: {"MINUTE", 1}, 73: {"SECOND", 1}, 74: {"NOW", 0},
+	75: {"AREAS", 1}, 76: {"ROWS", 1}, 77: {"COLUMNS", 1}, 78: {"OFFSET", -1},
+	82: {"SEARCH", -1}, 83: {"TRANSPOSE", 1}, 86: {"TYPE", 1}, 97: {"ATAN2", 2},
+	98: {"ASIN", 1}, 99: {"ACOS", 1}, 100: {"CHOOSE", -1}, 101: {"HLOOKUP", -1},
+	102: {"VLOOKUP", -1}, 105: {"ISREF", 1}, 109: {"LOG", -1}, 111: {"CHAR", 1},
+	112: {"LOWER", 1}, 113: {"UPPER", 1}, 114: {"PROPER", 1}, 115: {"LEFT", -1},
+	116: {"RIGHT", -1}, 117: {"EXACT", 2}, 118: {"TRIM", 1}, 119: {"REPLACE", 4},
+	120: {"SUBSTITUTE", -1}, 121: {"CODE", 1}, 124: {"FIND", -1}, 125: {"CELL", -1},
+	126: {"ISERR", 1}, 127: {"ISTEXT", 1}, 128: {"ISNUMBER", 1}, 129: {"ISBLANK", 1},
+	130: {"T", 1}, 131: {"N", 1}, 140: {"DATEVALUE", 1}, 141: {"TIMEVALUE", 1},
+	142: {"SLN", 3}, 143: {"SYD", 4}, 144: {"DDB", -1}, 148: {"INDIRECT", -1},
+	162: {"CLEAN", 1}, 163: {"MDETERM", 1}, 164: {"MINVERSE", 1}, 165: {"MMULT", 2},
+	167: {"IPMT", -1}, 168: {"PPMT", -1}, 169: {"COUNTA", -1}, 183: {"PRODUCT", -1},
+	184: {"FACT", 1}, 189: {"DPRODUCT", 3}, 190: {"ISNONTEXT", 1}, 193: {"STDEVP", -1},
+	194: {"VARP", -1}, 195: {"DSTDEVP", 3}, 196: {"DVARP", 3}, 197: {"TRUNC", -1},
+	198: {"ISLOGICAL", 1}, 199: {"DCOUNTA", 3}, 204: {"USDOLLAR", -1}, 205: {"FINDB", -1},
+	206: {"SEARCHB", -1}, 207: {"REPLACEB", 4}, 208: {"LEFTB", -1}, 209: {"RIGHTB", -1},
+	210: {"MIDB", 3}, 211: {"LENB", 1}, 212: {"ROUNDUP", 2}, 213: {"ROUNDDOWN", 2},
+	214: {"ASC", 1}, 215: {"DBCS", 1}, 216: {"RANK", -1}, 219: {"ADDRESS", -1},
+	220: {"DAYS360", -1}, 221: {"TODAY", 0}, 222: {"VDB", -1}, 227: {"MEDIAN", -1},
+	228: {"SUMPRODUCT", -1}, 229: {"SINH", 1}, 230: {"COSH", 1}, 231: {"TANH", 1},
+	232: {"ASINH", 1}, 233: {"ACOSH", 1}, 234: {"ATANH", 1}, 235: {"DGET", 3},
+	244: {"INFO", 1}, 247: {"DB", -1}, 252: {"FREQUENCY", 2}, 261: {"ERROR.TYPE", 1},
+	269: {"AVEDEV", -1}, 270: {"BETADIST", -1}, 271: {"GAMMALN", 1}, 272: {"BETAINV", -1},
+	273: {"BINOMDIST", 4}, 274: {"CHIDIST", 2}, 275: {"CHIINV", 2}, 276: {"COMBIN", 2},
+	277: {"CONFIDENCE", 3}, 278: {"CRITBINOM", 3}, 279: {"EVEN", 1}, 280: {"EXPONDIST", 3},
+	281: {"FDIST", 3}, 282: {"FINV", 3}, 283: {"FISHER", 1}, 284: {"FISHERINV", 1},
+	285: {"FLOOR", 2}, 286: {"GAMMADIST", 4}, 287: {"GAMMAINV", 3}, 288: {"CEILING", 2},
+	289: {"HYPGEOMDIST", 4}, 290: {"LOGNORMDIST", 3}, 291: {"LOGINV", 3}, 292: {"NEGBINOMDIST", 3},
+	293: {"NORMDIST", 4}, 294: {"NORMSDIST", 1}, 295: {"NORMINV", 3}, 296: {"NORMSINV", 1},
+	297: {"STANDARDIZE", 3}, 298: {"ODD", 1}, 299: {"PERMUT", 2}, 300: {"POISSON", 3},
+	301: {"TDIST", 3}, 302: {"WEIBULL", 4}, 303: {"SUMXMY2", 2}, 304: {"SUMX2MY2", 2},
+	305: {"SUMX2PY2", 2}, 306: {"CHITEST", 2}, 307: {"CORREL", 2}, 308: {"COVAR", 2},
+	309: {"FORECAST", 3}, 310: {"FTEST", 2}, 311: {"INTERCEPT", 2}, 312: {"PEARSON", 2},
+	313: {"RSQ", 2}, 314: {"STEYX", 2}, 315: {"SLOPE", 2}, 316: {"TTEST", 4},
+	317: {"PROB", -1}, 318: {"DEVSQ", -1}, 319: {"GEOMEAN", -1}, 320: {"HARMEAN", -1},
+	321: {"SUMSQ", -1}, 322: {"KURT", -1}, 323: {"SKEW", -1}, 324: {"ZTEST", -1},
+	325: {"LARGE", 2}, 326: {"SMALL", 2}, 327: {"QUARTILE", 2}, 328: {"PERCENTILE", 2},
+	329: {"PERCENTRANK", -1}, 330: {"MODE", -1}, 331: {"TRIMMEAN", 2}, 332: {"TINV", 2},
+	336: {"CONCATENATE", -1}, 337: {"POWER", 2}, 342: {"RADIANS", 1}, 343: {"DEGREES", 1},
+	344: {"SUBTOTAL", -1}, 345: {"SUMIF", -1}, 346: {"COUNTIF", 2}, 347: {"COUNTBLANK", 1},
+	350: {"ISPMT", 4}, 351: {"DATEDIF", 3}, 354: {"ROMAN", -1}, 358: {"GETPIVOTDATA", -1},
+	359: {"HYPERLINK", -1}, 360: {"PHONETIC", 1}, 361: {"AVERAGEA", -1}, 362: {"MAXA", -1},
+	363: {"MINA", -1}, 364: {"STDEVPA", -1}, 365: {"VARPA", -1}, 366: {"STDEVA", -1},
+	367: {"VARA", -1}, 368: {"BAHTTEXT", 1},
+}
+
+// binaryPartReader directly maps the reader for the records of the part in
+// the binary workbook.
+type binaryPartReader struct {
+	part string
+	data []byte
+	off  int
+}
+
+// varint provides a function to read the variable length integer of the
+// record type and the record size with the given maximum bytes.
+func (br *binaryPartReader) varint(maxBytes int) (int, bool) {
+	var value int
+	for i := 0; i < maxBytes; i++ {
+		if br.off >= len(br.data) {
+			return value, false
+		}
+		b := br.data[br.off]
+		br.off++
+		value |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return value, true
+}
+
+// next provides a function to read the type and the data of the next record,
+// and returns io.EOF at the end of the part.
+func (br *binaryPartReader) next() (int, *binaryRecord, error) {
+	if br.off >= len(br.data) {
+		return 0, nil, io.EOF
+	}
+	recordType, ok := br.varint(2)
+	if !ok {
+		return recordType, nil, newInvalidBinaryRecordError(br.part, recordType)
+	}
+	size, ok := br.varint(4)
+	if !ok || br.off+size > len(br.data) {
+		return recordType, nil, newInvalidBinaryRecordError(br.part, recordType)
+	}
+	record := &binaryRecord{data: br.data[br.off : br.off+size]}
+	br.off += size
+	return recordType, record, nil
+}
+
+// binaryRecord directly maps the data of the record in the binary workbook,
+// the invalid field will be set when reading out of the record data.
+type binaryRecord struct {
+	data    []byte
+	off     int
+	invalid bool
+}
+
+// bytes returns the next n bytes of the record data.
+func (r *binaryRecord) bytes(n int) []byte {
+	if r.invalid || n < 0 || r.off+n > len(r.data) {
+		r.invalid = true
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// u8 returns the next unsigned 8-bit integer of the record data.
+func (r *binaryRecord) u8() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// u16 returns the next unsigned 16-bit integer of the record data.
+func (r *binaryRecord) u16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+// u32 returns the next unsigned 32-bit integer of the record data.
+func (r *binaryRecord) u32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// f64 returns the next 64-bit floating-point number of the record data.
+func (r *binaryRecord) f64() float64 {
+	if b := r.bytes(8); b != nil {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return 0
+}
+
+// str returns the next UTF-16 string with the given number of characters.
+func (r *binaryRecord) str(chars int) string {
+	b := r.bytes(chars * 2)
+	if b == nil {
+		return ""
+	}
+	u := make([]uint16, chars)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(u))
+}
+
+// wideString returns the next XLWideString or XLNullableWideString of the
+// record data, the null string will be returned as an empty string.
+func (r *binaryRecord) wideString() string {
+	chars := r.u32()
+	if chars == math.MaxUint32 {
+		return ""
+	}
+	return r.str(int(chars))
+}
+
+// rfx returns the next cell range of the record data as the zero-based first
+// row, last row, first column and last column.
+func (r *binaryRecord) rfx() (int, int, int, int) {
+	return int(r.u32()), int(r.u32()), int(r.u32()), int(r.u32())
+}
+
+// formula returns the parsed formula tokens and the extra data of the formula
+// tokens of the record data.
+func (r *binaryRecord) formula() ([]byte, []byte) {
+	rgce := r.bytes(int(r.u32()))
+	rgcb := r.bytes(int(r.u32()))
+	return rgce, rgcb
+}
+
+// color returns the next color of the record data.
+func (r *binaryRecord) color() *xlsxColor {
+	b := r.bytes(8)
+	if b == nil {
+		return nil
+	}
+	color := &xlsxColor{Tint: math.Round(float64(int16(binary.LittleEndian.Uint16(b[2:])))/32767*1e6) / 1e6}
+	switch b[0] >> 1 {
+	case 0:
+		color.Auto = true
+	case 1:
+		color.Indexed = int(b[1])
+	case 2:
+		color.RGB = fmt.Sprintf("%02X%02X%02X%02X", b[7], b[4], b[5], b[6])
+	case 3:
+		color.Theme = intPtr(int(b[1]))
+	default:
+		return nil
+	}
+	return color
+}
+
+// binarySheet directly maps the sheet of the binary workbook.
+type binarySheet struct {
+	name  string
+	relID string
+	state uint32
+}
+
+// binaryExternSheet directly maps the sheet range reference of the binary
+// workbook, which used by the 3D references in the formulas.
+type binaryExternSheet struct {
+	supBook, first, last int
+}
+
+// binaryName directly maps the defined name of the binary workbook.
+type binaryName struct {
+	name, comment string
+	scope         int
+	hidden, fn    bool
+	rgce, rgcb    []byte
+}
+
+// binaryWorkbook directly maps the workbook part of the binary workbook.
+type binaryWorkbook struct {
+	date1904     bool
+	sheets       []binarySheet
+	supBooks     []bool
+	externSheets []binaryExternSheet
+	names        []binaryName
+}
+
+// binaryFormula directly maps the shared formula or the array formula in the
+// worksheet of the binary workbook.
+type binaryFormula struct {
+	rect    [4]int
+	formula string
+	ok      bool
+}
+
+// binaryFormulaCell directly maps the cell in the worksheet of the binary
+// workbook, which formula referenced to a shared formula or an array formula.
+type binaryFormulaCell struct {
+	row, cell, r, c, anchor int
+}
+
+// convertBinaryWorkbook provides a function to convert the parts of the
+// binary workbook (XLSB) to the equivalent SpreadsheetML parts in the given
+// parts of the package, so the workbook, worksheets, shared strings and
+// styles can be read by the same functions with the XLSX files. The other
+// binary parts, such as the calculation chain and the comments will be
+// ignored.
+func (f *File) convertBinaryWorkbook(parts map[string][]byte) error {
+	contentTypes, rootRels := new(xlsxTypes), new(xlsxRelationships)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(parts[defaultXMLPathContentTypes]))).
+		Decode(contentTypes); err != nil && err != io.EOF {
+		return err
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(parts["_rels/.rels"]))).
+		Decode(rootRels); err != nil && err != io.EOF {
+		return err
+	}
+	var wbPath string
+	for _, rel := range rootRels.Relationships {
+		if rel.Type == SourceRelationshipOfficeDocument {
+			wbPath = strings.TrimPrefix(rel.Target, "/")
+		}
+	}
+	if getPartContentType(contentTypes, wbPath) != contentTypeBinaryWorkbook {
+		return nil
+	}
+	wb, err := parseBinaryWorkbook(wbPath, parts[wbPath])
+	if err != nil {
+		return err
+	}
+	changed := map[string]string{wbPath: strings.TrimSuffix(wbPath, ".bin") + ".xml"}
+	wbRelsPath := getPartRelsPath(wbPath)
+	wbRels, err := f.convertBinaryRels(parts, contentTypes, changed, wbRelsPath, path.Dir(wbPath))
+	if err != nil {
+		return err
+	}
+	worksheets := map[string]bool{}
+	for i := 0; i < len(wbRels.Relationships); i++ {
+		rel := &wbRels.Relationships[i]
+		if rel.TargetMode == "External" {
+			continue
+		}
+		name := getPartPath(path.Dir(wbPath), rel.Target)
+		newName := changed[name]
+		if newName == "" {
+			continue
+		}
+		var output interface{}
+		switch getPartContentType(contentTypes, name) {
+		case contentTypeBinaryWorksheet:
+			if output, err = wb.parseWorksheet(name, parts[name]); err == nil {
+				if _, err = f.convertBinaryRels(parts, contentTypes, changed, getPartRelsPath(name), path.Dir(name)); err == nil {
+					worksheets[rel.ID] = true
+				}
+			}
+		case contentTypeBinarySharedStrings:
+			output, err = parseBinarySharedStrings(name, parts[name])
+		case contentTypeBinaryStyles:
+			output, err = parseBinaryStyles(name, parts[name])
+		}
+		if err != nil {
+			return err
+		}
+		if err = setBinaryConvertedPart(parts, newName, output); err != nil {
+			return err
+		}
+		rel.Target = strings.TrimSuffix(rel.Target, ".bin") + ".xml"
+	}
+	workbook := &xlsxWorkbook{WorkbookPr: &xlsxWorkbookPr{Date1904: wb.date1904}}
+	for i, sheet := range wb.sheets {
+		if !worksheets[sheet.relID] {
+			continue
+		}
+		state := []string{"", "hidden", "veryHidden"}
+		ws := xlsxSheet{Name: sheet.name, SheetID: i + 1, ID: sheet.relID}
+		if sheet.state < uint32(len(state)) {
+			ws.State = state[sheet.state]
+		}
+		workbook.Sheets.Sheet = append(workbook.Sheets.Sheet, ws)
+	}
+	workbook.DefinedNames = wb.definedNames()
+	for i := range rootRels.Relationships {
+		if rel := &rootRels.Relationships[i]; rel.Type == SourceRelationshipOfficeDocument {
+			rel.Target = strings.TrimSuffix(rel.Target, ".bin") + ".xml"
+		}
+	}
+	for _, v := range []struct {
+		name  string
+		value interface{}
+	}{
+		{changed[wbPath], workbook},
+		{strings.TrimSuffix(wbRelsPath, ".bin.rels") + ".xml.rels", wbRels},
+		{"_rels/.rels", rootRels},
+	} {
+		if err = setBinaryConvertedPart(parts, v.name, v.value); err != nil {
+			return err
+		}
+	}
+	changed["_rels/.rels"] = "_rels/.rels"
+	return setBinaryConvertedContentTypes(f, parts, contentTypes, changed)
+}
+
+// getPartContentType provides a function to get the content type of the part
+// by given content types and part name.
+func getPartContentType(contentTypes *xlsxTypes, name string) string {
+	for _, override := range contentTypes.Overrides {
+		if strings.EqualFold(strings.TrimPrefix(override.PartName, "/"), name) {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	for _, def := range contentTypes.Defaults {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
+
+// getPartRelsPath provides a function to get the relationships part path by
+// given part path.
+func getPartRelsPath(name string) string {
+	return strings.TrimPrefix(path.Dir(name)+"/_rels/"+path.Base(name)+".rels", "./")
+}
+
+// getPartPath provides a function to get the part path by given directory of
+// the source part and the relationship target.
+func getPartPath(dir, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(path.Clean(target), "/")
+	}
+	return strings.TrimPrefix(path.Join(dir, target), "/")
+}
+
+// convertBinaryRels provides a function to read the relationships part by
+// given path, and remove the relationships which target to the binary parts
+// can't be converted. The converted binary parts will be recorded in the
+// changed map with the new part name, and the removed parts will be recorded
+// with the empty name.
+func (f *File) convertBinaryRels(parts map[string][]byte, contentTypes *xlsxTypes, changed map[string]string, relsPath, dir string) (*xlsxRelationships, error) {
+	rels := new(xlsxRelationships)
+	content, ok := parts[relsPath]
+	if !ok {
+		return rels, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(rels); err != nil && err != io.EOF {
+		return rels, err
+	}
+	var relationships []xlsxRelationship
+	for _, rel := range rels.Relationships {
+		name := getPartPath(dir, rel.Target)
+		contentType := getPartContentType(contentTypes, name)
+		if rel.TargetMode != "External" && strings.HasPrefix(contentType, "application/vnd.ms-excel.") &&
+			!strings.HasSuffix(contentType, "+xml") {
+			switch contentType {
+			case contentTypeBinarySharedStrings, contentTypeBinaryStyles, contentTypeBinaryWorksheet:
+				changed[name] = strings.TrimSuffix(name, ".bin") + ".xml"
+			default:
+				changed[name], changed[getPartRelsPath(name)] = "", ""
+				continue
+			}
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	if strings.HasSuffix(relsPath, ".bin.rels") {
+		changed[relsPath] = strings.TrimSuffix(relsPath, ".bin.rels") + ".xml.rels"
+		return rels, setBinaryConvertedPart(parts, changed[relsPath], rels)
+	}
+	return rels, nil
+}
+
+// setBinaryConvertedPart provides a function to serialize the converted part
+// and store it into the parts of the package.
+func setBinaryConvertedPart(parts map[string][]byte, name string, v interface{}) error {
+	output, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	parts[name] = append([]byte(xml.Header), output...)
+	return nil
+}
+
+// setBinaryConvertedContentTypes provides a function to remove the converted
+// binary parts from the package, and update the content types of the package.
+func setBinaryConvertedContentTypes(f *File, parts map[string][]byte, contentTypes *xlsxTypes, changed map[string]string) error {
+	newContentTypes := map[string]string{
+		contentTypeBinarySharedStrings: ContentTypeSpreadSheetMLSharedStrings,
+		contentTypeBinaryStyles:        ContentTypeSpreadSheetMLStyles,
+		contentTypeBinaryWorkbook:      ContentTypeSheetML,
+		contentTypeBinaryWorksheet:     ContentTypeSpreadSheetMLWorksheet,
+	}
+	var overrides []xlsxOverride
+	for name, newName := range changed {
+		contentType := getPartContentType(contentTypes, name)
+		if name != newName {
+			delete(parts, name)
+			f.originParts.Delete(name)
+		}
+		if newContentType, ok := newContentTypes[contentType]; ok && newName != "" {
+			overrides = append(overrides, xlsxOverride{PartName: "/" + newName, ContentType: newContentType})
+		}
+		f.originParts.Delete(newName)
+	}
+	for _, override := range contentTypes.Overrides {
+		if _, ok := changed[strings.TrimPrefix(override.PartName, "/")]; !ok {
+			overrides = append(overrides, override)
+		}
+	}
+	contentTypes.Overrides = overrides
+	var hasXML bool
+	for i := range contentTypes.Defaults {
+		def := &contentTypes.Defaults[i]
+		if strings.HasPrefix(def.ContentType, "application/vnd.ms-excel.") && !strings.HasSuffix(def.ContentType, "+xml") {
+			def.ContentType = contentTypePrinterSettings
+		}
+		hasXML = hasXML || strings.EqualFold(def.Extension, "xml")
+	}
+	if !hasXML {
+		contentTypes.Defaults = append(contentTypes.Defaults, xlsxDefault{Extension: "xml", ContentType: "application/xml"})
+	}
+	f.originParts.Delete(defaultXMLPathContentTypes)
+	return setBinaryConvertedPart(parts, defaultXMLPathContentTypes, contentTypes)
+}
+
+// parseBinaryWorkbook provides a function to parse the workbook part of the
+// binary workbook.
+func parseBinaryWorkbook(part string, data []byte) (*binaryWorkbook, error) {
+	wb, br := &binaryWorkbook{}, &binaryPartReader{part: part, data: data}
+	for {
+		recordType, r, err := br.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return wb, err
+		}
+		switch recordType {
+		case brtWbProp:
+			wb.date1904 = r.u32()&1 == 1
+		case brtBundleSh:
+			sheet := binarySheet{state: r.u32()}
+			_ = r.u32()
+			sheet.relID, sheet.name = r.wideString(), r.wideString()
+			wb.sheets = append(wb.sheets, sheet)
+		case brtSupSelf, brtSupSame:
+			wb.supBooks = append(wb.supBooks, true)
+		case brtSupBookSrc, brtSupAddin:
+			wb.supBooks = append(wb.supBooks, false)
+		case brtExternSheet:
+			count := int(r.u32())
+			for i := 0; i < count && !r.invalid; i++ {
+				wb.externSheets = append(wb.externSheets, binaryExternSheet{
+					supBook: int(r.u32()), first: int(int32(r.u32())), last: int(int32(r.u32())),
+				})
+			}
+		case brtName:
+			flags := r.u32()
+			name := binaryName{hidden: flags&1 == 1, fn: flags&2 == 2}
+			_ = r.u8()
+			name.scope = int(int32(r.u32()))
+			name.name = r.wideString()
+			name.rgce, name.rgcb = r.formula()
+			name.comment = r.wideString()
+			wb.names = append(wb.names, name)
+		}
+		if r.invalid {
+			return wb, newInvalidBinaryRecordError(part, recordType)
+		}
+	}
+	return wb, nil
+}
+
+// definedNames provides a function to convert the defined names of the binary
+// workbook, the defined names which formula can't be parsed will be ignored.
+func (wb *binaryWorkbook) definedNames() *xlsxDefinedNames {
+	var definedNames []xlsxDefinedName
+	for _, name := range wb.names {
+		formula, ok := wb.parseFormula(name.rgce, 0, 0, false)
+		if !ok || name.fn {
+			continue
+		}
+		definedName := xlsxDefinedName{Name: name.name, Comment: name.comment, Hidden: name.hidden, Data: formula}
+		if name.scope >= 0 {
+			definedName.LocalSheetID = intPtr(name.scope)
+		}
+		definedNames = append(definedNames, definedName)
+	}
+	if definedNames == nil {
+		return nil
+	}
+	return &xlsxDefinedNames{DefinedName: definedNames}
+}
+
+// sheetPrefix provides a function to get the sheet name prefix of the 3D
+// reference by given index of the sheet range reference.
+func (wb *binaryWorkbook) sheetPrefix(ixti int) (string, bool) {
+	if ixti >= len(wb.externSheets) {
+		return "", false
+	}
+	xti := wb.externSheets[ixti]
+	if xti.supBook >= len(wb.supBooks) || !wb.supBooks[xti.supBook] {
+		return "", false
+	}
+	if xti.first == -2 {
+		return "", true
+	}
+	if xti.first < 0 || xti.first >= len(wb.sheets) || xti.last < 0 || xti.last >= len(wb.sheets) {
+		return "", false
+	}
+	name := wb.sheets[xti.first].name
+	if xti.last != xti.first {
+		name += ":" + wb.sheets[xti.last].name
+	}
+	return escapeSheetName(name) + "!", true
+}
+
+// binaryCellRef provides a function to get the cell reference by given row
+// and column of the parsed formula token. The relative row and column will
+// be calculated by the base cell if the offset is true.
+func binaryCellRef(row uint32, col uint16, baseRow, baseCol int, offset bool) (int, int, string, string) {
+	r, c := int(row), int(col&0x3FFF)
+	colAbs, rowAbs := "$", "$"
+	if col&0x4000 != 0 {
+		colAbs = ""
+		if offset {
+			c = ((baseCol+int(int16(col<<2)>>2))%MaxColumns + MaxColumns) % MaxColumns
+		}
+	}
+	if col&0x8000 != 0 {
+		rowAbs = ""
+		if offset {
+			r = ((baseRow+int(int32(row)))%TotalRows + TotalRows) % TotalRows
+		}
+	}
+	return r, c, colAbs, rowAbs
+}
+
+// formatBinaryRef provides a function to format the cell reference or the
+// cell range reference of the parsed formula token.
+func formatBinaryRef(rows []uint32, cols []uint16, baseRow, baseCol int, offset bool) string {
+	return formatFormulaRef(len(rows), TotalRows, MaxColumns, func(i int) (int, int, string, string) {
+		return binaryCellRef(rows[i], cols[i], baseRow, baseCol, offset)
+	})
+}
+
+// formatFormulaRef provides a function to format the cell reference or the
+// cell range reference by given number of cells, the maximum number of rows
+// and columns, and the function to get each cell of the reference. The whole
+// columns and whole rows range reference will be formatted in short form.
+func formatFormulaRef(count, totalRows, totalCols int, cellRef func(i int) (int, int, string, string)) string {
+	var refs, colRefs, rowRefs []string
+	var wholeCols, wholeRows = count == 2, count == 2
+	for i := 0; i < count; i++ {
+		r, c, colAbs, rowAbs := cellRef(i)
+		colName, _ := ColumnNumberToName(c + 1)
+		refs = append(refs, colAbs+colName+rowAbs+strconv.Itoa(r+1))
+		colRefs = append(colRefs, colAbs+colName)
+		rowRefs = append(rowRefs, rowAbs+strconv.Itoa(r+1))
+		wholeCols = wholeCols && r == []int{0, totalRows - 1}[i]
+		wholeRows = wholeRows && c == []int{0, totalCols - 1}[i]
+	}
+	if wholeCols {
+		return strings.Join(colRefs, ":")
+	}
+	if wholeRows {
+		return strings.Join(rowRefs, ":")
+	}
+	return strings.Join(refs, ":")
+}
+
+// formatBinaryNumber provides a function to format the number in the parsed
+// formula token.
+func formatBinaryNumber(num float64) string {
+	if abs := math.Abs(num); abs != 0 && (abs >= 1e21 || abs < 1e-9) {
+		return strings.Replace(strconv.FormatFloat(num, 'E', -1, 64), "E+", "E", 1)
+	}
+	return strconv.FormatFloat(num, 'f', -1, 64)
+}
+
+// parseFormula provides a function to convert the parsed formula tokens to
+// the formula text. The relative references of the shared formula will be
+// calculated by the given base cell if the offset is true. The false will be
+// returned if the formula tokens are invalid or unsupported.
+func (wb *binaryWorkbook) parseFormula(rgce []byte, baseRow, baseCol int, offset bool) (string, bool) {
+	r, stack := &binaryRecord{data: rgce}, []string{}
+	pop := func(n int) []string {
+		if n > len(stack) {
+			r.invalid = true
+			return make([]string, n)
+		}
+		args := append([]string{}, stack[len(stack)-n:]...)
+		stack = stack[:len(stack)-n]
+		return args
+	}
+	push := func(s string) { stack = append(stack, s) }
+	for r.off < len(r.data) && !r.invalid {
+		ptg := r.u8()
+		if op, ok := binaryOperators[ptg]; ok {
+			args := pop(2)
+			push(args[0] + op + args[1])
+			continue
+		}
+		if ptg >= 0x20 {
+			ptg = ptg&0x1F | 0x20
+		}
+		switch ptg {
+		case 0x12, 0x13:
+			push(map[byte]string{0x12: "+", 0x13: "-"}[ptg] + pop(1)[0])
+		case 0x14:
+			push(pop(1)[0] + "%")
+		case 0x15:
+			push("(" + pop(1)[0] + ")")
+		case 0x16:
+			push("")
+		case 0x17:
+			push("\"" + strings.ReplaceAll(r.str(int(r.u16())), "\"", "\"\"") + "\"")
+		case 0x19:
+			switch attr := r.u8(); attr {
+			case 0x04:
+				r.bytes(int(r.u16()+1) * 2)
+			case 0x10:
+				_ = r.u16()
+				push("SUM(" + pop(1)[0] + ")")
+			default:
+				_ = r.u16()
+			}
+		case 0x1C:
+			push(binaryErrorValues[r.u8()])
+		case 0x1D:
+			push(strings.ToUpper(strconv.FormatBool(r.u8() == 1)))
+		case 0x1E:
+			push(strconv.Itoa(int(r.u16())))
+		case 0x1F:
+			push(formatBinaryNumber(r.f64()))
+		case 0x21:
+			fn, ok := binaryFunctions[r.u16()]
+			if !ok || fn.args < 0 {
+				return "", false
+			}
+			push(fn.name + "(" + strings.Join(pop(fn.args), ",") + ")")
+		case 0x22:
+			argc, tab := int(r.u8()), r.u16()&0x7FFF
+			args := pop(argc)
+			if tab == 255 && argc > 0 {
+				push(args[0] + "(" + strings.Join(args[1:], ",") + ")")
+				continue
+			}
+			fn, ok := binaryFunctions[tab]
+			if !ok {
+				return "", false
+			}
+			push(fn.name + "(" + strings.Join(args, ",") + ")")
+		case 0x23:
+			idx := int(r.u32())
+			if idx < 1 || idx > len(wb.names) {
+				return "", false
+			}
+			push(wb.names[idx-1].name)
+		case 0x24, 0x2C:
+			row, col := r.u32(), r.u16()
+			push(formatBinaryRef([]uint32{row}, []uint16{col}, baseRow, baseCol, offset && ptg == 0x2C))
+		case 0x25, 0x2D:
+			rows, cols := []uint32{r.u32(), r.u32()}, []uint16{r.u16(), r.u16()}
+			push(formatBinaryRef(rows, cols, baseRow, baseCol, offset && ptg == 0x2D))
+		case 0x26, 0x27, 0x28:
+			r.bytes(6)
+		case 0x29:
+			_ = r.u16()
+		case 0x2A:
+			r.bytes(6)
+			push(formulaErrorREF)
+		case 0x2B:
+			r.bytes(12)
+			push(formulaErrorREF)
+		case 0x3A, 0x3B, 0x3C, 0x3D:
+			prefix, ok := wb.sheetPrefix(int(r.u16()))
+			if !ok {
+				return "", false
+			}
+			switch ptg {
+			case 0x3A:
+				row, col := r.u32(), r.u16()
+				push(prefix + formatBinaryRef([]uint32{row}, []uint16{col}, baseRow, baseCol, false))
+			case 0x3B:
+				rows, cols := []uint32{r.u32(), r.u32()}, []uint16{r.u16(), r.u16()}
+				push(prefix + formatBinaryRef(rows, cols, baseRow, baseCol, false))
+			default:
+				r.bytes(map[byte]int{0x3C: 6, 0x3D: 12}[ptg])
+				push(prefix + formulaErrorREF)
+			}
+		default:
+			return "", false
+		}
+	}
+	if r.invalid || len(stack) != 1 {
+		return "", false
+	}
+	return stack[0], true
+}
+
+// parseWorksheet provides a function to parse the worksheet part of the
+// binary workbook.
+func (wb *binaryWorkbook) parseWorksheet(part string, data []byte) (*xlsxWorksheet, error) {
+	var (
+		ws                     = &xlsxWorksheet{}
+		br                     = &binaryPartReader{part: part, data: data}
+		arrays, shared         []binaryFormula
+		formulaCells           []binaryFormulaCell
+		mergeCells, hyperlinks = &xlsxMergeCells{}, &xlsxHyperlinks{}
+	)
+	for {
+		recordType, r, err := br.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ws, err
+		}
+		switch recordType {
+		case brtWsDim:
+			r1, r2, c1, c2 := r.rfx()
+			ws.Dimension = &xlsxDimension{Ref: formatBinaryRange(r1, r2, c1, c2)}
+		case brtColInfo:
+			c1, c2, width, style, flags := int(r.u32()), int(r.u32()), r.u32(), int(r.u32()), r.u16()
+			if ws.Cols == nil {
+				ws.Cols = &xlsxCols{}
+			}
+			ws.Cols.Col = append(ws.Cols.Col, xlsxCol{
+				Min: c1 + 1, Max: c2 + 1, Width: float64Ptr(float64(width) / 256), Style: style,
+				Hidden: flags&1 != 0, CustomWidth: flags&2 != 0, BestFit: flags&4 != 0,
+				OutlineLevel: uint8(flags >> 8 & 7), Collapsed: flags&0x1000 != 0,
+			})
+		case brtRowHdr:
+			row := xlsxRow{R: int(r.u32()) + 1, S: int(r.u32())}
+			height, _, flags := r.u16(), r.u8(), r.u8()
+			row.OutlineLevel, row.Collapsed, row.Hidden = flags&7, flags&8 != 0, flags&16 != 0
+			row.CustomHeight, row.CustomFormat = flags&32 != 0, flags&64 != 0
+			if row.CustomHeight {
+				row.Ht = float64Ptr(float64(height) / 20)
+			}
+			if row.R > TotalRows {
+				return ws, newInvalidBinaryRecordError(part, recordType)
+			}
+			ws.SheetData.Row = append(ws.SheetData.Row, row)
+		case brtCellBlank, brtCellRk, brtCellError, brtCellBool, brtCellReal, brtCellSt, brtCellIsst,
+			brtFmlaString, brtFmlaNum, brtFmlaBool, brtFmlaError:
+			if len(ws.SheetData.Row) == 0 {
+				return ws, newInvalidBinaryRecordError(part, recordType)
+			}
+			row := &ws.SheetData.Row[len(ws.SheetData.Row)-1]
+			col := int(r.u32())
+			c := xlsxC{S: int(r.u32() & 0xFFFFFF)}
+			if c.R, err = CoordinatesToCellName(col+1, row.R); err != nil {
+				return ws, newInvalidBinaryRecordError(part, recordType)
+			}
+			if rgce, anchor, ok := parseBinaryCellValue(r, recordType, &c); ok {
+				if len(rgce) == 5 && rgce[0] == 0x01 {
+					formulaCells = append(formulaCells, binaryFormulaCell{
+						row: len(ws.SheetData.Row) - 1, cell: len(row.C), r: row.R - 1, c: col, anchor: anchor,
+					})
+				} else if formula, ok := wb.parseFormula(rgce, row.R-1, col, false); ok {
+					c.F = &xlsxF{Content: formula}
+				}
+			}
+			row.C = append(row.C, c)
+		case brtShrFmla, brtArrFmla:
+			r1, r2, c1, c2 := r.rfx()
+			if recordType == brtArrFmla {
+				_ = r.u8()
+			}
+			rgce, _ := r.formula()
+			formula, ok := wb.parseFormula(rgce, r1, c1, true)
+			fml := binaryFormula{rect: [4]int{r1, r2, c1, c2}, formula: formula, ok: ok}
+			if recordType == brtArrFmla {
+				arrays = append(arrays, fml)
+			} else {
+				shared = append(shared, fml)
+			}
+		case brtMergeCell:
+			mergeCells.Cells = append(mergeCells.Cells, &xlsxMergeCell{Ref: formatBinaryRange(r.rfx())})
+		case brtHLink:
+			hyperlink := xlsxHyperlink{Ref: formatBinaryRange(r.rfx())}
+			hyperlink.RID, hyperlink.Location = r.wideString(), r.wideString()
+			hyperlink.Tooltip, hyperlink.Display = r.wideString(), r.wideString()
+			hyperlinks.Hyperlink = append(hyperlinks.Hyperlink, hyperlink)
+		}
+		if r.invalid {
+			return ws, newInvalidBinaryRecordError(part, recordType)
+		}
+	}
+	setBinaryFormulaCells(ws, formulaCells, arrays, shared)
+	if len(mergeCells.Cells) > 0 {
+		mergeCells.Count = len(mergeCells.Cells)
+		ws.MergeCells = mergeCells
+	}
+	if len(hyperlinks.Hyperlink) > 0 {
+		ws.Hyperlinks = hyperlinks
+	}
+	return ws, nil
+}
+
+// parseBinaryCellValue provides a function to parse the cell value of the
+// cell record in the binary worksheet, and returns the parsed formula tokens
+// and the anchor row of the shared or array formula for the formula cell.
+func parseBinaryCellValue(r *binaryRecord, recordType int, c *xlsxC) ([]byte, int, bool) {
+	switch recordType {
+	case brtCellRk:
+		c.V = strconv.FormatFloat(decodeRKNumber(r.u32()), 'f', -1, 64)
+	case brtCellError, brtFmlaError:
+		c.T, c.V = "e", binaryErrorValues[r.u8()]
+	case brtCellBool, brtFmlaBool:
+		c.T, c.V = "b", strconv.Itoa(int(r.u8()&1))
+	case brtCellReal, brtFmlaNum:
+		c.V = strconv.FormatFloat(r.f64(), 'f', -1, 64)
+	case brtCellSt:
+		c.T, c.IS = "inlineStr", &xlsxSI{T: &xlsxT{}}
+		c.IS.T.Val, c.IS.T.Space = trimCellValue(r.wideString(), false)
+	case brtCellIsst:
+		c.T, c.V = "s", strconv.Itoa(int(r.u32()))
+	case brtFmlaString:
+		c.T = "str"
+		c.V, c.XMLSpace = trimCellValue(r.wideString(), false)
+	}
+	if recordType < brtFmlaString || r.invalid {
+		return nil, 0, false
+	}
+	_ = r.u16()
+	rgce, _ := r.formula()
+	if len(rgce) == 5 && rgce[0] == 0x01 {
+		return rgce, int(binary.LittleEndian.Uint32(rgce[1:])), true
+	}
+	return rgce, 0, true
+}
+
+// decodeRKNumber provides a function to decode the RK number, which is the
+// compressed integer or the truncated floating-point number.
+func decodeRKNumber(rk uint32) float64 {
+	num := math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	if rk&2 != 0 {
+		num = float64(int32(rk) >> 2)
+	}
+	if rk&1 != 0 {
+		num /= 100
+	}
+	return num
+}
+
+// setBinaryFormulaCells provides a function to set the shared formulas and
+// array formulas for the cells which formula referenced to them.
+func setBinaryFormulaCells(ws *xlsxWorksheet, cells []binaryFormulaCell, arrays, shared []binaryFormula) {
+	contains := func(fml binaryFormula, cell binaryFormulaCell) bool {
+		return fml.rect[0] == cell.anchor && cell.r >= fml.rect[0] && cell.r <= fml.rect[1] &&
+			cell.c >= fml.rect[2] && cell.c <= fml.rect[3]
+	}
+	for _, cell := range cells {
+		c, found := &ws.SheetData.Row[cell.row].C[cell.cell], false
+		for _, fml := range arrays {
+			if found = contains(fml, cell); found {
+				if fml.ok && cell.r == fml.rect[0] && cell.c == fml.rect[2] {
+					c.F = &xlsxF{T: STCellFormulaTypeArray, Ref: formatBinaryRange(fml.rect[0], fml.rect[1], fml.rect[2], fml.rect[3]), Content: fml.formula}
+				}
+				break
+			}
+		}
+		for si, fml := range shared {
+			if found || !contains(fml, cell) || !fml.ok {
+				continue
+			}
+			c.F, found = &xlsxF{T: STCellFormulaTypeShared, Si: intPtr(si)}, true
+			if cell.r == fml.rect[0] && cell.c == fml.rect[2] {
+				c.F.Ref, c.F.Content = formatBinaryRange(fml.rect[0], fml.rect[1], fml.rect[2], fml.rect[3]), fml.formula
+			}
+		}
+	}
+}
+
+// formatBinaryRange provides a function to format the cell range reference by
+// given zero-based first row, last row, first column and last column.
+func formatBinaryRange(r1, r2, c1, c2 int) string {
+	first, _ := CoordinatesToCellName(c1+1, r1+1)
+	if r1 == r2 && c1 == c2 {
+		return first
+	}
+	last, _ := CoordinatesToCellName(c2+1, r2+1)
+	return first + ":" + last
+}
+
+// parseBinarySharedStrings provides a function to parse the shared strings
+// part of the binary workbook.
+func parseBinarySharedStrings(part string, data []byte) (*xlsxSST, error) {
+	sst, br := &xlsxSST{}, &binaryPartReader{part: part, data: data}
+	for {
+		recordType, r, err := br.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sst, err
+		}
+		switch recordType {
+		case brtBeginSst:
+			sst.Count, sst.UniqueCount = int(r.u32()), int(r.u32())
+		case brtSSTItem:
+			_ = r.u8()
+			si := xlsxSI{T: &xlsxT{}}
+			si.T.Val, si.T.Space = trimCellValue(r.wideString(), false)
+			sst.SI = append(sst.SI, si)
+		}
+		if r.invalid {
+			return sst, newInvalidBinaryRecordError(part, recordType)
+		}
+	}
+	sst.UniqueCount = len(sst.SI)
+	if sst.Count < sst.UniqueCount {
+		sst.Count = sst.UniqueCount
+	}
+	return sst, nil
+}
+
+// parseBinaryStyles provides a function to parse the styles part of the
+// binary workbook.
+func parseBinaryStyles(part string, data []byte) (*xlsxStyleSheet, error) {
+	var (
+		br         = &binaryPartReader{part: part, data: data}
+		inStyleXFs bool
+		styleSheet = &xlsxStyleSheet{
+			NumFmts: &xlsxNumFmts{}, Fonts: &xlsxFonts{}, Fills: &xlsxFills{}, Borders: &xlsxBorders{},
+			CellStyleXfs: &xlsxCellStyleXfs{}, CellXfs: &xlsxCellXfs{}, CellStyles: &xlsxCellStyles{},
+		}
+	)
+	for {
+		recordType, r, err := br.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return styleSheet, err
+		}
+		switch recordType {
+		case brtFmt:
+			styleSheet.NumFmts.NumFmt = append(styleSheet.NumFmts.NumFmt, &xlsxNumFmt{NumFmtID: int(r.u16()), FormatCode: r.wideString()})
+		case brtFont:
+			styleSheet.Fonts.Font = append(styleSheet.Fonts.Font, parseBinaryFont(r))
+		case brtFill:
+			styleSheet.Fills.Fill = append(styleSheet.Fills.Fill, parseBinaryFill(r))
+		case brtBorder:
+			styleSheet.Borders.Border = append(styleSheet.Borders.Border, parseBinaryBorder(r))
+		case brtBeginCellStyleXFs, brtEndCellStyleXFs:
+			inStyleXFs = recordType == brtBeginCellStyleXFs
+		case brtBeginCellXFs, brtEndCellXFs:
+			inStyleXFs = false
+		case brtXF:
+			xf := parseBinaryXf(r, inStyleXFs)
+			if inStyleXFs {
+				styleSheet.CellStyleXfs.Xf = append(styleSheet.CellStyleXfs.Xf, xf)
+				break
+			}
+			styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xf)
+		case brtStyle:
+			cellStyle := &xlsxCellStyle{XfID: int(r.u32())}
+			flags, builtIn := r.u16(), r.u8()
+			_ = r.u8()
+			if cellStyle.Name = r.wideString(); flags&1 != 0 {
+				cellStyle.BuiltInID = intPtr(int(builtIn))
+			}
+			styleSheet.CellStyles.CellStyle = append(styleSheet.CellStyles.CellStyle, cellStyle)
+		}
+		if r.invalid {
+			return styleSheet, newInvalidBinaryRecordError(part, recordType)
+		}
+	}
+	styleSheet.NumFmts.Count, styleSheet.Fonts.Count = len(styleSheet.NumFmts.NumFmt), len(styleSheet.Fonts.Font)
+	styleSheet.Fills.Count, styleSheet.Borders.Count = len(styleSheet.Fills.Fill), len(styleSheet.Borders.Border)
+	styleSheet.CellStyleXfs.Count, styleSheet.CellXfs.Count = len(styleSheet.CellStyleXfs.Xf), len(styleSheet.CellXfs.Xf)
+	styleSheet.CellStyles.Count = len(styleSheet.CellStyles.CellStyle)
+	if styleSheet.NumFmts.Count == 0 {
+		styleSheet.NumFmts = nil
+	}
+	return styleSheet, nil
+}
+
+// parseBinaryFont provides a function to parse the font record of the styles
+// part in the binary workbook.
+func parseBinaryFont(r *binaryRecord) *xlsxFont {
+	height, flags, weight := r.u16(), r.u16(), r.u16()
+	_, underline, family, charset := r.u16(), r.u8(), r.u8(), r.u8()
+	_ = r.u8()
+	font := &xlsxFont{Sz: &attrValFloat{Val: float64Ptr(float64(height) / 20)}, Color: r.color()}
+	scheme, name := r.u8(), r.wideString()
+	for _, v := range []struct {
+		bit uint16
+		val **attrValBool
+	}{{2, &font.I}, {8, &font.Strike}, {16, &font.Outline}, {32, &font.Shadow}, {64, &font.Condense}, {128, &font.Extend}} {
+		if flags&v.bit != 0 {
+			*v.val = &attrValBool{Val: boolPtr(true)}
+		}
+	}
+	if weight >= 700 {
+		font.B = &attrValBool{Val: boolPtr(true)}
+	}
+	if u, ok := map[byte]string{1: "single", 2: "double", 0x21: "singleAccounting", 0x22: "doubleAccounting"}[underline]; ok {
+		font.U = &attrValString{Val: stringPtr(u)}
+	}
+	if name != "" {
+		font.Name = &attrValString{Val: stringPtr(name)}
+	}
+	if family > 0 {
+		font.Family = &attrValInt{Val: intPtr(int(family))}
+	}
+	if charset > 0 {
+		font.Charset = &attrValInt{Val: intPtr(int(charset))}
+	}
+	if s, ok := map[byte]string{1: "major", 2: "minor"}[scheme]; ok {
+		font.Scheme = &attrValString{Val: stringPtr(s)}
+	}
+	return font
+}
+
+// parseBinaryFill provides a function to parse the fill record of the styles
+// part in the binary workbook.
+func parseBinaryFill(r *binaryRecord) *xlsxFill {
+	pattern, fgColor, bgColor := r.u32(), r.color(), r.color()
+	if pattern == 0x28 {
+		gradientType := r.u32()
+		fill := &xlsxGradientFill{Degree: r.f64(), Left: r.f64(), Right: r.f64(), Top: r.f64(), Bottom: r.f64()}
+		if gradientType == 1 {
+			fill.Type = "path"
+		}
+		stops := int(r.u32())
+		for i := 0; i < stops && !r.invalid; i++ {
+			stop := &xlsxGradientFillStop{}
+			if color := r.color(); color != nil {
+				stop.Color = *color
+			}
+			stop.Position = r.f64()
+			fill.Stop = append(fill.Stop, stop)
+		}
+		return &xlsxFill{GradientFill: fill}
+	}
+	fill := &xlsxPatternFill{}
+	if pattern < uint32(len(binaryFillPatterns)) {
+		fill.PatternType = binaryFillPatterns[pattern]
+	}
+	if pattern > 0 {
+		fill.FgColor, fill.BgColor = fgColor, bgColor
+	}
+	return &xlsxFill{PatternFill: fill}
+}
+
+// parseBinaryBorder provides a function to parse the border record of the
+// styles part in the binary workbook.
+func parseBinaryBorder(r *binaryRecord) *xlsxBorder {
+	flags := r.u8()
+	border := &xlsxBorder{DiagonalDown: flags&1 != 0, DiagonalUp: flags&2 != 0}
+	for _, line := range []*xlsxLine{&border.Top, &border.Bottom, &border.Left, &border.Right, &border.Diagonal} {
+		style, _, color := r.u8(), r.u8(), r.color()
+		if int(style) < len(binaryBorderStyles) && style > 0 {
+			line.Style, line.Color = binaryBorderStyles[style], color
+		}
+	}
+	return border
+}
+
+// parseBinaryXf provides a function to parse the cell format record of the
+// styles part in the binary workbook.
+func parseBinaryXf(r *binaryRecord, styleXf bool) xlsxXf {
+	parent, numFmtID, fontID, fillID, borderID := r.u16(), r.u16(), r.u16(), r.u16(), r.u16()
+	rotation, indent, flags, apply := r.u8(), r.u8(), r.u16(), r.u8()
+	xf := xlsxXf{
+		NumFmtID: intPtr(int(numFmtID)), FontID: intPtr(int(fontID)),
+		FillID: intPtr(int(fillID)), BorderID: intPtr(int(borderID)),
+	}
+	if !styleXf {
+		xf.XfID = intPtr(int(parent))
+	}
+	for i, v := range []**bool{
+		&xf.ApplyNumberFormat, &xf.ApplyFont, &xf.ApplyAlignment, &xf.ApplyBorder, &xf.ApplyFill, &xf.ApplyProtection,
+	} {
+		if apply&(1<<i) != 0 {
+			*v = boolPtr(true)
+		}
+	}
+	if flags&0x8000 != 0 {
+		xf.QuotePrefix = boolPtr(true)
+	}
+	alignment := xlsxAlignment{
+		Horizontal:      []string{"", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}[flags&7],
+		Indent:          int(indent),
+		JustifyLastLine: flags&0x80 != 0,
+		ReadingOrder:    uint64(flags >> 10 & 3),
+		ShrinkToFit:     flags&0x100 != 0,
+		TextRotation:    int(rotation),
+		WrapText:        flags&0x40 != 0,
+	}
+	if vertical := int(flags >> 3 & 7); vertical < 5 {
+		alignment.Vertical = []string{"top", "center", "", "justify", "distributed"}[vertical]
+	}
+	if alignment != (xlsxAlignment{}) {
+		xf.Alignment = &alignment
+	}
+	if locked, hidden := flags&0x1000 != 0, flags&0x2000 != 0; !locked || hidden {
+		xf.Protection = &xlsxProtection{Locked: boolPtr(locked), Hidden: boolPtr(hidden)}
+	}
+	return xf
+}
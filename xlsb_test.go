@@ -0,0 +1,391 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// binaryRecords is a helper for building the records of the binary workbook
+// part, used for testing.
+type binaryRecords struct {
+	bytes.Buffer
+}
+
+// add encodes the record by given record type and the fields of the record.
+func (b *binaryRecords) add(recordType int, fields ...interface{}) *binaryRecords {
+	var data bytes.Buffer
+	for _, field := range fields {
+		switch v := field.(type) {
+		case string:
+			u := utf16.Encode([]rune(v))
+			_ = binary.Write(&data, binary.LittleEndian, uint32(len(u)))
+			_ = binary.Write(&data, binary.LittleEndian, u)
+		default:
+			_ = binary.Write(&data, binary.LittleEndian, v)
+		}
+	}
+	for i, v := 0, recordType; i < 2; i++ {
+		if v < 0x80 {
+			b.WriteByte(byte(v))
+			break
+		}
+		b.WriteByte(byte(v&0x7F | 0x80))
+		v >>= 7
+	}
+	for v := data.Len(); ; v >>= 7 {
+		if v < 0x80 {
+			b.WriteByte(byte(v))
+			break
+		}
+		b.WriteByte(byte(v&0x7F | 0x80))
+	}
+	b.Write(data.Bytes())
+	return b
+}
+
+// binaryCell returns the fields of the cell in the binary worksheet.
+func binaryCell(col, style uint32) []interface{} {
+	return []interface{}{col, style}
+}
+
+// binaryFormulaTokens returns the fields of the parsed formula tokens.
+func binaryFormulaTokens(rgce ...interface{}) []interface{} {
+	var buf bytes.Buffer
+	for _, v := range rgce {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return []interface{}{uint32(buf.Len()), buf.Bytes(), uint32(0)}
+}
+
+// binaryFields concatenates the fields of the binary record.
+func binaryFields(fields ...interface{}) []interface{} {
+	var result []interface{}
+	for _, field := range fields {
+		if v, ok := field.([]interface{}); ok {
+			result = append(result, v...)
+			continue
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// prepareBinaryWorkbook creates a binary workbook package with given parts,
+// used for testing.
+func prepareBinaryWorkbook(t *testing.T, parts map[string][]byte) []byte {
+	files := map[string][]byte{
+		"[Content_Types].xml":                 []byte(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="bin" ContentType="application/vnd.ms-excel.sheet.binary.macroEnabled.main"/><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Override PartName="/xl/worksheets/sheet1.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/worksheets/sheet2.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/chartsheets/sheet1.bin" ContentType="application/vnd.ms-excel.chartsheet"/><Override PartName="/xl/sharedStrings.bin" ContentType="application/vnd.ms-excel.sharedStrings"/><Override PartName="/xl/styles.bin" ContentType="application/vnd.ms-excel.styles"/><Override PartName="/xl/comments1.bin" ContentType="application/vnd.ms-excel.comments"/><Override PartName="/xl/calcChain.bin" ContentType="application/vnd.ms-excel.calcChain"/><Override PartName="/xl/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/></Types>`),
+		"_rels/.rels":                         []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.bin"/></Relationships>`),
+		"xl/_rels/workbook.bin.rels":          []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.bin"/><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/><Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/><Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet" Target="chartsheets/sheet1.bin"/><Relationship Id="rId6" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain" Target="/xl/calcChain.bin"/><Relationship Id="rId7" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/></Relationships>`),
+		"xl/theme/theme1.xml":                 []byte(xml.Header + templateTheme),
+		"xl/worksheets/_rels/sheet1.bin.rels": []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://github.com/xuri/excelize" TargetMode="External"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="../comments1.bin"/></Relationships>`),
+		"xl/comments1.bin":                    {},
+		"xl/calcChain.bin":                    {},
+		"xl/chartsheets/sheet1.bin":           {},
+	}
+	workbook := new(binaryRecords).
+		add(brtWbProp, uint32(0), uint32(0), "").
+		add(brtBundleSh, uint32(0), uint32(1), "rId1", "Sheet1").
+		add(brtBundleSh, uint32(1), uint32(2), "rId2", "Data Sheet").
+		add(brtBundleSh, uint32(0), uint32(3), "rId5", "Chart1").
+		add(brtSupSelf).
+		add(brtExternSheet, uint32(3), uint32(0), int32(1), int32(1), uint32(0), int32(-2), int32(-2), uint32(0), int32(0), int32(0)).
+		add(brtName, binaryFields(uint32(0), uint8(0), int32(-1), "Total",
+			binaryFormulaTokens(uint8(0x3A), uint16(2), uint32(0), uint16(2)), "Total value")...).
+		add(brtName, binaryFields(uint32(0), uint8(0), int32(0), "Local",
+			binaryFormulaTokens(uint8(0x3B), uint16(1), uint32(0), uint32(TotalRows-1), uint16(0), uint16(1)), "")...).
+		add(brtName, binaryFields(uint32(0), uint8(0), int32(-1), "Invalid",
+			binaryFormulaTokens(uint8(0x18), uint8(0)), "")...)
+	sheet1 := new(binaryRecords).
+		add(brtWsDim, uint32(0), uint32(4), uint32(0), uint32(3)).
+		add(brtColInfo, uint32(0), uint32(1), uint32(20*256), uint32(0), uint16(2)).
+		add(brtRowHdr, uint32(0), uint32(0), uint16(600), uint8(0), uint8(32), uint8(0), uint32(0)).
+		add(brtCellIsst, binaryFields(binaryCell(0, 0), uint32(0))...).
+		add(brtCellSt, binaryFields(binaryCell(1, 0), "Inline")...).
+		add(brtCellRk, binaryFields(binaryCell(2, 0), uint32(100<<2|2))...).
+		add(brtCellRk, binaryFields(binaryCell(3, 3), uint32(0x3FF80000|1))...).
+		add(brtRowHdr, uint32(1), uint32(0), uint16(300), uint8(0), uint8(0), uint8(0), uint32(0)).
+		add(brtCellReal, binaryFields(binaryCell(0, 1), float64(45352))...).
+		add(brtCellBool, binaryFields(binaryCell(1, 0), uint8(1))...).
+		add(brtCellError, binaryFields(binaryCell(2, 0), uint8(0x07))...).
+		add(brtFmlaNum, binaryFields(binaryCell(3, 0), float64(45452), uint16(0),
+			binaryFormulaTokens(uint8(0x24), uint32(0), uint16(2|0xC000), uint8(0x44), uint32(1), uint16(0), uint8(0x03)))...).
+		add(brtRowHdr, uint32(2), uint32(0), uint16(300), uint8(0), uint8(16), uint8(0), uint32(0)).
+		add(brtFmlaString, binaryFields(binaryCell(0, 0), "Inline!", uint16(0),
+			binaryFormulaTokens(uint8(0x24), uint32(0), uint16(1|0xC000), uint8(0x17), uint16(1), uint16('!'), uint8(0x08)))...).
+		add(brtFmlaNum, binaryFields(binaryCell(1, 0), float64(100.015), uint16(0),
+			binaryFormulaTokens(uint8(0x25), uint32(0), uint32(0), uint16(2|0xC000), uint16(3|0xC000), uint8(0x22), uint8(1), uint16(4)))...).
+		add(brtFmlaBool, binaryFields(binaryCell(2, 0), uint8(1), uint16(0),
+			binaryFormulaTokens(uint8(0x5A), uint16(0), uint32(0), uint16(0|0xC000), uint8(0x41), uint16(128)))...).
+		add(brtFmlaNum, binaryFields(binaryCell(3, 0), float64(100), uint16(0),
+			binaryFormulaTokens(uint8(0x23), uint32(1)))...).
+		add(brtRowHdr, uint32(3), uint32(0), uint16(300), uint8(0), uint8(0), uint8(0), uint32(0)).
+		add(brtFmlaNum, binaryFields(binaryCell(0, 0), float64(200), uint16(0), binaryFormulaTokens(uint8(0x01), uint32(3)))...).
+		add(brtShrFmla, binaryFields(uint32(3), uint32(3), uint32(0), uint32(1),
+			binaryFormulaTokens(uint8(0x2C), int32(-3), uint16(2|0xC000), uint8(0x1E), uint16(2), uint8(0x05)))...).
+		add(brtFmlaNum, binaryFields(binaryCell(1, 0), float64(0.03), uint16(0), binaryFormulaTokens(uint8(0x01), uint32(3)))...).
+		add(brtRowHdr, uint32(4), uint32(0), uint16(300), uint8(0), uint8(0), uint8(0), uint32(0)).
+		add(brtFmlaNum, binaryFields(binaryCell(0, 0), float64(200.03), uint16(0), binaryFormulaTokens(uint8(0x01), uint32(4)))...).
+		add(brtArrFmla, binaryFields(uint32(4), uint32(4), uint32(0), uint32(0), uint8(0),
+			binaryFormulaTokens(uint8(0x65), uint32(0), uint32(0), uint16(2), uint16(3), uint8(0x1E), uint16(2), uint8(0x05), uint8(0x22), uint8(1), uint16(4)))...).
+		add(brtFmlaNum, binaryFields(binaryCell(1, 0), float64(0), uint16(0),
+			binaryFormulaTokens(uint8(0x18), uint8(0x19)))...).
+		add(brtFmlaNum, binaryFields(binaryCell(2, 0), float64(1), uint16(0),
+			binaryFormulaTokens(uint8(0x1F), float64(1e-10), uint8(0x1F), float64(0.5), uint8(0x03), uint8(0x15), uint8(0x13), uint8(0x14), uint8(0x1D), uint8(1), uint8(0x03)))...).
+		add(brtMergeCell, uint32(5), uint32(5), uint32(0), uint32(1)).
+		add(brtHLink, uint32(0), uint32(0), uint32(1), uint32(1), "rId1", "", "Tooltip", "Inline")
+	sheet2 := new(binaryRecords).
+		add(brtRowHdr, uint32(0), uint32(0), uint16(300), uint8(0), uint8(0), uint8(0), uint32(0)).
+		add(brtCellReal, binaryFields(binaryCell(0, 2), float64(42))...)
+	sharedStrings := new(binaryRecords).
+		add(brtBeginSst, uint32(1), uint32(1)).
+		add(brtSSTItem, uint8(0), "Name")
+	color := func(colorType, index uint8, rgb ...uint8) []interface{} {
+		c := append([]uint8{colorType << 1, index, 0, 0}, append(rgb, 0, 0, 0, 0)[:4]...)
+		if len(rgb) > 0 {
+			c[7] = 0xFF
+		}
+		return []interface{}{c}
+	}
+	styles := new(binaryRecords).
+		add(brtFmt, uint16(164), "0.000").
+		add(brtFont, binaryFields(uint16(220), uint16(0), uint16(400), uint16(0), uint8(0), uint8(2), uint8(0), uint8(0), color(3, 1), uint8(2), "Calibri")...).
+		add(brtFont, binaryFields(uint16(240), uint16(2|8), uint16(700), uint16(0), uint8(1), uint8(0), uint8(134), uint8(0), color(2, 0, 0xFF, 0, 0), uint8(0), "Arial")...).
+		add(brtFill, binaryFields(uint32(0), color(0, 0), color(0, 0), uint32(0), make([]byte, 40), uint32(0))...).
+		add(brtFill, binaryFields(uint32(17), color(1, 64), color(1, 65), uint32(0), make([]byte, 40), uint32(0))...).
+		add(brtFill, binaryFields(uint32(1), color(2, 0, 0xFF, 0xFF, 0), color(1, 64), uint32(0), make([]byte, 40), uint32(0))...).
+		add(brtFill, binaryFields(uint32(0x28), color(0, 0), color(0, 0), uint32(1), make([]byte, 40), uint32(2),
+			color(2, 0, 0xFF, 0xFF, 0xFF), float64(0), color(3, 4), float64(1))...).
+		add(brtBorder, binaryFields(uint8(0), make([]byte, 50))...).
+		add(brtBorder, binaryFields(uint8(3), uint8(1), uint8(0), color(1, 64), uint8(6), uint8(0), color(1, 64), make([]byte, 30))...).
+		add(brtBeginCellStyleXFs).
+		add(brtXF, uint16(0xFFFF), uint16(0), uint16(0), uint16(0), uint16(0), uint8(0), uint8(0), uint16(0x1000|2<<3), uint8(0), uint8(0)).
+		add(brtEndCellStyleXFs).
+		add(brtBeginCellXFs).
+		add(brtXF, uint16(0), uint16(0), uint16(0), uint16(0), uint16(0), uint8(0), uint8(0), uint16(0x1000|2<<3), uint8(0), uint8(0)).
+		add(brtXF, uint16(0), uint16(14), uint16(1), uint16(2), uint16(1), uint8(45), uint8(1), uint16(2|1<<3|0x40|0x2000), uint8(0x3F), uint8(0)).
+		add(brtXF, uint16(0), uint16(164), uint16(0), uint16(3), uint16(0), uint8(0), uint8(0), uint16(2<<3|0x8000), uint8(1), uint8(0)).
+		add(brtXF, uint16(0), uint16(10), uint16(0), uint16(3), uint16(0), uint8(0), uint8(0), uint16(0x1000|2<<3), uint8(1), uint8(0)).
+		add(brtEndCellXFs).
+		add(brtStyle, uint32(0), uint16(1), uint8(0), uint8(0xFF), "Normal")
+	for name, content := range map[string][]byte{
+		"xl/workbook.bin":          workbook.Bytes(),
+		"xl/worksheets/sheet1.bin": sheet1.Bytes(),
+		"xl/worksheets/sheet2.bin": sheet2.Bytes(),
+		"xl/sharedStrings.bin":     sharedStrings.Bytes(),
+		"xl/styles.bin":            styles.Bytes(),
+	} {
+		files[name] = content
+	}
+	for name, content := range parts {
+		files[name] = content
+	}
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenBinaryWorkbook(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(prepareBinaryWorkbook(t, nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Data Sheet"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Data Sheet")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Inline", "100", "1.50%"},
+		{"03-01-24", "TRUE", "#DIV/0!", "45452"},
+		{"Inline!", "100.015", "TRUE", "100"},
+		{"200", "0.03"},
+		{"200.03", "0", "1"},
+	}, rows)
+	for cell, expected := range map[string]string{
+		"D2": "C1+$A$2", "A3": "B1&\"!\"", "B3": "SUM(C1:D1)", "C3": "ISNUMBER('Data Sheet'!A1)",
+		"D3": "Total", "A4": "C1*2", "B4": "D1*2", "A5": "SUM($C$1:$D$1*2)", "B5": "",
+		"C5": "-(1E-10+0.5)%+TRUE",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	for cell, expected := range map[string]string{"D2": "45452", "A3": "Inline!", "B4": "0.03", "D3": "100", "C3": "TRUE"} {
+		result, err := f.CalcCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	assert.Equal(t, []DefinedName{
+		{Name: "Total", Comment: "Total value", RefersTo: "Sheet1!$C$1", Scope: "Workbook"},
+		{Name: "Local", RefersTo: "$A:$B", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+
+	// Test get cell styles, column widths, row heights, merged cells and hyperlinks
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.True(t, style.Font.Bold)
+	assert.True(t, style.Font.Italic)
+	assert.True(t, style.Font.Strike)
+	assert.Equal(t, "single", style.Font.Underline)
+	assert.Equal(t, "Arial", style.Font.Family)
+	assert.Equal(t, 12.0, style.Font.Size)
+	assert.Equal(t, "FF0000", style.Font.Color)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, style.Fill)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 1}, {Type: "bottom", Color: "000000", Style: 6}}, style.Border)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 45, Indent: 1}, style.Alignment)
+	assert.Equal(t, &Protection{Hidden: true}, style.Protection)
+	styleID, err = f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, style.NumFmt)
+	assert.Equal(t, "gradient", style.Fill.Type)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A6:B6", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	link, target, err := f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	value, err := f.GetCellValue("Data Sheet", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42.000", value)
+
+	// Test save the binary workbook as the XLSX file
+	_, ok := f.Pkg.Load("xl/worksheets/sheet1.bin")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/comments1.bin")
+	assert.False(t, ok)
+	assert.Equal(t, ErrWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestOpenBinaryWorkbook.xlsb")))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenBinaryWorkbook.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test open the binary workbook by given path
+	assert.NoError(t, os.WriteFile(filepath.Join("test", "TestOpenBinaryWorkbook.xlsb"), prepareBinaryWorkbook(t, nil), 0o644))
+	f, err = OpenFile(filepath.Join("test", "TestOpenBinaryWorkbook.xlsb"))
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, value)
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestOpenBinaryWorkbook.xlsx"))
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.50%", rows[0][3])
+	ct, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range ct.Overrides {
+		assert.NotContains(t, override.ContentType, "application/vnd.ms-excel.")
+	}
+	assert.NoError(t, f.Close())
+
+	// Test open the binary workbook with load sheets and parse concurrency options
+	f, err = OpenReader(bytes.NewReader(prepareBinaryWorkbook(t, nil)), Options{LoadSheets: []string{"Data Sheet"}, ParseConcurrency: 2})
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", value)
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenBinaryWorkbookError(t *testing.T) {
+	for name, content := range map[string][]byte{
+		"xl/workbook.bin":          {0x9C, 0x01, 0x10, 0x00},
+		"xl/worksheets/sheet1.bin": {0x07, 0x08, 0x00},
+		"xl/sharedStrings.bin":     new(binaryRecords).add(brtSSTItem, uint8(0), uint32(10)).Bytes(),
+		"xl/styles.bin":            new(binaryRecords).add(brtFmt, uint16(164)).Bytes(),
+	} {
+		_, err := OpenReader(bytes.NewReader(prepareBinaryWorkbook(t, map[string][]byte{name: content})))
+		assert.Contains(t, err.Error(), "in binary part "+name, name)
+	}
+	for name, content := range map[string][]byte{
+		"xl/worksheets/sheet1.bin": new(binaryRecords).add(brtCellBlank, uint32(0), uint32(0)).Bytes(),
+		"xl/workbook.bin":          {0x9C},
+	} {
+		_, err := OpenReader(bytes.NewReader(prepareBinaryWorkbook(t, map[string][]byte{name: content})))
+		assert.Contains(t, err.Error(), "in binary part "+name, name)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.bin.rels", "xl/worksheets/_rels/sheet1.bin.rels"} {
+		_, err := OpenReader(bytes.NewReader(prepareBinaryWorkbook(t, map[string][]byte{name: MacintoshCyrillicCharset})))
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8", name)
+	}
+}
+
+func TestParseBinaryFormula(t *testing.T) {
+	wb := &binaryWorkbook{
+		sheets:       []binarySheet{{name: "Sheet1"}, {name: "Sheet 2"}},
+		supBooks:     []bool{true, false},
+		externSheets: []binaryExternSheet{{0, 0, 1}, {1, 0, 0}, {0, -1, -1}, {2, 0, 0}},
+		names:        []binaryName{{name: "Name1"}},
+	}
+	tokens := func(rgce ...interface{}) []byte { return binaryFormulaTokens(rgce...)[1].([]byte) }
+	for _, c := range []struct {
+		rgce     []byte
+		expected string
+		ok       bool
+	}{
+		{tokens(uint8(0x3A), uint16(0), uint32(0), uint16(0)), "'Sheet1:Sheet 2'!$A$1", true},
+		{tokens(uint8(0x3C), uint16(0), uint32(0), uint16(0)), "'Sheet1:Sheet 2'!#REF!", true},
+		{tokens(uint8(0x3D), uint16(0), make([]byte, 12)), "'Sheet1:Sheet 2'!#REF!", true},
+		{tokens(uint8(0x2A), make([]byte, 6), uint8(0x2B), make([]byte, 12), uint8(0x10)), "#REF!,#REF!", true},
+		{tokens(uint8(0x25), uint32(0), uint32(0), uint16(0), uint16(MaxColumns-1)), "$1:$1", true},
+		{tokens(uint8(0x2D), int32(-1), int32(1), uint16(0xFFFF), uint16(0xC001)), "A1:C3", true},
+		{tokens(uint8(0x2C), int32(-2), uint16(0xFFFE)), "XFD1048576", true},
+		{tokens(uint8(0x21), uint16(65), uint8(0x16), uint8(0x16)), "", false},
+		{tokens(uint8(0x16), uint8(0x16), uint8(0x16), uint8(0x21), uint16(65)), "DATE(,,)", true},
+		{tokens(uint8(0x17), uint16(1), uint16('a'), uint8(0x16), uint8(0x22), uint8(2), uint16(255)), "\"a\"()", true},
+		{tokens(uint8(0x23), uint32(1), uint8(0x19), uint8(0x40), uint16(0), uint8(0x19), uint8(0x04), uint16(1), uint32(0), uint8(0x12)), "+Name1", true},
+		{tokens(uint8(0x29), uint16(0), uint8(0x26), make([]byte, 6), uint8(0x1C), uint8(0x2A)), "#N/A", true},
+		{tokens(uint8(0x1F), float64(1e22), uint8(0x1E), uint16(3), uint8(0x07)), "1E22^3", true},
+		{tokens(uint8(0x3A), uint16(1), uint32(0), uint16(0)), "", false},
+		{tokens(uint8(0x3A), uint16(2), uint32(0), uint16(0)), "", false},
+		{tokens(uint8(0x3A), uint16(3), uint32(0), uint16(0)), "", false},
+		{tokens(uint8(0x3A), uint16(4), uint32(0), uint16(0)), "", false},
+		{tokens(uint8(0x23), uint32(2)), "", false},
+		{tokens(uint8(0x22), uint8(1), uint16(1000), uint8(0x1E), uint16(1)), "", false},
+		{tokens(uint8(0x21), uint16(1000)), "", false},
+		{tokens(uint8(0x03)), "", false},
+		{tokens(uint8(0x1F)), "", false},
+		{tokens(uint8(0x1E), uint16(1), uint8(0x1E), uint16(1)), "", false},
+	} {
+		formula, ok := wb.parseFormula(c.rgce, 1, 1, true)
+		assert.Equal(t, c.ok, ok, c.expected)
+		assert.Equal(t, c.expected, formula)
+	}
+	assert.Equal(t, "-1E-12", formatBinaryNumber(-1e-12))
+	assert.Equal(t, "0", formatBinaryNumber(0))
+	r := &binaryRecord{data: []byte{8 << 1, 0, 0, 0, 0, 0, 0, 0}}
+	assert.Nil(t, r.color())
+	assert.Nil(t, r.color())
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxCustomProperties directly maps the root element of the custom file
+// properties part docProps/custom.xml. This element specifies the custom
+// properties of the document which defined by the users or the document
+// management systems.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Vt       string               `xml:"xmlns:vt,attr"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty directly maps the property element of the custom file
+// properties, the Value field holds the variant type element of the value.
+type xlsxCustomProperty struct {
+	FmtID      string `xml:"fmtid,attr"`
+	PID        int    `xml:"pid,attr"`
+	Name       string `xml:"name,attr,omitempty"`
+	LinkTarget string `xml:"linkTarget,attr,omitempty"`
+	Value      string `xml:",innerxml"`
+}
+
+// decodeCustomProperties defines the structure used to parse the custom file
+// properties part docProps/custom.xml.
+type decodeCustomProperties struct {
+	XMLName  xml.Name               `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Property []decodeCustomProperty `xml:"property"`
+}
+
+// decodeCustomProperty defines the structure used to parse the property
+// element of the custom file properties.
+type decodeCustomProperty struct {
+	FmtID      string   `xml:"fmtid,attr"`
+	PID        int      `xml:"pid,attr"`
+	Name       string   `xml:"name,attr"`
+	LinkTarget string   `xml:"linkTarget,attr"`
+	Lpwstr     *string  `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes lpwstr"`
+	Lpstr      *string  `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes lpstr"`
+	I4         *int32   `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes i4"`
+	R8         *float64 `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes r8"`
+	Bool       *bool    `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes bool"`
+	FileTime   *string  `xml:"http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes filetime"`
+	Other      *struct {
+		XMLName xml.Name
+		Text    string `xml:",chardata"`
+	} `xml:",any"`
+	Content string `xml:",innerxml"`
+}
+
+// xlsxDatastoreItem directly maps the datastoreItem element of the custom XML
+// data properties part customXml/itemProps%d.xml. This element specifies the
+// unique identifier and the associated XML schemas of the custom XML data.
+type xlsxDatastoreItem struct {
+	XMLName    xml.Name        `xml:"ds:datastoreItem"`
+	ItemID     string          `xml:"ds:itemID,attr"`
+	XMLNSDs    string          `xml:"xmlns:ds,attr"`
+	SchemaRefs *xlsxSchemaRefs `xml:"ds:schemaRefs"`
+}
+
+// xlsxSchemaRefs directly maps the schemaRefs element of the custom XML data
+// properties.
+type xlsxSchemaRefs struct {
+	SchemaRef []xlsxSchemaRef `xml:"ds:schemaRef"`
+}
+
+// xlsxSchemaRef directly maps the schemaRef element of the custom XML data
+// properties, which specifies the namespace of the associated XML schema.
+type xlsxSchemaRef struct {
+	URI string `xml:"ds:uri,attr"`
+}
+
+// decodeDatastoreItem defines the structure used to parse the custom XML data
+// properties part customXml/itemProps%d.xml.
+type decodeDatastoreItem struct {
+	XMLName    xml.Name `xml:"http://schemas.openxmlformats.org/officeDocument/2006/customXml datastoreItem"`
+	ItemID     string   `xml:"itemID,attr"`
+	SchemaRefs *struct {
+		SchemaRef []struct {
+			URI string `xml:"uri,attr"`
+		} `xml:"schemaRef"`
+	} `xml:"schemaRefs"`
+}
+
+// CustomProperty directly maps the custom property of the workbook. The value
+// data type may be one of the following: int32, float64, string, bool,
+// time.Time, or nil.
+type CustomProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// CustomXMLPart directly maps the custom XML data part of the workbook. The ID
+// specifies the unique identifier of the custom XML data in the GUID format
+// like {A1B2C3D4-0000-4000-8000-000000000000}, and the SchemaRefs specifies
+// the namespaces of the XML schemas associated with the custom XML data.
+type CustomXMLPart struct {
+	ID         string
+	SchemaRefs []string
+	Data       []byte
+}
@@ -0,0 +1,235 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// decodeChartAnchor defines the structure used to deserialize the cell anchor
+// of the drawing object for finding the chart by the anchor cell.
+type decodeChartAnchor struct {
+	From *decodeFrom `xml:"from"`
+	To   *decodeTo   `xml:"to"`
+	Ext  *struct {
+		Cx int `xml:"cx,attr"`
+		Cy int `xml:"cy,attr"`
+	} `xml:"ext"`
+	GraphicFrame *struct {
+		Graphic struct {
+			GraphicData struct {
+				Chart *struct {
+					RID string `xml:"id,attr"`
+				} `xml:"chart"`
+			} `xml:"graphicData"`
+		} `xml:"graphic"`
+	} `xml:"graphicFrame"`
+}
+
+// decodeChartSpace directly maps the chartSpace element. This element
+// specifies the root of the chart part, the structures with the decode prefix
+// are used to deserialize the chart part for rendering the chart.
+type decodeChartSpace struct {
+	Date1904 *attrValBool     `xml:"date1904"`
+	Chart    decodeChart      `xml:"chart"`
+	SpPr     *decodeChartSpPr `xml:"spPr"`
+}
+
+// decodeChart directly maps the chart element.
+type decodeChart struct {
+	Title            *decodeChartTitle   `xml:"title"`
+	AutoTitleDeleted *attrValBool        `xml:"autoTitleDeleted"`
+	PlotArea         decodeChartPlotArea `xml:"plotArea"`
+	Legend           *decodeChartLegend  `xml:"legend"`
+	DispBlanksAs     *attrValString      `xml:"dispBlanksAs"`
+}
+
+// decodeChartTitle directly maps the title element of the chart and axis.
+type decodeChartTitle struct {
+	Tx   *decodeChartTx   `xml:"tx"`
+	TxPr *decodeChartRich `xml:"txPr"`
+}
+
+// decodeChartTx directly maps the tx element, which specifies the rich text
+// or the reference of the text.
+type decodeChartTx struct {
+	Rich   *decodeChartRich   `xml:"rich"`
+	StrRef *decodeChartStrRef `xml:"strRef"`
+	V      string             `xml:"v"`
+}
+
+// decodeChartRich directly maps the rich and txPr element, which specifies
+// the paragraphs of the text.
+type decodeChartRich struct {
+	P []struct {
+		PPr *struct {
+			DefRPr *decodeChartRPr `xml:"defRPr"`
+		} `xml:"pPr"`
+		R []struct {
+			RPr *decodeChartRPr `xml:"rPr"`
+			T   string          `xml:"t"`
+		} `xml:"r"`
+	} `xml:"p"`
+}
+
+// decodeChartRPr directly maps the rPr and defRPr element, which specifies the
+// text run properties.
+type decodeChartRPr struct {
+	B         *bool                 `xml:"b,attr"`
+	I         *bool                 `xml:"i,attr"`
+	Sz        float64               `xml:"sz,attr"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	Latin     *struct {
+		Typeface string `xml:"typeface,attr"`
+	} `xml:"latin"`
+}
+
+// decodeChartPlotArea directly maps the plotArea element. The chart groups
+// such as areaChart, barChart, lineChart and so on are kept in the order of
+// the document for finding the primary chart group.
+type decodeChartPlotArea struct {
+	CatAx  []*decodeChartAxis  `xml:"catAx"`
+	DateAx []*decodeChartAxis  `xml:"dateAx"`
+	ValAx  []*decodeChartAxis  `xml:"valAx"`
+	SpPr   *decodeChartSpPr    `xml:"spPr"`
+	Charts []*decodeChartGroup `xml:",any"`
+}
+
+// decodeChartGroup directly maps the elements of the chart group, such as
+// barChart, lineChart, pieChart and so on.
+type decodeChartGroup struct {
+	XMLName    xml.Name
+	BarDir     *attrValString    `xml:"barDir"`
+	Grouping   *attrValString    `xml:"grouping"`
+	OfPieType  *attrValString    `xml:"ofPieType"`
+	VaryColors *attrValBool      `xml:"varyColors"`
+	Ser        []decodeChartSer  `xml:"ser"`
+	DLbls      *decodeChartDLbls `xml:"dLbls"`
+	HoleSize   *attrValInt       `xml:"holeSize"`
+	AxID       []attrValInt      `xml:"axId"`
+}
+
+// decodeChartSer directly maps the ser element, which specifies the series of
+// the chart group.
+type decodeChartSer struct {
+	Tx     *decodeChartTx   `xml:"tx"`
+	SpPr   *decodeChartSpPr `xml:"spPr"`
+	Marker *struct {
+		Symbol *attrValString   `xml:"symbol"`
+		Size   *attrValInt      `xml:"size"`
+		SpPr   *decodeChartSpPr `xml:"spPr"`
+	} `xml:"marker"`
+	DLbls      *decodeChartDLbls `xml:"dLbls"`
+	Cat        *decodeChartData  `xml:"cat"`
+	Val        *decodeChartData  `xml:"val"`
+	XVal       *decodeChartData  `xml:"xVal"`
+	YVal       *decodeChartData  `xml:"yVal"`
+	BubbleSize *decodeChartData  `xml:"bubbleSize"`
+	Smooth     *attrValBool      `xml:"smooth"`
+}
+
+// decodeChartData directly maps the cat, val, xVal, yVal and bubbleSize
+// element, which specifies the reference and the cached values of the data.
+type decodeChartData struct {
+	StrRef *decodeChartStrRef `xml:"strRef"`
+	NumRef *decodeChartStrRef `xml:"numRef"`
+	StrLit *decodeChartCache  `xml:"strLit"`
+	NumLit *decodeChartCache  `xml:"numLit"`
+}
+
+// decodeChartStrRef directly maps the strRef and numRef element, which
+// specifies the formula of the reference and the cached values.
+type decodeChartStrRef struct {
+	F        string            `xml:"f"`
+	StrCache *decodeChartCache `xml:"strCache"`
+	NumCache *decodeChartCache `xml:"numCache"`
+}
+
+// decodeChartCache directly maps the strCache, numCache, strLit and numLit
+// element.
+type decodeChartCache struct {
+	FormatCode string `xml:"formatCode"`
+	Pt         []struct {
+		Idx int    `xml:"idx,attr"`
+		V   string `xml:"v"`
+	} `xml:"pt"`
+}
+
+// decodeChartDLbls directly maps the dLbls element, which specifies the
+// settings for the data labels.
+type decodeChartDLbls struct {
+	NumFmt *struct {
+		FormatCode   string `xml:"formatCode,attr"`
+		SourceLinked bool   `xml:"sourceLinked,attr"`
+	} `xml:"numFmt"`
+	DLblPos        *attrValString `xml:"dLblPos"`
+	ShowLegendKey  *attrValBool   `xml:"showLegendKey"`
+	ShowVal        *attrValBool   `xml:"showVal"`
+	ShowCatName    *attrValBool   `xml:"showCatName"`
+	ShowSerName    *attrValBool   `xml:"showSerName"`
+	ShowPercent    *attrValBool   `xml:"showPercent"`
+	ShowBubbleSize *attrValBool   `xml:"showBubbleSize"`
+	Delete         *attrValBool   `xml:"delete"`
+}
+
+// decodeChartAxis directly maps the catAx, dateAx and valAx element.
+type decodeChartAxis struct {
+	AxID    attrValInt `xml:"axId"`
+	Scaling *struct {
+		LogBase     *attrValFloat  `xml:"logBase"`
+		Orientation *attrValString `xml:"orientation"`
+		Max         *attrValFloat  `xml:"max"`
+		Min         *attrValFloat  `xml:"min"`
+	} `xml:"scaling"`
+	Delete         *attrValBool      `xml:"delete"`
+	AxPos          *attrValString    `xml:"axPos"`
+	MajorGridlines *struct{}         `xml:"majorGridlines"`
+	MinorGridlines *struct{}         `xml:"minorGridlines"`
+	Title          *decodeChartTitle `xml:"title"`
+	NumFmt         *struct {
+		FormatCode   string `xml:"formatCode,attr"`
+		SourceLinked bool   `xml:"sourceLinked,attr"`
+	} `xml:"numFmt"`
+	TxPr        *decodeChartRich `xml:"txPr"`
+	MajorUnit   *attrValFloat    `xml:"majorUnit"`
+	TickLblSkip *attrValInt      `xml:"tickLblSkip"`
+}
+
+// decodeChartLegend directly maps the legend element.
+type decodeChartLegend struct {
+	LegendPos *attrValString `xml:"legendPos"`
+}
+
+// decodeChartSpPr directly maps the spPr element, which specifies the shape
+// properties of the chart elements.
+type decodeChartSpPr struct {
+	NoFill    *struct{}             `xml:"noFill"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	Ln        *struct {
+		W         int                   `xml:"w,attr"`
+		NoFill    *struct{}             `xml:"noFill"`
+		SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	} `xml:"ln"`
+}
+
+// decodeChartSolidFill directly maps the solidFill element, which specifies
+// a solid color fill by the RGB color or the scheme color.
+type decodeChartSolidFill struct {
+	SrgbClr   *decodeChartColor `xml:"srgbClr"`
+	SchemeClr *decodeChartColor `xml:"schemeClr"`
+}
+
+// decodeChartColor directly maps the srgbClr and schemeClr element, which
+// specifies the color value with the luminance modulation and offset.
+type decodeChartColor struct {
+	Val    string      `xml:"val,attr"`
+	LumMod *attrValInt `xml:"lumMod"`
+	LumOff *attrValInt `xml:"lumOff"`
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxExternalLink directly maps the externalLink element of the external
+// workbook references part xl/externalLinks/externalLink%d.xml. This element
+// specifies the external workbook, DDE link or OLE link which the formulas in
+// the workbook refer to.
+type xlsxExternalLink struct {
+	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main externalLink"`
+	ExternalBook *xlsxExternalBook `xml:"externalBook"`
+	DdeLink      *xlsxInnerXML     `xml:"ddeLink"`
+	OleLink      *xlsxInnerXML     `xml:"oleLink"`
+}
+
+// xlsxExternalBook directly maps the externalBook element. This element
+// specifies the sheet names, defined names and the cached cell values of the
+// external workbook, the relationship of this element references the path
+// of the external workbook.
+type xlsxExternalBook struct {
+	RID          string                    `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	SheetNames   *xlsxExternalSheetNames   `xml:"sheetNames"`
+	DefinedNames *xlsxExternalDefinedNames `xml:"definedNames"`
+	SheetDataSet *xlsxExternalSheetDataSet `xml:"sheetDataSet"`
+}
+
+// xlsxExternalSheetNames directly maps the sheetNames element of the external
+// workbook.
+type xlsxExternalSheetNames struct {
+	SheetName []attrValString `xml:"sheetName"`
+}
+
+// xlsxExternalDefinedNames directly maps the definedNames element of the
+// external workbook.
+type xlsxExternalDefinedNames struct {
+	DefinedName []xlsxExternalDefinedName `xml:"definedName"`
+}
+
+// xlsxExternalDefinedName directly maps the definedName element of the
+// external workbook. The sheetId attribute specifies the zero-based index of
+// the sheet in the sheetNames element when the defined name is scoped to a
+// worksheet.
+type xlsxExternalDefinedName struct {
+	Name     string `xml:"name,attr"`
+	RefersTo string `xml:"refersTo,attr,omitempty"`
+	SheetID  *int   `xml:"sheetId,attr"`
+}
+
+// xlsxExternalSheetDataSet directly maps the sheetDataSet element of the
+// external workbook, which contains the cached cell values of the external
+// worksheets.
+type xlsxExternalSheetDataSet struct {
+	SheetData []xlsxExternalSheetData `xml:"sheetData"`
+}
+
+// xlsxExternalSheetData directly maps the sheetData element of the external
+// workbook.
+type xlsxExternalSheetData struct {
+	SheetID      int               `xml:"sheetId,attr"`
+	RefreshError bool              `xml:"refreshError,attr,omitempty"`
+	Row          []xlsxExternalRow `xml:"row"`
+}
+
+// xlsxExternalRow directly maps the row element of the cached cell values of
+// the external worksheet.
+type xlsxExternalRow struct {
+	R    int                `xml:"r,attr"`
+	Cell []xlsxExternalCell `xml:"cell"`
+}
+
+// xlsxExternalCell directly maps the cell element of the cached cell values of
+// the external worksheet.
+type xlsxExternalCell struct {
+	R  string `xml:"r,attr,omitempty"`
+	T  string `xml:"t,attr,omitempty"`
+	Vm int    `xml:"vm,attr,omitempty"`
+	V  string `xml:"v,omitempty"`
+}
+
+// ExternalLink directly maps the external workbook link of the workbook. The
+// Index specifies the one-based index of the link, which used in the formulas
+// with the format like [1]Sheet1!A1. The Type specifies the link type, the
+// possible values are "externalBook", "ddeLink" and "oleLink".
+type ExternalLink struct {
+	Index        int
+	Type         string
+	Target       string
+	SheetNames   []string
+	DefinedNames []ExternalLinkDefinedName
+}
+
+// ExternalLinkDefinedName directly maps the defined name of the external
+// workbook. The Scope specifies the worksheet name of the defined name, and
+// the empty Scope means the defined name is scoped to the workbook.
+type ExternalLinkDefinedName struct {
+	Name     string
+	RefersTo string
+	Scope    string
+}
+
+// ExternalLinkCellValue directly maps the cached cell value of the external
+// workbook.
+type ExternalLinkCellValue struct {
+	Sheet string
+	Cell  string
+	Type  CellType
+	Value string
+}
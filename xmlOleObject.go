@@ -0,0 +1,136 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxOleObjectContent directly maps the AlternateContent element in the
+// oleObjects element of the worksheet. The oleObject element in the Choice
+// element specifies the embedded object with the anchor and the preview
+// picture, which is supported by the Excel 2010 and later, and the oleObject
+// element in the Fallback element will be used by the other applications.
+type xlsxOleObjectContent struct {
+	XMLName  xml.Name `xml:"mc:AlternateContent"`
+	XMLNSMC  string   `xml:"xmlns:mc,attr"`
+	Choice   xlsxOleObjectChoice
+	Fallback xlsxOleObjectFallback
+}
+
+// xlsxOleObjectChoice directly maps the Choice element in the AlternateContent
+// element of the embedded object.
+type xlsxOleObjectChoice struct {
+	XMLName   xml.Name      `xml:"mc:Choice"`
+	Requires  string        `xml:"Requires,attr"`
+	OleObject xlsxOleObject `xml:"oleObject"`
+}
+
+// xlsxOleObjectFallback directly maps the Fallback element in the
+// AlternateContent element of the embedded object.
+type xlsxOleObjectFallback struct {
+	XMLName   xml.Name      `xml:"mc:Fallback"`
+	OleObject xlsxOleObject `xml:"oleObject"`
+}
+
+// xlsxOleObject directly maps the oleObject element. This element specifies
+// an embedded OLE object, the relationship of this element references the
+// embedded object part in the package.
+type xlsxOleObject struct {
+	ProgID   string        `xml:"progId,attr,omitempty"`
+	DvAspect string        `xml:"dvAspect,attr,omitempty"`
+	ShapeID  int           `xml:"shapeId,attr"`
+	RID      string        `xml:"r:id,attr,omitempty"`
+	ObjectPr *xlsxObjectPr `xml:"objectPr"`
+}
+
+// xlsxObjectPr directly maps the objectPr element. This element specifies the
+// properties of the embedded object, such as the anchor and the relationship
+// of the picture which used to represent the object.
+type xlsxObjectPr struct {
+	Locked      *bool            `xml:"locked,attr"`
+	DefaultSize *bool            `xml:"defaultSize,attr"`
+	Print       *bool            `xml:"print,attr"`
+	AutoPict    *bool            `xml:"autoPict,attr"`
+	AltText     string           `xml:"altText,attr,omitempty"`
+	RID         string           `xml:"r:id,attr,omitempty"`
+	Anchor      xlsxObjectAnchor `xml:"anchor"`
+}
+
+// xlsxObjectAnchor directly maps the anchor element of the embedded object and
+// the form control, which specifies the position of the object by the
+// starting and ending cell anchor.
+type xlsxObjectAnchor struct {
+	MoveWithCells bool     `xml:"moveWithCells,attr,omitempty"`
+	SizeWithCells bool     `xml:"sizeWithCells,attr,omitempty"`
+	From          xlsxFrom `xml:"from"`
+	To            xlsxTo   `xml:"to"`
+}
+
+// decodeOleObjects defines the structure used to parse the oleObjects element
+// of the worksheet, the embedded objects in the AlternateContent element and
+// the embedded objects without the AlternateContent element will be
+// collected.
+type decodeOleObjects struct {
+	OleObject        []decodeOleObject `xml:"oleObject"`
+	AlternateContent []struct {
+		Choice *struct {
+			OleObject []decodeOleObject `xml:"oleObject"`
+		} `xml:"Choice"`
+		Fallback *struct {
+			OleObject []decodeOleObject `xml:"oleObject"`
+		} `xml:"Fallback"`
+	} `xml:"AlternateContent"`
+}
+
+// decodeOleObject defines the structure used to parse the oleObject element.
+type decodeOleObject struct {
+	ProgID   string `xml:"progId,attr"`
+	DvAspect string `xml:"dvAspect,attr"`
+	ShapeID  int    `xml:"shapeId,attr"`
+	RID      string `xml:"id,attr"`
+	ObjectPr *struct {
+		Print   *bool  `xml:"print,attr"`
+		Locked  *bool  `xml:"locked,attr"`
+		AltText string `xml:"altText,attr"`
+		RID     string `xml:"id,attr"`
+		Anchor  struct {
+			MoveWithCells bool       `xml:"moveWithCells,attr"`
+			SizeWithCells bool       `xml:"sizeWithCells,attr"`
+			From          decodeFrom `xml:"from"`
+			To            decodeTo   `xml:"to"`
+		} `xml:"anchor"`
+	} `xml:"objectPr"`
+}
+
+// decodeOleShapeVal defines the structure used to parse the VML shape of the
+// embedded object, which is used to get the anchor and the preview picture of
+// the embedded object without the objectPr element.
+type decodeOleShapeVal struct {
+	ImageData struct {
+		RelID string `xml:"relid,attr"`
+	} `xml:"imagedata"`
+	ClientData struct {
+		ObjectType string `xml:"ObjectType,attr"`
+		Anchor     string `xml:"Anchor"`
+	} `xml:"ClientData"`
+}
+
+// EmbeddedObject directly maps the embedded OLE object or file in the
+// worksheet. The Picture specifies the icon or the preview picture which used
+// to represent the object with the format settings of the anchor.
+type EmbeddedObject struct {
+	Cell          string
+	ProgID        string
+	FileName      string
+	File          []byte
+	DisplayAsIcon bool
+	Picture       *Picture
+}
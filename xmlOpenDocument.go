@@ -0,0 +1,434 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// decodeODSDocument defines the structure used to parse the content.xml and
+// styles.xml parts of the OpenDocument Spreadsheet (ODS) package.
+type decodeODSDocument struct {
+	Styles          decodeODSStyles      `xml:"styles"`
+	AutomaticStyles decodeODSStyles      `xml:"automatic-styles"`
+	Spreadsheet     decodeODSSpreadsheet `xml:"body>spreadsheet"`
+}
+
+// decodeODSStyles defines the structure used to parse the common styles and
+// automatic styles, the data styles will be parsed as the other elements.
+type decodeODSStyles struct {
+	Style        []decodeODSStyle       `xml:"style"`
+	NumberStyles []decodeODSNumberStyle `xml:",any"`
+}
+
+// decodeODSStyle defines the structure used to parse the style element, the
+// attributes of all formatting properties elements will be parsed as the
+// properties.
+type decodeODSStyle struct {
+	Name            string                `xml:"name,attr"`
+	Family          string                `xml:"family,attr"`
+	ParentStyleName string                `xml:"parent-style-name,attr"`
+	DataStyleName   string                `xml:"data-style-name,attr"`
+	Properties      []decodeODSProperties `xml:",any"`
+}
+
+// decodeODSProperties defines the structure used to parse the formatting
+// properties elements of the style, such as table-cell-properties and
+// text-properties.
+type decodeODSProperties struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+}
+
+// decodeODSNumberStyle defines the structure used to parse the data styles,
+// such as number-style, date-style and percentage-style.
+type decodeODSNumberStyle struct {
+	XMLName            xml.Name
+	Name               string                `xml:"name,attr"`
+	TruncateOnOverflow string                `xml:"truncate-on-overflow,attr"`
+	Parts              []decodeODSNumberPart `xml:",any"`
+}
+
+// decodeODSNumberPart defines the structure used to parse the child elements
+// of the data styles.
+type decodeODSNumberPart struct {
+	XMLName              xml.Name
+	DecimalPlaces        *int   `xml:"decimal-places,attr"`
+	MinIntegerDigits     *int   `xml:"min-integer-digits,attr"`
+	MinExponentDigits    *int   `xml:"min-exponent-digits,attr"`
+	MinNumeratorDigits   int    `xml:"min-numerator-digits,attr"`
+	MinDenominatorDigits int    `xml:"min-denominator-digits,attr"`
+	DenominatorValue     string `xml:"denominator-value,attr"`
+	Grouping             bool   `xml:"grouping,attr"`
+	Style                string `xml:"style,attr"`
+	Textual              bool   `xml:"textual,attr"`
+	Color                string `xml:"color,attr"`
+	Condition            string `xml:"condition,attr"`
+	ApplyStyleName       string `xml:"apply-style-name,attr"`
+	Text                 string `xml:",chardata"`
+}
+
+// decodeODSSpreadsheet defines the structure used to parse the spreadsheet
+// element of the document body.
+type decodeODSSpreadsheet struct {
+	Tables           []decodeODSTable          `xml:"table"`
+	NamedExpressions decodeODSNamedExpressions `xml:"named-expressions"`
+}
+
+// decodeODSNamedExpressions defines the structure used to parse the named
+// ranges and named expressions.
+type decodeODSNamedExpressions struct {
+	NamedRange []struct {
+		Name             string `xml:"name,attr"`
+		CellRangeAddress string `xml:"cell-range-address,attr"`
+	} `xml:"named-range"`
+	NamedExpression []struct {
+		Name       string `xml:"name,attr"`
+		Expression string `xml:"expression,attr"`
+	} `xml:"named-expression"`
+}
+
+// decodeODSTable defines the structure used to parse the table element, the
+// columns and rows in the column groups, row groups and header rows will be
+// flattened.
+type decodeODSTable struct {
+	Name             string
+	StyleName        string
+	Columns          []decodeODSColumn
+	Rows             []decodeODSRow
+	NamedExpressions decodeODSNamedExpressions
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface to flatten the columns
+// and rows of the table.
+func (t *decodeODSTable) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "name":
+			t.Name = attr.Value
+		case "style-name":
+			t.StyleName = attr.Value
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "table-column":
+				var col decodeODSColumn
+				if err = d.DecodeElement(&col, &el); err != nil {
+					return err
+				}
+				t.Columns = append(t.Columns, col)
+			case "table-row":
+				var row decodeODSRow
+				if err = d.DecodeElement(&row, &el); err != nil {
+					return err
+				}
+				t.Rows = append(t.Rows, row)
+			case "named-expressions":
+				if err = d.DecodeElement(&t.NamedExpressions, &el); err != nil {
+					return err
+				}
+			case "table-column-group", "table-columns", "table-header-columns",
+				"table-row-group", "table-rows", "table-header-rows":
+				continue
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if el.Name.Local == start.Name.Local {
+				return nil
+			}
+		}
+	}
+}
+
+// decodeODSColumn defines the structure used to parse the table-column
+// element.
+type decodeODSColumn struct {
+	StyleName            string `xml:"style-name,attr"`
+	Repeated             int    `xml:"number-columns-repeated,attr"`
+	Visibility           string `xml:"visibility,attr"`
+	DefaultCellStyleName string `xml:"default-cell-style-name,attr"`
+}
+
+// decodeODSRow defines the structure used to parse the table-row element,
+// the cells and covered cells will be parsed in order.
+type decodeODSRow struct {
+	StyleName  string          `xml:"style-name,attr"`
+	Repeated   int             `xml:"number-rows-repeated,attr"`
+	Visibility string          `xml:"visibility,attr"`
+	Cells      []decodeODSCell `xml:",any"`
+}
+
+// decodeODSCell defines the structure used to parse the table-cell and
+// covered-table-cell elements.
+type decodeODSCell struct {
+	XMLName        xml.Name
+	StyleName      string               `xml:"style-name,attr"`
+	Repeated       int                  `xml:"number-columns-repeated,attr"`
+	ColumnsSpanned int                  `xml:"number-columns-spanned,attr"`
+	RowsSpanned    int                  `xml:"number-rows-spanned,attr"`
+	Formula        string               `xml:"formula,attr"`
+	ValueType      string               `xml:"urn:oasis:names:tc:opendocument:xmlns:office:1.0 value-type,attr"`
+	CalcExtType    string               `xml:"urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0 value-type,attr"`
+	Value          string               `xml:"value,attr"`
+	DateValue      string               `xml:"date-value,attr"`
+	TimeValue      string               `xml:"time-value,attr"`
+	BooleanValue   string               `xml:"boolean-value,attr"`
+	StringValue    *string              `xml:"string-value,attr"`
+	Paragraphs     []decodeODSParagraph `xml:"p"`
+}
+
+// decodeODSParagraph defines the structure used to parse the text of the
+// paragraph element, the spaces, tabs and line breaks elements will be
+// converted to the characters.
+type decodeODSParagraph struct {
+	Text string
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface to get the text of the
+// paragraph.
+func (p *decodeODSParagraph) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var sb strings.Builder
+	for depth := 0; ; {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch el := tok.(type) {
+		case xml.CharData:
+			sb.Write(el)
+		case xml.StartElement:
+			depth++
+			switch el.Name.Local {
+			case "s":
+				count := 1
+				for _, attr := range el.Attr {
+					if n, err := strconv.Atoi(attr.Value); err == nil && n > 0 && attr.Name.Local == "c" {
+						count = n
+					}
+				}
+				sb.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				sb.WriteByte('\t')
+			case "line-break":
+				sb.WriteByte('\n')
+			case "note", "annotation", "ruby-text":
+				depth--
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				p.Text = sb.String()
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+// odsDocumentContent directly maps the root element of the content.xml part
+// of the OpenDocument Spreadsheet (ODS) package.
+type odsDocumentContent struct {
+	XMLName         xml.Name           `xml:"office:document-content"`
+	XMLNSOffice     string             `xml:"xmlns:office,attr"`
+	XMLNSStyle      string             `xml:"xmlns:style,attr"`
+	XMLNSText       string             `xml:"xmlns:text,attr"`
+	XMLNSTable      string             `xml:"xmlns:table,attr"`
+	XMLNSNumber     string             `xml:"xmlns:number,attr"`
+	XMLNSFO         string             `xml:"xmlns:fo,attr"`
+	XMLNSOf         string             `xml:"xmlns:of,attr"`
+	Version         string             `xml:"office:version,attr"`
+	AutomaticStyles odsAutomaticStyles `xml:"office:automatic-styles"`
+	Spreadsheet     odsSpreadsheet     `xml:"office:body>office:spreadsheet"`
+}
+
+// odsAutomaticStyles directly maps the automatic-styles element.
+type odsAutomaticStyles struct {
+	NumberStyles []odsNumberStyle `xml:",any"`
+	Styles       []odsStyle       `xml:"style:style"`
+}
+
+// odsNumberStyle directly maps the data styles, such as number-style,
+// date-style and percentage-style.
+type odsNumberStyle struct {
+	XMLName            xml.Name
+	Name               string          `xml:"style:name,attr"`
+	TruncateOnOverflow string          `xml:"number:truncate-on-overflow,attr,omitempty"`
+	Parts              []odsNumberPart `xml:",any"`
+}
+
+// odsNumberPart directly maps the child elements of the data styles.
+type odsNumberPart struct {
+	XMLName              xml.Name
+	DecimalPlaces        *int   `xml:"number:decimal-places,attr"`
+	MinIntegerDigits     *int   `xml:"number:min-integer-digits,attr"`
+	MinExponentDigits    *int   `xml:"number:min-exponent-digits,attr"`
+	MinNumeratorDigits   int    `xml:"number:min-numerator-digits,attr,omitempty"`
+	MinDenominatorDigits int    `xml:"number:min-denominator-digits,attr,omitempty"`
+	Grouping             bool   `xml:"number:grouping,attr,omitempty"`
+	Style                string `xml:"number:style,attr,omitempty"`
+	DenominatorValue     string `xml:"number:denominator-value,attr,omitempty"`
+	Textual              bool   `xml:"number:textual,attr,omitempty"`
+	Color                string `xml:"fo:color,attr,omitempty"`
+	Text                 string `xml:",chardata"`
+}
+
+// odsStyle directly maps the style element of the automatic styles.
+type odsStyle struct {
+	Name                  string                    `xml:"style:name,attr"`
+	Family                string                    `xml:"style:family,attr"`
+	ParentStyleName       string                    `xml:"style:parent-style-name,attr,omitempty"`
+	DataStyleName         string                    `xml:"style:data-style-name,attr,omitempty"`
+	TableProperties       *odsTableProperties       `xml:"style:table-properties"`
+	TableColumnProperties *odsTableColumnProperties `xml:"style:table-column-properties"`
+	TableRowProperties    *odsTableRowProperties    `xml:"style:table-row-properties"`
+	TableCellProperties   *odsTableCellProperties   `xml:"style:table-cell-properties"`
+	ParagraphProperties   *odsParagraphProperties   `xml:"style:paragraph-properties"`
+	TextProperties        *odsTextProperties        `xml:"style:text-properties"`
+}
+
+// odsTableProperties directly maps the table-properties element.
+type odsTableProperties struct {
+	Display string `xml:"table:display,attr"`
+}
+
+// odsTableColumnProperties directly maps the table-column-properties element.
+type odsTableColumnProperties struct {
+	ColumnWidth string `xml:"style:column-width,attr"`
+}
+
+// odsTableRowProperties directly maps the table-row-properties element.
+type odsTableRowProperties struct {
+	RowHeight           string `xml:"style:row-height,attr"`
+	UseOptimalRowHeight string `xml:"style:use-optimal-row-height,attr"`
+}
+
+// odsTableCellProperties directly maps the table-cell-properties element.
+type odsTableCellProperties struct {
+	BackgroundColor string `xml:"fo:background-color,attr,omitempty"`
+	BorderLeft      string `xml:"fo:border-left,attr,omitempty"`
+	BorderRight     string `xml:"fo:border-right,attr,omitempty"`
+	BorderTop       string `xml:"fo:border-top,attr,omitempty"`
+	BorderBottom    string `xml:"fo:border-bottom,attr,omitempty"`
+	DiagonalBlTr    string `xml:"style:diagonal-bl-tr,attr,omitempty"`
+	DiagonalTlBr    string `xml:"style:diagonal-tl-br,attr,omitempty"`
+	WrapOption      string `xml:"fo:wrap-option,attr,omitempty"`
+	VerticalAlign   string `xml:"style:vertical-align,attr,omitempty"`
+	RotationAngle   string `xml:"style:rotation-angle,attr,omitempty"`
+	ShrinkToFit     string `xml:"style:shrink-to-fit,attr,omitempty"`
+	TextAlignSource string `xml:"style:text-align-source,attr,omitempty"`
+}
+
+// odsParagraphProperties directly maps the paragraph-properties element.
+type odsParagraphProperties struct {
+	TextAlign string `xml:"fo:text-align,attr,omitempty"`
+}
+
+// odsTextProperties directly maps the text-properties element.
+type odsTextProperties struct {
+	FontFamily         string `xml:"fo:font-family,attr,omitempty"`
+	FontSize           string `xml:"fo:font-size,attr,omitempty"`
+	FontWeight         string `xml:"fo:font-weight,attr,omitempty"`
+	FontStyle          string `xml:"fo:font-style,attr,omitempty"`
+	Color              string `xml:"fo:color,attr,omitempty"`
+	TextUnderlineStyle string `xml:"style:text-underline-style,attr,omitempty"`
+	TextUnderlineType  string `xml:"style:text-underline-type,attr,omitempty"`
+	TextUnderlineWidth string `xml:"style:text-underline-width,attr,omitempty"`
+	TextUnderlineColor string `xml:"style:text-underline-color,attr,omitempty"`
+	TextLineThrough    string `xml:"style:text-line-through-style,attr,omitempty"`
+	TextPosition       string `xml:"style:text-position,attr,omitempty"`
+}
+
+// odsSpreadsheet directly maps the spreadsheet element of the document body.
+type odsSpreadsheet struct {
+	Tables           []odsTable           `xml:"table:table"`
+	NamedExpressions *odsNamedExpressions `xml:"table:named-expressions"`
+}
+
+// odsNamedExpressions directly maps the named-expressions element.
+type odsNamedExpressions struct {
+	NamedRange      []odsNamedRange      `xml:"table:named-range"`
+	NamedExpression []odsNamedExpression `xml:"table:named-expression"`
+}
+
+// odsNamedRange directly maps the named-range element.
+type odsNamedRange struct {
+	Name             string `xml:"table:name,attr"`
+	CellRangeAddress string `xml:"table:cell-range-address,attr"`
+	BaseCellAddress  string `xml:"table:base-cell-address,attr"`
+}
+
+// odsNamedExpression directly maps the named-expression element.
+type odsNamedExpression struct {
+	Name            string `xml:"table:name,attr"`
+	Expression      string `xml:"table:expression,attr"`
+	BaseCellAddress string `xml:"table:base-cell-address,attr"`
+}
+
+// odsTable directly maps the table element.
+type odsTable struct {
+	Name             string               `xml:"table:name,attr"`
+	StyleName        string               `xml:"table:style-name,attr"`
+	Columns          []odsTableColumn     `xml:"table:table-column"`
+	Rows             []odsTableRow        `xml:"table:table-row"`
+	NamedExpressions *odsNamedExpressions `xml:"table:named-expressions"`
+}
+
+// odsTableColumn directly maps the table-column element.
+type odsTableColumn struct {
+	StyleName            string `xml:"table:style-name,attr"`
+	Repeated             int    `xml:"table:number-columns-repeated,attr,omitempty"`
+	Visibility           string `xml:"table:visibility,attr,omitempty"`
+	DefaultCellStyleName string `xml:"table:default-cell-style-name,attr,omitempty"`
+}
+
+// odsTableRow directly maps the table-row element.
+type odsTableRow struct {
+	StyleName  string         `xml:"table:style-name,attr,omitempty"`
+	Repeated   int            `xml:"table:number-rows-repeated,attr,omitempty"`
+	Visibility string         `xml:"table:visibility,attr,omitempty"`
+	Cells      []odsTableCell `xml:",any"`
+}
+
+// odsTableCell directly maps the table-cell and covered-table-cell elements.
+type odsTableCell struct {
+	XMLName        xml.Name
+	StyleName      string        `xml:"table:style-name,attr,omitempty"`
+	Repeated       int           `xml:"table:number-columns-repeated,attr,omitempty"`
+	ColumnsSpanned int           `xml:"table:number-columns-spanned,attr,omitempty"`
+	RowsSpanned    int           `xml:"table:number-rows-spanned,attr,omitempty"`
+	Formula        string        `xml:"table:formula,attr,omitempty"`
+	ValueType      string        `xml:"office:value-type,attr,omitempty"`
+	Value          string        `xml:"office:value,attr,omitempty"`
+	DateValue      string        `xml:"office:date-value,attr,omitempty"`
+	TimeValue      string        `xml:"office:time-value,attr,omitempty"`
+	BooleanValue   string        `xml:"office:boolean-value,attr,omitempty"`
+	Paragraphs     []odsInnerXML `xml:"text:p"`
+}
+
+// odsInnerXML directly maps the paragraph element with the escaped content.
+type odsInnerXML struct {
+	Content string `xml:",innerxml"`
+}